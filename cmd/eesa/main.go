@@ -4,9 +4,12 @@ import (
 	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"fyne.io/fyne/v2/app"
 	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/headless"
 	"github.com/company/eesa/internal/ui"
 	"github.com/company/eesa/pkg/utils"
 )
@@ -18,8 +21,28 @@ func main() {
 		log.Fatal("Failed to load configuration:", err)
 	}
 
+	// Run without the GUI when requested, keeping stdout for the result
+	if headless.Requested(os.Args[1:]) {
+		logger := utils.NewLoggerWithFormat(cfg.LogLevel, utils.ParseLogFormat(cfg.LogFormat), os.Stderr)
+		headless.ApplyProfile(os.Args[1:], cfg)
+		deps, err := headless.NewDependencies(cfg, logger)
+		if err != nil {
+			logger.Error("Failed to set up headless run", err)
+			os.Exit(1)
+		}
+		// Interrupts end the run, including a scheduled one
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		err = headless.Run(ctx, os.Args[1:], cfg, deps, os.Stdout, os.Stderr, logger)
+		stop()
+		if err != nil {
+			logger.Error("Headless run failed", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize logger
-	logger := utils.NewLogger(cfg.LogLevel)
+	logger := utils.NewLoggerWithFormat(cfg.LogLevel, utils.ParseLogFormat(cfg.LogFormat), os.Stdout)
 	logger.Info("Starting ESA application", utils.NewField("version", "1.0.0"))
 
 	// Create Fyne application
@@ -30,8 +53,15 @@ func main() {
 	})
 
 	// Create main window
-	ctx := context.Background()
-	mainWindow := ui.NewMainWindow(ctx, app, cfg, logger)
+	deps, err := headless.NewDependencies(cfg, logger)
+	if err != nil {
+		logger.Error("Failed to set up the pipeline", err)
+		os.Exit(1)
+	}
+	// Runs still in progress are stopped when the window closes
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mainWindow := ui.NewMainWindow(ctx, app, cfg, deps, logger)
 
 	// Show and run
 	mainWindow.ShowAndRun()
@@ -1,8 +1,13 @@
 package config
 
 import (
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -10,11 +15,14 @@ import (
 
 // Config represents the application configuration
 type Config struct {
-	LogLevel string `yaml:"log_level"`
+	LogLevel        string `yaml:"log_level"`
+	LogFormat       string `yaml:"log_format"`       // json or text
+	SummaryProvider string `yaml:"summary_provider"` // Registered summarizer that writes the narrative summary
 	
 	Jira struct {
-		URL      string `yaml:"url"`
-		Username string `yaml:"username"`
+		URL          string            `yaml:"url"`
+		Username     string            `yaml:"username"`
+		CustomFields map[string]string `yaml:"custom_fields"` // Logical names mapped to field IDs, such as team: customfield_10001; story_points sets the story points field
 		// Token stored in keyring, not in config file
 	} `yaml:"jira"`
 	
@@ -23,37 +31,92 @@ type Config struct {
 		Model       string  `yaml:"model"`
 		Temperature float32 `yaml:"temperature"`
 		MaxTokens   int     `yaml:"max_tokens"`
+		APIVersion  string  `yaml:"api_version"`
+		// Reuse the summary of an identical request, same activities, prompt, model and
+		// temperature, instead of calling Gemini again while the process runs
+		SummaryCache     bool          `yaml:"summary_cache"`
+		SummaryCacheSize int           `yaml:"summary_cache_size"` // Summaries kept, 0 keeps every one
+		SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`  // How long a summary is reused, 0 never expires it
 	} `yaml:"gemini"`
 	
+	// Prompt replaces the built-in summary prompt with a Go text/template
+	Prompt struct {
+		Template     string `yaml:"template"`      // Template text
+		TemplateFile string `yaml:"template_file"` // File holding the template, instead of Template
+	} `yaml:"prompt"`
+	
 	Google struct {
-		ClientID string `yaml:"client_id"`
+		ClientID        string `yaml:"client_id"`
+		SectionDividers bool   `yaml:"section_dividers"` // Horizontal rules between executive summary sections
 		// ClientSecret stored in keyring, not in config file
 	} `yaml:"google"`
 	
+	Confluence struct {
+		URL          string   `yaml:"url"`            // Site URL, including /wiki for Confluence Cloud
+		Username     string   `yaml:"username"`       // Account email for Cloud, empty to use a personal access token
+		SpaceKey     string   `yaml:"space_key"`      // Space new summary pages are created in
+		ParentPageID string   `yaml:"parent_page_id"` // Page new summary pages are created under, empty for the space root
+		Labels       []string `yaml:"labels"`         // Labels added to published summary pages
+		// Token stored in keyring, not in config file
+	} `yaml:"confluence"`
+	
+	Email struct {
+		SMTPHost string `yaml:"smtp_host"`
+		SMTPPort int    `yaml:"smtp_port"` // Defaults to 587 when unset
+		Username string `yaml:"username"`
+		From     string `yaml:"from"`
+		// Password comes from ESA_SMTP_PASSWORD, never from the config file
+		Password string `yaml:"-"`
+	} `yaml:"email"`
+	
 	Defaults struct {
 		TimeRange    string   `yaml:"time_range"`
 		Users        []string `yaml:"users"`
 		OutputFormat string   `yaml:"output_format"`
 	} `yaml:"defaults"`
 	
+	Processing struct {
+		// Progress credit, from 0 to 1, of non-completed statuses such as In Review: 0.8 in the
+		// productivity score. Replaces the built-in weights when set.
+		StatusWeights map[string]float64 `yaml:"status_weights"`
+	} `yaml:"processing"`
+	
 	Security struct {
 		TLSMinVersion string `yaml:"tls_min_version"`
-		VerifySSL     bool   `yaml:"verify_ssl"`
+		VerifySSL     *bool  `yaml:"verify_ssl"` // Nil verifies certificates, only an explicit false turns verification off
+		Profile       string `yaml:"profile"` // Credential profile, empty for the default profile
 	} `yaml:"security"`
+	
+	HTTP struct {
+		MaxIdleConns        int           `yaml:"max_idle_conns"`
+		MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host"`
+		IdleConnTimeout     time.Duration `yaml:"idle_conn_timeout"`
+		KeepAlive           time.Duration `yaml:"keep_alive"`
+		DisableKeepAlives   bool          `yaml:"disable_keep_alives"`
+	} `yaml:"http"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		LogLevel: "info",
+		LogLevel:        "info",
+		LogFormat:       "json",
+		SummaryProvider: "gemini",
 		Gemini: struct {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
 			MaxTokens:   4096,
+			APIVersion:  "v1",
+			SummaryCacheSize: 100,
+			SummaryCacheTTL:  24 * time.Hour,
 		},
 		Defaults: struct {
 			TimeRange    string   `yaml:"time_range"`
@@ -66,20 +129,42 @@ func DefaultConfig() *Config {
 		},
 		Security: struct {
 			TLSMinVersion string `yaml:"tls_min_version"`
-			VerifySSL     bool   `yaml:"verify_ssl"`
+			VerifySSL     *bool  `yaml:"verify_ssl"`
+			Profile       string `yaml:"profile"`
 		}{
 			TLSMinVersion: "1.3",
-			VerifySSL:     true,
+		},
+		HTTP: struct {
+			MaxIdleConns        int           `yaml:"max_idle_conns"`
+			MaxIdleConnsPerHost int           `yaml:"max_idle_conns_per_host"`
+			IdleConnTimeout     time.Duration `yaml:"idle_conn_timeout"`
+			KeepAlive           time.Duration `yaml:"keep_alive"`
+			DisableKeepAlives   bool          `yaml:"disable_keep_alives"`
+		}{
+			MaxIdleConns:        100,
+			MaxIdleConnsPerHost: 10,
+			IdleConnTimeout:     90 * time.Second,
+			KeepAlive:           30 * time.Second,
 		},
 	}
 }
 
 // Load loads configuration from file, with environment variable overrides
 func Load() (*Config, error) {
+	return loadFile(getConfigPath())
+}
+
+// Path returns the path of the configuration file Load reads
+func Path() string {
+	return getConfigPath()
+}
+
+// loadFile loads the configuration from the file at configPath, if it exists, applies the
+// environment variable overrides and validates the result
+func loadFile(configPath string) (*Config, error) {
 	config := DefaultConfig()
 	
 	// Try to load from config file
-	configPath := getConfigPath()
 	if _, err := os.Stat(configPath); err == nil {
 		data, err := os.ReadFile(configPath)
 		if err != nil {
@@ -99,8 +184,11 @@ func Load() (*Config, error) {
 		}
 	}
 	
-	// Override with environment variables
+	// Override with environment variables, the EnvPrefix bindings last so they win
 	applyEnvOverrides(config)
+	if err := applyEnvBindings(config); err != nil {
+		return nil, err
+	}
 	
 	// Validate configuration
 	if err := config.Validate(); err != nil {
@@ -143,34 +231,215 @@ func (c *Config) Save() error {
 	return nil
 }
 
-// Validate validates the configuration
+// MaxGeminiTemperature is the highest sampling temperature the Gemini API accepts
+const MaxGeminiTemperature = 2.0
+
+// validLogLevels are the log levels the logger understands
+var validLogLevels = []string{"debug", "info", "warn", "error"}
+
+// Validate checks the configuration and reports every problem found, so they can all be fixed
+// at once. A single problem is returned as a *ConfigError; several are returned as
+// ValidationErrors.
 func (c *Config) Validate() error {
+	var errs ValidationErrors
+	
+	if !containsString(validLogLevels, c.LogLevel) {
+		errs = append(errs, &ConfigError{
+			Code:    "LOG_LEVEL_INVALID",
+			Field:   "log_level",
+			Message: "log_level must be one of " + strings.Join(validLogLevels, ", ") + `, got "` + c.LogLevel + `"`,
+		})
+	}
+	
 	if c.Jira.URL == "" {
-		return &ConfigError{
+		errs = append(errs, &ConfigError{
 			Code:    "JIRA_URL_MISSING",
+			Field:   "jira.url",
 			Message: "Jira URL is required",
-		}
+		})
+	} else if err := validateURL(c.Jira.URL); err != nil {
+		errs = append(errs, &ConfigError{
+			Code:    "JIRA_URL_INVALID",
+			Field:   "jira.url",
+			Message: "jira.url must be an http or https URL such as https://company.atlassian.net",
+			Cause:   err,
+		})
 	}
 	
-	if c.Jira.Username == "" {
-		return &ConfigError{
+	if strings.TrimSpace(c.Jira.Username) == "" {
+		errs = append(errs, &ConfigError{
 			Code:    "JIRA_USERNAME_MISSING",
+			Field:   "jira.username",
 			Message: "Jira username is required",
-		}
+		})
+	}
+	
+	if c.Gemini.Temperature < 0 || c.Gemini.Temperature > MaxGeminiTemperature {
+		errs = append(errs, &ConfigError{
+			Code:    "GEMINI_TEMPERATURE_INVALID",
+			Field:   "gemini.temperature",
+			Message: "gemini.temperature must be between 0 and 2",
+		})
+	}
+	
+	if c.Gemini.MaxTokens <= 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "GEMINI_MAX_TOKENS_INVALID",
+			Field:   "gemini.max_tokens",
+			Message: "gemini.max_tokens must be greater than 0",
+		})
+	}
+	
+	if c.Gemini.SummaryCacheSize < 0 || c.Gemini.SummaryCacheTTL < 0 {
+		errs = append(errs, &ConfigError{
+			Code:    "GEMINI_SUMMARY_CACHE_INVALID",
+			Field:   "gemini.summary_cache_size",
+			Message: "gemini.summary_cache_size and gemini.summary_cache_ttl must not be negative",
+		})
+	}
+	
+	if status, ok := invalidStatusWeight(c.Processing.StatusWeights); ok {
+		errs = append(errs, &ConfigError{
+			Code:    "STATUS_WEIGHT_INVALID",
+			Field:   "processing.status_weights",
+			Message: "processing.status_weights must be between 0 and 1, got " + strconv.FormatFloat(c.Processing.StatusWeights[status], 'g', -1, 64) + " for " + status,
+		})
 	}
 	
 	if c.Google.ClientID == "" {
-		return &ConfigError{
+		errs = append(errs, &ConfigError{
 			Code:    "GOOGLE_CLIENT_ID_MISSING",
+			Field:   "google.client_id",
 			Message: "Google Client ID is required",
+		})
+	}
+	
+	// Confluence is optional, but a configured site must be reachable
+	if c.Confluence.URL != "" {
+		if err := validateURL(c.Confluence.URL); err != nil {
+			errs = append(errs, &ConfigError{
+				Code:    "CONFLUENCE_URL_INVALID",
+				Field:   "confluence.url",
+				Message: "confluence.url must be an http or https URL such as https://company.atlassian.net/wiki",
+				Cause:   err,
+			})
+		}
+	}
+	
+	if err := c.validatePromptTemplate(); err != nil {
+		errs = append(errs, err)
+	}
+	
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errs
+	}
+}
+
+// invalidStatusWeight returns the first status, in name order, whose weight is outside 0 to 1
+func invalidStatusWeight(weights map[string]float64) (string, bool) {
+	statuses := make([]string, 0, len(weights))
+	for status := range weights {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	
+	for _, status := range statuses {
+		// The negated range also rejects NaN
+		if weight := weights[status]; !(weight >= 0 && weight <= 1) {
+			return status, true
+		}
+	}
+	return "", false
+}
+
+// PromptTemplate returns the text of the configured summary prompt template, read from
+// TemplateFile when that is set, or an empty string when the built-in prompt is used
+func (c *Config) PromptTemplate() (string, error) {
+	if c.Prompt.TemplateFile == "" {
+		return c.Prompt.Template, nil
+	}
+	
+	data, err := os.ReadFile(c.Prompt.TemplateFile)
+	if err != nil {
+		return "", &ConfigError{
+			Code:    "PROMPT_TEMPLATE_UNREADABLE",
+			Field:   "prompt.template_file",
+			Message: "Failed to read prompt template " + c.Prompt.TemplateFile,
+			Cause:   err,
+		}
+	}
+	return string(data), nil
+}
+
+// validatePromptTemplate checks that a configured prompt template can be read and parsed.
+// Whether it only uses the data summaries are rendered with is checked when it is loaded.
+func (c *Config) validatePromptTemplate() *ConfigError {
+	if c.Prompt.Template != "" && c.Prompt.TemplateFile != "" {
+		return &ConfigError{
+			Code:    "PROMPT_TEMPLATE_CONFLICT",
+			Field:   "prompt.template",
+			Message: "Set prompt.template or prompt.template_file, not both",
 		}
 	}
 	
+	text, err := c.PromptTemplate()
+	if err != nil {
+		return err.(*ConfigError)
+	}
+	if text == "" {
+		return nil
+	}
+	
+	if _, err := template.New("prompt").Parse(text); err != nil {
+		field := "prompt.template"
+		if c.Prompt.TemplateFile != "" {
+			field = "prompt.template_file"
+		}
+		return &ConfigError{
+			Code:    "PROMPT_TEMPLATE_INVALID",
+			Field:   field,
+			Message: "The prompt template does not parse: " + err.Error(),
+			Cause:   err,
+		}
+	}
+	return nil
+}
+
+// validateURL checks that raw is an absolute http or https URL with a host
+func validateURL(raw string) error {
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return &ConfigError{Code: "URL_SCHEME_INVALID", Message: `unsupported scheme "` + parsed.Scheme + `"`}
+	}
+	if parsed.Host == "" {
+		return &ConfigError{Code: "URL_HOST_MISSING", Message: "missing host"}
+	}
 	return nil
 }
 
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
 // getConfigPath returns the path to the configuration file
 func getConfigPath() string {
+	if path := os.Getenv(EnvPrefix + "CONFIG_PATH"); path != "" {
+		return path
+	}
 	if path := os.Getenv("ESA_CONFIG_PATH"); path != "" {
 		return path
 	}
@@ -183,12 +452,21 @@ func getConfigPath() string {
 	return filepath.Join(homeDir, ".config", "eesa", "config.yaml")
 }
 
-// applyEnvOverrides applies environment variable overrides to the configuration
+// applyEnvOverrides applies the ESA_ environment variable overrides to the configuration. They
+// predate the EnvPrefix bindings, which cover every setting and take precedence over them.
 func applyEnvOverrides(config *Config) {
 	if logLevel := os.Getenv("ESA_LOG_LEVEL"); logLevel != "" {
 		config.LogLevel = logLevel
 	}
 	
+	if logFormat := os.Getenv("ESA_LOG_FORMAT"); logFormat != "" {
+		config.LogFormat = logFormat
+	}
+	
+	if summaryProvider := os.Getenv("ESA_SUMMARY_PROVIDER"); summaryProvider != "" {
+		config.SummaryProvider = summaryProvider
+	}
+	
 	if jiraURL := os.Getenv("ESA_JIRA_URL"); jiraURL != "" {
 		config.Jira.URL = jiraURL
 	}
@@ -204,6 +482,26 @@ func applyEnvOverrides(config *Config) {
 	if googleClientID := os.Getenv("ESA_GOOGLE_CLIENT_ID"); googleClientID != "" {
 		config.Google.ClientID = googleClientID
 	}
+	
+	if confluenceURL := os.Getenv("ESA_CONFLUENCE_URL"); confluenceURL != "" {
+		config.Confluence.URL = confluenceURL
+	}
+	
+	if confluenceUsername := os.Getenv("ESA_CONFLUENCE_USERNAME"); confluenceUsername != "" {
+		config.Confluence.Username = confluenceUsername
+	}
+	
+	if profile := os.Getenv("ESA_PROFILE"); profile != "" {
+		config.Security.Profile = profile
+	}
+	
+	if smtpHost := os.Getenv("ESA_SMTP_HOST"); smtpHost != "" {
+		config.Email.SMTPHost = smtpHost
+	}
+	
+	if smtpPassword := os.Getenv("ESA_SMTP_PASSWORD"); smtpPassword != "" {
+		config.Email.Password = smtpPassword
+	}
 }
 
 // TimeRange represents a time range for queries
@@ -243,6 +541,7 @@ func ParseTimeRange(s string) (TimeRange, error) {
 // ConfigError represents a configuration error
 type ConfigError struct {
 	Code    string
+	Field   string // YAML path of the offending setting, such as "jira.url", when there is one
 	Message string
 	Cause   error
 }
@@ -252,4 +551,38 @@ func (e *ConfigError) Error() string {
 		return e.Message + ": " + e.Cause.Error()
 	}
 	return e.Message
-}
\ No newline at end of file
+}
+
+// Unwrap returns the underlying cause
+func (e *ConfigError) Unwrap() error {
+	return e.Cause
+}
+
+// ValidationErrors lists every problem Validate found when there is more than one
+type ValidationErrors []*ConfigError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "Invalid configuration: " + strings.Join(messages, "; ")
+}
+
+// Unwrap returns the individual errors, so errors.As finds each *ConfigError
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Fields returns the settings that failed validation, in the order they were checked
+func (e ValidationErrors) Fields() []string {
+	fields := make([]string, 0, len(e))
+	for _, err := range e {
+		fields = append(fields, err.Field)
+	}
+	return fields
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -14,13 +15,21 @@ func TestDefaultConfig(t *testing.T) {
 	config := DefaultConfig()
 	
 	assert.Equal(t, "info", config.LogLevel)
+	assert.Equal(t, "json", config.LogFormat)
+	assert.Equal(t, "gemini", config.SummaryProvider)
 	assert.Equal(t, "gemini-pro", config.Gemini.Model)
 	assert.Equal(t, float32(0.7), config.Gemini.Temperature)
 	assert.Equal(t, 4096, config.Gemini.MaxTokens)
 	assert.Equal(t, "1w", config.Defaults.TimeRange)
 	assert.Equal(t, "google_docs", config.Defaults.OutputFormat)
 	assert.Equal(t, "1.3", config.Security.TLSMinVersion)
-	assert.True(t, config.Security.VerifySSL)
+	assert.Nil(t, config.Security.VerifySSL) // Unset, so certificates are verified
+	assert.Equal(t, 100, config.HTTP.MaxIdleConns)
+	assert.Equal(t, 10, config.HTTP.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, config.HTTP.IdleConnTimeout)
+	assert.Equal(t, 30*time.Second, config.HTTP.KeepAlive)
+	assert.False(t, config.HTTP.DisableKeepAlives)
+	assert.False(t, config.Google.SectionDividers)
 }
 
 func TestConfig_Validate(t *testing.T) {
@@ -32,69 +41,76 @@ func TestConfig_Validate(t *testing.T) {
 	}{
 		{
 			name: "valid config",
-			config: &Config{
+			config: withDefaults(&Config{
 				Jira: struct {
-					URL      string `yaml:"url"`
-					Username string `yaml:"username"`
+					URL          string            `yaml:"url"`
+					Username     string            `yaml:"username"`
+					CustomFields map[string]string `yaml:"custom_fields"`
 				}{
 					URL:      "https://company.atlassian.net",
 					Username: "testuser",
 				},
 				Google: struct {
-					ClientID string `yaml:"client_id"`
+					ClientID        string `yaml:"client_id"`
+					SectionDividers bool   `yaml:"section_dividers"`
 				}{
 					ClientID: "test-client-id",
 				},
-			},
+			}),
 			wantErr: false,
 		},
 		{
 			name: "missing jira url",
-			config: &Config{
+			config: withDefaults(&Config{
 				Jira: struct {
-					URL      string `yaml:"url"`
-					Username string `yaml:"username"`
+					URL          string            `yaml:"url"`
+					Username     string            `yaml:"username"`
+					CustomFields map[string]string `yaml:"custom_fields"`
 				}{
 					Username: "testuser",
 				},
 				Google: struct {
-					ClientID string `yaml:"client_id"`
+					ClientID        string `yaml:"client_id"`
+					SectionDividers bool   `yaml:"section_dividers"`
 				}{
 					ClientID: "test-client-id",
 				},
-			},
+			}),
 			wantErr: true,
 			errCode: "JIRA_URL_MISSING",
 		},
 		{
 			name: "missing jira username",
-			config: &Config{
+			config: withDefaults(&Config{
 				Jira: struct {
-					URL      string `yaml:"url"`
-					Username string `yaml:"username"`
+					URL          string            `yaml:"url"`
+					Username     string            `yaml:"username"`
+					CustomFields map[string]string `yaml:"custom_fields"`
 				}{
 					URL: "https://company.atlassian.net",
 				},
 				Google: struct {
-					ClientID string `yaml:"client_id"`
+					ClientID        string `yaml:"client_id"`
+					SectionDividers bool   `yaml:"section_dividers"`
 				}{
 					ClientID: "test-client-id",
 				},
-			},
+			}),
 			wantErr: true,
 			errCode: "JIRA_USERNAME_MISSING",
 		},
 		{
 			name: "missing google client id",
-			config: &Config{
+			config: withDefaults(&Config{
 				Jira: struct {
-					URL      string `yaml:"url"`
-					Username string `yaml:"username"`
+					URL          string            `yaml:"url"`
+					Username     string            `yaml:"username"`
+					CustomFields map[string]string `yaml:"custom_fields"`
 				}{
 					URL:      "https://company.atlassian.net",
 					Username: "testuser",
 				},
-			},
+			}),
 			wantErr: true,
 			errCode: "GOOGLE_CLIENT_ID_MISSING",
 		},
@@ -116,6 +132,173 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+// withDefaults fills in the settings a test config leaves unset with their default values
+func withDefaults(config *Config) *Config {
+	defaults := DefaultConfig()
+	config.LogLevel = defaults.LogLevel
+	config.Gemini = defaults.Gemini
+	return config
+}
+
+// validConfig returns a configuration that passes validation
+func validConfig() *Config {
+	config := DefaultConfig()
+	config.Jira.URL = "https://company.atlassian.net"
+	config.Jira.Username = "testuser"
+	config.Google.ClientID = "test-client-id"
+	return config
+}
+
+func TestConfig_Validate_InvalidValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		errCode string
+		field   string
+	}{
+		{
+			name:    "jira url without scheme",
+			modify:  func(c *Config) { c.Jira.URL = "company.atlassian.net" },
+			errCode: "JIRA_URL_INVALID",
+			field:   "jira.url",
+		},
+		{
+			name:    "jira url with unsupported scheme",
+			modify:  func(c *Config) { c.Jira.URL = "ftp://company.atlassian.net" },
+			errCode: "JIRA_URL_INVALID",
+			field:   "jira.url",
+		},
+		{
+			name:    "blank jira username",
+			modify:  func(c *Config) { c.Jira.Username = "   " },
+			errCode: "JIRA_USERNAME_MISSING",
+			field:   "jira.username",
+		},
+		{
+			name:    "negative temperature",
+			modify:  func(c *Config) { c.Gemini.Temperature = -0.1 },
+			errCode: "GEMINI_TEMPERATURE_INVALID",
+			field:   "gemini.temperature",
+		},
+		{
+			name:    "temperature above 2",
+			modify:  func(c *Config) { c.Gemini.Temperature = 2.5 },
+			errCode: "GEMINI_TEMPERATURE_INVALID",
+			field:   "gemini.temperature",
+		},
+		{
+			name:    "zero max tokens",
+			modify:  func(c *Config) { c.Gemini.MaxTokens = 0 },
+			errCode: "GEMINI_MAX_TOKENS_INVALID",
+			field:   "gemini.max_tokens",
+		},
+		{
+			name:    "negative summary cache size",
+			modify:  func(c *Config) { c.Gemini.SummaryCacheSize = -1 },
+			errCode: "GEMINI_SUMMARY_CACHE_INVALID",
+			field:   "gemini.summary_cache_size",
+		},
+		{
+			name:    "status weight above 1",
+			modify:  func(c *Config) { c.Processing.StatusWeights = map[string]float64{"In Review": 0.8, "Testing": 1.5} },
+			errCode: "STATUS_WEIGHT_INVALID",
+			field:   "processing.status_weights",
+		},
+		{
+			name:    "negative status weight",
+			modify:  func(c *Config) { c.Processing.StatusWeights = map[string]float64{"To Do": -0.1} },
+			errCode: "STATUS_WEIGHT_INVALID",
+			field:   "processing.status_weights",
+		},
+		{
+			name:    "unknown log level",
+			modify:  func(c *Config) { c.LogLevel = "verbose" },
+			errCode: "LOG_LEVEL_INVALID",
+			field:   "log_level",
+		},
+		{
+			name:    "invalid confluence url",
+			modify:  func(c *Config) { c.Confluence.URL = "https:///wiki" },
+			errCode: "CONFLUENCE_URL_INVALID",
+			field:   "confluence.url",
+		},
+		{
+			name:    "prompt template that does not parse",
+			modify:  func(c *Config) { c.Prompt.Template = "{{range .Activities}}" },
+			errCode: "PROMPT_TEMPLATE_INVALID",
+			field:   "prompt.template",
+		},
+		{
+			name:    "missing prompt template file",
+			modify:  func(c *Config) { c.Prompt.TemplateFile = filepath.Join(t.TempDir(), "missing.tmpl") },
+			errCode: "PROMPT_TEMPLATE_UNREADABLE",
+			field:   "prompt.template_file",
+		},
+		{
+			name: "prompt template inline and in a file",
+			modify: func(c *Config) {
+				c.Prompt.Template = "Summarize"
+				c.Prompt.TemplateFile = "prompt.tmpl"
+			},
+			errCode: "PROMPT_TEMPLATE_CONFLICT",
+			field:   "prompt.template",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := validConfig()
+			tt.modify(config)
+			
+			err := config.Validate()
+			require.Error(t, err)
+			configErr, ok := err.(*ConfigError)
+			require.True(t, ok, "Expected ConfigError")
+			assert.Equal(t, tt.errCode, configErr.Code)
+			assert.Equal(t, tt.field, configErr.Field)
+		})
+	}
+	
+	// The boundaries of the temperature range are allowed
+	config := validConfig()
+	config.Gemini.Temperature = 2
+	assert.NoError(t, config.Validate())
+	config.Gemini.Temperature = 0
+	assert.NoError(t, config.Validate())
+	
+	// So are those of the status weights
+	config.Processing.StatusWeights = map[string]float64{"To Do": 0, "Testing": 1}
+	assert.NoError(t, config.Validate())
+	
+	// A template file that parses is accepted
+	path := filepath.Join(t.TempDir(), "prompt.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("Summarize {{len .Activities}} activities"), 0600))
+	config.Prompt.TemplateFile = path
+	assert.NoError(t, config.Validate())
+}
+
+func TestConfig_Validate_MultipleErrors(t *testing.T) {
+	config := validConfig()
+	config.Jira.URL = "not a url"
+	config.Gemini.Temperature = 3
+	config.Gemini.MaxTokens = -1
+	config.Google.ClientID = ""
+	
+	err := config.Validate()
+	require.Error(t, err)
+	
+	// Every problem is reported, not just the first
+	validationErrs, ok := err.(ValidationErrors)
+	require.True(t, ok, "Expected ValidationErrors")
+	assert.Equal(t, []string{"jira.url", "gemini.temperature", "gemini.max_tokens", "google.client_id"}, validationErrs.Fields())
+	assert.Contains(t, err.Error(), "gemini.temperature must be between 0 and 2")
+	assert.Contains(t, err.Error(), "Google Client ID is required")
+	
+	var configErr *ConfigError
+	require.True(t, errors.As(err, &configErr))
+	assert.Equal(t, "JIRA_URL_INVALID", configErr.Code)
+}
+
 func TestConfig_SaveAndLoad(t *testing.T) {
 	// Create temporary config file
 	tempDir := t.TempDir()
@@ -138,6 +321,7 @@ func TestConfig_SaveAndLoad(t *testing.T) {
 	originalConfig.Jira.URL = "https://test.atlassian.net"
 	originalConfig.Jira.Username = "testuser"
 	originalConfig.Google.ClientID = "test-client-id"
+	originalConfig.Google.SectionDividers = true
 	
 	// Save config
 	err := originalConfig.Save()
@@ -152,6 +336,7 @@ func TestConfig_SaveAndLoad(t *testing.T) {
 	assert.Equal(t, originalConfig.Jira.URL, loadedConfig.Jira.URL)
 	assert.Equal(t, originalConfig.Jira.Username, loadedConfig.Jira.Username)
 	assert.Equal(t, originalConfig.Google.ClientID, loadedConfig.Google.ClientID)
+	assert.True(t, loadedConfig.Google.SectionDividers)
 }
 
 func TestParseTimeRange(t *testing.T) {
@@ -220,10 +405,16 @@ func TestEnvOverrides(t *testing.T) {
 	// Set test environment variables
 	testEnvs := map[string]string{
 		"ESA_LOG_LEVEL":        "debug",
+		"ESA_LOG_FORMAT":       "text",
+		"ESA_SUMMARY_PROVIDER": "self-hosted",
 		"ESA_JIRA_URL":         "https://env.atlassian.net",
 		"ESA_JIRA_USERNAME":    "envuser",
 		"ESA_GEMINI_MODEL":     "gemini-pro-vision",
 		"ESA_GOOGLE_CLIENT_ID": "env-client-id",
+		"ESA_SMTP_HOST":        "smtp.example.com",
+		"ESA_SMTP_PASSWORD":    "smtp-secret",
+		"ESA_CONFLUENCE_URL":   "https://env.atlassian.net/wiki",
+		"ESA_CONFLUENCE_USERNAME": "envuser@example.com",
 	}
 	
 	// Set environment variables
@@ -245,10 +436,16 @@ func TestEnvOverrides(t *testing.T) {
 	
 	// Check overrides
 	assert.Equal(t, "debug", config.LogLevel)
+	assert.Equal(t, "text", config.LogFormat)
+	assert.Equal(t, "self-hosted", config.SummaryProvider)
 	assert.Equal(t, "https://env.atlassian.net", config.Jira.URL)
 	assert.Equal(t, "envuser", config.Jira.Username)
 	assert.Equal(t, "gemini-pro-vision", config.Gemini.Model)
 	assert.Equal(t, "env-client-id", config.Google.ClientID)
+	assert.Equal(t, "smtp.example.com", config.Email.SMTPHost)
+	assert.Equal(t, "smtp-secret", config.Email.Password)
+	assert.Equal(t, "https://env.atlassian.net/wiki", config.Confluence.URL)
+	assert.Equal(t, "envuser@example.com", config.Confluence.Username)
 }
 
 func TestConfigError_Error(t *testing.T) {
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// EnvPrefix starts the name of every environment variable bound to a configuration value.
+//
+// Each setting is bound to EnvPrefix followed by its YAML path in upper case, with the section
+// and key joined by an underscore: jira.url is EESA_JIRA_URL, gemini.max_tokens is
+// EESA_GEMINI_MAX_TOKENS and the top-level log_level is EESA_LOG_LEVEL. Lists such as
+// defaults.users take comma separated values, and durations such as http.idle_conn_timeout
+// take Go duration strings like "90s". Maps, such as jira.custom_fields, cannot be set this
+// way. The SMTP password, which is never read from the file, is bound to EESA_EMAIL_PASSWORD.
+//
+// Bound variables take precedence over both the configuration file and the older ESA_
+// variables. An unset or empty variable leaves the value from the file in place.
+const EnvPrefix = "EESA_"
+
+// emailPasswordEnv carries the SMTP password, which has no YAML key
+const emailPasswordEnv = EnvPrefix + "EMAIL_PASSWORD"
+
+// durationType is the type of time.Duration settings, which are parsed as duration strings
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnvBindings overlays the EnvPrefix environment variables onto config
+func applyEnvBindings(config *Config) error {
+	if err := bindEnv(reflect.ValueOf(config).Elem(), EnvPrefix); err != nil {
+		return err
+	}
+	
+	if password := os.Getenv(emailPasswordEnv); password != "" {
+		config.Email.Password = password
+	}
+	
+	return nil
+}
+
+// bindEnv sets the fields of the struct v from the environment variables named by prefix and
+// their YAML keys, descending into nested sections
+func bindEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "" || key == "-" || !field.IsExported() {
+			continue
+		}
+		
+		name := prefix + strings.ToUpper(key)
+		value := v.Field(i)
+		if value.Kind() == reflect.Struct {
+			if err := bindEnv(value, name+"_"); err != nil {
+				return err
+			}
+			continue
+		}
+		
+		raw := os.Getenv(name)
+		if raw == "" {
+			continue
+		}
+		if err := setFromEnv(value, raw); err != nil {
+			return &ConfigError{
+				Code:    "ENV_OVERRIDE_INVALID",
+				Field:   name,
+				Message: "Invalid value for environment variable " + name,
+				Cause:   err,
+			}
+		}
+	}
+	
+	return nil
+}
+
+// setFromEnv parses raw into value according to its type. Unsupported types are left alone.
+func setFromEnv(value reflect.Value, raw string) error {
+	if value.Type() == durationType {
+		duration, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+		value.SetInt(int64(duration))
+		return nil
+	}
+	
+	switch value.Kind() {
+	case reflect.Ptr:
+		// Optional settings such as *bool are set to a newly allocated value
+		elem := reflect.New(value.Type().Elem())
+		if err := setFromEnv(elem.Elem(), raw); err != nil {
+			return err
+		}
+		value.Set(elem)
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		value.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(parsed)
+	case reflect.Slice:
+		if value.Type().Elem().Kind() != reflect.String {
+			return nil
+		}
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	}
+	
+	return nil
+}
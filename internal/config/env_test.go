@@ -0,0 +1,94 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTestConfigFile writes a configuration file and points EESA_CONFIG_PATH at it
+func writeTestConfigFile(t *testing.T, content string) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	t.Setenv("EESA_CONFIG_PATH", configPath)
+}
+
+const testConfigFile = `
+log_level: info
+jira:
+  url: https://file.atlassian.net
+  username: fileuser
+gemini:
+  model: gemini-pro
+  temperature: 0.7
+  max_tokens: 2048
+google:
+  client_id: file-client-id
+defaults:
+  users: [file-user]
+http:
+  idle_conn_timeout: 90s
+`
+
+func TestLoad_EnvBindings(t *testing.T) {
+	writeTestConfigFile(t, testConfigFile)
+	
+	t.Setenv("EESA_LOG_LEVEL", "debug")
+	t.Setenv("EESA_JIRA_URL", "https://env.atlassian.net")
+	t.Setenv("ESA_JIRA_URL", "https://legacy.atlassian.net") // The EESA_ binding wins
+	t.Setenv("EESA_GEMINI_MODEL", "gemini-1.5-pro")
+	t.Setenv("EESA_GEMINI_TEMPERATURE", "0.2")
+	t.Setenv("EESA_GEMINI_MAX_TOKENS", "8192")
+	t.Setenv("EESA_DEFAULTS_USERS", "alice, bob,")
+	t.Setenv("EESA_SECURITY_VERIFY_SSL", "false")
+	t.Setenv("EESA_HTTP_IDLE_CONN_TIMEOUT", "45s")
+	t.Setenv("EESA_EMAIL_PASSWORD", "smtp-secret")
+	t.Setenv("EESA_GOOGLE_CLIENT_ID", "") // Empty variables are ignored
+	
+	config, err := Load()
+	require.NoError(t, err)
+	
+	assert.Equal(t, "debug", config.LogLevel)
+	assert.Equal(t, "https://env.atlassian.net", config.Jira.URL)
+	assert.Equal(t, "gemini-1.5-pro", config.Gemini.Model)
+	assert.Equal(t, float32(0.2), config.Gemini.Temperature)
+	assert.Equal(t, 8192, config.Gemini.MaxTokens)
+	assert.Equal(t, []string{"alice", "bob"}, config.Defaults.Users)
+	require.NotNil(t, config.Security.VerifySSL)
+	assert.False(t, *config.Security.VerifySSL)
+	assert.Equal(t, 45*time.Second, config.HTTP.IdleConnTimeout)
+	assert.Equal(t, "smtp-secret", config.Email.Password)
+	
+	// Settings without a variable keep the file value, or the default when the file has none
+	assert.Equal(t, "fileuser", config.Jira.Username)
+	assert.Equal(t, "file-client-id", config.Google.ClientID)
+	assert.Equal(t, "json", config.LogFormat)
+	assert.Equal(t, 100, config.HTTP.MaxIdleConns)
+}
+
+func TestLoad_EnvBindingsInvalidValue(t *testing.T) {
+	writeTestConfigFile(t, testConfigFile)
+	t.Setenv("EESA_GEMINI_MAX_TOKENS", "lots")
+	
+	_, err := Load()
+	require.Error(t, err)
+	
+	configErr, ok := err.(*ConfigError)
+	require.True(t, ok, "Expected ConfigError")
+	assert.Equal(t, "ENV_OVERRIDE_INVALID", configErr.Code)
+	assert.Equal(t, "EESA_GEMINI_MAX_TOKENS", configErr.Field)
+}
+
+func TestLoad_EnvBindingsValidated(t *testing.T) {
+	writeTestConfigFile(t, testConfigFile)
+	t.Setenv("EESA_GEMINI_TEMPERATURE", "5")
+	
+	// Overridden values are validated like file values
+	_, err := Load()
+	require.Error(t, err)
+	assert.Equal(t, "GEMINI_TEMPERATURE_INVALID", err.(*ConfigError).Code)
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/fsnotify/fsnotify"
+)
+
+// DefaultReloadDelay is how long the watcher waits after the last change to the file before
+// reloading it, so an editor's burst of writes produces a single reload
+const DefaultReloadDelay = 100 * time.Millisecond
+
+// configMapDataLink is the symlink through which a mounted Kubernetes ConfigMap exposes its
+// files. An update swaps the link to a new directory without touching the file's own path.
+const configMapDataLink = "..data"
+
+// Watcher reloads the configuration file when it changes and delivers each new configuration
+// on a channel. A reload that fails to parse or validate is logged and ignored, keeping the
+// last good configuration.
+type Watcher struct {
+	path        string
+	logger      utils.Logger
+	reloadDelay time.Duration
+	changes     chan *Config
+	mu          sync.RWMutex
+	current     *Config
+}
+
+// NewWatcher loads the configuration file at path, or the default path when path is empty, and
+// returns a watcher for it. The initial load must succeed, since there is no good
+// configuration to fall back to yet.
+func NewWatcher(path string, logger utils.Logger) (*Watcher, error) {
+	if path == "" {
+		path = getConfigPath()
+	}
+	
+	config, err := loadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &Watcher{
+		path:        filepath.Clean(path),
+		logger:      logger,
+		reloadDelay: DefaultReloadDelay,
+		changes:     make(chan *Config, 1),
+		current:     config,
+	}, nil
+}
+
+// SetReloadDelay sets how long to wait after the last change before reloading
+func (w *Watcher) SetReloadDelay(delay time.Duration) {
+	w.reloadDelay = delay
+}
+
+// Current returns the last good configuration
+func (w *Watcher) Current() *Config {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	return w.current
+}
+
+// Changes returns the channel each reloaded configuration is sent on. Only the newest
+// configuration is kept for a slow reader. The channel is closed when Run returns.
+func (w *Watcher) Changes() <-chan *Config {
+	return w.changes
+}
+
+// Run watches the configuration file until ctx is done. The directory is watched rather than
+// the file, so edits that replace the file, as many editors do, are seen. A ConfigMap update
+// is seen through the swap of its ..data link.
+func (w *Watcher) Run(ctx context.Context) error {
+	defer close(w.changes)
+	
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return &ConfigError{Code: "CONFIG_WATCH_FAILED", Message: "Failed to create configuration watcher", Cause: err}
+	}
+	defer watcher.Close()
+	
+	if err := watcher.Add(filepath.Dir(w.path)); err != nil {
+		return &ConfigError{Code: "CONFIG_WATCH_FAILED", Message: "Failed to watch configuration directory", Cause: err}
+	}
+	
+	w.logger.Info("Watching configuration file", utils.NewField("path", w.path))
+	
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if !w.affectsConfig(event) {
+				continue
+			}
+			reload = time.After(w.reloadDelay)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Warn("Configuration watcher error", utils.NewField("error", err.Error()))
+		case <-reload:
+			reload = nil
+			w.reload()
+		}
+	}
+}
+
+// affectsConfig reports whether event changes the configuration file, either directly or by
+// swapping the ConfigMap data link the file points through
+func (w *Watcher) affectsConfig(event fsnotify.Event) bool {
+	if !event.Has(fsnotify.Write | fsnotify.Create | fsnotify.Rename) {
+		return false
+	}
+	name := filepath.Clean(event.Name)
+	return name == w.path || name == filepath.Join(filepath.Dir(w.path), configMapDataLink)
+}
+
+// reload loads the file again and publishes it when it is valid
+func (w *Watcher) reload() {
+	config, err := loadFile(w.path)
+	if err != nil {
+		w.logger.Error("Ignoring invalid configuration change, keeping the previous configuration", err,
+			utils.NewField("path", w.path),
+		)
+		return
+	}
+	
+	w.mu.Lock()
+	w.current = config
+	w.mu.Unlock()
+	
+	// Replace a configuration the reader has not picked up yet with the newer one
+	select {
+	case <-w.changes:
+	default:
+	}
+	w.changes <- config
+	
+	w.logger.Info("Configuration reloaded", utils.NewField("path", w.path))
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startTestWatcher writes content to a config file and watches it until the test ends
+func startTestWatcher(t *testing.T, content string) (*Watcher, string, *utils.MockLogger) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0600))
+	
+	watcher, logger := watchTestConfig(t, configPath)
+	return watcher, configPath, logger
+}
+
+// watchTestConfig watches the config file at configPath until the test ends
+func watchTestConfig(t *testing.T, configPath string) (*Watcher, *utils.MockLogger) {
+	logger := utils.NewMockLogger()
+	watcher, err := NewWatcher(configPath, logger)
+	require.NoError(t, err)
+	watcher.SetReloadDelay(10 * time.Millisecond)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- watcher.Run(ctx)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		require.NoError(t, <-done)
+	})
+	
+	// Wait for the watch to be in place before editing the file
+	require.Eventually(t, func() bool {
+		return len(logger.GetEntriesByMessage("Watching configuration file")) == 1
+	}, time.Second, 5*time.Millisecond)
+	
+	return watcher, logger
+}
+
+func TestWatcher_Reload(t *testing.T) {
+	watcher, configPath, _ := startTestWatcher(t, testConfigFile)
+	assert.Equal(t, "https://file.atlassian.net", watcher.Current().Jira.URL)
+	
+	modified := strings.NewReplacer(
+		"https://file.atlassian.net", "https://edited.atlassian.net",
+		"log_level: info", "log_level: debug",
+	).Replace(testConfigFile)
+	require.NoError(t, os.WriteFile(configPath, []byte(modified), 0600))
+	
+	select {
+	case config := <-watcher.Changes():
+		assert.Equal(t, "https://edited.atlassian.net", config.Jira.URL)
+		assert.Equal(t, "debug", config.LogLevel)
+		assert.Same(t, config, watcher.Current())
+	case <-time.After(5 * time.Second):
+		t.Fatal("No configuration delivered after the file changed")
+	}
+}
+
+func TestWatcher_ConfigMapUpdate(t *testing.T) {
+	// Lay the directory out as Kubernetes mounts a ConfigMap: the file links through ..data
+	// to a timestamped directory, and an update swaps ..data to a new one
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v1", "config.yaml"), []byte(testConfigFile), 0600))
+	require.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "config.yaml"), filepath.Join(dir, "config.yaml")))
+	
+	watcher, _ := watchTestConfig(t, filepath.Join(dir, "config.yaml"))
+	assert.Equal(t, "https://file.atlassian.net", watcher.Current().Jira.URL)
+	
+	modified := strings.Replace(testConfigFile, "https://file.atlassian.net", "https://configmap.atlassian.net", 1)
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "..v2", "config.yaml"), []byte(modified), 0600))
+	require.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	require.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+	
+	select {
+	case config := <-watcher.Changes():
+		assert.Equal(t, "https://configmap.atlassian.net", config.Jira.URL)
+	case <-time.After(5 * time.Second):
+		t.Fatal("No configuration delivered after the ConfigMap was updated")
+	}
+}
+
+func TestWatcher_InvalidReloadIgnored(t *testing.T) {
+	watcher, configPath, logger := startTestWatcher(t, testConfigFile)
+	original := watcher.Current()
+	
+	// An out of range value fails validation
+	invalid := strings.Replace(testConfigFile, "temperature: 0.7", "temperature: 5", 1)
+	require.NoError(t, os.WriteFile(configPath, []byte(invalid), 0600))
+	
+	require.Eventually(t, func() bool {
+		return len(logger.GetEntriesByLevel(utils.LogLevelError)) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	
+	select {
+	case <-watcher.Changes():
+		t.Fatal("An invalid configuration was delivered")
+	default:
+	}
+	assert.Same(t, original, watcher.Current())
+	
+	// A later valid edit is picked up again
+	valid := strings.Replace(testConfigFile, "temperature: 0.7", "temperature: 0.3", 1)
+	require.NoError(t, os.WriteFile(configPath, []byte(valid), 0600))
+	
+	select {
+	case config := <-watcher.Changes():
+		assert.Equal(t, float32(0.3), config.Gemini.Temperature)
+	case <-time.After(5 * time.Second):
+		t.Fatal("No configuration delivered after the file was fixed")
+	}
+}
+
+func TestNewWatcher_InvalidConfig(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("jira: [not, a, map]"), 0600))
+	
+	_, err := NewWatcher(configPath, utils.NewMockLogger())
+	require.Error(t, err)
+	assert.Equal(t, "CONFIG_PARSE_FAILED", err.(*ConfigError).Code)
+}
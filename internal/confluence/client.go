@@ -0,0 +1,431 @@
+// Package confluence publishes executive summaries as Confluence pages through the Confluence
+// REST API, as an alternative to Google Docs.
+package confluence
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/utils"
+)
+
+const (
+	// Confluence REST API endpoints, relative to the site URL
+	ContentEndpoint     = "/rest/api/content"
+	PageEndpoint        = "/rest/api/content/%s"
+	LabelEndpoint       = "/rest/api/content/%s/label"
+	CurrentUserEndpoint = "/rest/api/user/current"
+	
+	// pageExpand is the page detail requested when reading a page
+	pageExpand = "version,space,ancestors"
+)
+
+// ConfluenceClientInterface defines the interface for Confluence client
+type ConfluenceClientInterface interface {
+	CreatePage(ctx context.Context, spaceKey, title, body, parentID string) (*Page, error)
+	UpdatePage(ctx context.Context, pageID, title, body string) (*Page, error)
+	GetPage(ctx context.Context, pageID string) (*Page, error)
+	SetParent(ctx context.Context, pageID, parentID string) (*Page, error)
+	AddLabels(ctx context.Context, pageID string, labels []string) error
+	ValidateCredentials(ctx context.Context) error
+	CreateExecutiveSummaryPage(ctx context.Context, summary *processor.SummaryResponse) (*Page, error)
+}
+
+// Client represents a Confluence API client
+type Client struct {
+	baseURL      string
+	username     string
+	spaceKey     string
+	parentPageID string
+	labels       []string
+	httpClient   *security.AuthenticatedHTTPClient
+	auth         *security.ConfluenceAuthenticator
+	rateLimiter  *utils.RateLimiter
+	retryConfig  *utils.RetryConfig
+	logger       utils.Logger
+}
+
+// NewClient creates a new Confluence client
+func NewClient(cfg *config.Config, authManager *security.AuthManager, logger utils.Logger) *Client {
+	// Create rate limiter (Confluence Cloud allows bursts well above this, but a summary run
+	// only needs a handful of requests)
+	rateLimiter := utils.NewRateLimiter(100, time.Minute, logger)
+	
+	// Create retry configuration
+	retryConfig := utils.DefaultRetryConfig()
+	retryConfig.RetryableErrors = append(retryConfig.RetryableErrors, utils.ErrorCodeConfluenceError)
+	
+	return &Client{
+		baseURL:      strings.TrimSuffix(cfg.Confluence.URL, "/"),
+		username:     cfg.Confluence.Username,
+		spaceKey:     cfg.Confluence.SpaceKey,
+		parentPageID: cfg.Confluence.ParentPageID,
+		labels:       cfg.Confluence.Labels,
+		httpClient:   authManager.GetHTTPClient(),
+		auth:         authManager.GetConfluenceAuthenticator(),
+		rateLimiter:  rateLimiter,
+		retryConfig:  retryConfig,
+		logger:       logger,
+	}
+}
+
+// CreatePage creates a page with a storage format body in the space with spaceKey, under the
+// page with parentID, or at the space root when parentID is empty
+func (c *Client) CreatePage(ctx context.Context, spaceKey, title, body, parentID string) (*Page, error) {
+	if spaceKey == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Space key is required", nil)
+	}
+	if title == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Page title is required", nil)
+	}
+	
+	createRequest := PageRequest{
+		Type:  "page",
+		Title: title,
+		Space: &Space{Key: spaceKey},
+		Body:  storageBody(body),
+	}
+	if parentID != "" {
+		createRequest.Ancestors = []Ancestor{{ID: parentID}}
+	}
+	
+	page := &Page{}
+	err := c.do(ctx, "POST", ContentEndpoint, createRequest, page, "Page creation failed")
+	if err != nil {
+		return nil, err
+	}
+	
+	c.log(ctx).Info("Created Confluence page",
+		utils.NewField("page_id", page.ID),
+		utils.NewField("title", page.Title),
+		utils.NewField("space_key", spaceKey),
+	)
+	
+	return page, nil
+}
+
+// UpdatePage replaces the body of a page, keeping its parent. An empty title keeps the current
+// title. The update names the version after the one read beforehand, so a concurrent edit makes
+// it fail with ErrorCodeConflict instead of being overwritten.
+func (c *Client) UpdatePage(ctx context.Context, pageID, title, body string) (*Page, error) {
+	current, err := c.GetPage(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+	
+	if title == "" {
+		title = current.Title
+	}
+	
+	updateRequest := PageRequest{
+		ID:      pageID,
+		Type:    "page",
+		Title:   title,
+		Body:    storageBody(body),
+		Version: &Version{Number: current.VersionNumber() + 1},
+	}
+	
+	page, err := c.putPage(ctx, pageID, updateRequest, "Page update failed")
+	if err != nil {
+		return nil, err
+	}
+	
+	c.log(ctx).Info("Updated Confluence page",
+		utils.NewField("page_id", pageID),
+		utils.NewField("version", page.VersionNumber()),
+	)
+	
+	return page, nil
+}
+
+// GetPage retrieves a page with its version, space and ancestors
+func (c *Client) GetPage(ctx context.Context, pageID string) (*Page, error) {
+	if pageID == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Page ID is required", nil)
+	}
+	
+	endpoint := fmt.Sprintf(PageEndpoint, url.PathEscape(pageID)) + "?expand=" + url.QueryEscape(pageExpand)
+	page := &Page{}
+	err := c.do(ctx, "GET", endpoint, nil, page, "Failed to get page")
+	if err != nil {
+		return nil, err
+	}
+	
+	return page, nil
+}
+
+// SetParent moves a page under the page with parentID, leaving its title and body unchanged
+func (c *Client) SetParent(ctx context.Context, pageID, parentID string) (*Page, error) {
+	if parentID == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Parent page ID is required", nil)
+	}
+	
+	current, err := c.GetPage(ctx, pageID)
+	if err != nil {
+		return nil, err
+	}
+	
+	moveRequest := PageRequest{
+		ID:        pageID,
+		Type:      "page",
+		Title:     current.Title,
+		Ancestors: []Ancestor{{ID: parentID}},
+		Version:   &Version{Number: current.VersionNumber() + 1},
+	}
+	
+	page, err := c.putPage(ctx, pageID, moveRequest, "Failed to move page")
+	if err != nil {
+		return nil, err
+	}
+	
+	c.log(ctx).Info("Moved Confluence page",
+		utils.NewField("page_id", pageID),
+		utils.NewField("parent_id", parentID),
+	)
+	
+	return page, nil
+}
+
+// AddLabels adds global labels to a page. Labels the page already has are left as they are.
+func (c *Client) AddLabels(ctx context.Context, pageID string, labels []string) error {
+	if pageID == "" {
+		return utils.NewAppError(utils.ErrorCodeDataInvalid, "Page ID is required", nil)
+	}
+	
+	var labelRequest []Label
+	for _, label := range labels {
+		// Confluence labels cannot contain spaces
+		name := strings.Join(strings.Fields(strings.ToLower(label)), "-")
+		if name != "" {
+			labelRequest = append(labelRequest, Label{Prefix: "global", Name: name})
+		}
+	}
+	if len(labelRequest) == 0 {
+		return nil
+	}
+	
+	endpoint := fmt.Sprintf(LabelEndpoint, url.PathEscape(pageID))
+	err := c.do(ctx, "POST", endpoint, labelRequest, nil, "Failed to add labels")
+	if err != nil {
+		return err
+	}
+	
+	c.log(ctx).Info("Added labels to Confluence page",
+		utils.NewField("page_id", pageID),
+		utils.NewField("labels_count", len(labelRequest)),
+	)
+	
+	return nil
+}
+
+// ValidateCredentials validates the stored token by reading the current user
+func (c *Client) ValidateCredentials(ctx context.Context) error {
+	err := c.do(ctx, "GET", CurrentUserEndpoint, nil, nil, "Confluence credential validation failed")
+	if err != nil {
+		return err
+	}
+	
+	c.log(ctx).Info("Confluence credentials validated successfully")
+	
+	return nil
+}
+
+// CreateExecutiveSummaryPage publishes summary as a new page in the configured space and under
+// the configured parent page, labelled with the configured labels. A failure to add labels is
+// logged rather than returned, since the page itself was created.
+func (c *Client) CreateExecutiveSummaryPage(ctx context.Context, summary *processor.SummaryResponse) (*Page, error) {
+	body, err := RenderStorage(summary)
+	if err != nil {
+		return nil, err
+	}
+	
+	page, err := c.CreatePage(ctx, c.spaceKey, PageTitle(summary), body, c.parentPageID)
+	if err != nil {
+		return nil, err
+	}
+	
+	c.addConfiguredLabels(ctx, page.ID)
+	
+	return page, nil
+}
+
+// UpsertExecutiveSummaryPage writes summary into the page with pageID, replacing its title and
+// body, so links to a canonical report keep working across runs. An empty pageID creates a new
+// page as CreateExecutiveSummaryPage does.
+func (c *Client) UpsertExecutiveSummaryPage(ctx context.Context, pageID string, summary *processor.SummaryResponse) (*Page, error) {
+	if pageID == "" {
+		return c.CreateExecutiveSummaryPage(ctx, summary)
+	}
+	
+	body, err := RenderStorage(summary)
+	if err != nil {
+		return nil, err
+	}
+	
+	page, err := c.UpdatePage(ctx, pageID, PageTitle(summary), body)
+	if err != nil {
+		return nil, err
+	}
+	
+	c.addConfiguredLabels(ctx, page.ID)
+	
+	return page, nil
+}
+
+// addConfiguredLabels adds the configured labels to a published page, logging failures
+func (c *Client) addConfiguredLabels(ctx context.Context, pageID string) {
+	if err := c.AddLabels(ctx, pageID, c.labels); err != nil {
+		c.log(ctx).Warn("Failed to label Confluence page",
+			utils.NewField("page_id", pageID),
+			utils.NewField("error", err.Error()),
+		)
+	}
+}
+
+// putPage sends a page update
+func (c *Client) putPage(ctx context.Context, pageID string, request PageRequest, message string) (*Page, error) {
+	page := &Page{}
+	endpoint := fmt.Sprintf(PageEndpoint, url.PathEscape(pageID))
+	if err := c.do(ctx, "PUT", endpoint, request, page, message); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// do sends a JSON request with retries and rate limiting, decoding a successful response into
+// out unless out is nil. message describes the operation in errors.
+func (c *Client) do(ctx context.Context, method, endpoint string, request interface{}, out interface{}, message string) error {
+	var reqBody []byte
+	if request != nil {
+		var err error
+		reqBody, err = json.Marshal(request)
+		if err != nil {
+			return utils.NewAppError(utils.ErrorCodeConfluenceError, "Failed to marshal request", err)
+		}
+	}
+	
+	return utils.RetryWithRateLimit(ctx, c.retryConfig, c.rateLimiter, func() error {
+		// Create HTTP request
+		req, err := c.createRequest(ctx, method, endpoint, reqBody)
+		if err != nil {
+			return err
+		}
+		
+		// Make request
+		resp, err := c.httpClient.DoRequest(req)
+		if err != nil {
+			return utils.WrapError(err, utils.ErrorCodeConfluenceError, message)
+		}
+		defer resp.Body.Close()
+		
+		// Handle error responses
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return c.handleErrorResponse(resp, message)
+		}
+		
+		// Read response
+		body, err := c.httpClient.ReadBody(resp)
+		if err != nil {
+			return utils.NewAppError(utils.ErrorCodeConfluenceError, "Failed to read response", err)
+		}
+		
+		// Parse response
+		if out != nil {
+			if err := json.Unmarshal(body, out); err != nil {
+				return utils.NewAppError(utils.ErrorCodeConfluenceError, "Failed to parse response", err)
+			}
+		}
+		
+		return nil
+	}, c.logger)
+}
+
+// log returns the client's logger carrying the correlation ID of the run in ctx
+func (c *Client) log(ctx context.Context) utils.Logger {
+	return utils.LoggerWithContext(ctx, c.logger)
+}
+
+// createRequest creates an authenticated HTTP request for the Confluence API
+func (c *Client) createRequest(ctx context.Context, method, endpoint string, body []byte) (*http.Request, error) {
+	fullURL := c.baseURL + endpoint
+	
+	var bodyReader io.Reader
+	if body != nil {
+		bodyReader = bytes.NewReader(body)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, method, fullURL, bodyReader)
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeConfluenceError, "Failed to create request", err)
+	}
+	
+	// Add authentication headers
+	err = c.auth.AddAuthHeaders(req, c.username)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeConfluenceError, "Failed to add auth headers")
+	}
+	
+	req.Header.Set("Accept", "application/json")
+	
+	// Set content type for requests with a body
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	
+	return req, nil
+}
+
+// handleErrorResponse handles HTTP error responses
+func (c *Client) handleErrorResponse(resp *http.Response, message string) error {
+	body, _ := c.httpClient.ReadBody(resp)
+	
+	var errorCode utils.ErrorCode
+	switch resp.StatusCode {
+	case http.StatusUnauthorized:
+		errorCode = utils.ErrorCodeAPIUnauthorized
+	case http.StatusForbidden:
+		errorCode = utils.ErrorCodeAPIUnauthorized
+	case http.StatusNotFound:
+		errorCode = utils.ErrorCodeAPINotFound
+	case http.StatusConflict:
+		errorCode = utils.ErrorCodeConflict
+	case http.StatusTooManyRequests:
+		errorCode = utils.ErrorCodeAPIRateLimit
+	case http.StatusBadRequest:
+		errorCode = utils.ErrorCodeAPIBadRequest
+	default:
+		if resp.StatusCode >= 500 {
+			errorCode = utils.ErrorCodeAPIServerError
+		} else {
+			errorCode = utils.ErrorCodeConfluenceError
+		}
+	}
+	
+	// Try to parse error response
+	var confluenceError ErrorResponse
+	if err := json.Unmarshal(body, &confluenceError); err != nil {
+		message = fmt.Sprintf("%s: %s", message, utils.DescribeErrorBody(resp.StatusCode, body))
+	} else if confluenceError.Message != "" {
+		message = fmt.Sprintf("%s: %s", message, confluenceError.Message)
+	}
+	
+	return utils.NewAppError(errorCode, message, nil).
+		WithService("confluence").
+		WithExtra("status_code", resp.StatusCode).
+		WithExtra("response_body", string(body))
+}
+
+// storageBody wraps storage format content as a page body
+func storageBody(value string) *Body {
+	return &Body{Storage: &Storage{Value: value, Representation: RepresentationStorage}}
+}
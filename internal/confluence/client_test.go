@@ -0,0 +1,293 @@
+package confluence
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockConfluence is an in-memory Confluence site serving the content endpoints the client uses
+type mockConfluence struct {
+	t      *testing.T
+	mu     sync.Mutex
+	pages  map[string]*Page
+	labels map[string][]Label
+	nextID int
+	status int // When set, every request fails with this status
+}
+
+func newMockConfluence(t *testing.T) (*mockConfluence, *httptest.Server) {
+	mock := &mockConfluence{
+		t:      t,
+		pages:  make(map[string]*Page),
+		labels: make(map[string][]Label),
+		nextID: 1000,
+	}
+	return mock, httptest.NewServer(http.HandlerFunc(mock.serve))
+}
+
+func (m *mockConfluence) serve(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	username, token, ok := r.BasicAuth()
+	if !ok || username != "user@example.com" || token != "test_token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if m.status != 0 {
+		w.WriteHeader(m.status)
+		json.NewEncoder(w).Encode(ErrorResponse{StatusCode: m.status, Message: "Mock failure"})
+		return
+	}
+	
+	path := strings.TrimPrefix(r.URL.Path, "/wiki/rest/api/content")
+	switch {
+	case r.Method == "POST" && path == "":
+		var request PageRequest
+		require.NoError(m.t, json.NewDecoder(r.Body).Decode(&request))
+		m.nextID++
+		page := &Page{
+			ID:        strconv.Itoa(m.nextID),
+			Type:      request.Type,
+			Status:    "current",
+			Title:     request.Title,
+			Space:     request.Space,
+			Ancestors: request.Ancestors,
+			Body:      request.Body,
+			Version:   &Version{Number: 1},
+		}
+		m.pages[page.ID] = page
+		m.writePage(w, page)
+	case r.Method == "GET" && m.pages[strings.TrimPrefix(path, "/")] != nil:
+		assert.Equal(m.t, pageExpand, r.URL.Query().Get("expand"))
+		m.writePage(w, m.pages[strings.TrimPrefix(path, "/")])
+	case r.Method == "PUT" && m.pages[strings.TrimPrefix(path, "/")] != nil:
+		page := m.pages[strings.TrimPrefix(path, "/")]
+		var request PageRequest
+		require.NoError(m.t, json.NewDecoder(r.Body).Decode(&request))
+		if request.Version == nil || request.Version.Number != page.Version.Number+1 {
+			w.WriteHeader(http.StatusConflict)
+			json.NewEncoder(w).Encode(ErrorResponse{StatusCode: http.StatusConflict, Message: "Version must be incremented"})
+			return
+		}
+		page.Title = request.Title
+		page.Version = request.Version
+		if request.Body != nil {
+			page.Body = request.Body
+		}
+		if request.Ancestors != nil {
+			page.Ancestors = request.Ancestors
+		}
+		m.writePage(w, page)
+	case r.Method == "POST" && strings.HasSuffix(path, "/label"):
+		pageID := strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/label")
+		var labels []Label
+		require.NoError(m.t, json.NewDecoder(r.Body).Decode(&labels))
+		m.labels[pageID] = append(m.labels[pageID], labels...)
+		json.NewEncoder(w).Encode(map[string]interface{}{"results": m.labels[pageID]})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(ErrorResponse{StatusCode: http.StatusNotFound, Message: "No content found"})
+	}
+}
+
+func (m *mockConfluence) writePage(w http.ResponseWriter, page *Page) {
+	response := *page
+	response.Links = &Links{Base: "https://example.atlassian.net/wiki", WebUI: "/spaces/ENG/pages/" + page.ID}
+	json.NewEncoder(w).Encode(response)
+}
+
+func newTestClient(t *testing.T, serverURL string) *Client {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	cfg.Confluence.URL = serverURL + "/wiki/"
+	cfg.Confluence.Username = "user@example.com"
+	cfg.Confluence.SpaceKey = "ENG"
+	cfg.Confluence.ParentPageID = "42"
+	cfg.Confluence.Labels = []string{"executive summary", "weekly"}
+	
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	credStore := authManager.GetCredentialStore()
+	require.NoError(t, credStore.SetConfluenceCredentials(security.ConfluenceCredentials{Token: "test_token"}))
+	t.Cleanup(func() {
+		credStore.ClearAllCredentials()
+	})
+	
+	client := NewClient(cfg, authManager, logger)
+	client.retryConfig.InitialDelay = time.Millisecond
+	client.retryConfig.MaxDelay = time.Millisecond
+	return client
+}
+
+func testSummary() *processor.SummaryResponse {
+	return &processor.SummaryResponse{
+		Title:            "Weekly Report",
+		PeriodLabel:      "Week of March 4, 2024",
+		ExecutiveSummary: "Delivery is on track.",
+		KeyMetrics:       processor.SummaryKeyMetrics{TotalActivities: 12, CompletedActivities: 9, CompletionRate: 75},
+		Highlights:       []string{"Shipped search"},
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	_, server := newMockConfluence(t)
+	defer server.Close()
+	
+	client := newTestClient(t, server.URL)
+	
+	assert.Equal(t, server.URL+"/wiki", client.baseURL)
+	assert.Equal(t, "user@example.com", client.username)
+	assert.Equal(t, "ENG", client.spaceKey)
+	assert.NotNil(t, client.auth)
+	assert.NotNil(t, client.rateLimiter)
+	assert.Contains(t, client.retryConfig.RetryableErrors, utils.ErrorCodeConfluenceError)
+}
+
+func TestClient_CreatePage(t *testing.T) {
+	mock, server := newMockConfluence(t)
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	
+	page, err := client.CreatePage(context.Background(), "ENG", "Weekly Report", "<p>Hello</p>", "42")
+	require.NoError(t, err)
+	
+	assert.Equal(t, "Weekly Report", page.Title)
+	assert.Equal(t, 1, page.VersionNumber())
+	assert.Equal(t, "https://example.atlassian.net/wiki/spaces/ENG/pages/"+page.ID, page.URL())
+	
+	stored := mock.pages[page.ID]
+	require.NotNil(t, stored)
+	assert.Equal(t, "page", stored.Type)
+	assert.Equal(t, &Space{Key: "ENG"}, stored.Space)
+	assert.Equal(t, []Ancestor{{ID: "42"}}, stored.Ancestors)
+	assert.Equal(t, &Storage{Value: "<p>Hello</p>", Representation: RepresentationStorage}, stored.Body.Storage)
+	
+	// A space and title are required
+	_, err = client.CreatePage(context.Background(), "", "Weekly Report", "", "")
+	assert.Error(t, err)
+	_, err = client.CreatePage(context.Background(), "ENG", "", "", "")
+	assert.Error(t, err)
+}
+
+func TestClient_UpdatePage(t *testing.T) {
+	mock, server := newMockConfluence(t)
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	
+	created, err := client.CreatePage(context.Background(), "ENG", "Weekly Report", "<p>First</p>", "42")
+	require.NoError(t, err)
+	
+	// An empty title keeps the current one and the parent is left alone
+	updated, err := client.UpdatePage(context.Background(), created.ID, "", "<p>Second</p>")
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated.VersionNumber())
+	assert.Equal(t, "Weekly Report", updated.Title)
+	assert.Equal(t, "<p>Second</p>", mock.pages[created.ID].Body.Storage.Value)
+	assert.Equal(t, []Ancestor{{ID: "42"}}, mock.pages[created.ID].Ancestors)
+	
+	updated, err = client.UpdatePage(context.Background(), created.ID, "Renamed", "<p>Third</p>")
+	require.NoError(t, err)
+	assert.Equal(t, 3, updated.VersionNumber())
+	assert.Equal(t, "Renamed", updated.Title)
+	
+	// A missing page is reported as not found
+	_, err = client.UpdatePage(context.Background(), "404", "", "<p>Lost</p>")
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeAPINotFound, appErr.Code)
+}
+
+func TestClient_SetParentAndAddLabels(t *testing.T) {
+	mock, server := newMockConfluence(t)
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	
+	created, err := client.CreatePage(context.Background(), "ENG", "Weekly Report", "<p>Body</p>", "")
+	require.NoError(t, err)
+	
+	moved, err := client.SetParent(context.Background(), created.ID, "77")
+	require.NoError(t, err)
+	assert.Equal(t, 2, moved.VersionNumber())
+	assert.Equal(t, []Ancestor{{ID: "77"}}, mock.pages[created.ID].Ancestors)
+	assert.Equal(t, "<p>Body</p>", mock.pages[created.ID].Body.Storage.Value)
+	
+	err = client.AddLabels(context.Background(), created.ID, []string{"Executive Summary", " ", "q1"})
+	require.NoError(t, err)
+	assert.Equal(t, []Label{
+		{Prefix: "global", Name: "executive-summary"},
+		{Prefix: "global", Name: "q1"},
+	}, mock.labels[created.ID])
+}
+
+func TestClient_CreateExecutiveSummaryPage(t *testing.T) {
+	mock, server := newMockConfluence(t)
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	
+	page, err := client.CreateExecutiveSummaryPage(context.Background(), testSummary())
+	require.NoError(t, err)
+	
+	stored := mock.pages[page.ID]
+	assert.Equal(t, "Weekly Report: Week of March 4, 2024", stored.Title)
+	assert.Equal(t, []Ancestor{{ID: "42"}}, stored.Ancestors)
+	assert.Contains(t, stored.Body.Storage.Value, "<p>Delivery is on track.</p>")
+	assert.Equal(t, []Label{
+		{Prefix: "global", Name: "executive-summary"},
+		{Prefix: "global", Name: "weekly"},
+	}, mock.labels[page.ID])
+	
+	// Upserting the same page updates it in place
+	summary := testSummary()
+	summary.ExecutiveSummary = "Delivery slipped."
+	upserted, err := client.UpsertExecutiveSummaryPage(context.Background(), page.ID, summary)
+	require.NoError(t, err)
+	assert.Equal(t, page.ID, upserted.ID)
+	assert.Equal(t, 2, upserted.VersionNumber())
+	assert.Contains(t, mock.pages[page.ID].Body.Storage.Value, "<p>Delivery slipped.</p>")
+	assert.Len(t, mock.pages, 1)
+}
+
+func TestClient_ErrorResponses(t *testing.T) {
+	mock, server := newMockConfluence(t)
+	defer server.Close()
+	client := newTestClient(t, server.URL)
+	
+	tests := []struct {
+		status int
+		code   utils.ErrorCode
+	}{
+		{http.StatusBadRequest, utils.ErrorCodeAPIBadRequest},
+		{http.StatusForbidden, utils.ErrorCodeAPIUnauthorized},
+		{http.StatusConflict, utils.ErrorCodeConflict},
+		{http.StatusServiceUnavailable, utils.ErrorCodeAPIServerError},
+	}
+	
+	for _, tt := range tests {
+		mock.mu.Lock()
+		mock.status = tt.status
+		mock.mu.Unlock()
+		
+		_, err := client.CreatePage(context.Background(), "ENG", "Weekly Report", "", "")
+		require.Error(t, err)
+		appErr, ok := err.(*utils.AppError)
+		require.True(t, ok)
+		assert.Equal(t, tt.code, appErr.Code)
+		assert.Contains(t, appErr.Message, "Mock failure")
+		assert.Equal(t, tt.status, appErr.Context.Extra["status_code"])
+	}
+}
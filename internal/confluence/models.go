@@ -0,0 +1,89 @@
+package confluence
+
+// RepresentationStorage is the Confluence storage format, the XHTML pages are saved in
+const RepresentationStorage = "storage"
+
+// PageRequest represents a request to create or update a page
+type PageRequest struct {
+	ID        string     `json:"id,omitempty"`
+	Type      string     `json:"type"`
+	Title     string     `json:"title"`
+	Space     *Space     `json:"space,omitempty"`
+	Ancestors []Ancestor `json:"ancestors,omitempty"`
+	Body      *Body      `json:"body,omitempty"`
+	Version   *Version   `json:"version,omitempty"`
+}
+
+// Page represents a Confluence page
+type Page struct {
+	ID        string     `json:"id"`
+	Type      string     `json:"type"`
+	Status    string     `json:"status"`
+	Title     string     `json:"title"`
+	Space     *Space     `json:"space,omitempty"`
+	Ancestors []Ancestor `json:"ancestors,omitempty"`
+	Body      *Body      `json:"body,omitempty"`
+	Version   *Version   `json:"version,omitempty"`
+	Links     *Links     `json:"_links,omitempty"`
+}
+
+// Space identifies the space a page belongs to
+type Space struct {
+	Key string `json:"key"`
+}
+
+// Ancestor identifies a parent page
+type Ancestor struct {
+	ID string `json:"id"`
+}
+
+// Body holds the content of a page
+type Body struct {
+	Storage *Storage `json:"storage,omitempty"`
+}
+
+// Storage is page content in a given representation
+type Storage struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+// Version is a page version. Updates must carry the next version number.
+type Version struct {
+	Number  int    `json:"number"`
+	Message string `json:"message,omitempty"`
+}
+
+// Links holds the links returned with a page
+type Links struct {
+	Base  string `json:"base,omitempty"`
+	WebUI string `json:"webui,omitempty"`
+}
+
+// Label represents a page label
+type Label struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+// ErrorResponse represents a Confluence API error
+type ErrorResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Message    string `json:"message"`
+}
+
+// URL returns the browser URL of the page, or an empty string when the response had no links
+func (p *Page) URL() string {
+	if p.Links == nil || p.Links.WebUI == "" {
+		return ""
+	}
+	return p.Links.Base + p.Links.WebUI
+}
+
+// VersionNumber returns the page's current version, zero when unknown
+func (p *Page) VersionNumber() int {
+	if p.Version == nil {
+		return 0
+	}
+	return p.Version.Number
+}
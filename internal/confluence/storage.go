@@ -0,0 +1,128 @@
+package confluence
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// storageData is the view of a summary the storage template renders
+type storageData struct {
+	PeriodLabel      string
+	GeneratedAt      string
+	ExecutiveSummary []string // Paragraphs
+	Metrics          []processor.MetricRow
+	Highlights       []string
+	Concerns         []string
+	Recommendations  []string
+}
+
+// storageFuncs lets the template pass a titled list to its "list" template
+var storageFuncs = map[string]interface{}{
+	"section": processor.ListSection,
+}
+
+// storageTemplate renders a summary as Confluence storage format. Storage format is XHTML, so
+// every element is closed; html/template escapes text with numeric character references, which
+// XHTML accepts.
+var storageTemplate = template.Must(template.New("storage").Funcs(storageFuncs).Parse(`
+{{- if or .PeriodLabel .GeneratedAt}}<p><em>
+{{- .PeriodLabel}}{{if and .PeriodLabel .GeneratedAt}} | {{end}}{{if .GeneratedAt}}Generated: {{.GeneratedAt}}{{end -}}
+</em></p>{{end}}
+{{- if .ExecutiveSummary}}<h2>Executive Summary</h2>
+{{- range .ExecutiveSummary}}<p>{{.}}</p>{{end}}
+{{- end}}<h2>Key Metrics</h2><table><tbody>
+{{- range .Metrics}}<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>{{end -}}
+</tbody></table>
+{{- template "list" section "Highlights" .Highlights}}
+{{- template "list" section "Concerns" .Concerns}}
+{{- template "list" section "Recommendations" .Recommendations}}
+{{- define "list"}}{{if .Items}}<h2>{{.Title}}</h2><ul>{{range .Items}}<li>{{.}}</li>{{end}}</ul>{{end}}{{end}}`))
+
+// RenderStorage formats summary as the body of a Confluence page in storage format: the
+// executive summary, a key metrics table, and the highlights, concerns and recommendations as
+// lists. The page title carries the summary title, so the body does not repeat it.
+func RenderStorage(summary *processor.SummaryResponse) (string, error) {
+	if summary == nil {
+		return "", utils.NewAppError(utils.ErrorCodeValidationError, "Summary is required", nil).
+			WithService("confluence")
+	}
+	
+	var body bytes.Buffer
+	if err := storageTemplate.Execute(&body, newStorageData(summary)); err != nil {
+		return "", utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to render Confluence page").
+			WithService("confluence")
+	}
+	
+	return body.String(), nil
+}
+
+// PageTitle returns the title a summary is published under. Titles are unique within a space,
+// so the period label is included to keep each period's page apart.
+func PageTitle(summary *processor.SummaryResponse) string {
+	title := summary.Title
+	if title == "" {
+		title = "Executive Summary"
+	}
+	if summary.PeriodLabel != "" {
+		title = fmt.Sprintf("%s: %s", title, summary.PeriodLabel)
+	}
+	return xmlText(title)
+}
+
+// newStorageData prepares a summary for the storage template
+func newStorageData(summary *processor.SummaryResponse) storageData {
+	data := storageData{
+		PeriodLabel:     xmlText(summary.PeriodLabel),
+		Metrics:         summary.MetricRows(),
+		Highlights:      xmlTexts(summary.Highlights),
+		Concerns:        xmlTexts(summary.Concerns),
+		Recommendations: xmlTexts(summary.PublishedRecommendations()),
+	}
+	for i := range data.Metrics {
+		data.Metrics[i].Value = xmlText(data.Metrics[i].Value)
+	}
+	
+	if !summary.GeneratedAt.IsZero() {
+		data.GeneratedAt = summary.GeneratedAt.Format("January 2, 2006 at 3:04 PM")
+	}
+	
+	// Blank lines separate paragraphs of the executive summary
+	for _, paragraph := range strings.Split(summary.ExecutiveSummary, "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			data.ExecutiveSummary = append(data.ExecutiveSummary, xmlText(paragraph))
+		}
+	}
+	
+	return data
+}
+
+// xmlText drops the control characters XML 1.0 does not allow, which Confluence rejects even
+// when escaped
+func xmlText(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		if r == 0xFFFE || r == 0xFFFF {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// xmlTexts applies xmlText to each item
+func xmlTexts(items []string) []string {
+	if items == nil {
+		return nil
+	}
+	cleaned := make([]string, len(items))
+	for i, item := range items {
+		cleaned[i] = xmlText(item)
+	}
+	return cleaned
+}
@@ -0,0 +1,70 @@
+package confluence
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertWellFormed fails unless body parses as XML, as Confluence requires of storage format
+func assertWellFormed(t *testing.T, body string) {
+	decoder := xml.NewDecoder(strings.NewReader("<root>" + body + "</root>"))
+	for {
+		_, err := decoder.Token()
+		if err == io.EOF {
+			return
+		}
+		require.NoError(t, err, body)
+	}
+}
+
+func TestRenderStorage(t *testing.T) {
+	summary := testSummary()
+	summary.GeneratedAt = time.Date(2024, 3, 8, 15, 4, 0, 0, time.UTC)
+	summary.ExecutiveSummary = "First paragraph.\n\nSecond paragraph."
+	summary.Concerns = []string{"Review backlog"}
+	summary.OwnedRecommendations = []processor.OwnedRecommendation{{Text: "Add reviewers", Owner: "Alice"}}
+	
+	body, err := RenderStorage(summary)
+	require.NoError(t, err)
+	assertWellFormed(t, body)
+	
+	assert.Contains(t, body, "<p><em>Week of March 4, 2024 | Generated: March 8, 2024 at 3:04 PM</em></p>")
+	assert.Contains(t, body, "<h2>Executive Summary</h2><p>First paragraph.</p><p>Second paragraph.</p>")
+	assert.Contains(t, body, "<tr><th>Total activities</th><td>12</td></tr>")
+	assert.Contains(t, body, "<tr><th>Completion rate</th><td>75.0%</td></tr>")
+	assert.Contains(t, body, "<h2>Highlights</h2><ul><li>Shipped search</li></ul>")
+	assert.Contains(t, body, "<h2>Concerns</h2><ul><li>Review backlog</li></ul>")
+	assert.Contains(t, body, "<li>Add reviewers (Owner: Alice)</li>")
+	
+	// The summary title is the page title, not part of the body
+	assert.NotContains(t, body, "Weekly Report")
+}
+
+func TestRenderStorage_EscapesText(t *testing.T) {
+	summary := testSummary()
+	summary.ExecutiveSummary = `Fixed <script> & "quotes" in R&D`
+	summary.Highlights = []string{"Bell\a character", "</li></ul><h1>Injected"}
+	
+	body, err := RenderStorage(summary)
+	require.NoError(t, err)
+	assertWellFormed(t, body)
+	
+	assert.Contains(t, body, "Fixed &lt;script&gt; &amp; &#34;quotes&#34; in R&amp;D")
+	assert.Contains(t, body, "<li>Bell character</li>")
+	assert.NotContains(t, body, "<h1>")
+	
+	_, err = RenderStorage(nil)
+	assert.Error(t, err)
+}
+
+func TestPageTitle(t *testing.T) {
+	assert.Equal(t, "Weekly Report: Week of March 4, 2024", PageTitle(testSummary()))
+	assert.Equal(t, "Executive Summary", PageTitle(&processor.SummaryResponse{}))
+}
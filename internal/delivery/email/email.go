@@ -0,0 +1,225 @@
+// Package email sends executive summaries by email over SMTP, as an HTML message with a
+// plaintext alternative.
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// DefaultSMTPPort is the submission port used when the configuration sets none
+const DefaultSMTPPort = 587
+
+// Sender delivers rendered summaries through an SMTP server
+type Sender struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+	now      func() time.Time // Date header source, replaced in tests
+}
+
+// NewSender creates a sender from the email section of the configuration. The connection
+// authenticates only when a username is configured.
+func NewSender(cfg *config.Config) *Sender {
+	port := cfg.Email.SMTPPort
+	if port == 0 {
+		port = DefaultSMTPPort
+	}
+	
+	from := cfg.Email.From
+	if from == "" {
+		from = cfg.Email.Username
+	}
+	
+	return &Sender{
+		host:     cfg.Email.SMTPHost,
+		port:     port,
+		username: cfg.Email.Username,
+		password: cfg.Email.Password,
+		from:     from,
+		now:      time.Now,
+	}
+}
+
+// SendSummary renders summary and sends it to every recipient in a single message
+func (s *Sender) SendSummary(ctx context.Context, recipients []string, summary *processor.SummaryResponse, docURL string) error {
+	content, err := Render(summary, docURL)
+	if err != nil {
+		return err
+	}
+	return s.Send(ctx, recipients, content)
+}
+
+// Send delivers content to recipients. STARTTLS is used whenever the server offers it.
+func (s *Sender) Send(ctx context.Context, recipients []string, content *Content) error {
+	addresses, err := s.validate(recipients)
+	if err != nil {
+		return err
+	}
+	
+	message, err := s.buildMessage(addresses, content)
+	if err != nil {
+		return err
+	}
+	
+	addr := net.JoinHostPort(s.host, strconv.Itoa(s.port))
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return s.sendError(err, "Failed to connect to SMTP server")
+	}
+	defer conn.Close()
+	
+	// net/smtp does not take a context, so its deadline bounds the whole exchange
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		return s.sendError(err, "Failed to start SMTP session")
+	}
+	defer client.Close()
+	
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.host, MinVersion: tls.VersionTLS12}); err != nil {
+			return s.sendError(err, "Failed to start TLS with SMTP server")
+		}
+	}
+	
+	if s.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return utils.WrapError(err, utils.ErrorCodeAuthFailed, "SMTP authentication failed").
+				WithService("email").
+				WithExtra("smtp_host", s.host)
+		}
+	}
+	
+	if err := client.Mail(s.from); err != nil {
+		return s.sendError(err, "SMTP server rejected the sender")
+	}
+	for _, address := range addresses {
+		if err := client.Rcpt(address.Address); err != nil {
+			return s.sendError(err, "SMTP server rejected a recipient").WithExtra("recipient", address.Address)
+		}
+	}
+	
+	writer, err := client.Data()
+	if err != nil {
+		return s.sendError(err, "Failed to send message")
+	}
+	if _, err := writer.Write(message); err != nil {
+		return s.sendError(err, "Failed to send message")
+	}
+	if err := writer.Close(); err != nil {
+		return s.sendError(err, "SMTP server rejected the message")
+	}
+	
+	if err := client.Quit(); err != nil {
+		return s.sendError(err, "Failed to close SMTP session")
+	}
+	return nil
+}
+
+// validate checks the server settings and parses the recipient addresses before connecting
+func (s *Sender) validate(recipients []string) ([]*mail.Address, error) {
+	if s.host == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeConfigInvalid, "SMTP host is not configured", nil).
+			WithService("email")
+	}
+	if s.from == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeConfigInvalid, "Email sender address is not configured", nil).
+			WithService("email")
+	}
+	if len(recipients) == 0 {
+		return nil, utils.NewAppError(utils.ErrorCodeValidationError, "At least one recipient is required", nil).
+			WithService("email")
+	}
+	
+	addresses := make([]*mail.Address, len(recipients))
+	for i, recipient := range recipients {
+		address, err := mail.ParseAddress(recipient)
+		if err != nil {
+			return nil, utils.NewAppError(utils.ErrorCodeValidationError, "Invalid recipient address", err).
+				WithService("email").
+				WithExtra("recipient", recipient)
+		}
+		addresses[i] = address
+	}
+	
+	return addresses, nil
+}
+
+// buildMessage encodes content as a multipart/alternative message, plaintext part first so
+// clients prefer the HTML
+func (s *Sender) buildMessage(recipients []*mail.Address, content *Content) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	
+	for _, part := range []struct {
+		contentType string
+		text        string
+	}{
+		{contentType: "text/plain; charset=UTF-8", text: content.Text},
+		{contentType: "text/html; charset=UTF-8", text: content.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to encode email").WithService("email")
+		}
+		
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.text)); err != nil {
+			return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to encode email").WithService("email")
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to encode email").WithService("email")
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to encode email").WithService("email")
+	}
+	
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", s.from)
+	to := make([]string, len(recipients))
+	for i, recipient := range recipients {
+		to[i] = recipient.String()
+	}
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("UTF-8", content.Subject))
+	fmt.Fprintf(&message, "Date: %s\r\n", s.now().Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", parts.Boundary())
+	message.Write(body.Bytes())
+	
+	return message.Bytes(), nil
+}
+
+// sendError wraps a failure talking to the SMTP server
+func (s *Sender) sendError(err error, message string) *utils.AppError {
+	return utils.WrapError(err, utils.ErrorCodeNetworkError, message).
+		WithService("email").
+		WithExtra("smtp_host", s.host)
+}
@@ -0,0 +1,223 @@
+package email
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// smtpSession records what a client sent to the mock SMTP server
+type smtpSession struct {
+	auth       string
+	from       string
+	recipients []string
+	data       string
+}
+
+// startSMTPServer accepts a single SMTP session on a local port. Recipients in reject are
+// refused. The session is delivered once the client quits or disconnects.
+func startSMTPServer(t *testing.T, reject ...string) (string, int, <-chan smtpSession) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+	
+	sessions := make(chan smtpSession, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		
+		session := smtpSession{}
+		defer func() { sessions <- session }()
+		
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+		reply("220 localhost ESMTP ready")
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			line = strings.TrimRight(line, "\r\n")
+			command := strings.ToUpper(line)
+			
+			switch {
+			case strings.HasPrefix(command, "EHLO"):
+				reply("250-localhost")
+				reply("250 AUTH PLAIN")
+			case strings.HasPrefix(command, "AUTH PLAIN"):
+				session.auth = strings.TrimSpace(line[len("AUTH PLAIN"):])
+				reply("235 Authentication successful")
+			case strings.HasPrefix(command, "MAIL FROM:"):
+				session.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+				reply("250 OK")
+			case strings.HasPrefix(command, "RCPT TO:"):
+				recipient := strings.Trim(line[len("RCPT TO:"):], "<> ")
+				rejected := false
+				for _, address := range reject {
+					rejected = rejected || address == recipient
+				}
+				if rejected {
+					reply("550 No such user")
+					continue
+				}
+				session.recipients = append(session.recipients, recipient)
+				reply("250 OK")
+			case command == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(strings.TrimPrefix(dataLine, "."))
+				}
+				session.data = data.String()
+				reply("250 OK queued")
+			case command == "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("250 OK")
+			}
+		}
+	}()
+	
+	addr := listener.Addr().(*net.TCPAddr)
+	return addr.IP.String(), addr.Port, sessions
+}
+
+func createTestSender(host string, port int) *Sender {
+	cfg := config.DefaultConfig()
+	cfg.Email.SMTPHost = host
+	cfg.Email.SMTPPort = port
+	cfg.Email.Username = "reports@example.com"
+	cfg.Email.Password = "secret"
+	sender := NewSender(cfg)
+	sender.now = func() time.Time { return time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC) }
+	return sender
+}
+
+func TestSender_SendSummary(t *testing.T) {
+	host, port, sessions := startSMTPServer(t)
+	sender := createTestSender(host, port)
+	
+	recipients := []string{"Chief Executive <ceo@example.com>", "cto@example.com"}
+	docURL := "https://docs.google.com/document/d/doc-1/edit"
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	
+	err := sender.SendSummary(ctx, recipients, createTestSummary(), docURL)
+	require.NoError(t, err)
+	
+	session := <-sessions
+	credentials, err := base64.StdEncoding.DecodeString(session.auth)
+	require.NoError(t, err)
+	assert.Equal(t, "\x00reports@example.com\x00secret", string(credentials))
+	assert.Equal(t, "reports@example.com", session.from)
+	assert.Equal(t, []string{"ceo@example.com", "cto@example.com"}, session.recipients)
+	
+	message, err := mail.ReadMessage(strings.NewReader(session.data))
+	require.NoError(t, err)
+	assert.Equal(t, "reports@example.com", message.Header.Get("From"))
+	to, err := message.Header.AddressList("To")
+	require.NoError(t, err)
+	assert.Equal(t, []*mail.Address{{Name: "Chief Executive", Address: "ceo@example.com"}, {Address: "cto@example.com"}}, to)
+	assert.Equal(t, "Weekly Summary: Week of Mar 4, 2024", message.Header.Get("Subject"))
+	assert.Equal(t, "Mon, 11 Mar 2024 09:00:00 +0000", message.Header.Get("Date"))
+	
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	require.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+	
+	// Plaintext fallback first, then the HTML body
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	var contentTypes, bodies []string
+	for {
+		part, err := parts.NextPart()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(part)
+		require.NoError(t, err)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		bodies = append(bodies, string(body))
+	}
+	assert.Equal(t, []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}, contentTypes)
+	require.Len(t, bodies, 2)
+	assert.Contains(t, bodies[0], "Full summary: "+docURL)
+	assert.Contains(t, bodies[1], "<h2>Key Metrics</h2>")
+	assert.Contains(t, bodies[1], `<a href="`+docURL+`">Open the full summary</a>`)
+}
+
+func TestSender_Send_RejectedRecipient(t *testing.T) {
+	host, port, _ := startSMTPServer(t, "nobody@example.com")
+	sender := createTestSender(host, port)
+	
+	content, err := Render(createTestSummary(), "")
+	require.NoError(t, err)
+	
+	err = sender.Send(context.Background(), []string{"ceo@example.com", "nobody@example.com"}, content)
+	require.Error(t, err)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeNetworkError, appErr.Code)
+	assert.Equal(t, "nobody@example.com", appErr.Context.Extra["recipient"])
+}
+
+func TestSender_Send_ValidationErrors(t *testing.T) {
+	content := &Content{Subject: "Summary", Text: "text", HTML: "<p>html</p>"}
+	
+	tests := []struct {
+		name       string
+		host       string
+		recipients []string
+		code       utils.ErrorCode
+	}{
+		{name: "missing host", host: "", recipients: []string{"ceo@example.com"}, code: utils.ErrorCodeConfigInvalid},
+		{name: "no recipients", host: "smtp.example.com", recipients: nil, code: utils.ErrorCodeValidationError},
+		{name: "invalid recipient", host: "smtp.example.com", recipients: []string{"not an address"}, code: utils.ErrorCodeValidationError},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := createTestSender(tt.host, 25).Send(context.Background(), tt.recipients, content)
+			require.Error(t, err)
+			
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, appErr.Code)
+		})
+	}
+}
+
+func TestNewSender_Defaults(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Email.SMTPHost = "smtp.example.com"
+	cfg.Email.Username = "reports@example.com"
+	
+	sender := NewSender(cfg)
+	assert.Equal(t, DefaultSMTPPort, sender.port)
+	assert.Equal(t, "reports@example.com", sender.from)
+}
@@ -0,0 +1,151 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// Content is a summary rendered as the parts of an email
+type Content struct {
+	Subject string
+	HTML    string
+	Text    string // Plaintext alternative for clients that do not show HTML
+}
+
+// templateData is the view of a summary the templates render
+type templateData struct {
+	Title            string
+	PeriodLabel      string
+	ExecutiveSummary string
+	Metrics          []processor.MetricRow
+	Highlights       []string
+	Concerns         []string
+	Recommendations  []string
+	DocURL           string
+}
+
+// templateFuncs lets both templates pass a titled list to their shared "list" template
+var templateFuncs = map[string]interface{}{
+	"section": processor.ListSection,
+}
+
+var htmlTemplate = htmltemplate.Must(htmltemplate.New("summary").Funcs(templateFuncs).Parse(`<!DOCTYPE html>
+<html>
+<body style="font-family: Arial, sans-serif; color: #202124;">
+<h1>{{.Title}}</h1>
+{{- if .PeriodLabel}}
+<p style="color: #5f6368;">{{.PeriodLabel}}</p>
+{{- end}}
+{{- if .ExecutiveSummary}}
+<h2>Executive Summary</h2>
+<p>{{.ExecutiveSummary}}</p>
+{{- end}}
+<h2>Key Metrics</h2>
+<table cellpadding="6" style="border-collapse: collapse;">
+{{- range .Metrics}}
+<tr><td style="border: 1px solid #dadce0;"><strong>{{.Name}}</strong></td><td style="border: 1px solid #dadce0;">{{.Value}}</td></tr>
+{{- end}}
+</table>
+{{- template "list" section "Highlights" .Highlights}}
+{{- template "list" section "Concerns" .Concerns}}
+{{- template "list" section "Recommendations" .Recommendations}}
+{{- if .DocURL}}
+<p><a href="{{.DocURL}}">Open the full summary</a></p>
+{{- end}}
+</body>
+</html>
+{{define "list"}}{{if .Items}}
+<h2>{{.Title}}</h2>
+<ul>
+{{- range .Items}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}{{end}}`))
+
+var textTemplate = texttemplate.Must(texttemplate.New("summary").Funcs(templateFuncs).Parse(`{{.Title}}
+{{- if .PeriodLabel}}
+{{.PeriodLabel}}
+{{- end}}
+{{- if .ExecutiveSummary}}
+
+EXECUTIVE SUMMARY
+{{.ExecutiveSummary}}
+{{- end}}
+
+KEY METRICS
+{{- range .Metrics}}
+{{.Name}}: {{.Value}}
+{{- end}}
+{{- template "list" section "HIGHLIGHTS" .Highlights}}
+{{- template "list" section "CONCERNS" .Concerns}}
+{{- template "list" section "RECOMMENDATIONS" .Recommendations}}
+{{- if .DocURL}}
+
+Full summary: {{.DocURL}}
+{{- end}}
+{{define "list"}}{{if .Items}}
+
+{{.Title}}
+{{- range .Items}}
+- {{.}}
+{{- end}}
+{{- end}}{{end}}`))
+
+// Render formats summary as an email with an HTML body and a plaintext alternative. The HTML
+// holds the metrics table, highlights, concerns, recommendations and, when docURL is set, a
+// link to the full document.
+func Render(summary *processor.SummaryResponse, docURL string) (*Content, error) {
+	if summary == nil {
+		return nil, utils.NewAppError(utils.ErrorCodeValidationError, "Summary is required", nil).
+			WithService("email")
+	}
+	
+	data := newTemplateData(summary, docURL)
+	
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, data); err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to render HTML email").
+			WithService("email")
+	}
+	if err := textTemplate.Execute(&text, data); err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to render plaintext email").
+			WithService("email")
+	}
+	
+	subject := data.Title
+	if data.PeriodLabel != "" {
+		subject = fmt.Sprintf("%s: %s", data.Title, data.PeriodLabel)
+	}
+	
+	return &Content{
+		Subject: subject,
+		HTML:    html.String(),
+		Text:    strings.TrimSpace(text.String()) + "\n",
+	}, nil
+}
+
+// newTemplateData prepares a summary for the templates
+func newTemplateData(summary *processor.SummaryResponse, docURL string) templateData {
+	title := summary.Title
+	if title == "" {
+		title = "Executive Summary"
+	}
+	
+	return templateData{
+		Title:            title,
+		PeriodLabel:      summary.PeriodLabel,
+		ExecutiveSummary: summary.ExecutiveSummary,
+		Metrics:          summary.MetricRows(),
+		Highlights:       summary.Highlights,
+		Concerns:         summary.Concerns,
+		Recommendations:  summary.PublishedRecommendations(),
+		DocURL:           docURL,
+	}
+}
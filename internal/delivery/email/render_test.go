@@ -0,0 +1,95 @@
+package email
+
+import (
+	"testing"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSummary() *processor.SummaryResponse {
+	return &processor.SummaryResponse{
+		Title:            "Weekly Summary",
+		PeriodLabel:      "Week of Mar 4, 2024",
+		ExecutiveSummary: "The team closed out the login work.",
+		KeyMetrics: processor.SummaryKeyMetrics{
+			TotalActivities:     12,
+			CompletedActivities: 9,
+			CompletionRate:      75,
+			TotalTimeSpent:      "3d 4h",
+			AverageTimePerTask:  "2h 40m",
+			ProductivityScore:   82.5,
+			ActiveUsers:         4,
+			TopPriority:         "High",
+			MostActiveUser:      "Alice",
+		},
+		Highlights:      []string{"Shipped the login page", "Search is <2x> faster"},
+		Concerns:        []string{"Payment API is blocked"},
+		Recommendations: []string{"Unblock the payment API"},
+	}
+}
+
+func TestRender(t *testing.T) {
+	docURL := "https://docs.google.com/document/d/doc-1/edit"
+	content, err := Render(createTestSummary(), docURL)
+	require.NoError(t, err)
+	
+	assert.Equal(t, "Weekly Summary: Week of Mar 4, 2024", content.Subject)
+	
+	html := content.HTML
+	assert.Contains(t, html, "<h1>Weekly Summary</h1>")
+	assert.Contains(t, html, "<h2>Executive Summary</h2>\n<p>The team closed out the login work.</p>")
+	assert.Contains(t, html, "<h2>Key Metrics</h2>")
+	assert.Contains(t, html, "<strong>Completion rate</strong></td><td style=\"border: 1px solid #dadce0;\">75.0%</td>")
+	assert.Contains(t, html, "<strong>Most active user</strong></td><td style=\"border: 1px solid #dadce0;\">Alice</td>")
+	assert.Contains(t, html, "<h2>Highlights</h2>\n<ul>\n<li>Shipped the login page</li>")
+	assert.Contains(t, html, "<h2>Concerns</h2>\n<ul>\n<li>Payment API is blocked</li>")
+	assert.Contains(t, html, "<h2>Recommendations</h2>\n<ul>\n<li>Unblock the payment API</li>")
+	assert.Contains(t, html, `<a href="`+docURL+`">Open the full summary</a>`)
+	// Summary text is escaped
+	assert.Contains(t, html, "Search is &lt;2x&gt; faster")
+	
+	text := content.Text
+	assert.Contains(t, text, "KEY METRICS\nTotal activities: 12\nCompleted activities: 9\nCompletion rate: 75.0%")
+	assert.Contains(t, text, "HIGHLIGHTS\n- Shipped the login page\n- Search is <2x> faster")
+	assert.Contains(t, text, "CONCERNS\n- Payment API is blocked")
+	assert.Contains(t, text, "RECOMMENDATIONS\n- Unblock the payment API")
+	assert.Contains(t, text, "Full summary: "+docURL)
+}
+
+func TestRender_OmitsEmptySections(t *testing.T) {
+	summary := createTestSummary()
+	summary.Concerns = nil
+	summary.Recommendations = nil
+	summary.PeriodLabel = ""
+	
+	content, err := Render(summary, "")
+	require.NoError(t, err)
+	
+	assert.Equal(t, "Weekly Summary", content.Subject)
+	assert.Contains(t, content.HTML, "<h2>Highlights</h2>")
+	assert.NotContains(t, content.HTML, "Concerns")
+	assert.NotContains(t, content.HTML, "Recommendations")
+	assert.NotContains(t, content.HTML, "<a href")
+	assert.NotContains(t, content.Text, "CONCERNS")
+	assert.NotContains(t, content.Text, "Full summary")
+}
+
+func TestRender_OwnedRecommendations(t *testing.T) {
+	summary := createTestSummary()
+	summary.OwnedRecommendations = []processor.OwnedRecommendation{
+		{Text: "Unblock the payment API", Owner: "Bob"},
+	}
+	
+	content, err := Render(summary, "")
+	require.NoError(t, err)
+	
+	assert.Contains(t, content.HTML, "<li>Unblock the payment API (Owner: Bob)</li>")
+	assert.Contains(t, content.Text, "- Unblock the payment API (Owner: Bob)")
+}
+
+func TestRender_NilSummary(t *testing.T) {
+	_, err := Render(nil, "")
+	assert.Error(t, err)
+}
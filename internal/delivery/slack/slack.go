@@ -0,0 +1,209 @@
+// Package slack posts executive summaries to Slack through incoming webhooks, formatted as
+// Block Kit messages.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// Slack Block Kit limits
+const (
+	maxHeaderText  = 150  // Characters in a header block
+	maxSectionText = 3000 // Characters in a section block's text
+	maxFieldText   = 2000 // Characters in one section field
+	maxListItem    = 500  // Characters kept of a single highlight or concern
+	maxErrorBody   = 1024 // Bytes of an error response kept for the error
+)
+
+// MaxListItems is the number of highlights and concerns included in a message. Longer lists end
+// with a count of the items left out; the full lists are in the linked document.
+const MaxListItems = 5
+
+// webhookTimeout bounds a single webhook post
+const webhookTimeout = 30 * time.Second
+
+// httpClient posts to webhooks
+var httpClient = &http.Client{Timeout: webhookTimeout}
+
+// Message is the payload of an incoming webhook post
+type Message struct {
+	Text   string  `json:"text"` // Notification fallback for clients that cannot show blocks
+	Blocks []Block `json:"blocks"`
+}
+
+// Block is a Block Kit layout block
+type Block struct {
+	Type     string  `json:"type"`
+	Text     *Text   `json:"text,omitempty"`
+	Fields   []*Text `json:"fields,omitempty"`
+	Elements []*Text `json:"elements,omitempty"`
+}
+
+// Text is a Block Kit text object
+type Text struct {
+	Type string `json:"type"` // "plain_text" or "mrkdwn"
+	Text string `json:"text"`
+}
+
+// PostSummary posts the key metrics, top highlights and concerns of summary to a Slack
+// incoming webhook, with a link to the full document when docURL is set
+func PostSummary(ctx context.Context, webhookURL string, summary *processor.SummaryResponse, docURL string) error {
+	if webhookURL == "" {
+		return utils.NewAppError(utils.ErrorCodeValidationError, "Slack webhook URL is required", nil).
+			WithService("slack")
+	}
+	if summary == nil {
+		return utils.NewAppError(utils.ErrorCodeValidationError, "Summary is required", nil).
+			WithService("slack")
+	}
+	
+	payload, err := json.Marshal(BuildMessage(summary, docURL))
+	if err != nil {
+		return utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to encode Slack message").
+			WithService("slack")
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to create Slack request").
+			WithService("slack")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return utils.WrapError(err, utils.ErrorCodeNetworkError, "Failed to post summary to Slack").
+			WithService("slack")
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		code := utils.ErrorCodeAPIServerError
+		if resp.StatusCode < 500 {
+			code = utils.ErrorCodeAPIBadRequest
+		}
+		// The webhook URL is a secret, so only the response is recorded
+		return utils.NewAppError(code, fmt.Sprintf("Slack rejected the summary with status %d", resp.StatusCode), nil).
+			WithService("slack").
+			WithExtra("status_code", resp.StatusCode).
+			WithExtra("response", strings.TrimSpace(string(body)))
+	}
+	
+	return nil
+}
+
+// BuildMessage formats summary as a Block Kit message: a header, the period, the key metrics,
+// up to MaxListItems highlights and concerns and the document link
+func BuildMessage(summary *processor.SummaryResponse, docURL string) Message {
+	title := summary.Title
+	if title == "" {
+		title = "Executive Summary"
+	}
+	
+	blocks := []Block{
+		{Type: "header", Text: &Text{Type: "plain_text", Text: truncate(title, maxHeaderText)}},
+	}
+	if summary.PeriodLabel != "" {
+		blocks = append(blocks, Block{
+			Type:     "context",
+			Elements: []*Text{{Type: "mrkdwn", Text: escape(summary.PeriodLabel)}},
+		})
+	}
+	
+	blocks = append(blocks, Block{Type: "section", Fields: metricFields(summary.KeyMetrics)})
+	
+	if section := listSection("Highlights", summary.Highlights); section != nil {
+		blocks = append(blocks, *section)
+	}
+	if section := listSection("Concerns", summary.Concerns); section != nil {
+		blocks = append(blocks, *section)
+	}
+	
+	if docURL != "" {
+		blocks = append(blocks,
+			Block{Type: "divider"},
+			Block{Type: "section", Text: &Text{Type: "mrkdwn", Text: fmt.Sprintf("<%s|Open the full summary>", docURL)}},
+		)
+	}
+	
+	return Message{Text: title, Blocks: blocks}
+}
+
+// metricFields lays out the key metrics as section fields; Slack shows at most ten
+func metricFields(metrics processor.SummaryKeyMetrics) []*Text {
+	rows := [][2]string{
+		{"Activities", fmt.Sprintf("%d", metrics.TotalActivities)},
+		{"Completed", fmt.Sprintf("%d (%.1f%%)", metrics.CompletedActivities, metrics.CompletionRate)},
+		{"Time spent", metrics.TotalTimeSpent},
+		{"Average per task", metrics.AverageTimePerTask},
+		{"Productivity score", fmt.Sprintf("%.1f", metrics.ProductivityScore)},
+		{"Active users", fmt.Sprintf("%d", metrics.ActiveUsers)},
+		{"Top priority", metrics.TopPriority},
+		{"Most active user", metrics.MostActiveUser},
+	}
+	
+	fields := make([]*Text, 0, len(rows))
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		fields = append(fields, &Text{
+			Type: "mrkdwn",
+			Text: truncate(fmt.Sprintf("*%s*\n%s", row[0], escape(row[1])), maxFieldText),
+		})
+	}
+	return fields
+}
+
+// listSection formats a bulleted list under a bold title, or returns nil for an empty list.
+// Items past MaxListItems, or that would push the text over Slack's limit, are counted
+// instead of listed.
+func listSection(title string, items []string) *Block {
+	if len(items) == 0 {
+		return nil
+	}
+	
+	var text strings.Builder
+	text.WriteString("*" + title + "*")
+	listed := 0
+	for _, item := range items {
+		line := "\n• " + escape(truncate(item, maxListItem))
+		// Leave room for the count of items left out
+		if listed == MaxListItems || utf8.RuneCountInString(text.String()+line) > maxSectionText-40 {
+			break
+		}
+		text.WriteString(line)
+		listed++
+	}
+	if remaining := len(items) - listed; remaining > 0 {
+		fmt.Fprintf(&text, "\n_…and %d more_", remaining)
+	}
+	
+	return &Block{Type: "section", Text: &Text{Type: "mrkdwn", Text: text.String()}}
+}
+
+// escape replaces the characters Slack reserves for links and mentions
+func escape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// truncate shortens text to at most limit characters, ending with an ellipsis when cut
+func truncate(text string, limit int) string {
+	if utf8.RuneCountInString(text) <= limit {
+		return text
+	}
+	runes := []rune(text)
+	return string(runes[:limit-1]) + "…"
+}
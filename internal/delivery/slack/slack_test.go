@@ -0,0 +1,157 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestSummary() *processor.SummaryResponse {
+	return &processor.SummaryResponse{
+		Title:       "Weekly Summary",
+		PeriodLabel: "Week of Mar 4, 2024",
+		KeyMetrics: processor.SummaryKeyMetrics{
+			TotalActivities:     12,
+			CompletedActivities: 9,
+			CompletionRate:      75,
+			TotalTimeSpent:      "3d 4h",
+			AverageTimePerTask:  "2h 40m",
+			ProductivityScore:   82.5,
+			ActiveUsers:         4,
+			TopPriority:         "High",
+			MostActiveUser:      "Alice",
+		},
+		Highlights: []string{"Shipped the login page", "Search is <2x> faster & leaner"},
+		Concerns:   []string{"Payment API is blocked"},
+	}
+}
+
+// messageText joins all text of a message for content assertions
+func messageText(message Message) string {
+	var parts []string
+	for _, block := range message.Blocks {
+		if block.Text != nil {
+			parts = append(parts, block.Text.Text)
+		}
+		for _, field := range append(block.Fields, block.Elements...) {
+			parts = append(parts, field.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+func TestPostSummary(t *testing.T) {
+	var received Message
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/services/T000/B000/XXXX", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+	
+	docURL := "https://docs.google.com/document/d/doc-1/edit"
+	err := PostSummary(context.Background(), server.URL+"/services/T000/B000/XXXX", createTestSummary(), docURL)
+	require.NoError(t, err)
+	
+	assert.Equal(t, "Weekly Summary", received.Text)
+	require.NotEmpty(t, received.Blocks)
+	assert.Equal(t, "header", received.Blocks[0].Type)
+	assert.Equal(t, "Weekly Summary", received.Blocks[0].Text.Text)
+	
+	text := messageText(received)
+	assert.Contains(t, text, "Week of Mar 4, 2024")
+	assert.Contains(t, text, "*Activities*\n12")
+	assert.Contains(t, text, "*Completed*\n9 (75.0%)")
+	assert.Contains(t, text, "*Time spent*\n3d 4h")
+	assert.Contains(t, text, "*Productivity score*\n82.5")
+	assert.Contains(t, text, "*Most active user*\nAlice")
+	assert.Contains(t, text, "*Highlights*\n• Shipped the login page\n• Search is &lt;2x&gt; faster &amp; leaner")
+	assert.Contains(t, text, "*Concerns*\n• Payment API is blocked")
+	assert.Contains(t, text, "<"+docURL+"|Open the full summary>")
+}
+
+func TestPostSummary_Rejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte("invalid_blocks"))
+	}))
+	defer server.Close()
+	
+	err := PostSummary(context.Background(), server.URL, createTestSummary(), "")
+	require.Error(t, err)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeAPIBadRequest, appErr.Code)
+	assert.Equal(t, "slack", appErr.Context.Service)
+	assert.Equal(t, http.StatusBadRequest, appErr.Context.Extra["status_code"])
+	assert.Equal(t, "invalid_blocks", appErr.Context.Extra["response"])
+}
+
+func TestPostSummary_ValidationErrors(t *testing.T) {
+	err := PostSummary(context.Background(), "", createTestSummary(), "")
+	assert.Error(t, err)
+	
+	err = PostSummary(context.Background(), "https://hooks.slack.com/services/x", nil, "")
+	assert.Error(t, err)
+}
+
+func TestBuildMessage_TruncatesLongLists(t *testing.T) {
+	summary := createTestSummary()
+	summary.Title = strings.Repeat("T", 200)
+	summary.Highlights = nil
+	for i := 1; i <= 8; i++ {
+		summary.Highlights = append(summary.Highlights, fmt.Sprintf("Highlight %d", i))
+	}
+	summary.Concerns = []string{strings.Repeat("x", 5000)}
+	
+	message := BuildMessage(summary, "")
+	
+	assert.Equal(t, maxHeaderText, len([]rune(message.Blocks[0].Text.Text)))
+	
+	var highlights, concerns string
+	for _, block := range message.Blocks {
+		if block.Text == nil {
+			continue
+		}
+		if strings.HasPrefix(block.Text.Text, "*Highlights*") {
+			highlights = block.Text.Text
+		}
+		if strings.HasPrefix(block.Text.Text, "*Concerns*") {
+			concerns = block.Text.Text
+		}
+		assert.LessOrEqual(t, len([]rune(block.Text.Text)), maxSectionText)
+	}
+	
+	assert.Equal(t, MaxListItems, strings.Count(highlights, "\n• "))
+	assert.Contains(t, highlights, "• Highlight 5")
+	assert.NotContains(t, highlights, "Highlight 6")
+	assert.Contains(t, highlights, "_…and 3 more_")
+	assert.Contains(t, concerns, "…")
+	assert.NotContains(t, concerns, "more_")
+}
+
+func TestBuildMessage_OmitsEmptySections(t *testing.T) {
+	summary := createTestSummary()
+	summary.Highlights = nil
+	summary.Concerns = nil
+	summary.PeriodLabel = ""
+	
+	message := BuildMessage(summary, "")
+	
+	// Header and metrics only, with no document link
+	require.Len(t, message.Blocks, 2)
+	assert.Equal(t, "header", message.Blocks[0].Type)
+	assert.Len(t, message.Blocks[1].Fields, 8)
+}
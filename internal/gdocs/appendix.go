@@ -0,0 +1,101 @@
+package gdocs
+
+import (
+	"strings"
+
+	"github.com/company/eesa/pkg/models"
+)
+
+// DescriptionMode controls how issue descriptions are rendered in the appendix
+type DescriptionMode string
+
+const (
+	DescriptionModeFull      DescriptionMode = "full"
+	DescriptionModeTruncated DescriptionMode = "truncated"
+	DescriptionModeOmitted   DescriptionMode = "omitted"
+
+	// DefaultAppendixDescriptionLength is the truncation length used when none is configured
+	DefaultAppendixDescriptionLength = 200
+
+	// MetadataKeyIssues is the metadata key holding the []models.Activity listed in the appendix
+	MetadataKeyIssues = "issues"
+
+	appendixHeading = "Appendix: Issues"
+)
+
+// AppendixOptions configures the issue table appended after the executive summary
+type AppendixOptions struct {
+	Enabled              bool            `json:"enabled"`
+	DescriptionMode      DescriptionMode `json:"description_mode"`
+	MaxDescriptionLength int             `json:"max_description_length"` // Used in truncated mode
+}
+
+// DefaultAppendixOptions returns appendix options with descriptions truncated
+func DefaultAppendixOptions() AppendixOptions {
+	return AppendixOptions{
+		Enabled:              false,
+		DescriptionMode:      DescriptionModeTruncated,
+		MaxDescriptionLength: DefaultAppendixDescriptionLength,
+	}
+}
+
+// SetAppendixOptions configures the issue appendix for executive summary documents
+func (c *Client) SetAppendixOptions(opts AppendixOptions) {
+	c.appendix = opts
+}
+
+// formatAppendix renders the issue table as tab separated rows under a heading.
+// Descriptions only affect the appendix; the summary text is never modified.
+func (c *Client) formatAppendix(issues []models.Activity) string {
+	includeDescription := c.appendix.DescriptionMode != DescriptionModeOmitted
+	
+	header := []string{"Key", "Summary", "Status", "Assignee"}
+	if includeDescription {
+		header = append(header, "Description")
+	}
+	
+	lines := []string{appendixHeading, strings.Join(header, "\t")}
+	for _, issue := range issues {
+		assignee := issue.Assignee.DisplayName
+		if assignee == "" {
+			assignee = "Unassigned"
+		}
+		
+		row := []string{issue.Key, issue.Summary, issue.Status, assignee}
+		if includeDescription {
+			row = append(row, c.appendixDescription(issue.Description))
+		}
+		lines = append(lines, strings.Join(row, "\t"))
+	}
+	
+	return strings.Join(lines, "\n")
+}
+
+// appendixDescription flattens a description onto one line and applies the configured mode
+func (c *Client) appendixDescription(description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	
+	switch c.appendix.DescriptionMode {
+	case DescriptionModeOmitted:
+		return ""
+	case DescriptionModeFull:
+		return description
+	default:
+		maxLength := c.appendix.MaxDescriptionLength
+		if maxLength <= 0 {
+			maxLength = DefaultAppendixDescriptionLength
+		}
+		
+		runes := []rune(description)
+		if len(runes) <= maxLength {
+			return description
+		}
+		return strings.TrimSpace(string(runes[:maxLength])) + "..."
+	}
+}
+
+// appendixIssues extracts the appendix issues from document metadata
+func appendixIssues(metadata map[string]interface{}) []models.Activity {
+	issues, _ := metadata[MetadataKeyIssues].([]models.Activity)
+	return issues
+}
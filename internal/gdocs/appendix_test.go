@@ -0,0 +1,145 @@
+package gdocs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAppendixTestClient(opts AppendixOptions) *Client {
+	logger := utils.NewMockLogger()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	client := NewClient(&config.Config{}, authManager, logger)
+	client.SetAppendixOptions(opts)
+	return client
+}
+
+func createAppendixIssues() []models.Activity {
+	return []models.Activity{
+		{
+			Key:         "PROJ-1",
+			Summary:     "Fix login bug",
+			Status:      "Done",
+			Description: "Users were logged out\nafter   every refresh. " + strings.Repeat("x", 50),
+			Assignee:    models.User{DisplayName: "User One"},
+		},
+		{
+			Key:     "PROJ-2",
+			Summary: "Add password reset",
+			Status:  "In Progress",
+		},
+	}
+}
+
+// appendixText returns the appendix insert from the built requests, if any
+func appendixText(requests []Request) string {
+	for _, req := range requests {
+		if req.InsertText != nil && strings.Contains(req.InsertText.Text, appendixHeading) {
+			return req.InsertText.Text
+		}
+	}
+	return ""
+}
+
+func TestDefaultAppendixOptions(t *testing.T) {
+	opts := DefaultAppendixOptions()
+
+	assert.False(t, opts.Enabled)
+	assert.Equal(t, DescriptionModeTruncated, opts.DescriptionMode)
+	assert.Equal(t, DefaultAppendixDescriptionLength, opts.MaxDescriptionLength)
+}
+
+func TestClient_Appendix_DescriptionModes(t *testing.T) {
+	summary := "Summary body"
+	fullDescription := "Users were logged out after every refresh. " + strings.Repeat("x", 50)
+
+	tests := []struct {
+		name     string
+		opts     AppendixOptions
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "full",
+			opts:     AppendixOptions{Enabled: true, DescriptionMode: DescriptionModeFull},
+			contains: []string{"Key\tSummary\tStatus\tAssignee\tDescription", "PROJ-1\tFix login bug\tDone\tUser One\t" + fullDescription},
+		},
+		{
+			name:     "truncated",
+			opts:     AppendixOptions{Enabled: true, DescriptionMode: DescriptionModeTruncated, MaxDescriptionLength: 12},
+			contains: []string{"Key\tSummary\tStatus\tAssignee\tDescription", "PROJ-1\tFix login bug\tDone\tUser One\tUsers were l..."},
+			excludes: []string{fullDescription},
+		},
+		{
+			name:     "omitted",
+			opts:     AppendixOptions{Enabled: true, DescriptionMode: DescriptionModeOmitted},
+			contains: []string{"Key\tSummary\tStatus\tAssignee\n", "PROJ-1\tFix login bug\tDone\tUser One\n"},
+			excludes: []string{"Description", "logged out"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newAppendixTestClient(tt.opts)
+
+			requests := client.buildExecutiveSummaryRequests("Title", summary, map[string]interface{}{
+				"model":           "gemini-pro",
+				MetadataKeyIssues: createAppendixIssues(),
+			})
+
+			text := appendixText(requests)
+			require.NotEmpty(t, text)
+			for _, expected := range tt.contains {
+				assert.Contains(t, text, expected)
+			}
+			for _, unexpected := range tt.excludes {
+				assert.NotContains(t, text, unexpected)
+			}
+			assert.Contains(t, text, "PROJ-2\tAdd password reset\tIn Progress\tUnassigned")
+
+			// The summary itself is never affected by the appendix mode
+			var summaryInserts []string
+			for _, req := range requests {
+				if req.InsertText != nil && req.InsertText.Text == summary {
+					summaryInserts = append(summaryInserts, req.InsertText.Text)
+				}
+			}
+			assert.Len(t, summaryInserts, 1)
+		})
+	}
+}
+
+func TestClient_Appendix_Disabled(t *testing.T) {
+	client := newAppendixTestClient(DefaultAppendixOptions())
+
+	requests := client.buildExecutiveSummaryRequests("Title", "Summary body", map[string]interface{}{
+		MetadataKeyIssues: createAppendixIssues(),
+	})
+
+	assert.Empty(t, appendixText(requests))
+}
+
+func TestClient_Appendix_Placement(t *testing.T) {
+	client := newAppendixTestClient(AppendixOptions{Enabled: true, DescriptionMode: DescriptionModeOmitted})
+
+	requests := client.buildExecutiveSummaryRequests("Title", "Summary body", map[string]interface{}{
+		MetadataKeyIssues: createAppendixIssues(),
+	})
+
+	require.GreaterOrEqual(t, len(requests), 2)
+	summaryReq := requests[len(requests)-3]
+	appendixReq := requests[len(requests)-2]
+	styleReq := requests[len(requests)-1]
+
+	assert.Equal(t, "Summary body", summaryReq.InsertText.Text)
+	assert.Equal(t, summaryReq.InsertText.Location.Index+int32(len("Summary body")), appendixReq.InsertText.Location.Index)
+	require.NotNil(t, styleReq.UpdateTextStyle)
+	assert.Equal(t, appendixReq.InsertText.Location.Index+2, styleReq.UpdateTextStyle.Range.StartIndex)
+	assert.Equal(t, styleReq.UpdateTextStyle.Range.StartIndex+int32(len(appendixHeading)), styleReq.UpdateTextStyle.Range.EndIndex)
+}
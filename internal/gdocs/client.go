@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
+	"unicode/utf16"
 
 	"github.com/company/eesa/internal/config"
 	"github.com/company/eesa/internal/security"
@@ -25,14 +27,36 @@ const (
 	// Google Drive API endpoints for sharing
 	DriveBaseURL      = "https://www.googleapis.com"
 	ShareEndpoint     = "/drive/v3/files/%s/permissions"
+	DeleteEndpoint    = "/drive/v3/files/%s"
+	ExportEndpoint    = "/drive/v3/files/%s/export?mimeType=%s"
+	
+	// MimeTypePDF is the export format for static copies of a document
+	MimeTypePDF = "application/pdf"
+	
+	// DocumentURLFormat is the browser URL of a document, formatted with its ID
+	DocumentURLFormat = "https://docs.google.com/document/d/%s/edit"
 )
 
+// DocumentURL returns the URL for opening a document in the browser
+func DocumentURL(documentID string) string {
+	return fmt.Sprintf(DocumentURLFormat, documentID)
+}
+
+// DefaultMaxInsertChars caps the summary text sent in a single InsertText request, in UTF-16
+// code units. Larger summaries are split into sequential inserts.
+const DefaultMaxInsertChars = 30000
+
+// MetadataKeyLanguage is the metadata key holding the language tag the summary is written in.
+// The Docs API has no per-document locale, so the language is shown in the metadata line.
+const MetadataKeyLanguage = "language"
+
 // GoogleDocsClientInterface defines the interface for Google Docs client
 type GoogleDocsClientInterface interface {
 	CreateDocument(ctx context.Context, title string, content string) (*DocumentResponse, error)
 	UpdateDocument(ctx context.Context, documentID string, requests []Request) (*BatchUpdateResponse, error)
 	GetDocument(ctx context.Context, documentID string) (*DocumentResponse, error)
 	ShareDocument(ctx context.Context, documentID string, emails []string, role string) error
+	DeleteDocument(ctx context.Context, documentID string) error
 	ValidateCredentials(ctx context.Context) error
 	CreateExecutiveSummaryDocument(ctx context.Context, title, summary string, metadata map[string]interface{}) (*DocumentResponse, error)
 }
@@ -46,6 +70,12 @@ type Client struct {
 	rateLimiter *utils.RateLimiter
 	retryConfig *utils.RetryConfig
 	logger      utils.Logger
+	sectionDividers bool
+	appendix    AppendixOptions
+	maxInsertChars int
+	renderMarkdown bool
+	issueLinks     bool
+	jiraBaseURL    string // Target of issue links
 }
 
 // NewClient creates a new Google Docs client
@@ -65,6 +95,8 @@ func NewClient(cfg *config.Config, authManager *security.AuthManager, logger uti
 		rateLimiter: rateLimiter,
 		retryConfig: retryConfig,
 		logger:      logger,
+		appendix:    DefaultAppendixOptions(),
+		jiraBaseURL: strings.TrimRight(cfg.Jira.URL, "/"),
 	}
 }
 
@@ -94,7 +126,7 @@ func (c *Client) CreateDocument(ctx context.Context, title string, content strin
 		}
 		
 		// Make request
-		resp, err := c.httpClient.DoRequest(req)
+		resp, err := c.auth.Do(req)
 		if err != nil {
 			return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to create document")
 		}
@@ -106,7 +138,7 @@ func (c *Client) CreateDocument(ctx context.Context, title string, content strin
 		}
 		
 		// Read response
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeGoogleError, "Failed to read response", err)
 		}
@@ -135,14 +167,14 @@ func (c *Client) CreateDocument(ctx context.Context, title string, content strin
 			},
 		})
 		if err != nil {
-			c.logger.Warn("Failed to add content to document",
+			c.log(ctx).Warn("Failed to add content to document",
 				utils.NewField("document_id", response.DocumentID),
 				utils.NewField("error", err.Error()),
 			)
 		}
 	}
 
-	c.logger.Info("Created Google Docs document",
+	c.log(ctx).Info("Created Google Docs document",
 		utils.NewField("document_id", response.DocumentID),
 		utils.NewField("title", response.Title),
 	)
@@ -152,6 +184,12 @@ func (c *Client) CreateDocument(ctx context.Context, title string, content strin
 
 // UpdateDocument updates a Google Docs document with batch requests
 func (c *Client) UpdateDocument(ctx context.Context, documentID string, requests []Request) (*BatchUpdateResponse, error) {
+	return c.updateDocument(ctx, documentID, requests, nil)
+}
+
+// updateDocument sends batch requests, failing without changes when writeControl names a
+// revision the document has moved past
+func (c *Client) updateDocument(ctx context.Context, documentID string, requests []Request, writeControl *WriteControl) (*BatchUpdateResponse, error) {
 	if documentID == "" {
 		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Document ID is required", nil)
 	}
@@ -160,7 +198,8 @@ func (c *Client) UpdateDocument(ctx context.Context, documentID string, requests
 	}
 
 	updateRequest := BatchUpdateDocumentRequest{
-		Requests: requests,
+		Requests:     requests,
+		WriteControl: writeControl,
 	}
 
 	var response *BatchUpdateResponse
@@ -180,7 +219,7 @@ func (c *Client) UpdateDocument(ctx context.Context, documentID string, requests
 		}
 		
 		// Make request
-		resp, err := c.httpClient.DoRequest(req)
+		resp, err := c.auth.Do(req)
 		if err != nil {
 			return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to update document")
 		}
@@ -192,7 +231,7 @@ func (c *Client) UpdateDocument(ctx context.Context, documentID string, requests
 		}
 		
 		// Read response
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeGoogleError, "Failed to read response", err)
 		}
@@ -210,7 +249,7 @@ func (c *Client) UpdateDocument(ctx context.Context, documentID string, requests
 		return nil, err
 	}
 
-	c.logger.Info("Updated Google Docs document",
+	c.log(ctx).Info("Updated Google Docs document",
 		utils.NewField("document_id", documentID),
 		utils.NewField("requests_count", len(requests)),
 	)
@@ -235,7 +274,7 @@ func (c *Client) GetDocument(ctx context.Context, documentID string) (*DocumentR
 		}
 		
 		// Make request
-		resp, err := c.httpClient.DoRequest(req)
+		resp, err := c.auth.Do(req)
 		if err != nil {
 			return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to get document")
 		}
@@ -252,7 +291,7 @@ func (c *Client) GetDocument(ctx context.Context, documentID string) (*DocumentR
 		}
 		
 		// Read response
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeGoogleError, "Failed to read response", err)
 		}
@@ -273,19 +312,58 @@ func (c *Client) GetDocument(ctx context.Context, documentID string) (*DocumentR
 	return response, nil
 }
 
-// ShareDocument shares a Google Docs document with specified users
+// ShareResult is the outcome of sharing a document with one email address
+type ShareResult struct {
+	Email string `json:"email"`
+	Error error  `json:"-"` // Nil when the permission was created
+}
+
+// Succeeded reports whether the document was shared with the address
+func (r ShareResult) Succeeded() bool {
+	return r.Error == nil
+}
+
+// ShareDocument shares a Google Docs document with specified users. Every address is tried
+// even when some fail; if any fail the returned error names them and carries the
+// []ShareResult for all addresses in the "share_results" extra.
 func (c *Client) ShareDocument(ctx context.Context, documentID string, emails []string, role string) error {
+	results, err := c.ShareDocumentWithResults(ctx, documentID, emails, role)
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+	for _, result := range results {
+		if !result.Succeeded() {
+			failures = append(failures, fmt.Sprintf("%s (%s)", result.Email, result.Error.Error()))
+		}
+	}
+	if len(failures) == 0 {
+		return nil
+	}
+
+	return utils.NewAppError(utils.ErrorCodeGoogleError,
+		fmt.Sprintf("Failed to share document with %d of %d recipients: %s", len(failures), len(results), strings.Join(failures, "; ")), nil).
+		WithService("google_drive").
+		WithExtra("document_id", documentID).
+		WithExtra("share_results", results)
+}
+
+// ShareDocumentWithResults shares a document with each email address and returns one result
+// per non-empty address, in order. Only invalid arguments are returned as an error.
+func (c *Client) ShareDocumentWithResults(ctx context.Context, documentID string, emails []string, role string) ([]ShareResult, error) {
 	if documentID == "" {
-		return utils.NewAppError(utils.ErrorCodeDataInvalid, "Document ID is required", nil)
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Document ID is required", nil)
 	}
 	if len(emails) == 0 {
-		return utils.NewAppError(utils.ErrorCodeDataInvalid, "At least one email is required", nil)
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "At least one email is required", nil)
 	}
 	if role == "" {
 		role = "reader" // Default to reader permission
 	}
 
 	// Share with each email
+	results := make([]ShareResult, 0, len(emails))
 	for _, email := range emails {
 		if email == "" {
 			continue
@@ -312,7 +390,7 @@ func (c *Client) ShareDocument(ctx context.Context, documentID string, emails []
 			}
 			
 			// Make request
-			resp, err := c.httpClient.DoRequest(req)
+			resp, err := c.auth.Do(req)
 			if err != nil {
 				return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to share document")
 			}
@@ -325,9 +403,10 @@ func (c *Client) ShareDocument(ctx context.Context, documentID string, emails []
 			
 			return nil
 		}, c.logger)
+		results = append(results, ShareResult{Email: email, Error: err})
 
 		if err != nil {
-			c.logger.Warn("Failed to share document with user",
+			c.log(ctx).Warn("Failed to share document with user",
 				utils.NewField("document_id", documentID),
 				utils.NewField("email", email),
 				utils.NewField("error", err.Error()),
@@ -335,25 +414,148 @@ func (c *Client) ShareDocument(ctx context.Context, documentID string, emails []
 			continue
 		}
 
-		c.logger.Info("Shared document with user",
+		c.log(ctx).Info("Shared document with user",
 			utils.NewField("document_id", documentID),
 			utils.NewField("email", email),
 			utils.NewField("role", role),
 		)
 	}
 
+	return results, nil
+}
+
+// DeleteDocument permanently deletes a document through the Drive API
+func (c *Client) DeleteDocument(ctx context.Context, documentID string) error {
+	if documentID == "" {
+		return utils.NewAppError(utils.ErrorCodeDataInvalid, "Document ID is required", nil)
+	}
+
+	err := utils.RetryWithRateLimit(ctx, c.retryConfig, c.rateLimiter, func() error {
+		// Create HTTP request
+		endpoint := fmt.Sprintf(DeleteEndpoint, documentID)
+		req, err := c.createDriveRequest(ctx, "DELETE", endpoint, nil)
+		if err != nil {
+			return err
+		}
+		
+		// Make request
+		resp, err := c.auth.Do(req)
+		if err != nil {
+			return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to delete document")
+		}
+		defer resp.Body.Close()
+		
+		// Drive responds with 204 No Content on success
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			return c.handleErrorResponse(resp, "Document deletion failed")
+		}
+		
+		return nil
+	}, c.logger)
+
+	if err != nil {
+		return err
+	}
+
+	c.log(ctx).Info("Deleted Google Docs document",
+		utils.NewField("document_id", documentID),
+	)
+
 	return nil
 }
 
+// ExportDocumentPDF exports a document as PDF through the Drive API and returns the file bytes
+func (c *Client) ExportDocumentPDF(ctx context.Context, documentID string) ([]byte, error) {
+	if documentID == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Document ID is required", nil)
+	}
+
+	var pdf []byte
+	
+	err := utils.RetryWithRateLimit(ctx, c.retryConfig, c.rateLimiter, func() error {
+		// Create HTTP request
+		endpoint := fmt.Sprintf(ExportEndpoint, documentID, url.QueryEscape(MimeTypePDF))
+		req, err := c.createDriveRequest(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return err
+		}
+		
+		// Make request
+		resp, err := c.auth.Do(req)
+		if err != nil {
+			return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to export document")
+		}
+		defer resp.Body.Close()
+		
+		// Handle error responses
+		if resp.StatusCode == http.StatusNotFound {
+			return utils.NewAppError(utils.ErrorCodeAPINotFound, "Document not found", nil).
+				WithExtra("document_id", documentID)
+		}
+		
+		if resp.StatusCode != http.StatusOK {
+			return c.handleErrorResponse(resp, "Document export failed")
+		}
+		
+		// Read the exported file
+		body, err := c.httpClient.ReadBody(resp)
+		if err != nil {
+			return utils.NewAppError(utils.ErrorCodeGoogleError, "Failed to read exported document", err)
+		}
+		pdf = body
+		
+		return nil
+	}, c.logger)
+	
+	if err != nil {
+		return nil, err
+	}
+
+	c.log(ctx).Info("Exported Google Docs document",
+		utils.NewField("document_id", documentID),
+		utils.NewField("mime_type", MimeTypePDF),
+		utils.NewField("bytes", len(pdf)),
+	)
+
+	return pdf, nil
+}
+
+// validationCleanupTimeout bounds deleting the credential test document once validation is done
+const validationCleanupTimeout = 15 * time.Second
+
 // ValidateCredentials validates Google API credentials
 func (c *Client) ValidateCredentials(ctx context.Context) error {
-	// Try to create a simple test document and then delete it
-	testDoc, err := c.CreateDocument(ctx, "API Test Document - Safe to Delete", "This is a test document to validate API credentials.")
+	// Create a simple test document, write to it, and delete it again
+	testDoc, err := c.CreateDocument(ctx, "API Test Document - Safe to Delete", "")
 	if err != nil {
 		return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to validate Google Docs credentials")
 	}
 
-	c.logger.Info("Google Docs credentials validated successfully",
+	// Always clean up the test document, even if writing to it fails or ctx was canceled
+	defer func() {
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), validationCleanupTimeout)
+		defer cancel()
+		if err := c.DeleteDocument(cleanupCtx, testDoc.DocumentID); err != nil {
+			c.log(ctx).Warn("Failed to delete credential test document",
+				utils.NewField("document_id", testDoc.DocumentID),
+				utils.NewField("error", err.Error()),
+			)
+		}
+	}()
+
+	_, err = c.UpdateDocument(ctx, testDoc.DocumentID, []Request{
+		{
+			InsertText: &InsertTextRequest{
+				Text:     "This is a test document to validate API credentials.",
+				Location: &Location{Index: 1},
+			},
+		},
+	})
+	if err != nil {
+		return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to validate Google Docs credentials")
+	}
+
+	c.log(ctx).Info("Google Docs credentials validated successfully",
 		utils.NewField("test_document_id", testDoc.DocumentID),
 	)
 
@@ -362,6 +564,98 @@ func (c *Client) ValidateCredentials(ctx context.Context) error {
 
 // CreateExecutiveSummaryDocument creates a properly formatted executive summary document
 func (c *Client) CreateExecutiveSummaryDocument(ctx context.Context, title, summary string, metadata map[string]interface{}) (*DocumentResponse, error) {
+	// Build formatting requests for executive summary
+	requests, err := c.PreviewExecutiveSummaryRequests(title, summary, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the document first
+	doc, err := c.CreateDocument(ctx, title, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply formatting
+	_, err = c.UpdateDocument(ctx, doc.DocumentID, requests)
+	if err != nil {
+		c.log(ctx).Warn("Failed to format executive summary document",
+			utils.NewField("document_id", doc.DocumentID),
+			utils.NewField("error", err.Error()),
+		)
+	}
+
+	c.log(ctx).Info("Created executive summary document",
+		utils.NewField("document_id", doc.DocumentID),
+		utils.NewField("title", title),
+		utils.NewField("summary_length", len(summary)),
+	)
+
+	return doc, nil
+}
+
+// UpsertExecutiveSummaryDocument writes an executive summary into the document with documentID,
+// replacing its body, so links to a canonical report keep working across runs. The existing
+// content is deleted and the new content inserted in a single batch update that requires the
+// revision read beforehand, so a concurrent edit makes the update fail instead of being mixed
+// into the report. The Drive file name is left unchanged. An empty documentID creates a new
+// document as CreateExecutiveSummaryDocument does.
+func (c *Client) UpsertExecutiveSummaryDocument(ctx context.Context, documentID, title, summary string, metadata map[string]interface{}) (*DocumentResponse, error) {
+	if documentID == "" {
+		return c.CreateExecutiveSummaryDocument(ctx, title, summary, metadata)
+	}
+
+	requests, err := c.PreviewExecutiveSummaryRequests(title, summary, metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := c.GetDocument(ctx, documentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The final newline of the body can never be deleted
+	if end := bodyEndIndex(doc.Body); end > 2 {
+		requests = append([]Request{
+			{
+				DeleteContentRange: &DeleteContentRangeRequest{
+					Range: &Range{StartIndex: 1, EndIndex: end - 1},
+				},
+			},
+		}, requests...)
+	}
+
+	var writeControl *WriteControl
+	if doc.RevisionID != "" {
+		writeControl = &WriteControl{RequiredRevisionID: doc.RevisionID}
+	}
+
+	if _, err := c.updateDocument(ctx, documentID, requests, writeControl); err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to overwrite executive summary document").
+			WithExtra("document_id", documentID)
+	}
+
+	c.log(ctx).Info("Overwrote executive summary document",
+		utils.NewField("document_id", documentID),
+		utils.NewField("title", title),
+		utils.NewField("summary_length", len(summary)),
+	)
+
+	return doc, nil
+}
+
+// bodyEndIndex returns the index just past the last element of a document body
+func bodyEndIndex(body *Body) int32 {
+	if body == nil || len(body.Content) == 0 {
+		return 0
+	}
+	return body.Content[len(body.Content)-1].EndIndex
+}
+
+// PreviewExecutiveSummaryRequests validates the input and returns the batch update requests
+// CreateExecutiveSummaryDocument would send, without making any API calls
+func (c *Client) PreviewExecutiveSummaryRequests(title, summary string, metadata map[string]interface{}) ([]Request, error) {
 	if title == "" {
 		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Document title is required", nil)
 	}
@@ -369,79 +663,213 @@ func (c *Client) CreateExecutiveSummaryDocument(ctx context.Context, title, summ
 		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Summary content is required", nil)
 	}
 
-	// Create the document first
-	doc, err := c.CreateDocument(ctx, title, "")
-	if err != nil {
-		return nil, err
+	return c.buildExecutiveSummaryRequests(title, summary, metadata), nil
+}
+
+// SetSectionDividers toggles horizontal rules between executive summary sections
+func (c *Client) SetSectionDividers(enabled bool) {
+	c.sectionDividers = enabled
+}
+
+// SetRenderMarkdown toggles converting the markdown in Gemini output into headings, lists and
+// emphasis. When disabled the summary is inserted as plain text with its markers.
+func (c *Client) SetRenderMarkdown(enabled bool) {
+	c.renderMarkdown = enabled
+}
+
+// SetMaxInsertChars caps the summary text sent in a single InsertText request, in UTF-16 code
+// units. Zero uses DefaultMaxInsertChars.
+func (c *Client) SetMaxInsertChars(chars int) {
+	c.maxInsertChars = chars
+}
+
+// insertCharLimit returns the configured insert cap, falling back to the default
+func (c *Client) insertCharLimit() int32 {
+	if c.maxInsertChars > 0 {
+		return int32(c.maxInsertChars)
 	}
+	return DefaultMaxInsertChars
+}
 
-	// Build formatting requests for executive summary
+// buildExecutiveSummaryRequests builds the batch update requests for an executive summary document.
+// A single running index tracks where the next insert lands, counted in UTF-16 code units
+// because that is how the Docs API addresses document positions.
+func (c *Client) buildExecutiveSummaryRequests(title, summary string, metadata map[string]interface{}) []Request {
+	currentIndex := int32(1)
+	titleText := title + "\n\n"
+	
+	// Insert title and format it as heading
 	requests := []Request{
-		// Insert title
 		{
 			InsertText: &InsertTextRequest{
-				Text:     title + "\n\n",
-				Location: &Location{Index: 1},
+				Text:     titleText,
+				Location: &Location{Index: currentIndex},
 			},
 		},
-		// Format title as heading
 		{
 			UpdateTextStyle: &UpdateTextStyleRequest{
-				Range: &Range{StartIndex: 1, EndIndex: int32(len(title) + 1)},
+				Range: &Range{StartIndex: currentIndex, EndIndex: currentIndex + utf16Len(title)},
 				TextStyle: &TextStyle{
 					Bold:         boolPtr(true),
-					FontSize:     &Dimension{Magnitude: 18, Unit: "PT"},
+					FontSize:     Pt(18),
 				},
 				Fields: "bold,fontSize",
 			},
 		},
-		// Insert metadata if provided
 	}
-
-	// Add metadata section if provided
-	if len(metadata) > 0 {
-		metadataText := c.formatMetadata(metadata)
-		currentIndex := int32(len(title) + 3) // After title and newlines
+	currentIndex += utf16Len(titleText)
+	
+	// Add metadata section if any metadata fields are displayable
+	if metadataLine := c.formatMetadata(metadata); metadataLine != "" {
+		if c.sectionDividers {
+			ruleRequests, consumed := buildHorizontalRuleRequests(currentIndex)
+			requests = append(requests, ruleRequests...)
+			currentIndex += consumed
+		}
 		
+		metadataText := metadataLine + "\n\n"
 		requests = append(requests, Request{
 			InsertText: &InsertTextRequest{
-				Text:     metadataText + "\n\n",
+				Text:     metadataText,
 				Location: &Location{Index: currentIndex},
 			},
 		})
-		currentIndex += int32(len(metadataText) + 2)
+		currentIndex += utf16Len(metadataText)
+	}
+	
+	if c.sectionDividers {
+		ruleRequests, consumed := buildHorizontalRuleRequests(currentIndex)
+		requests = append(requests, ruleRequests...)
+		currentIndex += consumed
+	}
+	
+	// Insert summary content, split so no single insert exceeds the character cap
+	summaryIndex, summaryText := currentIndex, summary
+	if c.renderMarkdown {
+		summaryRequests, consumed := markdownRequests(summary, currentIndex, c.insertCharLimit())
+		requests = append(requests, summaryRequests...)
+		currentIndex += consumed
+		
+		// Markdown markers are not inserted, so links are placed over the rendered text
+		var rendered strings.Builder
+		for _, req := range summaryRequests {
+			if req.InsertText != nil {
+				rendered.WriteString(req.InsertText.Text)
+			}
+		}
+		summaryText = rendered.String()
+	} else {
+		for _, chunk := range splitInsertText(summary, c.insertCharLimit()) {
+			requests = append(requests, Request{
+				InsertText: &InsertTextRequest{
+					Text:     chunk,
+					Location: &Location{Index: currentIndex},
+				},
+			})
+			currentIndex += utf16Len(chunk)
+		}
 	}
+	requests = append(requests, c.issueLinkRequests(summaryText, summaryIndex)...)
+	
+	// Append the issue table if enabled
+	if issues := appendixIssues(metadata); c.appendix.Enabled && len(issues) > 0 {
+		headingIndex := currentIndex + utf16Len("\n\n")
+		appendixText := "\n\n" + c.formatAppendix(issues)
+		requests = append(requests,
+			Request{
+				InsertText: &InsertTextRequest{
+					Text:     appendixText,
+					Location: &Location{Index: currentIndex},
+				},
+			},
+			Request{
+				UpdateTextStyle: &UpdateTextStyleRequest{
+					Range:     &Range{StartIndex: headingIndex, EndIndex: headingIndex + utf16Len(appendixHeading)},
+					TextStyle: &TextStyle{Bold: boolPtr(true)},
+					Fields:    "bold",
+				},
+			},
+		)
+		requests = append(requests, c.issueLinkRequests(appendixText, currentIndex)...)
+	}
+	
+	return requests
+}
 
-	// Insert summary content
-	summaryIndex := int32(len(title) + 3)
-	if len(metadata) > 0 {
-		metadataText := c.formatMetadata(metadata)
-		summaryIndex += int32(len(metadataText) + 2)
+// splitInsertText splits text into consecutive chunks of at most limit UTF-16 code units.
+// A chunk ends after its last newline when it has one so paragraphs are not broken mid-line,
+// and surrogate pairs are never split.
+func splitInsertText(text string, limit int32) []string {
+	if limit <= 0 || utf16Len(text) <= limit {
+		return []string{text}
 	}
+	
+	var chunks []string
+	runes := []rune(text)
+	for len(runes) > 0 {
+		size := int32(0)
+		end := 0
+		lastNewline := -1
+		for end < len(runes) {
+			width := int32(utf16.RuneLen(runes[end]))
+			if size+width > limit {
+				break
+			}
+			size += width
+			if runes[end] == '\n' {
+				lastNewline = end
+			}
+			end++
+		}
+		
+		// A limit smaller than one character still makes progress
+		if end == 0 {
+			end = 1
+		}
+		if end < len(runes) && lastNewline >= 0 {
+			end = lastNewline + 1
+		}
+		
+		chunks = append(chunks, string(runes[:end]))
+		runes = runes[end:]
+	}
+	
+	return chunks
+}
 
-	requests = append(requests, Request{
-		InsertText: &InsertTextRequest{
-			Text:     summary,
-			Location: &Location{Index: summaryIndex},
+// buildHorizontalRuleRequests builds requests that insert a horizontal rule at the given index.
+// The Docs API cannot insert HorizontalRule elements directly, so the rule is drawn as the
+// bottom border of an empty paragraph. It returns the requests and the number of indexes consumed.
+func buildHorizontalRuleRequests(index int32) ([]Request, int32) {
+	requests := []Request{
+		{
+			InsertText: &InsertTextRequest{
+				Text:     "\n",
+				Location: &Location{Index: index},
+			},
+		},
+		{
+			UpdateParagraphStyle: &UpdateParagraphStyleRequest{
+				Range: &Range{StartIndex: index, EndIndex: index + 1},
+				ParagraphStyle: &ParagraphStyle{
+					BorderBottom: &ParagraphBorder{
+						Color:     RGB(0.75, 0.75, 0.75),
+						Width:     Pt(1),
+						Padding:   Pt(6),
+						DashStyle: "SOLID",
+					},
+				},
+				Fields: "borderBottom",
+			},
 		},
-	})
-
-	// Apply formatting
-	_, err = c.UpdateDocument(ctx, doc.DocumentID, requests)
-	if err != nil {
-		c.logger.Warn("Failed to format executive summary document",
-			utils.NewField("document_id", doc.DocumentID),
-			utils.NewField("error", err.Error()),
-		)
 	}
+	
+	return requests, 1
+}
 
-	c.logger.Info("Created executive summary document",
-		utils.NewField("document_id", doc.DocumentID),
-		utils.NewField("title", title),
-		utils.NewField("summary_length", len(summary)),
-	)
-
-	return doc, nil
+// log returns the client's logger carrying the correlation ID of the run in ctx
+func (c *Client) log(ctx context.Context) utils.Logger {
+	return utils.LoggerWithContext(ctx, c.logger)
 }
 
 // createRequest creates an authenticated HTTP request for Google Docs API
@@ -502,7 +930,7 @@ func (c *Client) createDriveRequest(ctx context.Context, method, endpoint string
 
 // handleErrorResponse handles HTTP error responses
 func (c *Client) handleErrorResponse(resp *http.Response, message string) error {
-	body, _ := io.ReadAll(resp.Body)
+	body, _ := c.httpClient.ReadBody(resp)
 	
 	var errorCode utils.ErrorCode
 	switch resp.StatusCode {
@@ -526,7 +954,9 @@ func (c *Client) handleErrorResponse(resp *http.Response, message string) error
 	
 	// Try to parse error response
 	var googleError GoogleErrorResponse
-	if err := json.Unmarshal(body, &googleError); err == nil && googleError.ErrorInfo.Message != "" {
+	if err := json.Unmarshal(body, &googleError); err != nil {
+		message = fmt.Sprintf("%s: %s", message, utils.DescribeErrorBody(resp.StatusCode, body))
+	} else if googleError.ErrorInfo.Message != "" {
 		message = googleError.ErrorInfo.Message
 	}
 	
@@ -560,6 +990,10 @@ func (c *Client) formatMetadata(metadata map[string]interface{}) string {
 		parts = append(parts, fmt.Sprintf("Time Period: %s", timeRange))
 	}
 	
+	if language, ok := metadata[MetadataKeyLanguage].(string); ok && language != "" {
+		parts = append(parts, fmt.Sprintf("Language: %s", language))
+	}
+	
 	if len(parts) == 0 {
 		return ""
 	}
@@ -568,6 +1002,12 @@ func (c *Client) formatMetadata(metadata map[string]interface{}) string {
 }
 
 // Helper functions
+
+// utf16Len returns the length of s in UTF-16 code units, the unit used for Docs API indexes
+func utf16Len(s string) int32 {
+	return int32(len(utf16.Encode([]rune(s))))
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
+	"unicode/utf16"
 
 	"github.com/company/eesa/internal/config"
 	"github.com/company/eesa/internal/security"
@@ -58,6 +60,10 @@ func TestClient_formatMetadata(t *testing.T) {
 	assert.Contains(t, result, "Tokens Used: 150")
 	assert.Contains(t, result, "Activities Analyzed: 25")
 	assert.Contains(t, result, "Time Period: January 1-7, 2023")
+	assert.NotContains(t, result, "Language")
+
+	metadata[MetadataKeyLanguage] = "es-MX"
+	assert.True(t, strings.HasSuffix(client.formatMetadata(metadata), " | Language: es-MX"))
 }
 
 func TestClient_formatMetadata_Empty(t *testing.T) {
@@ -155,6 +161,64 @@ func TestClient_handleErrorResponse(t *testing.T) {
 	}
 }
 
+func TestClient_handleErrorResponse_NonJSONBody(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		responseBody  string
+		expectedError utils.ErrorCode
+		contains      []string
+	}{
+		{
+			name:          "plain text rate limit",
+			statusCode:    http.StatusTooManyRequests,
+			responseBody:  "Rate limit exceeded. Retry later.",
+			expectedError: utils.ErrorCodeAPIRateLimit,
+			contains:      []string{"Test error", "429 Too Many Requests", "Rate limit exceeded. Retry later."},
+		},
+		{
+			name:          "html service unavailable",
+			statusCode:    http.StatusServiceUnavailable,
+			responseBody:  "<html><body><h1>503 Service Temporarily Unavailable</h1></body></html>",
+			expectedError: utils.ErrorCodeAPIServerError,
+			contains:      []string{"Test error", "503 Service Unavailable", "<h1>503 Service Temporarily Unavailable</h1>"},
+		},
+		{
+			name:          "empty body",
+			statusCode:    http.StatusTooManyRequests,
+			responseBody:  "",
+			expectedError: utils.ErrorCodeAPIRateLimit,
+			contains:      []string{"Test error", "429 Too Many Requests"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+			}
+
+			err := client.handleErrorResponse(resp, "Test error")
+
+			require.Error(t, err)
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedError, appErr.Code)
+			for _, expected := range tt.contains {
+				assert.Contains(t, appErr.Message, expected)
+			}
+			assert.Equal(t, tt.responseBody, appErr.Context.Extra["response_body"])
+		})
+	}
+}
+
 func TestClient_createRequest(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{}
@@ -368,6 +432,8 @@ func createMockGoogleDocsServer(t *testing.T) *httptest.Server {
 				"type": "user",
 				"role": "reader",
 			})
+		case r.Method == "DELETE" && strings.HasPrefix(r.URL.Path, "/drive/v3/files/"):
+			w.WriteHeader(http.StatusNoContent)
 		default:
 			w.WriteHeader(http.StatusNotFound)
 		}
@@ -447,6 +513,102 @@ func TestClient_Integration(t *testing.T) {
 	authManager.GetCredentialStore().ClearAllCredentials()
 }
 
+func TestClient_ValidateCredentials_DeletesTestDocument(t *testing.T) {
+	tests := []struct {
+		name        string
+		failInsert  bool
+		failDelete  bool
+		cancelWrite bool
+		expectError bool
+	}{
+		{name: "success"},
+		{name: "insert fails", failInsert: true, expectError: true},
+		{name: "delete fails", failDelete: true},
+		{name: "canceled while writing", cancelWrite: true, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var deletedPaths []string
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/v1/documents":
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(DocumentResponse{DocumentID: "temp_document_id"})
+				case r.Method == "POST" && strings.Contains(r.URL.Path, "batchUpdate"):
+					if tt.cancelWrite {
+						cancel()
+					}
+					if tt.failInsert || tt.cancelWrite {
+						w.WriteHeader(http.StatusBadRequest)
+						json.NewEncoder(w).Encode(GoogleErrorResponse{
+							ErrorInfo: GoogleAPIError{Code: 400, Message: "Invalid request", Status: "INVALID_ARGUMENT"},
+						})
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+					json.NewEncoder(w).Encode(BatchUpdateResponse{DocumentID: "temp_document_id"})
+				case r.Method == "DELETE":
+					deletedPaths = append(deletedPaths, r.URL.Path)
+					if tt.failDelete {
+						w.WriteHeader(http.StatusForbidden)
+						json.NewEncoder(w).Encode(GoogleErrorResponse{
+							ErrorInfo: GoogleAPIError{Code: 403, Message: "Forbidden", Status: "PERMISSION_DENIED"},
+						})
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			logger := utils.NewMockLogger()
+			cfg := &config.Config{}
+			authConfig := security.DefaultAuthConfig()
+			authManager := security.NewAuthManager(authConfig, logger)
+
+			err := authManager.GetCredentialStore().SetGoogleCredentials(security.GoogleCredentials{
+				ClientSecret: "test_client_secret",
+				AccessToken:  "test_access_token",
+			})
+			require.NoError(t, err)
+			defer authManager.GetCredentialStore().ClearAllCredentials()
+
+			client := NewClient(cfg, authManager, logger)
+			client.baseURL = server.URL
+			client.driveBaseURL = server.URL
+			client.retryConfig.MaxRetries = 0
+
+			err = client.ValidateCredentials(ctx)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			require.NotEmpty(t, deletedPaths)
+			assert.Equal(t, "/drive/v3/files/temp_document_id", deletedPaths[0])
+			if tt.failDelete {
+				assert.NotEmpty(t, logger.GetEntriesByLevel(utils.LogLevelWarn))
+			}
+		})
+	}
+}
+
+func TestClient_DeleteDocument_ValidationErrors(t *testing.T) {
+	logger := utils.NewMockLogger()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	client := NewClient(&config.Config{}, authManager, logger)
+
+	err := client.DeleteDocument(context.Background(), "")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Document ID is required")
+}
+
 func TestClient_CreateDocument_ValidationErrors(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{}
@@ -561,6 +723,74 @@ func TestClient_CreateExecutiveSummaryDocument_ValidationErrors(t *testing.T) {
 	assert.Contains(t, appErr.Message, "Summary content is required")
 }
 
+func TestClient_PreviewExecutiveSummaryRequests(t *testing.T) {
+	var mu sync.Mutex
+	var sent []json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/v1/documents":
+			json.NewEncoder(w).Encode(DocumentResponse{DocumentID: "test_document_id"})
+		case r.Method == "POST" && strings.Contains(r.URL.Path, "batchUpdate"):
+			var body struct {
+				Requests json.RawMessage `json:"requests"`
+			}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			mu.Lock()
+			sent = append(sent, body.Requests)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(BatchUpdateResponse{DocumentID: "test_document_id"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	logger := utils.NewMockLogger()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	err := authManager.GetCredentialStore().SetGoogleCredentials(security.GoogleCredentials{
+		ClientSecret: "test_client_secret",
+		AccessToken:  "test_access_token",
+		RefreshToken: "test_refresh_token",
+	})
+	require.NoError(t, err)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+
+	client := NewClient(&config.Config{}, authManager, logger)
+	client.baseURL = server.URL
+	client.driveBaseURL = server.URL
+	client.SetSectionDividers(true)
+
+	title := "Weekly Executive Summary"
+	summary := "Highlights\nThe team shipped the login page."
+	metadata := map[string]interface{}{
+		"generated_at":   time.Date(2024, 3, 8, 17, 0, 0, 0, time.UTC),
+		"model":          "gemini-pro",
+		"tokens_used":    150,
+		"activity_count": 25,
+	}
+
+	preview, err := client.PreviewExecutiveSummaryRequests(title, summary, metadata)
+	require.NoError(t, err)
+	require.NotEmpty(t, preview)
+	assert.Equal(t, title+"\n\n", preview[0].InsertText.Text)
+
+	_, err = client.CreateExecutiveSummaryDocument(context.Background(), title, summary, metadata)
+	require.NoError(t, err)
+
+	// The create path sends exactly the previewed requests
+	require.Len(t, sent, 1)
+	expected, err := json.Marshal(preview)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(sent[0]))
+
+	// Invalid input is rejected without contacting the API
+	_, err = client.PreviewExecutiveSummaryRequests("", summary, metadata)
+	assert.Error(t, err)
+	_, err = client.PreviewExecutiveSummaryRequests(title, "", metadata)
+	assert.Error(t, err)
+	assert.Len(t, sent, 1)
+}
+
 func TestClient_DocumentNotFound(t *testing.T) {
 	// Create mock server that returns 404
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -603,4 +833,434 @@ func TestClient_DocumentNotFound(t *testing.T) {
 
 	// Clean up
 	authManager.GetCredentialStore().ClearAllCredentials()
-}
\ No newline at end of file
+}
+
+func TestClient_buildExecutiveSummaryRequests_NoDividers(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	requests := client.buildExecutiveSummaryRequests("Title", "Summary body", map[string]interface{}{
+		"model": "gemini-pro",
+	})
+
+	for _, req := range requests {
+		assert.Nil(t, req.UpdateParagraphStyle)
+	}
+	require.Len(t, requests, 4)
+	assert.Equal(t, int32(1), requests[0].InsertText.Location.Index)
+	assert.Equal(t, int32(8), requests[2].InsertText.Location.Index)
+	assert.Equal(t, int32(8+len("AI Model: gemini-pro")+2), requests[3].InsertText.Location.Index)
+}
+
+func TestClient_buildExecutiveSummaryRequests_WithDividers(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	client.SetSectionDividers(true)
+
+	requests := client.buildExecutiveSummaryRequests("Title", "Summary body", map[string]interface{}{
+		"model": "gemini-pro",
+	})
+
+	// title, title style, rule, metadata, rule, summary
+	require.Len(t, requests, 8)
+	metadataText := "AI Model: gemini-pro\n\n"
+
+	// First rule sits between the title and the metadata
+	assert.Equal(t, "\n", requests[2].InsertText.Text)
+	assert.Equal(t, int32(8), requests[2].InsertText.Location.Index)
+	require.NotNil(t, requests[3].UpdateParagraphStyle)
+	assert.Equal(t, int32(8), requests[3].UpdateParagraphStyle.Range.StartIndex)
+	assert.Equal(t, int32(9), requests[3].UpdateParagraphStyle.Range.EndIndex)
+	assert.NotNil(t, requests[3].UpdateParagraphStyle.ParagraphStyle.BorderBottom)
+	assert.Equal(t, "borderBottom", requests[3].UpdateParagraphStyle.Fields)
+
+	assert.Equal(t, metadataText, requests[4].InsertText.Text)
+	assert.Equal(t, int32(9), requests[4].InsertText.Location.Index)
+
+	// Second rule sits between the metadata and the summary
+	ruleIndex := int32(9 + len(metadataText))
+	assert.Equal(t, "\n", requests[5].InsertText.Text)
+	assert.Equal(t, ruleIndex, requests[5].InsertText.Location.Index)
+	require.NotNil(t, requests[6].UpdateParagraphStyle)
+	assert.Equal(t, ruleIndex, requests[6].UpdateParagraphStyle.Range.StartIndex)
+
+	assert.Equal(t, "Summary body", requests[7].InsertText.Text)
+	assert.Equal(t, ruleIndex+1, requests[7].InsertText.Location.Index)
+}
+
+func TestClient_buildExecutiveSummaryRequests_DividerWithoutMetadata(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	client.SetSectionDividers(true)
+
+	requests := client.buildExecutiveSummaryRequests("Title", "Summary body", nil)
+
+	// title, title style, rule, summary
+	require.Len(t, requests, 5)
+	assert.NotNil(t, requests[3].UpdateParagraphStyle)
+	assert.Equal(t, int32(9), requests[4].InsertText.Location.Index)
+}
+
+// applyInsertRequests replays InsertText requests against an empty document the way the
+// Docs API does, addressing positions in UTF-16 code units, and returns the resulting body
+func applyInsertRequests(t *testing.T, requests []Request) *Body {
+	// A new document holds a single trailing newline starting at index 1
+	content := utf16.Encode([]rune("\n"))
+	for _, req := range requests {
+		if req.InsertText == nil {
+			continue
+		}
+
+		offset := int(req.InsertText.Location.Index) - 1
+		require.GreaterOrEqual(t, offset, 0)
+		require.Less(t, offset, len(content), "insert must land before the final newline")
+
+		inserted := utf16.Encode([]rune(req.InsertText.Text))
+		content = append(content[:offset], append(inserted, content[offset:]...)...)
+	}
+
+	body := &Body{}
+	start := 0
+	for i, unit := range content {
+		if unit != '\n' {
+			continue
+		}
+		text := string(utf16.Decode(content[start : i+1]))
+		startIndex, endIndex := int32(start+1), int32(i+2)
+		body.Content = append(body.Content, StructuralElement{
+			StartIndex: startIndex,
+			EndIndex:   endIndex,
+			Paragraph: &Paragraph{
+				Elements: []ParagraphElement{
+					{StartIndex: startIndex, EndIndex: endIndex, TextRun: &TextRun{Content: text}},
+				},
+			},
+		})
+		start = i + 1
+	}
+
+	return body
+}
+
+func TestClient_buildExecutiveSummaryRequests_Ordering(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	// Multi-byte and surrogate pair characters shift byte offsets away from UTF-16 offsets
+	title := "Résumé 🚀 Weekly"
+	metadata := map[string]interface{}{
+		"model":      "gemini-pro",
+		"time_range": "2024-01-01 → 2024-01-07",
+	}
+	summary := "Delivery was on track."
+
+	requests := client.buildExecutiveSummaryRequests(title, summary, metadata)
+	body := applyInsertRequests(t, requests)
+
+	var paragraphs []string
+	for _, element := range body.Content {
+		paragraphs = append(paragraphs, element.Paragraph.Elements[0].TextRun.Content)
+	}
+
+	assert.Equal(t, []string{
+		title + "\n",
+		"\n",
+		"AI Model: gemini-pro | Time Period: 2024-01-01 → 2024-01-07\n",
+		"\n",
+		summary + "\n",
+	}, paragraphs)
+
+	// The heading style covers exactly the title
+	titleElement := body.Content[0]
+	require.NotNil(t, requests[1].UpdateTextStyle)
+	assert.Equal(t, titleElement.StartIndex, requests[1].UpdateTextStyle.Range.StartIndex)
+	assert.Equal(t, titleElement.EndIndex-1, requests[1].UpdateTextStyle.Range.EndIndex)
+}
+
+func TestClient_buildExecutiveSummaryRequests_SplitsLongSummary(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	client.SetMaxInsertChars(40)
+
+	title := "Title"
+	summary := strings.Repeat("The team shipped the login page. 🚀\n", 5) + strings.Repeat("x", 100)
+
+	requests := client.buildExecutiveSummaryRequests(title, summary, nil)
+
+	// Every summary insert stays under the cap and follows the previous one
+	var chunks []string
+	index := int32(1) + utf16Len(title+"\n\n")
+	for _, req := range requests[2:] {
+		require.NotNil(t, req.InsertText)
+		assert.LessOrEqual(t, utf16Len(req.InsertText.Text), int32(40))
+		assert.Equal(t, index, req.InsertText.Location.Index)
+		index += utf16Len(req.InsertText.Text)
+		chunks = append(chunks, req.InsertText.Text)
+	}
+	assert.Greater(t, len(chunks), 1)
+	assert.Equal(t, summary, strings.Join(chunks, ""))
+
+	// Chunks break at line ends while whole lines fit
+	assert.Equal(t, "The team shipped the login page. 🚀\n", chunks[0])
+
+	body := applyInsertRequests(t, requests)
+	var content strings.Builder
+	for _, element := range body.Content {
+		content.WriteString(element.Paragraph.Elements[0].TextRun.Content)
+	}
+	assert.Equal(t, title+"\n\n"+summary+"\n", content.String())
+}
+
+func TestSplitInsertText(t *testing.T) {
+	// Short text is left whole
+	assert.Equal(t, []string{"short"}, splitInsertText("short", 10))
+
+	// Long lines are cut at the cap
+	assert.Equal(t, []string{"abcd", "efgh", "ij"}, splitInsertText("abcdefghij", 4))
+
+	// Newlines are preferred as split points
+	assert.Equal(t, []string{"ab\n", "cdef\n", "gh"}, splitInsertText("ab\ncdef\ngh", 5))
+
+	// Surrogate pairs count as two units and are never split
+	assert.Equal(t, []string{"a", "🚀", "🚀", "b"}, splitInsertText("a🚀🚀b", 2))
+}
+
+func TestClient_UpsertExecutiveSummaryDocument(t *testing.T) {
+	tests := []struct {
+		name       string
+		documentID string
+		failUpdate bool
+	}{
+		{name: "creates when no document is given"},
+		{name: "overwrites existing document", documentID: "canonical_id"},
+		{name: "concurrent edit", documentID: "canonical_id", failUpdate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created, fetched int
+			var batches []BatchUpdateDocumentRequest
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == "POST" && r.URL.Path == "/v1/documents":
+					created++
+					json.NewEncoder(w).Encode(DocumentResponse{DocumentID: "new_id", Title: "Weekly Summary"})
+				case r.Method == "GET" && r.URL.Path == "/v1/documents/canonical_id":
+					fetched++
+					json.NewEncoder(w).Encode(DocumentResponse{
+						DocumentID: "canonical_id",
+						Title:      "Weekly Summary",
+						RevisionID: "rev-1",
+						Body: &Body{
+							Content: []StructuralElement{
+								{StartIndex: 0, EndIndex: 1, SectionBreak: &SectionBreak{}},
+								{StartIndex: 1, EndIndex: 25, Paragraph: &Paragraph{}},
+								{StartIndex: 25, EndIndex: 40, Paragraph: &Paragraph{}},
+							},
+						},
+					})
+				case r.Method == "POST" && strings.HasSuffix(r.URL.Path, ":batchUpdate"):
+					var batch BatchUpdateDocumentRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+					batches = append(batches, batch)
+					if tt.failUpdate {
+						w.WriteHeader(http.StatusBadRequest)
+						json.NewEncoder(w).Encode(GoogleErrorResponse{
+							ErrorInfo: GoogleAPIError{Code: 400, Message: "The required revision ID 'rev-1' does not match the latest revision.", Status: "FAILED_PRECONDITION"},
+						})
+						return
+					}
+					json.NewEncoder(w).Encode(BatchUpdateResponse{DocumentID: "canonical_id"})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			logger := utils.NewMockLogger()
+			authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+			err := authManager.GetCredentialStore().SetGoogleCredentials(security.GoogleCredentials{
+				ClientSecret: "test_client_secret",
+				AccessToken:  "test_access_token",
+			})
+			require.NoError(t, err)
+			defer authManager.GetCredentialStore().ClearAllCredentials()
+
+			client := NewClient(&config.Config{}, authManager, logger)
+			client.baseURL = server.URL
+			client.retryConfig.MaxRetries = 0
+
+			doc, err := client.UpsertExecutiveSummaryDocument(context.Background(), tt.documentID, "Weekly Summary", "Summary body", nil)
+			require.Len(t, batches, 1)
+
+			if tt.documentID == "" {
+				require.NoError(t, err)
+				assert.Equal(t, "new_id", doc.DocumentID)
+				assert.Equal(t, 1, created)
+				assert.Zero(t, fetched)
+				assert.Nil(t, batches[0].WriteControl)
+				assert.Nil(t, batches[0].Requests[0].DeleteContentRange)
+				return
+			}
+
+			assert.Zero(t, created)
+			assert.Equal(t, 1, fetched)
+
+			// The old body is deleted and the new content inserted in the same batch
+			batch := batches[0]
+			require.NotNil(t, batch.WriteControl)
+			assert.Equal(t, "rev-1", batch.WriteControl.RequiredRevisionID)
+			require.NotNil(t, batch.Requests[0].DeleteContentRange)
+			assert.Equal(t, &Range{StartIndex: 1, EndIndex: 39}, batch.Requests[0].DeleteContentRange.Range)
+			assert.Equal(t, client.buildExecutiveSummaryRequests("Weekly Summary", "Summary body", nil), batch.Requests[1:])
+
+			if tt.failUpdate {
+				require.Error(t, err)
+				appErr, ok := err.(*utils.AppError)
+				require.True(t, ok)
+				assert.Equal(t, "canonical_id", appErr.Context.Extra["document_id"])
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "canonical_id", doc.DocumentID)
+		})
+	}
+}
+
+func TestClient_ExportDocumentPDF(t *testing.T) {
+	fakePDF := []byte("%PDF-1.4\n\x00\x01\xff binary content\n%%EOF")
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/drive/v3/files/doc_id/export":
+			assert.Equal(t, "application/pdf", r.URL.Query().Get("mimeType"))
+			assert.Equal(t, "Bearer test_access_token", r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/pdf")
+			w.Write(fakePDF)
+		case r.URL.Path == "/drive/v3/files/large_doc/export":
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(GoogleErrorResponse{
+				ErrorInfo: GoogleAPIError{Code: 403, Message: "This file is too large to be exported.", Status: "PERMISSION_DENIED"},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	logger := utils.NewMockLogger()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	err := authManager.GetCredentialStore().SetGoogleCredentials(security.GoogleCredentials{
+		ClientSecret: "test_client_secret",
+		AccessToken:  "test_access_token",
+	})
+	require.NoError(t, err)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+
+	client := NewClient(&config.Config{}, authManager, logger)
+	client.driveBaseURL = server.URL
+	client.retryConfig.MaxRetries = 0
+
+	pdf, err := client.ExportDocumentPDF(context.Background(), "doc_id")
+	require.NoError(t, err)
+	assert.Equal(t, fakePDF, pdf)
+
+	// Errors use the Google error envelope
+	_, err = client.ExportDocumentPDF(context.Background(), "large_doc")
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeAPIUnauthorized, appErr.Code)
+	assert.Equal(t, "This file is too large to be exported.", appErr.Message)
+
+	_, err = client.ExportDocumentPDF(context.Background(), "missing_doc")
+	appErr, ok = err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeAPINotFound, appErr.Code)
+
+	// An empty ID is rejected without a request
+	requestsBefore := requests
+	_, err = client.ExportDocumentPDF(context.Background(), "")
+	require.Error(t, err)
+	assert.Equal(t, requestsBefore, requests)
+}
+
+func TestClient_ShareDocument_PartialFailure(t *testing.T) {
+	var shared []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var permission Permission
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&permission))
+		if strings.HasPrefix(permission.EmailAddress, "external") {
+			w.WriteHeader(http.StatusForbidden)
+			json.NewEncoder(w).Encode(GoogleErrorResponse{
+				ErrorInfo: GoogleAPIError{Code: 403, Message: "Sharing outside the domain is not allowed", Status: "PERMISSION_DENIED"},
+			})
+			return
+		}
+		shared = append(shared, permission.EmailAddress)
+		json.NewEncoder(w).Encode(Permission{ID: "permission_id", Type: permission.Type, Role: permission.Role})
+	}))
+	defer server.Close()
+
+	logger := utils.NewMockLogger()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	err := authManager.GetCredentialStore().SetGoogleCredentials(security.GoogleCredentials{
+		ClientSecret: "test_client_secret",
+		AccessToken:  "test_access_token",
+	})
+	require.NoError(t, err)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+
+	client := NewClient(&config.Config{}, authManager, logger)
+	client.driveBaseURL = server.URL
+	client.retryConfig.MaxRetries = 0
+
+	emails := []string{"alice@example.com", "external1@partner.com", "", "bob@example.com", "external2@partner.com"}
+
+	// Failures do not stop the remaining addresses from being shared
+	results, err := client.ShareDocumentWithResults(context.Background(), "doc_id", emails, RoleWriter)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, shared)
+	assert.True(t, results[0].Succeeded())
+	assert.Equal(t, "external1@partner.com", results[1].Email)
+	assert.False(t, results[1].Succeeded())
+	assert.Contains(t, results[1].Error.Error(), "Sharing outside the domain is not allowed")
+	assert.True(t, results[2].Succeeded())
+	assert.False(t, results[3].Succeeded())
+
+	// ShareDocument reports the failed addresses instead of returning nil
+	err = client.ShareDocument(context.Background(), "doc_id", emails, RoleWriter)
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeGoogleError, appErr.Code)
+	assert.Contains(t, appErr.Message, "2 of 4 recipients")
+	assert.Contains(t, appErr.Message, "external1@partner.com")
+	assert.Contains(t, appErr.Message, "external2@partner.com")
+	assert.NotContains(t, appErr.Message, "alice@example.com")
+
+	shareResults, ok := appErr.Context.Extra["share_results"].([]ShareResult)
+	require.True(t, ok)
+	assert.Equal(t, results[0].Email, shareResults[0].Email)
+	assert.Len(t, shareResults, 4)
+
+	// Success for every address returns nil
+	assert.NoError(t, client.ShareDocument(context.Background(), "doc_id", []string{"carol@example.com"}, RoleReader))
+}
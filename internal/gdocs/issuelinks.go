@@ -0,0 +1,52 @@
+package gdocs
+
+import (
+	"regexp"
+	"strings"
+)
+
+// issueKeyPattern matches Jira issue keys such as PROJ-123
+var issueKeyPattern = regexp.MustCompile(`\b[A-Z][A-Z0-9]*-\d+\b`)
+
+// SetIssueLinks toggles linking Jira issue keys in the summary and appendix to the issue in
+// Jira. Links need the Jira URL from the configuration.
+func (c *Client) SetIssueLinks(enabled bool) {
+	c.issueLinks = enabled
+}
+
+// IssueLinkRequests builds one request per issue key occurrence in text, which starts at index,
+// linking it to <jiraBaseURL>/browse/<KEY>. Ranges are in UTF-16 code units. No requests are
+// built without a Jira URL.
+func IssueLinkRequests(text string, index int32, jiraBaseURL string) []Request {
+	jiraBaseURL = strings.TrimRight(jiraBaseURL, "/")
+	if jiraBaseURL == "" {
+		return nil
+	}
+
+	var requests []Request
+	offset, consumed := int32(0), 0
+	for _, match := range issueKeyPattern.FindAllStringIndex(text, -1) {
+		// Count code units incrementally so repeated keys each get their own range
+		offset += utf16Len(text[consumed:match[0]])
+		length := utf16Len(text[match[0]:match[1]])
+		consumed = match[0]
+
+		requests = append(requests, Request{
+			UpdateTextStyle: &UpdateTextStyleRequest{
+				Range:     &Range{StartIndex: index + offset, EndIndex: index + offset + length},
+				TextStyle: &TextStyle{Link: &Link{URL: jiraBaseURL + "/browse/" + text[match[0]:match[1]]}},
+				Fields:    "link",
+			},
+		})
+	}
+
+	return requests
+}
+
+// issueLinkRequests links issue keys in text when issue links are enabled
+func (c *Client) issueLinkRequests(text string, index int32) []Request {
+	if !c.issueLinks {
+		return nil
+	}
+	return IssueLinkRequests(text, index, c.jiraBaseURL)
+}
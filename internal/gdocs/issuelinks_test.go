@@ -0,0 +1,96 @@
+package gdocs
+
+import (
+	"testing"
+	"unicode/utf16"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// linkedText returns the text each link request covers, with the request's URL
+func linkedText(t *testing.T, text string, index int32, requests []Request) map[string][]string {
+	units := utf16.Encode([]rune(text))
+	links := make(map[string][]string)
+	for _, req := range requests {
+		require.NotNil(t, req.UpdateTextStyle)
+		require.Equal(t, "link", req.UpdateTextStyle.Fields)
+		r := req.UpdateTextStyle.Range
+		covered := string(utf16.Decode(units[r.StartIndex-index : r.EndIndex-index]))
+		links[covered] = append(links[covered], req.UpdateTextStyle.TextStyle.Link.URL)
+	}
+	return links
+}
+
+func TestIssueLinkRequests(t *testing.T) {
+	text := "TEST-123 blocks 🚧 TEST-123 and OPS-7; TEST-123 again. lowercase-1 and TEST- are not keys"
+	index := int32(10)
+
+	requests := IssueLinkRequests(text, index, "https://jira.example.com/")
+
+	// One request per occurrence, including repeats of the same key
+	require.Len(t, requests, 4)
+	assert.Equal(t, &Range{StartIndex: 10, EndIndex: 18}, requests[0].UpdateTextStyle.Range)
+	// The emoji before the second occurrence is two UTF-16 code units
+	assert.Equal(t, &Range{StartIndex: 10 + 19, EndIndex: 10 + 27}, requests[1].UpdateTextStyle.Range)
+
+	links := linkedText(t, text, index, requests)
+	assert.Equal(t, map[string][]string{
+		"TEST-123": {
+			"https://jira.example.com/browse/TEST-123",
+			"https://jira.example.com/browse/TEST-123",
+			"https://jira.example.com/browse/TEST-123",
+		},
+		"OPS-7": {"https://jira.example.com/browse/OPS-7"},
+	}, links)
+}
+
+func TestIssueLinkRequests_NoBaseURL(t *testing.T) {
+	assert.Empty(t, IssueLinkRequests("TEST-1", 1, ""))
+	assert.Empty(t, IssueLinkRequests("No keys here", 1, "https://jira.example.com"))
+}
+
+func TestClient_buildExecutiveSummaryRequests_IssueLinks(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	cfg.Jira.URL = "https://jira.example.com/"
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	summary := "Shipped **TEST-1** and TEST-2; TEST-1 is done."
+
+	// Links are opt-in
+	for _, req := range client.buildExecutiveSummaryRequests("Title", summary, nil) {
+		if req.UpdateTextStyle != nil {
+			assert.Nil(t, req.UpdateTextStyle.TextStyle.Link)
+		}
+	}
+
+	client.SetIssueLinks(true)
+	client.SetRenderMarkdown(true)
+	requests := client.buildExecutiveSummaryRequests("Title", summary, nil)
+
+	body := applyInsertRequests(t, requests)
+	var document []uint16
+	for _, element := range body.Content {
+		document = append(document, utf16.Encode([]rune(element.Paragraph.Elements[0].TextRun.Content))...)
+	}
+
+	var linked []string
+	for _, req := range requests {
+		if req.UpdateTextStyle == nil || req.UpdateTextStyle.TextStyle.Link == nil {
+			continue
+		}
+		r := req.UpdateTextStyle.Range
+		key := string(utf16.Decode(document[r.StartIndex-1 : r.EndIndex-1]))
+		assert.Equal(t, "https://jira.example.com/browse/"+key, req.UpdateTextStyle.TextStyle.Link.URL)
+		linked = append(linked, key)
+	}
+
+	// Ranges follow the rendered text, which no longer holds the bold markers
+	assert.Equal(t, []string{"TEST-1", "TEST-2", "TEST-1"}, linked)
+}
@@ -0,0 +1,317 @@
+package gdocs
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/models"
+)
+
+// Section headings of a structured summary document
+const (
+	layoutHeadingSummary         = "Executive Summary"
+	layoutHeadingMetrics         = "Key Metrics"
+	layoutHeadingPriorities      = "Priority Breakdown"
+	layoutHeadingHighlights      = "Highlights"
+	layoutHeadingConcerns        = "Concerns"
+	layoutHeadingRecommendations = "Recommendations"
+	layoutHeadingTeam            = "Team Insights"
+	layoutHeadingAchievements    = "Key Achievements"
+	layoutHeadingImprovements    = "Areas for Improvement"
+	
+	// DefaultEmptySectionText is shown under sections kept without content
+	DefaultEmptySectionText = "None reported."
+)
+
+// LayoutOptions configures how BuildSummaryResponseRequests lays out a structured summary
+type LayoutOptions struct {
+	SuppressEmptySections bool   `json:"suppress_empty_sections"` // Leave out sections without content, headings included
+	EmptySectionText      string `json:"empty_section_text"`      // Placeholder under kept empty sections
+}
+
+// DefaultLayoutOptions returns layout options that leave out empty sections
+func DefaultLayoutOptions() LayoutOptions {
+	return LayoutOptions{
+		SuppressEmptySections: true,
+		EmptySectionText:      DefaultEmptySectionText,
+	}
+}
+
+// priorityTableOrder ranks the standard Jira priorities from most to least urgent
+var priorityTableOrder = map[string]int{
+	"Blocker":  0,
+	"Highest":  1,
+	"Critical": 2,
+	"High":     3,
+	"Medium":   4,
+	"Low":      5,
+	"Lowest":   6,
+	"Trivial":  7,
+}
+
+// layoutBuilder appends requests to a new document, tracking the index where the next
+// insert lands in UTF-16 code units
+type layoutBuilder struct {
+	index    int32
+	requests []Request
+	opts     LayoutOptions
+}
+
+// BuildSummaryResponseRequests builds the batch update requests that lay out a structured
+// summary in an empty document: a title, the executive summary, a key metrics table, bulleted
+// highlights, concerns and recommendations, and a section per user. Sections without content
+// are left out or shown with a placeholder, depending on opts.
+func BuildSummaryResponseRequests(summary *processor.SummaryResponse, opts LayoutOptions) []Request {
+	if summary == nil {
+		return nil
+	}
+	if opts.EmptySectionText == "" {
+		opts.EmptySectionText = DefaultEmptySectionText
+	}
+	
+	b := &layoutBuilder{index: 1, opts: opts}
+	
+	title := summary.Title
+	if title == "" {
+		title = layoutHeadingSummary
+	}
+	b.paragraph(title, NamedStyleTypeTitle)
+	
+	var subtitle []string
+	if summary.PeriodLabel != "" {
+		subtitle = append(subtitle, summary.PeriodLabel)
+	}
+	if !summary.GeneratedAt.IsZero() {
+		subtitle = append(subtitle, "Generated "+summary.GeneratedAt.Format("January 2, 2006"))
+	}
+	if len(subtitle) > 0 {
+		b.paragraph(strings.Join(subtitle, " | "), NamedStyleTypeSubtitle)
+	}
+	
+	var summaryLines []string
+	if text := strings.TrimSpace(summary.ExecutiveSummary); text != "" {
+		summaryLines = strings.Split(text, "\n")
+	}
+	if b.section(layoutHeadingSummary, NamedStyleTypeHeading1, len(summaryLines)) {
+		for _, line := range summaryLines {
+			b.paragraph(line, "")
+		}
+	}
+	
+	b.paragraph(layoutHeadingMetrics, NamedStyleTypeHeading1)
+	b.table(summaryMetricRows(summary))
+	if summary.RawData != nil && b.section(layoutHeadingPriorities, NamedStyleTypeHeading2, len(summary.RawData.PriorityBreakdown)) {
+		b.table(priorityTableRows(summary.RawData.PriorityBreakdown))
+	}
+	
+	b.bulletSection(layoutHeadingHighlights, NamedStyleTypeHeading1, summary.Highlights)
+	b.bulletSection(layoutHeadingConcerns, NamedStyleTypeHeading1, summary.Concerns)
+	b.bulletSection(layoutHeadingRecommendations, NamedStyleTypeHeading1, summary.PublishedRecommendations())
+	
+	if b.section(layoutHeadingTeam, NamedStyleTypeHeading1, len(summary.UserInsights)) {
+		for _, insight := range summary.UserInsights {
+			name := insight.DisplayName
+			if name == "" {
+				name = insight.UserID
+			}
+			b.paragraph(name, NamedStyleTypeHeading2)
+			b.paragraph(fmt.Sprintf("Rank #%d | %d activities | %.1f%% completed | %s logged",
+				insight.ProductivityRank, insight.TotalActivities, insight.CompletionRate, insight.TimeSpent), "")
+			
+			b.bulletSection(layoutHeadingAchievements, NamedStyleTypeHeading3, insight.KeyAchievements)
+			b.bulletSection(layoutHeadingImprovements, NamedStyleTypeHeading3, insight.AreasForImprovement)
+		}
+	}
+	
+	return b.requests
+}
+
+// summaryMetricRows returns the key metrics table, header row first
+func summaryMetricRows(summary *processor.SummaryResponse) [][]string {
+	rows := [][]string{{"Metric", "Value"}}
+	for _, row := range summary.MetricRows() {
+		rows = append(rows, []string{row.Name, row.Value})
+	}
+	return rows
+}
+
+// PriorityTableRequests builds requests that insert a table of priority against item count,
+// completion and logged time at index, one row per priority below a header row. Rows run from
+// the most to the least urgent standard priority, followed by any others alphabetically. It
+// returns the requests and the number of indexes the filled table consumes, including the
+// newline the Docs API inserts before it.
+func PriorityTableRequests(breakdown map[string]processor.PriorityMetrics, index int32) ([]Request, int32) {
+	b := &layoutBuilder{index: index}
+	b.table(priorityTableRows(breakdown))
+	return b.requests, b.index - index
+}
+
+// priorityTableRows returns the priority breakdown table, header row first
+func priorityTableRows(breakdown map[string]processor.PriorityMetrics) [][]string {
+	priorities := make([]string, 0, len(breakdown))
+	for priority := range breakdown {
+		priorities = append(priorities, priority)
+	}
+	sort.Slice(priorities, func(i, j int) bool {
+		rankI, knownI := priorityTableOrder[priorities[i]]
+		rankJ, knownJ := priorityTableOrder[priorities[j]]
+		if knownI != knownJ {
+			return knownI
+		}
+		if knownI && rankI != rankJ {
+			return rankI < rankJ
+		}
+		return priorities[i] < priorities[j]
+	})
+	
+	rows := [][]string{{"Priority", "Items", "Completed", "Time Spent"}}
+	for _, priority := range priorities {
+		metrics := breakdown[priority]
+		rows = append(rows, []string{
+			priority,
+			fmt.Sprintf("%d", metrics.Count),
+			fmt.Sprintf("%d (%.1f%%)", metrics.CompletedCount, metrics.CompletionRate),
+			models.FormatTimeSpent(metrics.TotalTimeSpent),
+		})
+	}
+	return rows
+}
+
+// insertText inserts text at the current index and returns where it starts
+func (b *layoutBuilder) insertText(text string) int32 {
+	start := b.index
+	b.requests = append(b.requests, Request{
+		InsertText: &InsertTextRequest{
+			Text:     text,
+			Location: &Location{Index: start},
+		},
+	})
+	b.index += utf16Len(text)
+	return start
+}
+
+// paragraph inserts a line of text and applies a named style to it unless style is empty
+func (b *layoutBuilder) paragraph(text, style string) {
+	start := b.insertText(text + "\n")
+	if style == "" || text == "" {
+		return
+	}
+	
+	b.requests = append(b.requests, Request{
+		UpdateParagraphStyle: &UpdateParagraphStyleRequest{
+			Range:          &Range{StartIndex: start, EndIndex: start + utf16Len(text)},
+			ParagraphStyle: &ParagraphStyle{NamedStyleType: style},
+			Fields:         "namedStyleType",
+		},
+	})
+}
+
+// section inserts a section heading and reports whether its content should follow. A section
+// without items is either left out entirely or given the placeholder text in place of content,
+// and nothing is inserted for a suppressed section so the running index is unchanged.
+func (b *layoutBuilder) section(heading, style string, items int) bool {
+	if items == 0 && b.opts.SuppressEmptySections {
+		return false
+	}
+	
+	b.paragraph(heading, style)
+	if items == 0 {
+		b.paragraph(b.opts.EmptySectionText, "")
+		return false
+	}
+	return true
+}
+
+// bulletSection inserts a heading followed by a bulleted list of items
+func (b *layoutBuilder) bulletSection(heading, style string, items []string) {
+	if b.section(heading, style, len(items)) {
+		b.bullets(items)
+	}
+}
+
+// bullets inserts one paragraph per item and turns them into a bulleted list. Items are
+// flattened onto a single line so each stays one bullet.
+func (b *layoutBuilder) bullets(items []string) {
+	lines := make([]string, len(items))
+	for i, item := range items {
+		lines[i] = strings.Join(strings.Fields(item), " ")
+	}
+	text := strings.Join(lines, "\n")
+	start := b.insertText(text + "\n")
+	
+	b.requests = append(b.requests, Request{
+		CreateParagraphBullets: &CreateParagraphBulletsRequest{
+			Range:        &Range{StartIndex: start, EndIndex: start + utf16Len(text)},
+			BulletPreset: BulletDiscCircleSquare,
+		},
+	})
+}
+
+// table inserts a table holding rows, with the first row in bold. The Docs API adds a newline
+// before the table, and a table occupies one index for its start and end, one for each row
+// and two for each empty cell (the cell start and its paragraph). Cells are filled from last
+// to first so every insert lands at an index that earlier fills have not shifted.
+func (b *layoutBuilder) table(rows [][]string) {
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return
+	}
+	
+	rowCount, columnCount := int32(len(rows)), int32(len(rows[0]))
+	tableStart := b.index + 1
+	b.requests = append(b.requests, Request{
+		InsertTable: &InsertTableRequest{
+			Rows:     rowCount,
+			Columns:  columnCount,
+			Location: &Location{Index: b.index},
+		},
+	})
+	
+	cellIndex := func(row, column int32) int32 {
+		return tableStart + 3 + row*(1+2*columnCount) + 2*column
+	}
+	
+	for row := rowCount - 1; row >= 0; row-- {
+		for column := columnCount - 1; column >= 0; column-- {
+			if int(column) >= len(rows[row]) || rows[row][column] == "" {
+				continue
+			}
+			b.requests = append(b.requests, Request{
+				InsertText: &InsertTextRequest{
+					Text:     rows[row][column],
+					Location: &Location{Index: cellIndex(row, column)},
+				},
+			})
+		}
+	}
+	
+	// Only header cells to the left shift a header cell once the table is filled
+	filled := int32(0)
+	for column := int32(0); column < columnCount; column++ {
+		text := rows[0][column]
+		start := cellIndex(0, column) + filled
+		filled += utf16Len(text)
+		if text == "" {
+			continue
+		}
+		b.requests = append(b.requests, Request{
+			UpdateTextStyle: &UpdateTextStyleRequest{
+				Range:     &Range{StartIndex: start, EndIndex: start + utf16Len(text)},
+				TextStyle: &TextStyle{Bold: boolPtr(true)},
+				Fields:    "bold",
+			},
+		})
+	}
+	
+	// The newline before the table, the empty table and the text of every cell
+	size := 1 + 2 + rowCount*(1+2*columnCount)
+	for _, row := range rows {
+		for column, cell := range row {
+			if int32(column) < columnCount {
+				size += utf16Len(cell)
+			}
+		}
+	}
+	b.index += size
+}
@@ -0,0 +1,291 @@
+package gdocs
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Placeholder code units standing in for the structural indexes a table occupies
+const (
+	tableStartUnit = 0xE000
+	tableRowUnit   = 0xE001
+	tableCellUnit  = 0xE002
+	tableEndUnit   = 0xE003
+)
+
+// simulateLayout replays InsertText and InsertTable requests against an empty document and
+// returns its content, so content[index-1] is the code unit at a document index
+func simulateLayout(t *testing.T, requests []Request) []uint16 {
+	content := utf16.Encode([]rune("\n"))
+	insert := func(index int32, units []uint16) {
+		offset := int(index) - 1
+		require.GreaterOrEqual(t, offset, 0)
+		require.Less(t, offset, len(content), "insert must land before the final newline")
+		content = append(content[:offset], append(units, content[offset:]...)...)
+	}
+
+	for _, req := range requests {
+		switch {
+		case req.InsertText != nil:
+			// Text inside a table has to land in a cell paragraph
+			if previous := int(req.InsertText.Location.Index) - 2; previous >= 0 && previous < len(content) {
+				require.NotContains(t, []uint16{tableStartUnit, tableRowUnit}, content[previous])
+			}
+			insert(req.InsertText.Location.Index, utf16.Encode([]rune(req.InsertText.Text)))
+		case req.InsertTable != nil:
+			units := []uint16{'\n', tableStartUnit}
+			for row := int32(0); row < req.InsertTable.Rows; row++ {
+				units = append(units, tableRowUnit)
+				for column := int32(0); column < req.InsertTable.Columns; column++ {
+					units = append(units, tableCellUnit, '\n')
+				}
+			}
+			units = append(units, tableEndUnit)
+			insert(req.InsertTable.Location.Index, units)
+		}
+	}
+
+	return content
+}
+
+// layoutText returns the text covered by a range of a simulated document
+func layoutText(content []uint16, r *Range) string {
+	return string(utf16.Decode(content[r.StartIndex-1 : r.EndIndex-1]))
+}
+
+func TestBuildSummaryResponseRequests(t *testing.T) {
+	summary := &processor.SummaryResponse{
+		Title:            "Weekly Summary 🚀",
+		PeriodLabel:      "Jan 1 - Jan 7, 2024",
+		GeneratedAt:      time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC),
+		ExecutiveSummary: "The team had a productive week.\nDelivery is on track.",
+		KeyMetrics: processor.SummaryKeyMetrics{
+			TotalActivities:     20,
+			CompletedActivities: 15,
+			CompletionRate:      75,
+			TotalTimeSpent:      "40h",
+			ActiveUsers:         2,
+			MostActiveUser:      "Jane Doe",
+		},
+		Highlights:      []string{"Shipped the login page", "Résumé parser\nrewritten"},
+		Concerns:        []string{"Payment API is blocked"},
+		Recommendations: []string{"Ignored when owners are assigned"},
+		OwnedRecommendations: []processor.OwnedRecommendation{
+			{Text: "Unblock the payment API", Owner: "Jane Doe"},
+			{Text: "Review open bugs", Owner: processor.UnassignedOwner},
+			{Text: "Plan the next release", Owner: "John Smith"},
+		},
+		UserInsights: []processor.UserInsight{
+			{DisplayName: "Jane Doe", ProductivityRank: 1, TotalActivities: 12, CompletionRate: 80, TimeSpent: "24h",
+				KeyAchievements: []string{"Shipped login", "Fixed search"}},
+			{DisplayName: "John Smith", ProductivityRank: 2, TotalActivities: 8, CompletionRate: 62.5, TimeSpent: "16h",
+				AreasForImprovement: []string{"Close stale tickets"}},
+		},
+	}
+
+	requests := BuildSummaryResponseRequests(summary, DefaultLayoutOptions())
+	content := simulateLayout(t, requests)
+
+	headings := make(map[string][]string)
+	var bulletLists [][]string
+	var boldText []string
+	for _, req := range requests {
+		switch {
+		case req.UpdateParagraphStyle != nil:
+			style := req.UpdateParagraphStyle.ParagraphStyle.NamedStyleType
+			headings[style] = append(headings[style], layoutText(content, req.UpdateParagraphStyle.Range))
+		case req.CreateParagraphBullets != nil:
+			bulletLists = append(bulletLists, strings.Split(layoutText(content, req.CreateParagraphBullets.Range), "\n"))
+		case req.UpdateTextStyle != nil:
+			boldText = append(boldText, layoutText(content, req.UpdateTextStyle.Range))
+		}
+	}
+
+	assert.Equal(t, []string{"Weekly Summary 🚀"}, headings[NamedStyleTypeTitle])
+	assert.Equal(t, []string{"Jan 1 - Jan 7, 2024 | Generated January 8, 2024"}, headings[NamedStyleTypeSubtitle])
+	assert.Equal(t, []string{"Executive Summary", "Key Metrics", "Highlights", "Concerns", "Recommendations", "Team Insights"},
+		headings[NamedStyleTypeHeading1])
+	assert.Equal(t, []string{"Jane Doe", "John Smith"}, headings[NamedStyleTypeHeading2])
+	assert.Equal(t, []string{"Key Achievements", "Areas for Improvement"}, headings[NamedStyleTypeHeading3])
+
+	// One bulleted list per section, each with an entry per input item
+	require.Len(t, bulletLists, 5)
+	assert.Equal(t, []string{"Shipped the login page", "Résumé parser rewritten"}, bulletLists[0])
+	assert.Equal(t, []string{"Payment API is blocked"}, bulletLists[1])
+	assert.Equal(t, []string{
+		"Unblock the payment API (Owner: Jane Doe)",
+		"Review open bugs (Owner: Unassigned)",
+		"Plan the next release (Owner: John Smith)",
+	}, bulletLists[2])
+	assert.Len(t, bulletLists[3], len(summary.UserInsights[0].KeyAchievements))
+	assert.Len(t, bulletLists[4], len(summary.UserInsights[1].AreasForImprovement))
+
+	// Every metric lands in its own cell and the header row is bold
+	assert.Equal(t, []string{"Metric", "Value"}, boldText)
+	text := string(utf16.Decode(content))
+	assert.Contains(t, text, "Completion rate\n75.0%\n")
+	assert.Contains(t, text, "Most active user\nJane Doe\n")
+	assert.Contains(t, text, "Average time per task\n\n") // Empty values leave the cell empty
+
+	// Text after the table continues right after it
+	assert.Contains(t, text, "Highlights\n")
+	assert.True(t, strings.HasSuffix(text, "Close stale tickets\n\n"))
+}
+
+func TestBuildSummaryResponseRequests_EmptySections(t *testing.T) {
+	requests := BuildSummaryResponseRequests(&processor.SummaryResponse{}, DefaultLayoutOptions())
+	content := simulateLayout(t, requests)
+
+	var headings []string
+	for _, req := range requests {
+		if req.UpdateParagraphStyle != nil {
+			headings = append(headings, layoutText(content, req.UpdateParagraphStyle.Range))
+		}
+		assert.Nil(t, req.CreateParagraphBullets)
+	}
+	assert.Equal(t, []string{"Executive Summary", "Key Metrics"}, headings)
+
+	assert.Nil(t, BuildSummaryResponseRequests(nil, DefaultLayoutOptions()))
+}
+
+func TestBuildSummaryResponseRequests_SuppressEmptySections(t *testing.T) {
+	summary := &processor.SummaryResponse{
+		Title:    "Weekly Summary",
+		Concerns: []string{"Payment API is blocked"},
+		UserInsights: []processor.UserInsight{
+			{DisplayName: "Jane Doe", KeyAchievements: []string{"Shipped login"}},
+		},
+	}
+
+	headingsOf := func(requests []Request, content []uint16) []string {
+		var headings []string
+		for _, req := range requests {
+			if req.UpdateParagraphStyle != nil && req.UpdateParagraphStyle.ParagraphStyle.NamedStyleType == NamedStyleTypeHeading1 {
+				headings = append(headings, layoutText(content, req.UpdateParagraphStyle.Range))
+			}
+		}
+		return headings
+	}
+
+	// Empty highlights and recommendations are left out and later indexes stay correct
+	requests := BuildSummaryResponseRequests(summary, DefaultLayoutOptions())
+	content := simulateLayout(t, requests)
+	assert.Equal(t, []string{"Key Metrics", "Concerns", "Team Insights"}, headingsOf(requests, content))
+	text := string(utf16.Decode(content))
+	assert.NotContains(t, text, "Highlights")
+	assert.NotContains(t, text, DefaultEmptySectionText)
+	for _, req := range requests {
+		if req.CreateParagraphBullets != nil {
+			assert.NotEqual(t, "", layoutText(content, req.CreateParagraphBullets.Range))
+		}
+	}
+
+	// Kept empty sections show the placeholder instead of a bare heading
+	requests = BuildSummaryResponseRequests(summary, LayoutOptions{EmptySectionText: "Nothing this week."})
+	content = simulateLayout(t, requests)
+	assert.Equal(t, []string{"Executive Summary", "Key Metrics", "Highlights", "Concerns", "Recommendations", "Team Insights"},
+		headingsOf(requests, content))
+	text = string(utf16.Decode(content))
+	assert.Contains(t, text, "Highlights\nNothing this week.\nConcerns\nPayment API is blocked\n")
+	assert.Contains(t, text, "Areas for Improvement\nNothing this week.\n")
+}
+
+// simulatedTables returns the cell text of every table in a simulated document
+func simulatedTables(content []uint16) [][][]string {
+	var tables [][][]string
+	var table [][]string
+	var cell []uint16
+	inCell := false
+	for _, unit := range content {
+		switch unit {
+		case tableStartUnit:
+			table = nil
+		case tableRowUnit:
+			table = append(table, nil)
+		case tableCellUnit:
+			inCell, cell = true, nil
+		case tableEndUnit:
+			tables = append(tables, table)
+		case '\n':
+			if inCell {
+				table[len(table)-1] = append(table[len(table)-1], string(utf16.Decode(cell)))
+				inCell = false
+			}
+		default:
+			cell = append(cell, unit)
+		}
+	}
+	return tables
+}
+
+func TestPriorityTableRequests(t *testing.T) {
+	breakdown := map[string]processor.PriorityMetrics{
+		"Low":    {Priority: "Low", Count: 4, CompletedCount: 1, CompletionRate: 25, TotalTimeSpent: 1800},
+		"Custom": {Priority: "Custom", Count: 1},
+		"High":   {Priority: "High", Count: 12, CompletedCount: 9, CompletionRate: 75, TotalTimeSpent: 45000},
+		"Medium": {Priority: "Medium", Count: 6, CompletedCount: 4, CompletionRate: 66.666, TotalTimeSpent: 7200},
+	}
+
+	// Insert after existing text so the table does not start at the first index
+	requests := []Request{{InsertText: &InsertTextRequest{Text: "Before\n", Location: &Location{Index: 1}}}}
+	tableRequests, consumed := PriorityTableRequests(breakdown, 8)
+	requests = append(requests, tableRequests...)
+	requests = append(requests, Request{InsertText: &InsertTextRequest{Text: "After", Location: &Location{Index: 8 + consumed}}})
+
+	require.NotNil(t, tableRequests[0].InsertTable)
+	assert.Equal(t, int32(5), tableRequests[0].InsertTable.Rows)
+	assert.Equal(t, int32(4), tableRequests[0].InsertTable.Columns)
+	assert.Equal(t, int32(8), tableRequests[0].InsertTable.Location.Index)
+
+	content := simulateLayout(t, requests)
+	tables := simulatedTables(content)
+	require.Len(t, tables, 1)
+	assert.Equal(t, [][]string{
+		{"Priority", "Items", "Completed", "Time Spent"},
+		{"High", "12", "9 (75.0%)", "12h 30m"},
+		{"Medium", "6", "4 (66.7%)", "2h 0m"},
+		{"Low", "4", "1 (25.0%)", "30m"},
+		{"Custom", "1", "0 (0.0%)", "0m"},
+	}, tables[0])
+
+	// The consumed length places the next insert right after the table
+	text := string(utf16.Decode(content))
+	assert.True(t, strings.HasPrefix(text, "Before\n\n"))
+	assert.True(t, strings.HasSuffix(text, string(rune(tableEndUnit))+"After\n"))
+
+	// The header row is bold
+	var bold []string
+	for _, req := range tableRequests {
+		if req.UpdateTextStyle != nil {
+			bold = append(bold, layoutText(content, req.UpdateTextStyle.Range))
+		}
+	}
+	assert.Equal(t, []string{"Priority", "Items", "Completed", "Time Spent"}, bold)
+}
+
+func TestBuildSummaryResponseRequests_PriorityBreakdown(t *testing.T) {
+	summary := &processor.SummaryResponse{
+		Highlights: []string{"Shipped login"},
+		RawData: &processor.ProcessingResult{
+			PriorityBreakdown: map[string]processor.PriorityMetrics{
+				"High": {Priority: "High", Count: 2, CompletedCount: 1, CompletionRate: 50},
+			},
+		},
+	}
+
+	requests := BuildSummaryResponseRequests(summary, DefaultLayoutOptions())
+	content := simulateLayout(t, requests)
+
+	tables := simulatedTables(content)
+	require.Len(t, tables, 2)
+	assert.Len(t, tables[0], 10) // Key metrics
+	assert.Equal(t, []string{"High", "2", "1 (50.0%)", "0m"}, tables[1][1])
+	assert.Contains(t, string(utf16.Decode(content)), "Priority Breakdown\n")
+	assert.True(t, strings.HasSuffix(string(utf16.Decode(content)), "Highlights\nShipped login\n\n"))
+}
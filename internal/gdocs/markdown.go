@@ -0,0 +1,224 @@
+package gdocs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+var (
+	markdownHeadingPattern  = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	markdownBulletPattern   = regexp.MustCompile(`^\s*[-*+]\s+(.*)$`)
+	markdownNumberedPattern = regexp.MustCompile(`^\s*\d+[.)]\s+(.*)$`)
+)
+
+// markdownParagraph is one line of markdown with its markers removed
+type markdownParagraph struct {
+	text    string
+	spans   []markdownSpan
+	heading int    // Heading level 1-6, zero for body text
+	bullets string // Bullet preset for list items, empty otherwise
+}
+
+// markdownSpan is a bold or italic run within a paragraph, in UTF-16 code units from its start
+type markdownSpan struct {
+	start  int32
+	end    int32
+	bold   bool
+	italic bool
+}
+
+// MarkdownRequests converts a markdown subset into requests that insert the text at index and
+// format it. Headings (#), bullet (-, *, +) and numbered list items and **bold**/*italic*
+// emphasis are supported; other markdown is inserted as written. It returns the requests and
+// the number of indexes the inserted text consumes.
+func MarkdownRequests(markdown string, index int32) ([]Request, int32) {
+	return markdownRequests(markdown, index, DefaultMaxInsertChars)
+}
+
+// markdownRequests converts markdown into requests, splitting the text insert at insertLimit
+func markdownRequests(markdown string, index int32, insertLimit int32) ([]Request, int32) {
+	lines := strings.Split(markdown, "\n")
+	paragraphs := make([]markdownParagraph, len(lines))
+	texts := make([]string, len(lines))
+	for i, line := range lines {
+		paragraphs[i] = parseMarkdownLine(line)
+		texts[i] = paragraphs[i].text
+	}
+	text := strings.Join(texts, "\n")
+
+	var requests []Request
+	insertIndex := index
+	for _, chunk := range splitInsertText(text, insertLimit) {
+		requests = append(requests, Request{
+			InsertText: &InsertTextRequest{
+				Text:     chunk,
+				Location: &Location{Index: insertIndex},
+			},
+		})
+		insertIndex += utf16Len(chunk)
+	}
+
+	// Formatting is applied once all text is in place, so the indexes below stay valid
+	var styleRequests, bulletRequests []Request
+	listStart, listEnd, listPreset := int32(-1), int32(0), ""
+	flushList := func() {
+		if listStart >= 0 {
+			bulletRequests = append(bulletRequests, Request{
+				CreateParagraphBullets: &CreateParagraphBulletsRequest{
+					Range:        &Range{StartIndex: listStart, EndIndex: listEnd},
+					BulletPreset: listPreset,
+				},
+			})
+		}
+		listStart = -1
+	}
+
+	start := index
+	for _, paragraph := range paragraphs {
+		end := start + utf16Len(paragraph.text)
+
+		if paragraph.bullets != listPreset || paragraph.bullets == "" {
+			flushList()
+		}
+		if paragraph.bullets != "" {
+			if listStart < 0 {
+				listStart = start
+			}
+			listEnd = end
+			listPreset = paragraph.bullets
+		}
+
+		if paragraph.heading > 0 && end > start {
+			styleRequests = append(styleRequests, Request{
+				UpdateParagraphStyle: &UpdateParagraphStyleRequest{
+					Range:          &Range{StartIndex: start, EndIndex: end},
+					ParagraphStyle: &ParagraphStyle{NamedStyleType: fmt.Sprintf("HEADING_%d", paragraph.heading)},
+					Fields:         "namedStyleType",
+				},
+			})
+		}
+
+		for _, span := range paragraph.spans {
+			style, fields := &TextStyle{Bold: boolPtr(true)}, "bold"
+			if span.italic {
+				style, fields = &TextStyle{Italic: boolPtr(true)}, "italic"
+			}
+			styleRequests = append(styleRequests, Request{
+				UpdateTextStyle: &UpdateTextStyleRequest{
+					Range:     &Range{StartIndex: start + span.start, EndIndex: start + span.end},
+					TextStyle: style,
+					Fields:    fields,
+				},
+			})
+		}
+
+		start = end + 1 // Skip the newline ending the paragraph
+	}
+	flushList()
+
+	requests = append(requests, styleRequests...)
+	requests = append(requests, bulletRequests...)
+
+	return requests, utf16Len(text)
+}
+
+// parseMarkdownLine strips the block and inline markers from a single line of markdown
+func parseMarkdownLine(line string) markdownParagraph {
+	paragraph := markdownParagraph{}
+	content := line
+
+	if match := markdownHeadingPattern.FindStringSubmatch(line); match != nil {
+		paragraph.heading = len(match[1])
+		content = match[2]
+	} else if match := markdownBulletPattern.FindStringSubmatch(line); match != nil {
+		paragraph.bullets = BulletDiscCircleSquare
+		content = match[1]
+	} else if match := markdownNumberedPattern.FindStringSubmatch(line); match != nil {
+		paragraph.bullets = NumberedDecimalAlphaRoman
+		content = match[1]
+	}
+
+	paragraph.text, paragraph.spans = parseMarkdownInline(content)
+	return paragraph
+}
+
+// parseMarkdownInline removes **bold**, __bold__, *italic* and _italic_ markers from text and
+// returns the plain text with the emphasized ranges. A marker without a closing partner is
+// kept as literal text, as are underscores inside words such as snake_case names.
+func parseMarkdownInline(text string) (string, []markdownSpan) {
+	var plain strings.Builder
+	var spans []markdownSpan
+	offset := int32(0)
+	boldStart, boldMarker := int32(-1), ""
+	italicStart, italicMarker := int32(-1), ""
+
+	for i := 0; i < len(text); {
+		if marker := emphasisMarker(text[i:]); marker != "" {
+			bold := len(marker) == 2
+			switch {
+			case bold && boldStart >= 0 && marker == boldMarker:
+				if offset > boldStart {
+					spans = append(spans, markdownSpan{start: boldStart, end: offset, bold: true})
+				}
+				boldStart = -1
+				i += len(marker)
+				continue
+			case !bold && italicStart >= 0 && marker == italicMarker:
+				if offset > italicStart {
+					spans = append(spans, markdownSpan{start: italicStart, end: offset, italic: true})
+				}
+				italicStart = -1
+				i += len(marker)
+				continue
+			case opensEmphasis(text, i, marker):
+				if bold && boldStart < 0 {
+					boldStart, boldMarker = offset, marker
+					i += len(marker)
+					continue
+				}
+				if !bold && italicStart < 0 {
+					italicStart, italicMarker = offset, marker
+					i += len(marker)
+					continue
+				}
+			}
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		plain.WriteRune(r)
+		offset += int32(utf16.RuneLen(r))
+		i += size
+	}
+
+	return plain.String(), spans
+}
+
+// emphasisMarker returns the emphasis marker at the start of text, if any
+func emphasisMarker(text string) string {
+	for _, marker := range []string{"**", "__", "*", "_"} {
+		if strings.HasPrefix(text, marker) {
+			return marker
+		}
+	}
+	return ""
+}
+
+// opensEmphasis reports whether marker at text[i:] starts an emphasized run: it must be
+// followed by a non-space character, be closed later on the line and, for underscores, not
+// sit inside a word
+func opensEmphasis(text string, i int, marker string) bool {
+	rest := text[i+len(marker):]
+	if rest == "" || rest[0] == ' ' || !strings.Contains(rest, marker) {
+		return false
+	}
+	if marker[0] == '_' && i > 0 {
+		previous, _ := utf8.DecodeLastRuneInString(text[:i])
+		if previous == '_' || previous >= '0' && previous <= '9' || previous >= 'A' && previous <= 'Z' || previous >= 'a' && previous <= 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,141 @@
+package gdocs
+
+import (
+	"testing"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleMarkdown = `## Highlights
+The team closed **12 issues** this week.
+- Login page *shipped*
+- Search is __faster__
+## Risks
+1. Payment API blocked
+2. Two reviews pending`
+
+func TestMarkdownRequests(t *testing.T) {
+	requests, consumed := MarkdownRequests(sampleMarkdown, 10)
+
+	plain := "Highlights\n" +
+		"The team closed 12 issues this week.\n" +
+		"Login page shipped\n" +
+		"Search is faster\n" +
+		"Risks\n" +
+		"Payment API blocked\n" +
+		"Two reviews pending"
+	require.NotEmpty(t, requests)
+	require.NotNil(t, requests[0].InsertText)
+	assert.Equal(t, plain, requests[0].InsertText.Text)
+	assert.Equal(t, int32(10), requests[0].InsertText.Location.Index)
+	assert.Equal(t, utf16Len(plain), consumed)
+
+	var headings, bullets, textStyles []Request
+	for _, req := range requests[1:] {
+		switch {
+		case req.UpdateParagraphStyle != nil:
+			headings = append(headings, req)
+		case req.CreateParagraphBullets != nil:
+			bullets = append(bullets, req)
+		case req.UpdateTextStyle != nil:
+			textStyles = append(textStyles, req)
+		default:
+			t.Fatalf("unexpected request %+v", req)
+		}
+	}
+
+	// Both headings are styled over exactly their text
+	require.Len(t, headings, 2)
+	assert.Equal(t, NamedStyleTypeHeading2, headings[0].UpdateParagraphStyle.ParagraphStyle.NamedStyleType)
+	assert.Equal(t, "namedStyleType", headings[0].UpdateParagraphStyle.Fields)
+	assert.Equal(t, &Range{StartIndex: 10, EndIndex: 20}, headings[0].UpdateParagraphStyle.Range)
+	risksStart := int32(10) + utf16Len("Highlights\nThe team closed 12 issues this week.\nLogin page shipped\nSearch is faster\n")
+	assert.Equal(t, &Range{StartIndex: risksStart, EndIndex: risksStart + 5}, headings[1].UpdateParagraphStyle.Range)
+
+	// Consecutive list items form one bulleted run per list
+	require.Len(t, bullets, 2)
+	listStart := int32(10) + utf16Len("Highlights\nThe team closed 12 issues this week.\n")
+	assert.Equal(t, BulletDiscCircleSquare, bullets[0].CreateParagraphBullets.BulletPreset)
+	assert.Equal(t, &Range{StartIndex: listStart, EndIndex: listStart + utf16Len("Login page shipped\nSearch is faster")}, bullets[0].CreateParagraphBullets.Range)
+	numberedStart := risksStart + utf16Len("Risks\n")
+	assert.Equal(t, NumberedDecimalAlphaRoman, bullets[1].CreateParagraphBullets.BulletPreset)
+	assert.Equal(t, &Range{StartIndex: numberedStart, EndIndex: numberedStart + utf16Len("Payment API blocked\nTwo reviews pending")}, bullets[1].CreateParagraphBullets.Range)
+
+	// Emphasis covers the text between the stripped markers
+	require.Len(t, textStyles, 3)
+	boldStart := int32(10) + utf16Len("Highlights\nThe team closed ")
+	assert.Equal(t, &Range{StartIndex: boldStart, EndIndex: boldStart + 9}, textStyles[0].UpdateTextStyle.Range)
+	assert.Equal(t, "bold", textStyles[0].UpdateTextStyle.Fields)
+	assert.Equal(t, "italic", textStyles[1].UpdateTextStyle.Fields)
+	assert.Equal(t, boolPtr(true), textStyles[1].UpdateTextStyle.TextStyle.Italic)
+	assert.Equal(t, "bold", textStyles[2].UpdateTextStyle.Fields)
+}
+
+func TestParseMarkdownInline(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		text  string
+		spans []markdownSpan
+	}{
+		{name: "plain", input: "No markers here", text: "No markers here"},
+		{name: "bold", input: "a **b** c", text: "a b c", spans: []markdownSpan{{start: 2, end: 3, bold: true}}},
+		{name: "italic", input: "_a_ b", text: "a b", spans: []markdownSpan{{start: 0, end: 1, italic: true}}},
+		{name: "unclosed marker", input: "5 * 3 and **open", text: "5 * 3 and **open"},
+		{name: "snake case", input: "the user_id_field value", text: "the user_id_field value"},
+		{name: "surrogate pairs", input: "🚀 **go**", text: "🚀 go", spans: []markdownSpan{{start: 3, end: 5, bold: true}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, spans := parseMarkdownInline(tt.input)
+			assert.Equal(t, tt.text, text)
+			assert.Equal(t, tt.spans, spans)
+		})
+	}
+}
+
+func TestClient_buildExecutiveSummaryRequests_RenderMarkdown(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	summary := "## Overview\n- Shipped **login**"
+
+	// Markers are inserted verbatim unless rendering is enabled
+	requests := client.buildExecutiveSummaryRequests("Title", summary, nil)
+	require.Len(t, requests, 3)
+	assert.Equal(t, summary, requests[2].InsertText.Text)
+
+	client.SetRenderMarkdown(true)
+	requests = client.buildExecutiveSummaryRequests("Title", summary, nil)
+
+	summaryIndex := int32(1) + utf16Len("Title\n\n")
+	body := applyInsertRequests(t, requests)
+	require.Len(t, body.Content, 4)
+	assert.Equal(t, "Overview\n", body.Content[2].Paragraph.Elements[0].TextRun.Content)
+	assert.Equal(t, "Shipped login\n", body.Content[3].Paragraph.Elements[0].TextRun.Content)
+
+	var heading *UpdateParagraphStyleRequest
+	var bullets *CreateParagraphBulletsRequest
+	for _, req := range requests {
+		if req.UpdateParagraphStyle != nil {
+			heading = req.UpdateParagraphStyle
+		}
+		if req.CreateParagraphBullets != nil {
+			bullets = req.CreateParagraphBullets
+		}
+	}
+	require.NotNil(t, heading)
+	assert.Equal(t, NamedStyleTypeHeading2, heading.ParagraphStyle.NamedStyleType)
+	assert.Equal(t, body.Content[2].StartIndex, heading.Range.StartIndex)
+	assert.Equal(t, summaryIndex, heading.Range.StartIndex)
+	require.NotNil(t, bullets)
+	assert.Equal(t, body.Content[3].StartIndex, bullets.Range.StartIndex)
+}
@@ -832,6 +832,7 @@ type ReplaceAllTextReply struct {
 
 // Permission represents a permission for sharing
 type Permission struct {
+	ID           string `json:"id,omitempty"`
 	Type         string `json:"type"`
 	Role         string `json:"role"`
 	EmailAddress string `json:"emailAddress,omitempty"`
@@ -840,6 +841,12 @@ type Permission struct {
 	ExpirationTime     string `json:"expirationTime,omitempty"`
 }
 
+// PermissionList represents a page of permissions returned by the Drive API
+type PermissionList struct {
+	Permissions   []Permission `json:"permissions"`
+	NextPageToken string       `json:"nextPageToken,omitempty"`
+}
+
 // GoogleErrorResponse represents a Google API error response
 type GoogleErrorResponse struct {
 	ErrorInfo GoogleAPIError `json:"error"`
@@ -920,6 +927,7 @@ const (
 	BulletCheckboxArrow3DCircle  = "BULLET_CHECKBOX_ARROW3D_CIRCLE"
 	BulletCheckboxCircleSquare   = "BULLET_CHECKBOX_CIRCLE_SQUARE"
 	BulletCheckboxSquareCircle   = "BULLET_CHECKBOX_SQUARE_CIRCLE"
+	NumberedDecimalAlphaRoman    = "NUMBERED_DECIMAL_ALPHA_ROMAN"
 )
 
 // Constants for sharing roles
@@ -0,0 +1,189 @@
+package gdocs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// permissionFields limits permission listings to the fields VerifySharing compares
+const permissionFields = "nextPageToken,permissions(id,type,role,emailAddress,domain)"
+
+// roleRanks orders Drive roles by the access they grant, so a stronger role satisfies a weaker request
+var roleRanks = map[string]int{
+	RoleReader:        1,
+	RoleCommenter:     2,
+	RoleWriter:        3,
+	RoleFileOrganizer: 4,
+	RoleOrganizer:     5,
+	RoleOwner:         6,
+}
+
+// SharingDiscrepancy describes a requested permission that is not in effect on a document.
+// Actual is nil when no permission exists for the grantee.
+type SharingDiscrepancy struct {
+	Expected Permission  `json:"expected"`
+	Actual   *Permission `json:"actual,omitempty"`
+}
+
+// String describes the discrepancy for logs and error messages
+func (d SharingDiscrepancy) String() string {
+	if d.Actual == nil {
+		return fmt.Sprintf("%s has no access (expected %s)", permissionGrantee(d.Expected), d.Expected.Role)
+	}
+	return fmt.Sprintf("%s has %s access (expected %s)", permissionGrantee(d.Expected), d.Actual.Role, d.Expected.Role)
+}
+
+// ListPermissions returns every permission currently applied to a document through the Drive API
+func (c *Client) ListPermissions(ctx context.Context, documentID string) ([]Permission, error) {
+	if documentID == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Document ID is required", nil)
+	}
+	
+	var permissions []Permission
+	pageToken := ""
+	for {
+		var page PermissionList
+		err := utils.RetryWithRateLimit(ctx, c.retryConfig, c.rateLimiter, func() error {
+			// Create HTTP request
+			query := url.Values{"fields": {permissionFields}}
+			if pageToken != "" {
+				query.Set("pageToken", pageToken)
+			}
+			endpoint := fmt.Sprintf(ShareEndpoint, documentID) + "?" + query.Encode()
+			req, err := c.createDriveRequest(ctx, "GET", endpoint, nil)
+			if err != nil {
+				return err
+			}
+			
+			// Make request
+			resp, err := c.auth.Do(req)
+			if err != nil {
+				return utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to list document permissions")
+			}
+			defer resp.Body.Close()
+			
+			if resp.StatusCode != http.StatusOK {
+				return c.handleErrorResponse(resp, "Failed to list document permissions")
+			}
+			
+			// Read and parse response
+			body, err := c.httpClient.ReadBody(resp)
+			if err != nil {
+				return utils.NewAppError(utils.ErrorCodeGoogleError, "Failed to read response", err)
+			}
+			page = PermissionList{}
+			if err := json.Unmarshal(body, &page); err != nil {
+				return utils.NewAppError(utils.ErrorCodeGoogleError, "Failed to parse response", err)
+			}
+			
+			return nil
+		}, c.logger)
+		if err != nil {
+			return nil, err
+		}
+		
+		permissions = append(permissions, page.Permissions...)
+		if page.NextPageToken == "" {
+			return permissions, nil
+		}
+		pageToken = page.NextPageToken
+	}
+}
+
+// VerifySharing checks that each expected permission is in effect on a document. A grantee
+// holding a stronger role than requested, such as the owner, counts as shared. When any
+// permission is missing or weaker, the returned error lists the discrepancies and carries
+// them as []SharingDiscrepancy in the "discrepancies" extra.
+func (c *Client) VerifySharing(ctx context.Context, documentID string, expected []Permission) error {
+	actual, err := c.ListPermissions(ctx, documentID)
+	if err != nil {
+		return err
+	}
+	
+	discrepancies := compareSharing(expected, actual)
+	if len(discrepancies) == 0 {
+		c.log(ctx).Debug("Document sharing verified",
+			utils.NewField("document_id", documentID),
+			utils.NewField("permissions", len(expected)),
+		)
+		return nil
+	}
+	
+	descriptions := make([]string, len(discrepancies))
+	for i, discrepancy := range discrepancies {
+		descriptions[i] = discrepancy.String()
+	}
+	return utils.NewAppError(utils.ErrorCodeGoogleError,
+		"Document sharing was not fully applied: "+strings.Join(descriptions, "; "), nil).
+		WithService("google_drive").
+		WithExtra("document_id", documentID).
+		WithExtra("discrepancies", discrepancies)
+}
+
+// compareSharing returns the expected permissions that actual does not satisfy
+func compareSharing(expected, actual []Permission) []SharingDiscrepancy {
+	var discrepancies []SharingDiscrepancy
+	for _, want := range expected {
+		var match *Permission
+		for i := range actual {
+			if !sameGrantee(want, actual[i]) {
+				continue
+			}
+			if match == nil || roleRanks[actual[i].Role] > roleRanks[match.Role] {
+				match = &actual[i]
+			}
+		}
+		
+		if match == nil || !roleSatisfies(match.Role, want.Role) {
+			discrepancies = append(discrepancies, SharingDiscrepancy{Expected: want, Actual: match})
+		}
+	}
+	
+	return discrepancies
+}
+
+// sameGrantee reports whether two permissions apply to the same user, group, domain or anyone
+func sameGrantee(a, b Permission) bool {
+	if a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case "user", "group":
+		return strings.EqualFold(a.EmailAddress, b.EmailAddress)
+	case "domain":
+		return strings.EqualFold(a.Domain, b.Domain)
+	default:
+		return true
+	}
+}
+
+// roleSatisfies reports whether an applied role grants at least the requested access. Unknown
+// roles only satisfy an identical request.
+func roleSatisfies(applied, requested string) bool {
+	if applied == requested {
+		return true
+	}
+	appliedRank, ok := roleRanks[applied]
+	if !ok {
+		return false
+	}
+	return appliedRank >= roleRanks[requested] && roleRanks[requested] > 0
+}
+
+// permissionGrantee names who a permission applies to
+func permissionGrantee(permission Permission) string {
+	switch {
+	case permission.EmailAddress != "":
+		return permission.EmailAddress
+	case permission.Domain != "":
+		return permission.Domain
+	default:
+		return permission.Type
+	}
+}
@@ -0,0 +1,114 @@
+package gdocs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newSharingTestClient returns a client whose Drive requests go to a server listing pages of permissions
+func newSharingTestClient(t *testing.T, pages ...[]Permission) *Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" || r.URL.Path != "/drive/v3/files/doc_id/permissions" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, permissionFields, r.URL.Query().Get("fields"))
+
+		page := 0
+		if token := r.URL.Query().Get("pageToken"); token != "" {
+			page = int(token[0] - '0')
+		}
+		list := PermissionList{Permissions: pages[page]}
+		if page+1 < len(pages) {
+			list.NextPageToken = string(rune('0' + page + 1))
+		}
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(server.Close)
+
+	logger := utils.NewMockLogger()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	err := authManager.GetCredentialStore().SetGoogleCredentials(security.GoogleCredentials{
+		ClientSecret: "test_client_secret",
+		AccessToken:  "test_access_token",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { authManager.GetCredentialStore().ClearAllCredentials() })
+
+	client := NewClient(&config.Config{}, authManager, logger)
+	client.driveBaseURL = server.URL
+	client.retryConfig.MaxRetries = 0
+	return client
+}
+
+func TestClient_ListPermissions(t *testing.T) {
+	client := newSharingTestClient(t,
+		[]Permission{{ID: "1", Type: "user", Role: RoleOwner, EmailAddress: "owner@example.com"}},
+		[]Permission{{ID: "2", Type: "domain", Role: RoleReader, Domain: "example.com"}},
+	)
+
+	permissions, err := client.ListPermissions(context.Background(), "doc_id")
+	require.NoError(t, err)
+	require.Len(t, permissions, 2)
+	assert.Equal(t, "owner@example.com", permissions[0].EmailAddress)
+	assert.Equal(t, "example.com", permissions[1].Domain)
+
+	_, err = client.ListPermissions(context.Background(), "")
+	require.Error(t, err)
+}
+
+func TestClient_VerifySharing(t *testing.T) {
+	// Drive applied only part of what was requested
+	client := newSharingTestClient(t, []Permission{
+		{ID: "1", Type: "user", Role: RoleOwner, EmailAddress: "owner@example.com"},
+		{ID: "2", Type: "user", Role: RoleReader, EmailAddress: "Alice@Example.com"},
+		{ID: "3", Type: "user", Role: RoleCommenter, EmailAddress: "bob@example.com"},
+	})
+
+	// Matching is case-insensitive and a stronger role satisfies a weaker request
+	err := client.VerifySharing(context.Background(), "doc_id", []Permission{
+		{Type: "user", Role: RoleReader, EmailAddress: "alice@example.com"},
+		{Type: "user", Role: RoleWriter, EmailAddress: "owner@example.com"},
+	})
+	assert.NoError(t, err)
+
+	err = client.VerifySharing(context.Background(), "doc_id", []Permission{
+		{Type: "user", Role: RoleReader, EmailAddress: "alice@example.com"},
+		{Type: "user", Role: RoleWriter, EmailAddress: "bob@example.com"},
+		{Type: "user", Role: RoleReader, EmailAddress: "carol@example.com"},
+	})
+	require.Error(t, err)
+
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeGoogleError, appErr.Code)
+	assert.Contains(t, appErr.Message, "bob@example.com has commenter access (expected writer)")
+	assert.Contains(t, appErr.Message, "carol@example.com has no access (expected reader)")
+
+	discrepancies, ok := appErr.Context.Extra["discrepancies"].([]SharingDiscrepancy)
+	require.True(t, ok)
+	require.Len(t, discrepancies, 2)
+	assert.Equal(t, "bob@example.com", discrepancies[0].Expected.EmailAddress)
+	require.NotNil(t, discrepancies[0].Actual)
+	assert.Equal(t, RoleCommenter, discrepancies[0].Actual.Role)
+	assert.Equal(t, "carol@example.com", discrepancies[1].Expected.EmailAddress)
+	assert.Nil(t, discrepancies[1].Actual)
+}
+
+func TestRoleSatisfies(t *testing.T) {
+	assert.True(t, roleSatisfies(RoleReader, RoleReader))
+	assert.True(t, roleSatisfies(RoleOwner, RoleCommenter))
+	assert.False(t, roleSatisfies(RoleReader, RoleWriter))
+	assert.False(t, roleSatisfies("custom", RoleReader))
+	assert.False(t, roleSatisfies(RoleOwner, "custom"))
+}
@@ -0,0 +1,31 @@
+package gdocs
+
+import "math"
+
+// RGB returns an optional color with the given red, green and blue components. The Docs API
+// expects each component in the range 0 to 1, so values outside it are clamped and NaN is
+// treated as 0.
+func RGB(red, green, blue float64) *OptionalColor {
+	return &OptionalColor{
+		Color: &Color{
+			RgbColor: &RgbColor{
+				Red:   clampColorComponent(red),
+				Green: clampColorComponent(green),
+				Blue:  clampColorComponent(blue),
+			},
+		},
+	}
+}
+
+// Pt returns a dimension measured in points
+func Pt(magnitude float64) *Dimension {
+	return &Dimension{Magnitude: magnitude, Unit: "PT"}
+}
+
+// clampColorComponent limits a color component to the range accepted by the Docs API
+func clampColorComponent(value float64) float64 {
+	if math.IsNaN(value) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, value))
+}
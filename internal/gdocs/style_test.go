@@ -0,0 +1,57 @@
+package gdocs
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRGB(t *testing.T) {
+	color := RGB(0.2, 0.4, 0.6)
+	require.NotNil(t, color.Color)
+	require.NotNil(t, color.Color.RgbColor)
+	assert.Equal(t, RgbColor{Red: 0.2, Green: 0.4, Blue: 0.6}, *color.Color.RgbColor)
+
+	encoded, err := json.Marshal(color)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"color":{"rgbColor":{"red":0.2,"green":0.4,"blue":0.6}}}`, string(encoded))
+}
+
+func TestRGB_ClampsOutOfRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		red      float64
+		green    float64
+		blue     float64
+		expected RgbColor
+	}{
+		{name: "bounds are kept", red: 0, green: 1, blue: 0.5, expected: RgbColor{Red: 0, Green: 1, Blue: 0.5}},
+		{name: "negative values", red: -0.5, green: -1, blue: 0.3, expected: RgbColor{Red: 0, Green: 0, Blue: 0.3}},
+		{name: "byte scale values", red: 255, green: 128, blue: 1.5, expected: RgbColor{Red: 1, Green: 1, Blue: 1}},
+		{name: "not a number", red: math.NaN(), green: math.Inf(1), blue: math.Inf(-1), expected: RgbColor{Red: 0, Green: 1, Blue: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, *RGB(tt.red, tt.green, tt.blue).Color.RgbColor)
+		})
+	}
+}
+
+func TestPt(t *testing.T) {
+	assert.Equal(t, &Dimension{Magnitude: 18, Unit: "PT"}, Pt(18))
+	assert.Equal(t, &Dimension{Magnitude: 0.5, Unit: "PT"}, Pt(0.5))
+}
+
+func TestBuildHorizontalRuleRequests_UsesStyleHelpers(t *testing.T) {
+	requests, _ := buildHorizontalRuleRequests(5)
+	require.Len(t, requests, 2)
+
+	border := requests[1].UpdateParagraphStyle.ParagraphStyle.BorderBottom
+	assert.Equal(t, RGB(0.75, 0.75, 0.75), border.Color)
+	assert.Equal(t, Pt(1), border.Width)
+	assert.Equal(t, Pt(6), border.Padding)
+}
@@ -0,0 +1,158 @@
+package gemini
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// SummaryCache stores generated summaries by key, so summarizing the same activities with the
+// same prompt and model settings again does not pay for another Gemini call. Implementations
+// must be safe for concurrent use.
+type SummaryCache interface {
+	Get(key string) (*SummaryResponse, bool)
+	Put(key string, summary *SummaryResponse)
+}
+
+// MemoryCache is an in-memory SummaryCache bounded by size and age
+type MemoryCache struct {
+	mu         sync.Mutex
+	entries    map[string]memoryCacheEntry
+	maxEntries int
+	ttl        time.Duration
+	now        func() time.Time
+}
+
+// memoryCacheEntry is a cached summary and when it was stored
+type memoryCacheEntry struct {
+	summary  *SummaryResponse
+	storedAt time.Time
+}
+
+// NewMemoryCache creates an empty in-memory cache. maxEntries caps how many summaries are
+// kept, evicting the oldest first, and ttl expires entries; zero disables either limit.
+func NewMemoryCache(maxEntries int, ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		entries:    make(map[string]memoryCacheEntry),
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// Get returns a copy of the summary stored under key, if it exists and has not expired
+func (c *MemoryCache) Get(key string) (*SummaryResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	entry, exists := c.entries[key]
+	if !exists {
+		return nil, false
+	}
+	if c.ttl > 0 && c.now().Sub(entry.storedAt) > c.ttl {
+		delete(c.entries, key)
+		return nil, false
+	}
+	
+	summary := *entry.summary
+	return &summary, true
+}
+
+// Put stores a copy of summary under key, evicting the oldest entry when the cache is full
+func (c *MemoryCache) Put(key string, summary *SummaryResponse) {
+	if summary == nil {
+		return
+	}
+	
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	if _, exists := c.entries[key]; !exists && c.maxEntries > 0 && len(c.entries) >= c.maxEntries {
+		oldestKey := ""
+		var oldest time.Time
+		for key, entry := range c.entries {
+			if oldestKey == "" || entry.storedAt.Before(oldest) {
+				oldestKey = key
+				oldest = entry.storedAt
+			}
+		}
+		delete(c.entries, oldestKey)
+	}
+	
+	stored := *summary
+	c.entries[key] = memoryCacheEntry{summary: &stored, storedAt: c.now()}
+}
+
+// Len returns the number of cached summaries
+func (c *MemoryCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// NewSummaryCache creates the summary cache configured in cfg, nil when caching is disabled
+func NewSummaryCache(cfg *config.Config) SummaryCache {
+	if !cfg.Gemini.SummaryCache {
+		return nil
+	}
+	return NewMemoryCache(cfg.Gemini.SummaryCacheSize, cfg.Gemini.SummaryCacheTTL)
+}
+
+// SetSummaryCache caches generated summaries in cache; nil, the default, disables caching
+func (c *Client) SetSummaryCache(cache SummaryCache) {
+	c.summaryCache = cache
+}
+
+// cacheProvider names Gemini in summary cache keys, so a cache shared with another summary
+// provider never returns a Gemini summary to it
+const cacheProvider = "gemini"
+
+// summaryCacheInput is everything that determines a generated summary
+type summaryCacheInput struct {
+	Provider    string  `json:"provider"`
+	Prompt      string  `json:"prompt"`
+	Model       string  `json:"model"`
+	APIVersion  string  `json:"api_version"`
+	Temperature float32 `json:"temperature"`
+	MaxTokens   int     `json:"max_tokens"`
+	SafetyRetry bool    `json:"safety_retry"`
+}
+
+// summaryCacheKey hashes the prompt for the activities, ordered by key so the order they were
+// fetched in does not matter, together with the model settings. The prompt covers the custom
+// prompt and every activity field the formatter renders.
+func (c *Client) summaryCacheKey(activities []models.Activity, customPrompt string) (string, error) {
+	model := c.model
+	if model == "" {
+		model = ModelGeminiPro
+	}
+	
+	normalized := make([]models.Activity, len(activities))
+	copy(normalized, activities)
+	sort.SliceStable(normalized, func(i, j int) bool {
+		return normalized[i].Key < normalized[j].Key
+	})
+	
+	encoded, err := json.Marshal(summaryCacheInput{
+		Provider:    cacheProvider,
+		Prompt:      c.buildSummaryPrompt(normalized, customPrompt),
+		Model:       model,
+		APIVersion:  c.apiVersion,
+		Temperature: c.temperature,
+		MaxTokens:   c.maxTokens,
+		SafetyRetry: c.safetyRetry,
+	})
+	if err != nil {
+		return "", utils.NewAppError(utils.ErrorCodeInternalError, "Failed to encode summary cache key", err)
+	}
+	
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
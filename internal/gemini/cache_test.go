@@ -0,0 +1,167 @@
+package gemini
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/pkg/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_GenerateSummary_CacheHit(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(100000)
+	cache := NewMemoryCache(10, time.Hour)
+	client.SetSummaryCache(cache)
+
+	activities := chunkingTestActivities(3)
+	first, err := client.GenerateSummary(context.Background(), activities, "Focus on risks")
+	require.NoError(t, err)
+	assert.False(t, first.Cached)
+	assert.Equal(t, 1, cache.Len())
+
+	// The same activities in another order hit the cache without calling Gemini
+	reordered := []models.Activity{activities[2], activities[0], activities[1]}
+	second, err := client.GenerateSummary(context.Background(), reordered, "Focus on risks")
+	require.NoError(t, err)
+	assert.Equal(t, 1, model.generateCalls())
+	assert.True(t, second.Cached)
+	assert.Equal(t, first.Summary, second.Summary)
+	assert.Equal(t, first.GeneratedAt, second.GeneratedAt)
+	assert.Equal(t, reordered, second.Activities)
+}
+
+func TestClient_GenerateSummary_CacheMiss(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(100000)
+	client.SetSummaryCache(NewMemoryCache(10, time.Hour))
+
+	activities := chunkingTestActivities(3)
+	_, err := client.GenerateSummary(context.Background(), activities, "")
+	require.NoError(t, err)
+
+	// A changed activity misses the cache
+	changed := chunkingTestActivities(3)
+	changed[1].Status = "In Progress"
+	response, err := client.GenerateSummary(context.Background(), changed, "")
+	require.NoError(t, err)
+	assert.False(t, response.Cached)
+	assert.Equal(t, 2, model.generateCalls())
+
+	// So does an added activity, another prompt or another temperature
+	_, err = client.GenerateSummary(context.Background(), chunkingTestActivities(4), "")
+	require.NoError(t, err)
+	_, err = client.GenerateSummary(context.Background(), activities, "Focus on risks")
+	require.NoError(t, err)
+	client.temperature = 0.2
+	_, err = client.GenerateSummary(context.Background(), activities, "")
+	require.NoError(t, err)
+	assert.Equal(t, 5, model.generateCalls())
+}
+
+func TestClient_SummaryCacheKey_ModelSettings(t *testing.T) {
+	client := &Client{model: ModelGeminiPro, temperature: 0.7, apiVersion: DefaultAPIVersion}
+	activities := chunkingTestActivities(2)
+
+	key, err := client.summaryCacheKey(activities, "")
+	require.NoError(t, err)
+
+	// An unset model is the default model
+	client.model = ""
+	same, err := client.summaryCacheKey(activities, "")
+	require.NoError(t, err)
+	assert.Equal(t, key, same)
+
+	client.model = ModelGeminiProVision
+	otherModel, err := client.summaryCacheKey(activities, "")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherModel)
+
+	client.model = ModelGeminiPro
+	client.temperature = 0.2
+	otherTemperature, err := client.summaryCacheKey(activities, "")
+	require.NoError(t, err)
+	assert.NotEqual(t, key, otherTemperature)
+}
+
+func TestNewSummaryCache(t *testing.T) {
+	cfg := config.DefaultConfig()
+	assert.Nil(t, NewSummaryCache(cfg))
+
+	cfg.Gemini.SummaryCache = true
+	cfg.Gemini.SummaryCacheSize = 2
+	cfg.Gemini.SummaryCacheTTL = time.Hour
+	cache, ok := NewSummaryCache(cfg).(*MemoryCache)
+	require.True(t, ok)
+	assert.Equal(t, 2, cache.maxEntries)
+	assert.Equal(t, time.Hour, cache.ttl)
+}
+
+func TestClient_GenerateSummary_NoCache(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(100000)
+
+	for i := 0; i < 2; i++ {
+		_, err := client.GenerateSummary(context.Background(), chunkingTestActivities(2), "")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, model.generateCalls())
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(0, time.Hour)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("key", &SummaryResponse{Summary: "cached"})
+
+	now = now.Add(30 * time.Minute)
+	summary, ok := cache.Get("key")
+	require.True(t, ok)
+	assert.Equal(t, "cached", summary.Summary)
+
+	// Callers get a copy, so edits do not leak into the cache
+	summary.Summary = "edited"
+	summary, _ = cache.Get("key")
+	assert.Equal(t, "cached", summary.Summary)
+
+	now = now.Add(time.Hour)
+	_, ok = cache.Get("key")
+	assert.False(t, ok)
+	assert.Equal(t, 0, cache.Len())
+}
+
+func TestMemoryCache_EvictsOldest(t *testing.T) {
+	now := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	cache := NewMemoryCache(2, 0)
+	cache.now = func() time.Time {
+		now = now.Add(time.Minute)
+		return now
+	}
+
+	cache.Put("first", &SummaryResponse{Summary: "1"})
+	cache.Put("second", &SummaryResponse{Summary: "2"})
+	cache.Put("third", &SummaryResponse{Summary: "3"})
+
+	assert.Equal(t, 2, cache.Len())
+	_, ok := cache.Get("first")
+	assert.False(t, ok)
+	_, ok = cache.Get("third")
+	assert.True(t, ok)
+}
@@ -0,0 +1,246 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// CharsPerToken approximates how many prompt characters make up one token
+const CharsPerToken = 4
+
+// promptHeaderAllowance reserves tokens for the per-project headers added around activities
+const promptHeaderAllowance = 64
+
+// DefaultInputTokenLimit is the prompt size that triggers batching while the model's input token
+// limit cannot be looked up
+const DefaultInputTokenLimit = 30720
+
+// limitLookupBackoff is how long a failed input token limit lookup is cached before it is retried
+const limitLookupBackoff = 5 * time.Minute
+
+// EstimateTokens roughly estimates how many tokens text uses
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + CharsPerToken - 1) / CharsPerToken
+}
+
+// SetMaxPromptTokens caps the estimated prompt size before activities are summarized in batches.
+// Zero uses the model's input token limit.
+func (c *Client) SetMaxPromptTokens(tokens int) {
+	c.maxPromptTokens = tokens
+}
+
+// MaxPromptTokens returns the configured prompt size cap, zero when the model limit is used
+func (c *Client) MaxPromptTokens() int {
+	return c.maxPromptTokens
+}
+
+// promptTokenLimit returns the prompt size that triggers batching. The model's input token limit is
+// looked up once, outside limitMu so concurrent summaries wait for the lookup in flight rather than
+// for the lock. A failed lookup falls back to DefaultInputTokenLimit until limitLookupBackoff has
+// passed and the lookup is tried again.
+func (c *Client) promptTokenLimit(ctx context.Context) int {
+	if c.maxPromptTokens > 0 {
+		return c.maxPromptTokens
+	}
+	
+	c.limitMu.Lock()
+	for {
+		if c.inputLimitResolved && (c.limitRetryAt.IsZero() || time.Now().Before(c.limitRetryAt)) {
+			limit := c.inputTokenLimit
+			c.limitMu.Unlock()
+			return limit
+		}
+		if c.limitLookup == nil {
+			break
+		}
+		
+		// Another summary is looking the limit up, wait for its result
+		lookup := c.limitLookup
+		c.limitMu.Unlock()
+		select {
+		case <-lookup:
+		case <-ctx.Done():
+			return DefaultInputTokenLimit
+		}
+		c.limitMu.Lock()
+	}
+	lookup := make(chan struct{})
+	c.limitLookup = lookup
+	c.limitMu.Unlock()
+	
+	limit, err := c.fetchInputTokenLimit(ctx)
+	
+	c.limitMu.Lock()
+	defer c.limitMu.Unlock()
+	c.limitLookup = nil
+	close(lookup)
+	
+	if err != nil {
+		c.log(ctx).Warn("Failed to look up model input token limit, using the default limit",
+			utils.NewField("model", c.model),
+			utils.NewField("default_limit", DefaultInputTokenLimit),
+			utils.NewField("error", err.Error()),
+		)
+		// A lookup cut short by the caller says nothing about the model, so it is not cached
+		if ctx.Err() == nil {
+			c.inputTokenLimit = DefaultInputTokenLimit
+			c.inputLimitResolved = true
+			c.limitRetryAt = time.Now().Add(limitLookupBackoff)
+		}
+		return DefaultInputTokenLimit
+	}
+	
+	c.inputTokenLimit = limit
+	c.inputLimitResolved = true
+	c.limitRetryAt = time.Time{}
+	return limit
+}
+
+// fetchInputTokenLimit reads the input token limit of the configured model
+func (c *Client) fetchInputTokenLimit(ctx context.Context) (int, error) {
+	model := c.model
+	if model == "" {
+		model = ModelGeminiPro
+	}
+	
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.WaitForSlot(ctx); err != nil {
+			return 0, err
+		}
+	}
+	
+	req, err := c.createRequest(ctx, "GET", fmt.Sprintf(ModelEndpoint, c.apiVersion, model), nil)
+	if err != nil {
+		return 0, err
+	}
+	
+	resp, err := c.httpClient.DoRequest(req)
+	if err != nil {
+		return 0, utils.WrapError(err, utils.ErrorCodeGeminiError, "Failed to get model")
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		return 0, c.handleErrorResponse(resp, "Failed to get model")
+	}
+	
+	body, err := c.httpClient.ReadBody(resp)
+	if err != nil {
+		return 0, utils.NewAppError(utils.ErrorCodeGeminiError, "Failed to read response", err)
+	}
+	
+	var modelInfo Model
+	if err := json.Unmarshal(body, &modelInfo); err != nil {
+		return 0, utils.NewAppError(utils.ErrorCodeGeminiError, "Failed to parse response", err)
+	}
+	
+	return modelInfo.InputTokenLimit, nil
+}
+
+// generateBatchedSummary summarizes activities in batches that fit the prompt limit,
+// then merges the partial summaries in a final reduce pass
+func (c *Client) generateBatchedSummary(ctx context.Context, activities []models.Activity, customPrompt string, limit int) (string, int, error) {
+	batches := c.batchActivities(activities, customPrompt, limit)
+	
+	c.log(ctx).Info("Activity prompt exceeds token limit, summarizing in batches",
+		utils.NewField("activities_count", len(activities)),
+		utils.NewField("batch_count", len(batches)),
+		utils.NewField("token_limit", limit),
+	)
+	
+	partials := make([]string, 0, len(batches))
+	tokensUsed := 0
+	for i, batch := range batches {
+		text, tokens, err := c.generateText(ctx, c.buildSummaryPrompt(batch, customPrompt))
+		if err != nil {
+			if IsSafetyBlock(err) {
+				return "", tokensUsed, err
+			}
+			return "", tokensUsed, utils.WrapError(err, utils.ErrorCodeGeminiError,
+				fmt.Sprintf("Failed to summarize batch %d of %d", i+1, len(batches)))
+		}
+		partials = append(partials, text)
+		tokensUsed += tokens
+	}
+	
+	if len(partials) == 1 {
+		return partials[0], tokensUsed, nil
+	}
+	
+	text, tokens, err := c.generateText(ctx, c.buildReducePrompt(partials, customPrompt))
+	if err != nil {
+		if IsSafetyBlock(err) {
+			return "", tokensUsed, err
+		}
+		return "", tokensUsed, utils.WrapError(err, utils.ErrorCodeGeminiError, "Failed to merge batch summaries")
+	}
+	
+	return text, tokensUsed + tokens, nil
+}
+
+// batchActivities splits activities into batches whose estimated prompt fits within limit.
+// An activity that is too large on its own still gets a batch of its own.
+func (c *Client) batchActivities(activities []models.Activity, customPrompt string, limit int) [][]models.Activity {
+	budget := limit - EstimateTokens(c.buildSummaryPrompt(nil, customPrompt)) - promptHeaderAllowance
+	
+	var batches [][]models.Activity
+	var current []models.Activity
+	used := 0
+	for _, activity := range activities {
+		tokens := EstimateTokens(c.formatActivity(activity))
+		if len(current) > 0 && used+tokens > budget {
+			batches = append(batches, current)
+			current = nil
+			used = 0
+		}
+		current = append(current, activity)
+		used += tokens
+	}
+	if len(current) > 0 {
+		batches = append(batches, current)
+	}
+	
+	return batches
+}
+
+// buildReducePrompt builds the prompt that merges partial batch summaries into one summary
+func (c *Client) buildReducePrompt(partials []string, customPrompt string) string {
+	var prompt strings.Builder
+	
+	prompt.WriteString(`You are an executive assistant combining partial executive summaries for a technology organization.
+Each partial summary below covers a different subset of the same period's Jira activity.
+
+INSTRUCTIONS:
+1. Merge the partial summaries into a single structured executive summary with the same sections
+2. Combine and re-total any metrics rather than listing them per part
+3. Remove duplicated points and keep the most significant accomplishments, risks and recommendations
+4. Do not mention that the input was split into parts
+5. Keep the summary concise but comprehensive (500-1000 words)
+
+`)
+
+	if customPrompt != "" {
+		prompt.WriteString("ADDITIONAL INSTRUCTIONS:\n")
+		prompt.WriteString(customPrompt)
+		prompt.WriteString("\n\n")
+	}
+	
+	for i, partial := range partials {
+		prompt.WriteString(fmt.Sprintf("PARTIAL SUMMARY %d OF %d:\n", i+1, len(partials)))
+		prompt.WriteString("===================\n")
+		prompt.WriteString(partial)
+		prompt.WriteString("\n\n")
+	}
+	
+	prompt.WriteString("Please generate the combined executive summary.")
+	
+	return prompt.String()
+}
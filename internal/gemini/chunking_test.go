@@ -0,0 +1,265 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGenerateModel records the prompts sent to generateContent and answers each with a numbered summary
+type fakeGenerateModel struct {
+	mu              sync.Mutex
+	prompts         []string
+	inputTokenLimit int
+	modelRequests   int
+	modelMissing    bool
+}
+
+func (f *fakeGenerateModel) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/models/gemini-pro":
+			f.modelRequests++
+			if f.modelMissing {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			json.NewEncoder(w).Encode(Model{Name: "models/gemini-pro", InputTokenLimit: f.inputTokenLimit})
+		case "/v1/models/gemini-pro:generateContent":
+			var request GenerateRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			f.prompts = append(f.prompts, request.Contents[0].Parts[0].Text)
+
+			json.NewEncoder(w).Encode(GenerateResponse{
+				Candidates: []Candidate{
+					{Content: Content{Parts: []Part{{Text: fmt.Sprintf("summary %d", len(f.prompts))}}}},
+				},
+				UsageMetadata: &UsageMetadata{TotalTokenCount: 10},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func (f *fakeGenerateModel) generateCalls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.prompts)
+}
+
+func chunkingTestActivities(count int) []models.Activity {
+	activities := make([]models.Activity, count)
+	for i := range activities {
+		activities[i] = models.Activity{
+			ID:       fmt.Sprintf("%d", i+1),
+			Key:      fmt.Sprintf("TEST-%d", i+1),
+			Summary:  strings.Repeat("Detailed activity summary text ", 10),
+			Status:   "Done",
+			Priority: "High",
+			Type:     "Story",
+			Project:  models.Project{Key: "TEST"},
+		}
+	}
+	return activities
+}
+
+func TestEstimateTokens(t *testing.T) {
+	assert.Equal(t, 0, EstimateTokens(""))
+	assert.Equal(t, 1, EstimateTokens("abc"))
+	assert.Equal(t, 1, EstimateTokens("abcd"))
+	assert.Equal(t, 2, EstimateTokens("abcde"))
+	assert.Equal(t, 1, EstimateTokens("héé"))
+}
+
+func TestClient_GenerateSummary_SingleBatch(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(100000)
+
+	response, err := client.GenerateSummary(context.Background(), chunkingTestActivities(5), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, model.generateCalls())
+	assert.Equal(t, "summary 1", response.Summary)
+	assert.Equal(t, 10, response.TokensUsed)
+	assert.Equal(t, 0, model.modelRequests)
+}
+
+func TestClient_GenerateSummary_MultiBatchReduce(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	activities := chunkingTestActivities(10)
+
+	// Leave room for the prompt template and roughly three activities per batch
+	base := EstimateTokens(client.buildSummaryPrompt(nil, "Focus on delivery"))
+	perActivity := EstimateTokens(formatPromptActivity(activities[0]))
+	client.SetMaxPromptTokens(base + promptHeaderAllowance + 3*perActivity)
+
+	response, err := client.GenerateSummary(context.Background(), activities, "Focus on delivery")
+	require.NoError(t, err)
+
+	// Four map calls (3+3+3+1 activities) plus one reduce call
+	require.Equal(t, 5, model.generateCalls())
+	assert.Equal(t, "summary 5", response.Summary)
+	assert.Equal(t, 50, response.TokensUsed)
+
+	// Every activity is sent in exactly one batch
+	for _, activity := range activities {
+		count := 0
+		for _, prompt := range model.prompts[:4] {
+			if strings.Contains(prompt, activity.Key+" [") {
+				count++
+			}
+		}
+		assert.Equal(t, 1, count, activity.Key)
+	}
+
+	reducePrompt := model.prompts[4]
+	assert.Contains(t, reducePrompt, "PARTIAL SUMMARY 1 OF 4")
+	assert.Contains(t, reducePrompt, "summary 1")
+	assert.Contains(t, reducePrompt, "summary 4")
+	assert.Contains(t, reducePrompt, "Focus on delivery")
+	assert.NotContains(t, reducePrompt, "TEST-1 [")
+}
+
+func TestClient_GenerateSummary_UsesModelInputLimit(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	activities := chunkingTestActivities(4)
+
+	base := EstimateTokens(client.buildSummaryPrompt(nil, ""))
+	perActivity := EstimateTokens(formatPromptActivity(activities[0]))
+	model.inputTokenLimit = base + promptHeaderAllowance + 2*perActivity
+
+	_, err := client.GenerateSummary(context.Background(), activities, "")
+	require.NoError(t, err)
+	assert.Equal(t, 3, model.generateCalls()) // Two batches plus the reduce pass
+
+	// The model limit is looked up once and reused
+	_, err = client.GenerateSummary(context.Background(), activities, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, model.modelRequests)
+}
+
+func TestClient_GenerateSummary_CachesFailedLimitLookup(t *testing.T) {
+	model := &fakeGenerateModel{modelMissing: true}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	activities := chunkingTestActivities(4)
+
+	_, err := client.GenerateSummary(context.Background(), activities, "")
+	require.NoError(t, err)
+	assert.Equal(t, 1, model.generateCalls())
+
+	base := EstimateTokens(client.buildSummaryPrompt(nil, ""))
+	perActivity := EstimateTokens(formatPromptActivity(activities[0]))
+	model.mu.Lock()
+	model.modelMissing = false
+	model.inputTokenLimit = base + promptHeaderAllowance + 2*perActivity
+	model.mu.Unlock()
+
+	// Within the backoff the failure is cached and the default limit used
+	_, err = client.GenerateSummary(context.Background(), activities, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, model.generateCalls())
+	assert.Equal(t, 1, model.modelRequests)
+
+	// Once the backoff has passed the lookup is retried, finds the limit and batches
+	client.limitMu.Lock()
+	client.limitRetryAt = time.Now().Add(-time.Second)
+	client.limitMu.Unlock()
+
+	_, err = client.GenerateSummary(context.Background(), activities, "")
+	require.NoError(t, err)
+	assert.Equal(t, 5, model.generateCalls()) // Two batches plus the reduce pass
+	assert.Equal(t, 2, model.modelRequests)
+}
+
+func TestClient_PromptTokenLimit_SingleLookup(t *testing.T) {
+	release := make(chan struct{})
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		<-release
+		json.NewEncoder(w).Encode(Model{Name: "models/gemini-pro", InputTokenLimit: 1000})
+	}))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	// Concurrent callers share the lookup in flight
+	var wg sync.WaitGroup
+	limits := make([]int, 5)
+	for i := range limits {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			limits[i] = client.promptTokenLimit(context.Background())
+		}(i)
+	}
+
+	// The lock is not held during the lookup
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&requests) == 1 }, time.Second, 10*time.Millisecond)
+	client.limitMu.Lock()
+	inFlight := client.limitLookup != nil
+	client.limitMu.Unlock()
+	assert.True(t, inFlight)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for _, limit := range limits {
+		assert.Equal(t, 1000, limit)
+	}
+}
+
+func TestClient_GenerateSummary_UnknownLimitSendsSinglePrompt(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models/gemini-pro:generateContent" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(GenerateResponse{
+			Candidates: []Candidate{{Content: Content{Parts: []Part{{Text: "single"}}}}},
+		})
+	}))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	response, err := client.GenerateSummary(context.Background(), chunkingTestActivities(3), "")
+	require.NoError(t, err)
+	assert.Equal(t, "single", response.Summary)
+	assert.Equal(t, 0, response.TokensUsed)
+
+	logger := client.logger.(*utils.MockLogger)
+	assert.NotEmpty(t, logger.GetEntriesByLevel(utils.LogLevelWarn))
+}
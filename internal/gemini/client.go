@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/company/eesa/internal/config"
@@ -17,12 +18,25 @@ import (
 )
 
 const (
-	// Gemini API endpoints
+	// Gemini API endpoints, formatted with the API version (and model)
 	BaseURL         = "https://generativelanguage.googleapis.com"
-	GenerateEndpoint = "/v1/models/gemini-pro:generateContent"
-	ModelsEndpoint   = "/v1/models"
+	GenerateEndpoint = "/%s/models/%s:generateContent"
+	StreamEndpoint   = "/%s/models/%s:streamGenerateContent?alt=sse"
+	ModelsEndpoint   = "/%s/models"
+	ModelEndpoint    = "/%s/models/%s"
+	
+	// Supported Gemini API versions
+	APIVersionV1     = "v1"
+	APIVersionV1Beta = "v1beta"
+	DefaultAPIVersion = APIVersionV1
 )
 
+// supportedAPIVersions lists the API versions the client can target
+var supportedAPIVersions = map[string]bool{
+	APIVersionV1:     true,
+	APIVersionV1Beta: true,
+}
+
 // GeminiClientInterface defines the interface for Gemini AI client
 type GeminiClientInterface interface {
 	GenerateSummary(ctx context.Context, activities []models.Activity, prompt string) (*SummaryResponse, error)
@@ -34,6 +48,7 @@ type GeminiClientInterface interface {
 // Client represents a Gemini AI client
 type Client struct {
 	baseURL     string
+	apiVersion  string
 	model       string
 	temperature float32
 	maxTokens   int
@@ -42,6 +57,26 @@ type Client struct {
 	rateLimiter *utils.RateLimiter
 	retryConfig *utils.RetryConfig
 	logger      utils.Logger
+	
+	// Prompt size limits used to decide when activities are summarized in batches
+	maxPromptTokens    int
+	limitMu            sync.Mutex
+	inputTokenLimit    int
+	inputLimitResolved bool
+	limitRetryAt       time.Time     // When a failed lookup is retried, zero after a successful one
+	limitLookup        chan struct{} // Closed when the lookup in flight finishes
+	
+	// Retry once with a softened prompt when a response is blocked for safety
+	safetyRetry bool
+	
+	// Renders each activity in summary prompts, nil uses the default format
+	activityFormatter ActivityFormatter
+	
+	// Reuses summaries of identical requests, nil disables caching
+	summaryCache SummaryCache
+	
+	// Replaces the built-in summary prompt, nil uses the built-in one
+	promptTemplate *SummaryPrompt
 }
 
 // NewClient creates a new Gemini AI client
@@ -53,8 +88,20 @@ func NewClient(cfg *config.Config, authManager *security.AuthManager, logger uti
 	retryConfig := utils.DefaultRetryConfig()
 	retryConfig.RetryableErrors = append(retryConfig.RetryableErrors, utils.ErrorCodeGeminiError)
 	
+	apiVersion := cfg.Gemini.APIVersion
+	if err := ValidateAPIVersion(apiVersion); err != nil {
+		if apiVersion != "" {
+			logger.Warn("Unsupported Gemini API version, using default",
+				utils.NewField("api_version", apiVersion),
+				utils.NewField("default", DefaultAPIVersion),
+			)
+		}
+		apiVersion = DefaultAPIVersion
+	}
+	
 	return &Client{
 		baseURL:     BaseURL,
+		apiVersion:  apiVersion,
 		model:       cfg.Gemini.Model,
 		temperature: cfg.Gemini.Temperature,
 		maxTokens:   cfg.Gemini.MaxTokens,
@@ -72,11 +119,113 @@ func (c *Client) GenerateSummary(ctx context.Context, activities []models.Activi
 		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "No activities provided for summary generation", nil)
 	}
 	
+	cacheKey := ""
+	if c.summaryCache != nil {
+		key, err := c.summaryCacheKey(activities, customPrompt)
+		if err != nil {
+			c.log(ctx).Warn("Failed to build summary cache key, generating a new summary",
+				utils.NewField("error", err.Error()),
+			)
+		} else if cached, ok := c.summaryCache.Get(key); ok {
+			c.log(ctx).Info("Reusing cached executive summary",
+				utils.NewField("activities_count", len(activities)),
+				utils.NewField("generated_at", cached.GeneratedAt),
+			)
+			cached.Activities = activities
+			cached.Cached = true
+			return cached, nil
+		}
+		cacheKey = key
+	}
+	
 	// Build the prompt with activities data
 	prompt := c.buildSummaryPrompt(activities, customPrompt)
 	
-	// Create generate request
-	request := &GenerateRequest{
+	// Summarize in batches when the prompt would overflow the model's input limit
+	var summaryText string
+	var tokensUsed int
+	var err error
+	if limit := c.promptTokenLimit(ctx); limit > 0 && EstimateTokens(prompt) > limit {
+		summaryText, tokensUsed, err = c.generateBatchedSummary(ctx, activities, customPrompt, limit)
+	} else {
+		summaryText, tokensUsed, err = c.generateText(ctx, prompt)
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	// Create summary response
+	summaryResponse := &SummaryResponse{
+		Summary:     summaryText,
+		TokensUsed:  tokensUsed,
+		Model:       c.model,
+		Temperature: c.temperature,
+		GeneratedAt: time.Now(),
+		Activities:  activities,
+	}
+	
+	c.log(ctx).Info("Generated executive summary",
+		utils.NewField("activities_count", len(activities)),
+		utils.NewField("summary_length", len(summaryText)),
+		utils.NewField("tokens_used", tokensUsed),
+		utils.NewField("model", c.model),
+	)
+	
+	if cacheKey != "" {
+		c.summaryCache.Put(cacheKey, summaryResponse)
+	}
+	
+	return summaryResponse, nil
+}
+
+// generateText sends a single summary prompt and returns the generated text and tokens used.
+// When safety retries are enabled a blocked prompt is retried once with a sanitizing instruction.
+func (c *Client) generateText(ctx context.Context, prompt string) (string, int, error) {
+	text, tokensUsed, err := c.generatePromptText(ctx, prompt)
+	if err == nil || !c.safetyRetry {
+		return text, tokensUsed, err
+	}
+	blocked, ok := safetyBlockError(err)
+	if !ok {
+		return text, tokensUsed, err
+	}
+	
+	c.log(ctx).Warn("Response blocked for safety, retrying with softened prompt",
+		utils.NewField("model", c.model),
+		utils.NewField("category", blocked.Context.Extra["category"]),
+	)
+	
+	return c.generatePromptText(ctx, SafetyRetryInstruction+prompt)
+}
+
+// generatePromptText sends a prompt once and extracts the generated text
+func (c *Client) generatePromptText(ctx context.Context, prompt string) (string, int, error) {
+	response, err := c.GenerateContent(ctx, c.newSummaryRequest(prompt))
+	if err != nil {
+		return "", 0, utils.WrapError(err, utils.ErrorCodeGeminiError, "Failed to generate summary")
+	}
+	
+	// Blocked prompts and candidates come back with little or no text
+	if err := checkSafetyBlock(response); err != nil {
+		return "", 0, err
+	}
+	
+	// Extract summary from response
+	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
+		return "", 0, utils.NewAppError(utils.ErrorCodeGeminiError, "No content generated", nil)
+	}
+	
+	tokensUsed := 0
+	if response.UsageMetadata != nil {
+		tokensUsed = response.UsageMetadata.TotalTokenCount
+	}
+	
+	return response.Candidates[0].Content.Parts[0].Text, tokensUsed, nil
+}
+
+// newSummaryRequest builds the generate request used for executive summaries
+func (c *Client) newSummaryRequest(prompt string) *GenerateRequest {
+	return &GenerateRequest{
 		Contents: []Content{
 			{
 				Parts: []Part{
@@ -112,38 +261,6 @@ func (c *Client) GenerateSummary(ctx context.Context, activities []models.Activi
 			},
 		},
 	}
-	
-	// Generate content
-	response, err := c.GenerateContent(ctx, request)
-	if err != nil {
-		return nil, utils.WrapError(err, utils.ErrorCodeGeminiError, "Failed to generate summary")
-	}
-	
-	// Extract summary from response
-	if len(response.Candidates) == 0 || len(response.Candidates[0].Content.Parts) == 0 {
-		return nil, utils.NewAppError(utils.ErrorCodeGeminiError, "No content generated", nil)
-	}
-	
-	summaryText := response.Candidates[0].Content.Parts[0].Text
-	
-	// Create summary response
-	summaryResponse := &SummaryResponse{
-		Summary:     summaryText,
-		TokensUsed:  response.UsageMetadata.TotalTokenCount,
-		Model:       c.model,
-		Temperature: c.temperature,
-		GeneratedAt: time.Now(),
-		Activities:  activities,
-	}
-	
-	c.logger.Info("Generated executive summary",
-		utils.NewField("activities_count", len(activities)),
-		utils.NewField("summary_length", len(summaryText)),
-		utils.NewField("tokens_used", response.UsageMetadata.TotalTokenCount),
-		utils.NewField("model", c.model),
-	)
-	
-	return summaryResponse, nil
 }
 
 // GenerateContent generates content using Gemini API
@@ -158,7 +275,7 @@ func (c *Client) GenerateContent(ctx context.Context, request *GenerateRequest)
 		}
 		
 		// Create HTTP request
-		req, err := c.createRequest(ctx, "POST", GenerateEndpoint, reqBody)
+		req, err := c.createRequest(ctx, "POST", c.generateEndpoint(), reqBody)
 		if err != nil {
 			return err
 		}
@@ -176,7 +293,7 @@ func (c *Client) GenerateContent(ctx context.Context, request *GenerateRequest)
 		}
 		
 		// Read response
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeGeminiError, "Failed to read response", err)
 		}
@@ -208,7 +325,7 @@ func (c *Client) ListModels(ctx context.Context) (*ModelsResponse, error) {
 	var response *ModelsResponse
 	
 	err := utils.RetryWithRateLimit(ctx, c.retryConfig, c.rateLimiter, func() error {
-		req, err := c.createRequest(ctx, "GET", ModelsEndpoint, nil)
+		req, err := c.createRequest(ctx, "GET", c.modelsEndpoint(), nil)
 		if err != nil {
 			return err
 		}
@@ -223,7 +340,7 @@ func (c *Client) ListModels(ctx context.Context) (*ModelsResponse, error) {
 			return c.handleErrorResponse(resp, "Failed to list models")
 		}
 		
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeGeminiError, "Failed to read response", err)
 		}
@@ -243,6 +360,59 @@ func (c *Client) ListModels(ctx context.Context) (*ModelsResponse, error) {
 	return response, nil
 }
 
+// ValidateAPIVersion checks that an API version is supported by the client
+func ValidateAPIVersion(version string) error {
+	if !supportedAPIVersions[version] {
+		return utils.NewAppError(utils.ErrorCodeConfigInvalid,
+			fmt.Sprintf("Unsupported Gemini API version: %q", version), nil).
+			WithService("gemini").
+			WithExtra("api_version", version)
+	}
+	return nil
+}
+
+// SetAPIVersion switches the API version used to build endpoint paths
+func (c *Client) SetAPIVersion(version string) error {
+	if err := ValidateAPIVersion(version); err != nil {
+		return err
+	}
+	c.apiVersion = version
+	return nil
+}
+
+// APIVersion returns the API version used to build endpoint paths
+func (c *Client) APIVersion() string {
+	return c.apiVersion
+}
+
+// generateEndpoint builds the content generation path for the configured version and model
+func (c *Client) generateEndpoint() string {
+	model := c.model
+	if model == "" {
+		model = ModelGeminiPro
+	}
+	return fmt.Sprintf(GenerateEndpoint, c.apiVersion, model)
+}
+
+// streamEndpoint builds the streaming generation path for the configured version and model
+func (c *Client) streamEndpoint() string {
+	model := c.model
+	if model == "" {
+		model = ModelGeminiPro
+	}
+	return fmt.Sprintf(StreamEndpoint, c.apiVersion, model)
+}
+
+// modelsEndpoint builds the model listing path for the configured version
+func (c *Client) modelsEndpoint() string {
+	return fmt.Sprintf(ModelsEndpoint, c.apiVersion)
+}
+
+// log returns the client's logger carrying the correlation ID of the run in ctx
+func (c *Client) log(ctx context.Context) utils.Logger {
+	return utils.LoggerWithContext(ctx, c.logger)
+}
+
 // createRequest creates an authenticated HTTP request
 func (c *Client) createRequest(ctx context.Context, method, endpoint string, body []byte) (*http.Request, error) {
 	fullURL := c.baseURL + endpoint
@@ -273,7 +443,7 @@ func (c *Client) createRequest(ctx context.Context, method, endpoint string, bod
 
 // handleErrorResponse handles HTTP error responses
 func (c *Client) handleErrorResponse(resp *http.Response, message string) error {
-	body, _ := io.ReadAll(resp.Body)
+	body, _ := c.httpClient.ReadBody(resp)
 	
 	var errorCode utils.ErrorCode
 	switch resp.StatusCode {
@@ -297,18 +467,40 @@ func (c *Client) handleErrorResponse(resp *http.Response, message string) error
 	
 	// Try to parse error response
 	var geminiError ErrorResponse
-	if err := json.Unmarshal(body, &geminiError); err == nil && geminiError.ErrorInfo.Message != "" {
+	if err := json.Unmarshal(body, &geminiError); err != nil {
+		message = fmt.Sprintf("%s: %s", message, utils.DescribeErrorBody(resp.StatusCode, body))
+	} else if geminiError.ErrorInfo.Message != "" {
 		message = geminiError.ErrorInfo.Message
 	}
 	
-	return utils.NewAppError(errorCode, message, nil).
+	appErr := utils.NewAppError(errorCode, message, nil).
 		WithService("gemini").
 		WithExtra("status_code", resp.StatusCode).
 		WithExtra("response_body", string(body))
+	
+	// Respect the server's requested backoff on rate limit responses
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := utils.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			appErr = appErr.WithExtra(utils.RetryAfterKey, retryAfter)
+		}
+	}
+	
+	return appErr
 }
 
-// buildSummaryPrompt builds the prompt for executive summary generation
+// buildSummaryPrompt builds the prompt for executive summary generation, from the configured
+// prompt template or the built-in prompt
 func (c *Client) buildSummaryPrompt(activities []models.Activity, customPrompt string) string {
+	if c.promptTemplate != nil {
+		prompt, err := c.promptTemplate.render(newPromptData(activities, customPrompt, c.formatActivity))
+		if err == nil {
+			return prompt
+		}
+		c.logger.Warn("Failed to render prompt template, using the built-in prompt",
+			utils.NewField("error", err.Error()),
+		)
+	}
+	
 	var prompt strings.Builder
 	
 	// Add system prompt
@@ -343,38 +535,15 @@ INSTRUCTIONS:
 	prompt.WriteString("JIRA ACTIVITY DATA:\n")
 	prompt.WriteString("===================\n\n")
 	
-	// Group activities by project for better organization
-	projectGroups := make(map[string][]models.Activity)
-	for _, activity := range activities {
-		projectKey := activity.Project.Key
-		if projectKey == "" {
-			projectKey = "UNKNOWN"
-		}
-		projectGroups[projectKey] = append(projectGroups[projectKey], activity)
-	}
+	data := newPromptData(activities, customPrompt, c.formatActivity)
 	
-	// Add project summaries
-	for projectKey, projectActivities := range projectGroups {
-		prompt.WriteString(fmt.Sprintf("PROJECT: %s\n", projectKey))
+	// Add activities grouped by project for better organization
+	for _, project := range data.Projects {
+		prompt.WriteString(fmt.Sprintf("PROJECT: %s\n", project.Key))
 		prompt.WriteString("=================\n")
 		
-		for _, activity := range projectActivities {
-			prompt.WriteString(fmt.Sprintf("- %s [%s]: %s\n", 
-				activity.Key, activity.Status, activity.Summary))
-			prompt.WriteString(fmt.Sprintf("  Priority: %s | Type: %s | Assignee: %s\n", 
-				activity.Priority, activity.Type, activity.Assignee.DisplayName))
-			prompt.WriteString(fmt.Sprintf("  Created: %s | Updated: %s\n", 
-				activity.Created.Format("2006-01-02"), activity.Updated.Format("2006-01-02")))
-			
-			if activity.TimeSpent > 0 {
-				prompt.WriteString(fmt.Sprintf("  Time Spent: %s\n", activity.GetFormattedTimeSpent()))
-			}
-			
-			if len(activity.Comments) > 0 {
-				prompt.WriteString(fmt.Sprintf("  Comments: %d\n", len(activity.Comments)))
-			}
-			
-			prompt.WriteString("\n")
+		for _, activity := range project.Activities {
+			prompt.WriteString(activity.Entry)
 		}
 		prompt.WriteString("\n")
 	}
@@ -383,28 +552,14 @@ INSTRUCTIONS:
 	prompt.WriteString("SUMMARY STATISTICS:\n")
 	prompt.WriteString("==================\n")
 	
-	totalIssues := len(activities)
-	completedIssues := 0
-	inProgressIssues := 0
-	totalTimeSpent := int64(0)
+	metrics := data.Metrics
+	prompt.WriteString(fmt.Sprintf("Total Issues: %d\n", metrics.TotalIssues))
+	prompt.WriteString(fmt.Sprintf("Completed Issues: %d\n", metrics.CompletedIssues))
+	prompt.WriteString(fmt.Sprintf("In Progress Issues: %d\n", metrics.InProgressIssues))
+	prompt.WriteString(fmt.Sprintf("Total Time Spent: %s\n", metrics.TotalTimeSpent))
 	
-	for _, activity := range activities {
-		if activity.IsCompleted() {
-			completedIssues++
-		} else if activity.IsInProgress() {
-			inProgressIssues++
-		}
-		totalTimeSpent += activity.TimeSpent
-	}
-	
-	prompt.WriteString(fmt.Sprintf("Total Issues: %d\n", totalIssues))
-	prompt.WriteString(fmt.Sprintf("Completed Issues: %d\n", completedIssues))
-	prompt.WriteString(fmt.Sprintf("In Progress Issues: %d\n", inProgressIssues))
-	prompt.WriteString(fmt.Sprintf("Total Time Spent: %s\n", models.FormatTimeSpent(totalTimeSpent)))
-	
-	if totalIssues > 0 {
-		completionRate := float64(completedIssues) / float64(totalIssues) * 100
-		prompt.WriteString(fmt.Sprintf("Completion Rate: %.1f%%\n", completionRate))
+	if metrics.TotalIssues > 0 {
+		prompt.WriteString(fmt.Sprintf("Completion Rate: %.1f%%\n", metrics.CompletionRate))
 	}
 	
 	prompt.WriteString("\n")
@@ -413,6 +568,29 @@ INSTRUCTIONS:
 	return prompt.String()
 }
 
+// formatPromptActivity formats a single activity entry for the summary prompt
+func formatPromptActivity(activity models.Activity) string {
+	var entry strings.Builder
+	
+	entry.WriteString(fmt.Sprintf("- %s [%s]: %s\n", 
+		activity.Key, activity.Status, activity.Summary))
+	entry.WriteString(fmt.Sprintf("  Priority: %s | Type: %s | Assignee: %s\n", 
+		activity.Priority, activity.Type, activity.Assignee.DisplayName))
+	entry.WriteString(fmt.Sprintf("  Created: %s | Updated: %s\n", 
+		activity.Created.Format("2006-01-02"), activity.Updated.Format("2006-01-02")))
+	
+	if activity.TimeSpent > 0 {
+		entry.WriteString(fmt.Sprintf("  Time Spent: %s\n", activity.GetFormattedTimeSpent()))
+	}
+	
+	if len(activity.Comments) > 0 {
+		entry.WriteString(fmt.Sprintf("  Comments: %d\n", len(activity.Comments)))
+	}
+	
+	entry.WriteString("\n")
+	return entry.String()
+}
+
 // Helper functions for pointer types
 func float32Ptr(v float32) *float32 {
 	return &v
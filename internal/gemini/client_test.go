@@ -25,6 +25,10 @@ func TestNewClient(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -56,6 +60,10 @@ func TestClient_buildSummaryPrompt(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -110,6 +118,10 @@ func TestClient_buildSummaryPrompt_WithCustomPrompt(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -143,6 +155,10 @@ func TestClient_handleErrorResponse(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -231,6 +247,127 @@ func TestClient_handleErrorResponse(t *testing.T) {
 	}
 }
 
+func TestClient_handleErrorResponse_RetryAfter(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Gemini: struct {
+			Model       string  `yaml:"model"`
+			Temperature float32 `yaml:"temperature"`
+			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
+		}{
+			Model:       "gemini-pro",
+			Temperature: 0.7,
+			MaxTokens:   2048,
+		},
+	}
+
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"5"}},
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Quota exceeded"}}`)),
+	}
+
+	err := client.handleErrorResponse(resp, "Test error")
+
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeAPIRateLimit, appErr.Code)
+
+	retryAfter, ok := utils.RetryAfter(err)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, retryAfter, 5*time.Second)
+
+	// Other statuses ignore the header
+	resp = &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"5"}},
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"message":"Quota exceeded"}}`)),
+	}
+	_, ok = utils.RetryAfter(client.handleErrorResponse(resp, "Test error"))
+	assert.False(t, ok)
+}
+
+func TestClient_handleErrorResponse_NonJSONBody(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Gemini: struct {
+			Model       string  `yaml:"model"`
+			Temperature float32 `yaml:"temperature"`
+			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
+		}{
+			Model:       "gemini-pro",
+			Temperature: 0.7,
+			MaxTokens:   2048,
+		},
+	}
+
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	tests := []struct {
+		name          string
+		statusCode    int
+		responseBody  string
+		expectedError utils.ErrorCode
+		contains      []string
+	}{
+		{
+			name:          "plain text rate limit",
+			statusCode:    http.StatusTooManyRequests,
+			responseBody:  "Rate limit exceeded. Retry later.",
+			expectedError: utils.ErrorCodeAPIRateLimit,
+			contains:      []string{"Test error", "429 Too Many Requests", "Rate limit exceeded. Retry later."},
+		},
+		{
+			name:          "html service unavailable",
+			statusCode:    http.StatusServiceUnavailable,
+			responseBody:  "<html><body><h1>503 Service Temporarily Unavailable</h1></body></html>",
+			expectedError: utils.ErrorCodeAPIServerError,
+			contains:      []string{"Test error", "503 Service Unavailable", "<h1>503 Service Temporarily Unavailable</h1>"},
+		},
+		{
+			name:          "empty body",
+			statusCode:    http.StatusTooManyRequests,
+			responseBody:  "",
+			expectedError: utils.ErrorCodeAPIRateLimit,
+			contains:      []string{"Test error", "429 Too Many Requests"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+			}
+
+			err := client.handleErrorResponse(resp, "Test error")
+
+			require.Error(t, err)
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedError, appErr.Code)
+			for _, expected := range tt.contains {
+				assert.Contains(t, appErr.Message, expected)
+			}
+			assert.Equal(t, tt.responseBody, appErr.Context.Extra["response_body"])
+		})
+	}
+}
+
 func TestClient_createRequest(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
@@ -238,6 +375,10 @@ func TestClient_createRequest(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -403,6 +544,10 @@ func TestClient_Integration(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -495,6 +640,10 @@ func TestClient_GenerateSummary_ValidationErrors(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -535,6 +684,10 @@ func TestClient_GenerateContent_EmptyResponse(t *testing.T) {
 			Model       string  `yaml:"model"`
 			Temperature float32 `yaml:"temperature"`
 			MaxTokens   int     `yaml:"max_tokens"`
+			APIVersion  string  `yaml:"api_version"`
+			SummaryCache     bool          `yaml:"summary_cache"`
+			SummaryCacheSize int           `yaml:"summary_cache_size"`
+			SummaryCacheTTL  time.Duration `yaml:"summary_cache_ttl"`
 		}{
 			Model:       "gemini-pro",
 			Temperature: 0.7,
@@ -571,4 +724,74 @@ func TestClient_GenerateContent_EmptyResponse(t *testing.T) {
 
 	// Clean up
 	authManager.GetCredentialStore().ClearAllCredentials()
-}
\ No newline at end of file
+}
+
+func TestClient_APIVersionEndpoints(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	cfg.Gemini.Model = ModelGemini15Pro
+
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+
+	tests := []struct {
+		version          string
+		expectedGenerate string
+		expectedModels   string
+	}{
+		{
+			version:          APIVersionV1,
+			expectedGenerate: "/v1/models/gemini-1.5-pro:generateContent",
+			expectedModels:   "/v1/models",
+		},
+		{
+			version:          APIVersionV1Beta,
+			expectedGenerate: "/v1beta/models/gemini-1.5-pro:generateContent",
+			expectedModels:   "/v1beta/models",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			cfg.Gemini.APIVersion = tt.version
+			client := NewClient(cfg, authManager, logger)
+
+			assert.Equal(t, tt.version, client.APIVersion())
+			assert.Equal(t, tt.expectedGenerate, client.generateEndpoint())
+			assert.Equal(t, tt.expectedModels, client.modelsEndpoint())
+		})
+	}
+}
+
+func TestClient_SetAPIVersion(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+
+	assert.Equal(t, DefaultAPIVersion, client.APIVersion())
+
+	require.NoError(t, client.SetAPIVersion(APIVersionV1Beta))
+	assert.Equal(t, "/v1beta/models", client.modelsEndpoint())
+
+	err := client.SetAPIVersion("v2alpha")
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeConfigInvalid, appErr.Code)
+	assert.Equal(t, APIVersionV1Beta, client.APIVersion())
+}
+
+func TestNewClient_UnsupportedAPIVersion(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	cfg.Gemini.APIVersion = "v3"
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+
+	client := NewClient(cfg, authManager, logger)
+
+	assert.Equal(t, DefaultAPIVersion, client.APIVersion())
+	assert.Len(t, logger.GetEntriesByLevel(utils.LogLevelWarn), 1)
+}
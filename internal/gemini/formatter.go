@@ -0,0 +1,43 @@
+package gemini
+
+import (
+	"github.com/company/eesa/pkg/models"
+)
+
+// ActivityFormatter renders a single activity as an entry in the summary prompt. Custom
+// formatters can add, drop or reorder fields; the entry should end with a blank line so
+// activities stay visually separated.
+type ActivityFormatter interface {
+	FormatActivity(activity models.Activity) string
+}
+
+// ActivityFormatterFunc adapts an ordinary function to the ActivityFormatter interface
+type ActivityFormatterFunc func(activity models.Activity) string
+
+// FormatActivity calls f(activity)
+func (f ActivityFormatterFunc) FormatActivity(activity models.Activity) string {
+	return f(activity)
+}
+
+// DefaultActivityFormatter renders the key, status and summary followed by priority, type,
+// assignee, dates, logged time and comment count
+type DefaultActivityFormatter struct{}
+
+// FormatActivity renders activity in the default prompt format
+func (DefaultActivityFormatter) FormatActivity(activity models.Activity) string {
+	return formatPromptActivity(activity)
+}
+
+// SetActivityFormatter overrides how activities are rendered in summary prompts, nil restores
+// the default. Prompt batching estimates activity sizes with the same formatter.
+func (c *Client) SetActivityFormatter(formatter ActivityFormatter) {
+	c.activityFormatter = formatter
+}
+
+// formatActivity renders an activity with the configured formatter
+func (c *Client) formatActivity(activity models.Activity) string {
+	if c.activityFormatter == nil {
+		return formatPromptActivity(activity)
+	}
+	return c.activityFormatter.FormatActivity(activity)
+}
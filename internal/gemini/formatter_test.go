@@ -0,0 +1,69 @@
+package gemini
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/company/eesa/pkg/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// keyFirstFormatter renders only the assignee and key, in that order
+type keyFirstFormatter struct{}
+
+func (keyFirstFormatter) FormatActivity(activity models.Activity) string {
+	return fmt.Sprintf("* %s owns %s\n\n", activity.Assignee.DisplayName, activity.Key)
+}
+
+func TestClient_SetActivityFormatter(t *testing.T) {
+	server := httptest.NewServer(nil)
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	activities := streamTestActivities()
+
+	// The default format lists the status, priority and dates
+	prompt := client.buildSummaryPrompt(activities, "")
+	assert.Contains(t, prompt, formatPromptActivity(activities[0]))
+	assert.Contains(t, prompt, "Priority: ")
+
+	client.SetActivityFormatter(keyFirstFormatter{})
+	prompt = client.buildSummaryPrompt(activities, "")
+	for _, activity := range activities {
+		assert.Contains(t, prompt, fmt.Sprintf("* %s owns %s\n", activity.Assignee.DisplayName, activity.Key))
+	}
+	assert.NotContains(t, prompt, "Priority: ")
+	assert.NotContains(t, prompt, activities[0].Summary)
+	assert.Contains(t, prompt, "SUMMARY STATISTICS:") // Only the activity entries change
+
+	// Functions can be used directly
+	client.SetActivityFormatter(ActivityFormatterFunc(func(activity models.Activity) string {
+		return strings.ToLower(activity.Key) + "\n"
+	}))
+	prompt = client.buildSummaryPrompt(activities, "")
+	assert.Contains(t, prompt, strings.ToLower(activities[0].Key)+"\n")
+
+	// nil restores the default format
+	client.SetActivityFormatter(nil)
+	assert.Contains(t, client.buildSummaryPrompt(activities, ""), formatPromptActivity(activities[0]))
+	assert.Equal(t, formatPromptActivity(activities[0]), DefaultActivityFormatter{}.FormatActivity(activities[0]))
+}
+
+func TestClient_SetActivityFormatter_Batching(t *testing.T) {
+	server := httptest.NewServer(nil)
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	activities := chunkingTestActivities(10)
+	limit := EstimateTokens(client.buildSummaryPrompt(nil, "")) + promptHeaderAllowance + 3*EstimateTokens(formatPromptActivity(activities[0]))
+
+	assert.Len(t, client.batchActivities(activities, "", limit), 4)
+
+	// Batches are sized with the configured formatter, so compact entries fit in one prompt
+	client.SetActivityFormatter(ActivityFormatterFunc(func(activity models.Activity) string {
+		return activity.Key + "\n"
+	}))
+	assert.Len(t, client.batchActivities(activities, "", limit), 1)
+}
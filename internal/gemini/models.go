@@ -162,6 +162,7 @@ type SummaryResponse struct {
 	GeneratedAt time.Time        `json:"generatedAt"`
 	Activities  []models.Activity `json:"activities"`
 	Metadata    *SummaryMetadata `json:"metadata,omitempty"`
+	Cached      bool             `json:"cached,omitempty"` // Reused from the summary cache
 }
 
 // SummaryMetadata represents metadata about the summary generation
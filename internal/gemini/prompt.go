@@ -0,0 +1,184 @@
+package gemini
+
+import (
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// PromptData is what a custom summary prompt template is rendered with. A template that writes
+// every entry of .Activities, or of each project's .Activities, gives the model the same data
+// as the built-in prompt:
+//
+//	Summarize {{.Metrics.TotalIssues}} issues from {{date .Period.Start}} to {{date .Period.End}}.
+//	{{range .Projects}}Project {{.Key}}:
+//	{{range .Activities}}{{.Entry}}{{end}}{{end}}
+type PromptData struct {
+	Activities   []PromptActivity
+	Projects     []PromptProject // Activities grouped by project, ordered by project key
+	Period       PromptPeriod
+	Metrics      PromptMetrics
+	CustomPrompt string // Instructions passed with the request, such as a focus or language
+}
+
+// PromptActivity is an activity in a prompt template. Its fields are those of the activity,
+// and Entry holds it rendered by the client's ActivityFormatter.
+type PromptActivity struct {
+	models.Activity
+	Entry string
+}
+
+// PromptProject is the activities of one project
+type PromptProject struct {
+	Key        string // Project key, "UNKNOWN" for activities without one
+	Activities []PromptActivity
+}
+
+// PromptPeriod spans the last updates of the activities
+type PromptPeriod struct {
+	Start time.Time
+	End   time.Time
+}
+
+// PromptMetrics are the summary statistics of the activities
+type PromptMetrics struct {
+	TotalIssues      int
+	CompletedIssues  int
+	InProgressIssues int
+	TotalTimeSpent   string  // Formatted, such as "3h 30m"
+	CompletionRate   float64 // Percent, 0 without activities
+}
+
+// promptFuncs are the functions available to prompt templates
+var promptFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+	"join": strings.Join,
+}
+
+// SummaryPrompt is a parsed custom summary prompt template
+type SummaryPrompt struct {
+	tmpl *template.Template
+}
+
+// ParsePromptTemplate parses text as a summary prompt template and renders it once with sample
+// data, so templates that refer to fields PromptData does not have are rejected up front
+func ParsePromptTemplate(text string) (*SummaryPrompt, error) {
+	tmpl, err := template.New("prompt").Funcs(promptFuncs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeConfigInvalid, "Invalid prompt template", err).
+			WithService("gemini")
+	}
+	
+	prompt := &SummaryPrompt{tmpl: tmpl}
+	sample := []models.Activity{{
+		Key:     "SAMPLE-1",
+		Summary: "Sample activity",
+		Status:  "Done",
+		Project: models.Project{Key: "SAMPLE"},
+		Created: time.Now(),
+		Updated: time.Now(),
+	}}
+	if _, err := prompt.render(newPromptData(sample, "", formatPromptActivity)); err != nil {
+		return nil, err
+	}
+	return prompt, nil
+}
+
+// LoadPromptTemplate loads the prompt template configured in cfg, returning nil when the
+// built-in prompt is used
+func LoadPromptTemplate(cfg *config.Config) (*SummaryPrompt, error) {
+	text, err := cfg.PromptTemplate()
+	if err != nil {
+		return nil, err
+	}
+	if text == "" {
+		return nil, nil
+	}
+	return ParsePromptTemplate(text)
+}
+
+// SetPromptTemplate replaces the built-in summary prompt with prompt, nil restores it
+func (c *Client) SetPromptTemplate(prompt *SummaryPrompt) {
+	c.promptTemplate = prompt
+}
+
+// render executes the template with data
+func (t *SummaryPrompt) render(data PromptData) (string, error) {
+	var prompt strings.Builder
+	if err := t.tmpl.Execute(&prompt, data); err != nil {
+		return "", utils.NewAppError(utils.ErrorCodeConfigInvalid, "Failed to render prompt template", err).
+			WithService("gemini")
+	}
+	return prompt.String(), nil
+}
+
+// newPromptData prepares activities for a prompt template, rendering each entry with format
+func newPromptData(activities []models.Activity, customPrompt string, format func(models.Activity) string) PromptData {
+	data := PromptData{
+		Activities:   make([]PromptActivity, len(activities)),
+		Metrics:      newPromptMetrics(activities),
+		CustomPrompt: customPrompt,
+	}
+	
+	projects := make(map[string]int)
+	for i, activity := range activities {
+		entry := PromptActivity{Activity: activity, Entry: format(activity)}
+		data.Activities[i] = entry
+		
+		key := promptProjectKey(activity)
+		index, ok := projects[key]
+		if !ok {
+			index = len(data.Projects)
+			projects[key] = index
+			data.Projects = append(data.Projects, PromptProject{Key: key})
+		}
+		data.Projects[index].Activities = append(data.Projects[index].Activities, entry)
+		
+		if data.Period.Start.IsZero() || activity.Updated.Before(data.Period.Start) {
+			data.Period.Start = activity.Updated
+		}
+		if activity.Updated.After(data.Period.End) {
+			data.Period.End = activity.Updated
+		}
+	}
+	sort.SliceStable(data.Projects, func(i, j int) bool {
+		return data.Projects[i].Key < data.Projects[j].Key
+	})
+	
+	return data
+}
+
+// newPromptMetrics computes the summary statistics of activities
+func newPromptMetrics(activities []models.Activity) PromptMetrics {
+	metrics := PromptMetrics{TotalIssues: len(activities)}
+	totalTimeSpent := int64(0)
+	for _, activity := range activities {
+		if activity.IsCompleted() {
+			metrics.CompletedIssues++
+		} else if activity.IsInProgress() {
+			metrics.InProgressIssues++
+		}
+		totalTimeSpent += activity.TimeSpent
+	}
+	
+	metrics.TotalTimeSpent = models.FormatTimeSpent(totalTimeSpent)
+	if metrics.TotalIssues > 0 {
+		metrics.CompletionRate = float64(metrics.CompletedIssues) / float64(metrics.TotalIssues) * 100
+	}
+	return metrics
+}
+
+// promptProjectKey returns the project an activity is listed under in the prompt
+func promptProjectKey(activity models.Activity) string {
+	if activity.Project.Key == "" {
+		return "UNKNOWN"
+	}
+	return activity.Project.Key
+}
@@ -0,0 +1,128 @@
+package gemini
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPromptTemplate = `You write upbeat release notes for engineers.
+Cover {{.Metrics.TotalIssues}} issues ({{.Metrics.CompletedIssues}} done, {{printf "%.0f" .Metrics.CompletionRate}}%) from {{date .Period.Start}} to {{date .Period.End}}.
+{{if .CustomPrompt}}Also: {{.CustomPrompt}}
+{{end}}{{range .Projects}}## {{.Key}}
+{{range .Activities}}* {{.Key}} {{.Summary}} ({{.Assignee.DisplayName}})
+{{end}}{{end}}`
+
+func promptTestActivities() []models.Activity {
+	return []models.Activity{
+		{
+			Key:      "WEB-7",
+			Summary:  "Ship the login page",
+			Status:   "Done",
+			Project:  models.Project{Key: "WEB"},
+			Assignee: models.User{DisplayName: "Alice"},
+			Updated:  time.Date(2024, 3, 5, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			Key:      "API-3",
+			Summary:  "Rate limit the search endpoint",
+			Status:   "In Progress",
+			Project:  models.Project{Key: "API"},
+			Assignee: models.User{DisplayName: "Bob"},
+			Updated:  time.Date(2024, 3, 8, 16, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func TestClient_buildSummaryPrompt_Template(t *testing.T) {
+	server := httptest.NewServer(nil)
+	defer server.Close()
+	client := newStreamTestClient(t, server)
+
+	prompt, err := ParsePromptTemplate(testPromptTemplate)
+	require.NoError(t, err)
+	client.SetPromptTemplate(prompt)
+
+	rendered := client.buildSummaryPrompt(promptTestActivities(), "Mention the beta")
+	assert.Equal(t, `You write upbeat release notes for engineers.
+Cover 2 issues (1 done, 50%) from 2024-03-05 to 2024-03-08.
+Also: Mention the beta
+## API
+* API-3 Rate limit the search endpoint (Bob)
+## WEB
+* WEB-7 Ship the login page (Alice)
+`, rendered)
+	assert.NotContains(t, rendered, "executive assistant")
+
+	// nil restores the built-in prompt
+	client.SetPromptTemplate(nil)
+	assert.Contains(t, client.buildSummaryPrompt(promptTestActivities(), ""), "executive assistant")
+}
+
+func TestClient_buildSummaryPrompt_TemplateEntries(t *testing.T) {
+	server := httptest.NewServer(nil)
+	defer server.Close()
+	client := newStreamTestClient(t, server)
+
+	// Entries are rendered with the configured activity formatter
+	prompt, err := ParsePromptTemplate("{{range .Activities}}{{.Entry}}{{end}}")
+	require.NoError(t, err)
+	client.SetPromptTemplate(prompt)
+	client.SetActivityFormatter(ActivityFormatterFunc(func(activity models.Activity) string {
+		return activity.Key + ";"
+	}))
+
+	assert.Equal(t, "WEB-7;API-3;", client.buildSummaryPrompt(promptTestActivities(), ""))
+}
+
+func TestParsePromptTemplate_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+	}{
+		{"syntax error", "{{range .Activities}}"},
+		{"unknown field", "{{.Team}}"},
+		{"unknown activity field", "{{range .Activities}}{{.Sprint}}{{end}}"},
+		{"unknown function", "{{upper .CustomPrompt}}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePromptTemplate(tt.template)
+			require.Error(t, err)
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, utils.ErrorCodeConfigInvalid, appErr.Code)
+		})
+	}
+}
+
+func TestLoadPromptTemplate(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	// Without a template the built-in prompt is used
+	prompt, err := LoadPromptTemplate(cfg)
+	require.NoError(t, err)
+	assert.Nil(t, prompt)
+
+	path := filepath.Join(t.TempDir(), "prompt.tmpl")
+	require.NoError(t, os.WriteFile(path, []byte("Issues: {{.Metrics.TotalIssues}}"), 0600))
+	cfg.Prompt.TemplateFile = path
+	prompt, err = LoadPromptTemplate(cfg)
+	require.NoError(t, err)
+	rendered, err := prompt.render(newPromptData(promptTestActivities(), "", formatPromptActivity))
+	require.NoError(t, err)
+	assert.Equal(t, "Issues: 2", rendered)
+
+	cfg.Prompt.TemplateFile = filepath.Join(t.TempDir(), "missing.tmpl")
+	_, err = LoadPromptTemplate(cfg)
+	assert.Error(t, err)
+}
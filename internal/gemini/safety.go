@@ -0,0 +1,94 @@
+package gemini
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// SafetyRetryInstruction is prepended to a prompt when retrying a response blocked for safety
+const SafetyRetryInstruction = `IMPORTANT: Keep the summary strictly professional and factual. Describe work items in neutral business language, do not quote issue text verbatim, and paraphrase or omit any wording that could be considered offensive or sensitive.
+
+`
+
+// safetyProbabilityRank orders safety probabilities from least to most likely harmful
+var safetyProbabilityRank = map[string]int{
+	"NEGLIGIBLE": 1,
+	"LOW":        2,
+	"MEDIUM":     3,
+	"HIGH":       4,
+}
+
+// SetSafetyRetry enables retrying once with a sanitizing instruction when a summary is blocked
+// for safety. When disabled the ErrorCodeGeminiSafetyBlock error is returned as is.
+func (c *Client) SetSafetyRetry(enabled bool) {
+	c.safetyRetry = enabled
+}
+
+// IsSafetyBlock reports whether err, or any error it wraps, is a Gemini safety block error
+func IsSafetyBlock(err error) bool {
+	_, ok := safetyBlockError(err)
+	return ok
+}
+
+// safetyBlockError returns the safety block error in err's chain, if there is one
+func safetyBlockError(err error) (*utils.AppError, bool) {
+	var appErr *utils.AppError
+	for errors.As(err, &appErr) {
+		if appErr.IsType(utils.ErrorCodeGeminiSafetyBlock) {
+			return appErr, true
+		}
+		err = appErr.Cause
+	}
+	return nil, false
+}
+
+// checkSafetyBlock returns an ErrorCodeGeminiSafetyBlock error when the prompt or the first
+// candidate was blocked for safety
+func checkSafetyBlock(response *GenerateResponse) error {
+	if len(response.Candidates) == 0 {
+		if response.PromptFeedback != nil && response.PromptFeedback.BlockReason != "" {
+			return newSafetyBlockError(response.PromptFeedback.BlockReason,
+				blockedCategory(response.PromptFeedback.SafetyRatings))
+		}
+		return nil
+	}
+	
+	candidate := response.Candidates[0]
+	if candidate.FinishReason != FinishReasonSafety {
+		return nil
+	}
+	
+	return newSafetyBlockError(candidate.FinishReason, blockedCategory(candidate.SafetyRatings))
+}
+
+// newSafetyBlockError builds the typed error for a safety blocked response
+func newSafetyBlockError(reason, category string) error {
+	return utils.NewAppError(utils.ErrorCodeGeminiSafetyBlock,
+		fmt.Sprintf("Gemini blocked the response for safety (category: %s)", category), nil).
+		WithService("gemini").
+		WithExtra("category", category).
+		WithExtra("reason", reason)
+}
+
+// blockedCategory returns the category that triggered a block, preferring ratings marked as
+// blocked and otherwise the rating with the highest probability
+func blockedCategory(ratings []SafetyRating) string {
+	category := ""
+	highest := 0
+	for _, rating := range ratings {
+		if rating.Blocked {
+			return rating.Category
+		}
+		if rank := safetyProbabilityRank[rating.Probability]; rank > highest {
+			highest = rank
+			category = rating.Category
+		}
+	}
+	
+	if category == "" {
+		return "UNKNOWN"
+	}
+	return category
+}
@@ -0,0 +1,194 @@
+package gemini
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// safetyTestServer blocks the first blockCount generate calls for safety and answers the rest normally
+type safetyTestServer struct {
+	mu         sync.Mutex
+	blockCount int
+	prompts    []string
+}
+
+func (s *safetyTestServer) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models/gemini-pro:generateContent" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		var request GenerateRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+
+		s.mu.Lock()
+		s.prompts = append(s.prompts, request.Contents[0].Parts[0].Text)
+		blocked := len(s.prompts) <= s.blockCount
+		s.mu.Unlock()
+
+		if blocked {
+			json.NewEncoder(w).Encode(GenerateResponse{
+				Candidates: []Candidate{
+					{
+						FinishReason: FinishReasonSafety,
+						SafetyRatings: []SafetyRating{
+							{Category: SafetyCategoryHarassment, Probability: "LOW"},
+							{Category: SafetyCategoryHateSpeech, Probability: "HIGH", Blocked: true},
+						},
+					},
+				},
+			})
+			return
+		}
+
+		json.NewEncoder(w).Encode(GenerateResponse{
+			Candidates: []Candidate{
+				{Content: Content{Parts: []Part{{Text: "Clean summary"}}}, FinishReason: FinishReasonStop},
+			},
+			UsageMetadata: &UsageMetadata{TotalTokenCount: 20},
+		})
+	}
+}
+
+func TestClient_GenerateSummary_SafetyBlock(t *testing.T) {
+	fake := &safetyTestServer{blockCount: 1}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(1000000)
+
+	_, err := client.GenerateSummary(context.Background(), streamTestActivities(), "")
+	require.Error(t, err)
+	assert.True(t, IsSafetyBlock(err))
+
+	appErr := err.(*utils.AppError)
+	assert.Equal(t, utils.ErrorCodeGeminiSafetyBlock, appErr.Code)
+	assert.Equal(t, SafetyCategoryHateSpeech, appErr.Context.Extra["category"])
+	assert.Equal(t, FinishReasonSafety, appErr.Context.Extra["reason"])
+	assert.Contains(t, appErr.Message, SafetyCategoryHateSpeech)
+
+	// Without the retry option the blocked request is not repeated
+	assert.Len(t, fake.prompts, 1)
+}
+
+func TestClient_GenerateSummary_SafetyRetry(t *testing.T) {
+	fake := &safetyTestServer{blockCount: 1}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(1000000)
+	client.SetSafetyRetry(true)
+
+	response, err := client.GenerateSummary(context.Background(), streamTestActivities(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "Clean summary", response.Summary)
+	assert.Equal(t, 20, response.TokensUsed)
+
+	require.Len(t, fake.prompts, 2)
+	assert.False(t, strings.HasPrefix(fake.prompts[0], SafetyRetryInstruction))
+	assert.Equal(t, SafetyRetryInstruction+fake.prompts[0], fake.prompts[1])
+}
+
+func TestClient_GenerateSummary_SafetyRetryStillBlocked(t *testing.T) {
+	fake := &safetyTestServer{blockCount: 2}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(1000000)
+	client.SetSafetyRetry(true)
+
+	_, err := client.GenerateSummary(context.Background(), streamTestActivities(), "")
+	require.Error(t, err)
+	assert.True(t, IsSafetyBlock(err))
+
+	// Only one retry is attempted
+	assert.Len(t, fake.prompts, 2)
+}
+
+func TestCheckSafetyBlock(t *testing.T) {
+	tests := []struct {
+		name     string
+		response *GenerateResponse
+		blocked  bool
+		category string
+	}{
+		{
+			name: "completed candidate",
+			response: &GenerateResponse{Candidates: []Candidate{
+				{FinishReason: FinishReasonStop},
+			}},
+		},
+		{
+			name: "max tokens is not a safety block",
+			response: &GenerateResponse{Candidates: []Candidate{
+				{FinishReason: FinishReasonMaxTokens},
+			}},
+		},
+		{
+			name: "blocked candidate uses highest probability",
+			response: &GenerateResponse{Candidates: []Candidate{
+				{FinishReason: FinishReasonSafety, SafetyRatings: []SafetyRating{
+					{Category: SafetyCategoryHarassment, Probability: "MEDIUM"},
+					{Category: SafetyCategoryDangerousContent, Probability: "HIGH"},
+				}},
+			}},
+			blocked:  true,
+			category: SafetyCategoryDangerousContent,
+		},
+		{
+			name: "blocked prompt",
+			response: &GenerateResponse{PromptFeedback: &PromptFeedback{
+				BlockReason:   BlockReasonSafety,
+				SafetyRatings: []SafetyRating{{Category: SafetyCategorySexuallyExplicit, Blocked: true}},
+			}},
+			blocked:  true,
+			category: SafetyCategorySexuallyExplicit,
+		},
+		{
+			name: "blocked without ratings",
+			response: &GenerateResponse{Candidates: []Candidate{
+				{FinishReason: FinishReasonSafety},
+			}},
+			blocked:  true,
+			category: "UNKNOWN",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkSafetyBlock(tt.response)
+			if !tt.blocked {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.True(t, IsSafetyBlock(err))
+			assert.Equal(t, tt.category, err.(*utils.AppError).Context.Extra["category"])
+		})
+	}
+}
+
+func TestIsSafetyBlock_Wrapped(t *testing.T) {
+	blocked := newSafetyBlockError(FinishReasonSafety, SafetyCategoryHateSpeech)
+
+	assert.True(t, IsSafetyBlock(blocked))
+	assert.True(t, IsSafetyBlock(utils.WrapError(blocked, utils.ErrorCodeGeminiError, "Failed to generate summary")))
+	assert.True(t, IsSafetyBlock(fmt.Errorf("summarize batch: %w", blocked)))
+	assert.False(t, IsSafetyBlock(utils.NewAppError(utils.ErrorCodeGeminiError, "Failed to generate summary", nil)))
+	assert.False(t, IsSafetyBlock(nil))
+}
@@ -0,0 +1,129 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// RegenerateSection generates the text of a single executive summary section from processed
+// metrics, so an edited section can be refreshed without regenerating the whole summary.
+// The instruction is optional and describes how the section should change.
+func (c *Client) RegenerateSection(ctx context.Context, data *processor.ProcessingResult, section string, instruction string) (string, error) {
+	section = strings.TrimSpace(section)
+	if section == "" {
+		return "", utils.NewAppError(utils.ErrorCodeDataInvalid, "Section name is required", nil)
+	}
+	if data == nil {
+		return "", utils.NewAppError(utils.ErrorCodeDataMissing, "Processing data is required for section regeneration", nil)
+	}
+	
+	text, tokensUsed, err := c.generateText(ctx, c.buildSectionPrompt(data, section, instruction))
+	if err != nil {
+		if IsSafetyBlock(err) {
+			return "", err
+		}
+		return "", utils.WrapError(err, utils.ErrorCodeGeminiError,
+			fmt.Sprintf("Failed to regenerate section %q", section))
+	}
+	
+	c.log(ctx).Info("Regenerated summary section",
+		utils.NewField("section", section),
+		utils.NewField("section_length", len(text)),
+		utils.NewField("tokens_used", tokensUsed),
+		utils.NewField("model", c.model),
+	)
+	
+	return strings.TrimSpace(text), nil
+}
+
+// buildSectionPrompt builds the prompt that asks for a single summary section
+func (c *Client) buildSectionPrompt(data *processor.ProcessingResult, section string, instruction string) string {
+	var prompt strings.Builder
+	
+	prompt.WriteString(fmt.Sprintf(`You are an executive assistant revising one section of an executive summary for a technology organization.
+
+INSTRUCTIONS:
+1. Write only the "%s" section, without a heading and without any other section
+2. Focus on business impact and strategic insights, not technical details
+3. Use clear, professional language appropriate for C-level executives
+4. Include specific numbers and timeframes from the metrics below where relevant
+5. Keep the section concise (100-250 words)
+
+`, section))
+	
+	if instruction != "" {
+		prompt.WriteString("REVISION INSTRUCTIONS:\n")
+		prompt.WriteString(instruction)
+		prompt.WriteString("\n\n")
+	}
+	
+	summary := data.Summary
+	prompt.WriteString("PROCESSED METRICS:\n")
+	prompt.WriteString("==================\n")
+	if summary.DateRange.Label != "" {
+		prompt.WriteString(fmt.Sprintf("Period: %s\n", summary.DateRange.Label))
+	}
+	prompt.WriteString(fmt.Sprintf("Total Issues: %d\n", summary.TotalActivities))
+	prompt.WriteString(fmt.Sprintf("Active Users: %d\n", summary.TotalUsers))
+	prompt.WriteString(fmt.Sprintf("Completion Rate: %.1f%%\n", summary.CompletionRate))
+	prompt.WriteString(fmt.Sprintf("Total Time Spent: %s\n", models.FormatTimeSpent(summary.TotalTimeSpent)))
+	if summary.MostActiveUser != "" {
+		prompt.WriteString(fmt.Sprintf("Most Active User: %s\n", summary.MostActiveUser))
+	}
+	if summary.TopPriority != "" {
+		prompt.WriteString(fmt.Sprintf("Top Priority: %s\n", summary.TopPriority))
+	}
+	if summary.ReworkAvailable {
+		prompt.WriteString(fmt.Sprintf("Rework Rate: %.1f%% (%d reopened)\n", summary.ReworkRate, summary.ReopenCount))
+	}
+	if data.VelocityMetrics != nil {
+		prompt.WriteString(fmt.Sprintf("Velocity: %.2f (%s)\n", data.VelocityMetrics.CurrentVelocity, data.VelocityMetrics.VelocityTrend))
+	}
+	prompt.WriteString("\n")
+	
+	if len(data.PriorityBreakdown) > 0 {
+		prompt.WriteString("BY PRIORITY:\n")
+		for _, priority := range sortedKeys(data.PriorityBreakdown) {
+			metrics := data.PriorityBreakdown[priority]
+			prompt.WriteString(fmt.Sprintf("- %s: %d issues, %d completed\n", priority, metrics.Count, metrics.CompletedCount))
+		}
+		prompt.WriteString("\n")
+	}
+	
+	if len(data.StatusBreakdown) > 0 {
+		prompt.WriteString("BY STATUS:\n")
+		for _, status := range sortedKeys(data.StatusBreakdown) {
+			prompt.WriteString(fmt.Sprintf("- %s: %d issues\n", status, data.StatusBreakdown[status].Count))
+		}
+		prompt.WriteString("\n")
+	}
+	
+	if len(data.ProjectBreakdown) > 0 {
+		prompt.WriteString("BY PROJECT:\n")
+		for _, project := range sortedKeys(data.ProjectBreakdown) {
+			metrics := data.ProjectBreakdown[project]
+			prompt.WriteString(fmt.Sprintf("- %s: %d issues, %.1f%% complete\n", project, metrics.Count, metrics.CompletionRate))
+		}
+		prompt.WriteString("\n")
+	}
+	
+	prompt.WriteString(fmt.Sprintf("Please write the %s section.", section))
+	
+	return prompt.String()
+}
+
+// sortedKeys returns the keys of a breakdown map in a stable order for prompts
+func sortedKeys[V any](breakdown map[string]V) []string {
+	keys := make([]string, 0, len(breakdown))
+	for key := range breakdown {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,72 @@
+package gemini
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sectionTestData() *processor.ProcessingResult {
+	return &processor.ProcessingResult{
+		Summary: processor.ProcessingSummary{
+			TotalActivities: 12,
+			TotalUsers:      3,
+			CompletionRate:  75,
+			TopPriority:     "High",
+			DateRange:       processor.TimeRange{Label: "2023-01-02 to 2023-01-09"},
+		},
+		PriorityBreakdown: map[string]processor.PriorityMetrics{
+			"High":   {Priority: "High", Count: 8, CompletedCount: 6},
+			"Medium": {Priority: "Medium", Count: 4, CompletedCount: 3},
+		},
+		StatusBreakdown: map[string]processor.StatusMetrics{
+			"Done": {Status: "Done", Count: 9},
+		},
+	}
+}
+
+func TestClient_RegenerateSection(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+	client.SetMaxPromptTokens(100000)
+
+	text, err := client.RegenerateSection(context.Background(), sectionTestData(), "Issues and Risks", "Mention the open high priority work")
+	require.NoError(t, err)
+	assert.Equal(t, "summary 1", text)
+
+	// A single prompt asks for the targeted section only
+	require.Equal(t, 1, model.generateCalls())
+	prompt := model.prompts[0]
+	assert.Contains(t, prompt, `Write only the "Issues and Risks" section`)
+	assert.Contains(t, prompt, "Mention the open high priority work")
+	assert.Contains(t, prompt, "Total Issues: 12")
+	assert.Contains(t, prompt, "- High: 8 issues, 6 completed")
+	assert.NotContains(t, prompt, "Key Accomplishments")
+	assert.NotContains(t, prompt, "Executive Overview")
+}
+
+func TestClient_RegenerateSection_InvalidInput(t *testing.T) {
+	model := &fakeGenerateModel{}
+	server := httptest.NewServer(model.handler(t))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	_, err := client.RegenerateSection(context.Background(), sectionTestData(), "  ", "")
+	require.Error(t, err)
+	assert.Equal(t, utils.ErrorCodeDataInvalid, err.(*utils.AppError).Code)
+
+	_, err = client.RegenerateSection(context.Background(), nil, "Next Steps", "")
+	require.Error(t, err)
+	assert.Equal(t, utils.ErrorCodeDataMissing, err.(*utils.AppError).Code)
+
+	assert.Equal(t, 0, model.generateCalls())
+}
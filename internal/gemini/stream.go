@@ -0,0 +1,162 @@
+package gemini
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// maxStreamEventSize caps the size of a single server-sent event line
+const maxStreamEventSize = 1024 * 1024
+
+// SummaryChunk is a single event from a streamed summary.
+// Text chunks arrive in order; the final chunk has Done set and carries the usage metadata.
+// A chunk with Err set reports a failure and is the last event sent.
+type SummaryChunk struct {
+	Text  string         `json:"text,omitempty"`
+	Usage *UsageMetadata `json:"usage,omitempty"`
+	Done  bool           `json:"done"`
+	Err   error          `json:"-"`
+}
+
+// GenerateSummaryStream generates an executive summary and streams the text as it is produced.
+// The returned channel is closed when the stream ends, fails or ctx is cancelled.
+func (c *Client) GenerateSummaryStream(ctx context.Context, activities []models.Activity, customPrompt string) (<-chan SummaryChunk, error) {
+	if len(activities) == 0 {
+		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "No activities provided for summary generation", nil)
+	}
+	
+	reqBody, err := json.Marshal(c.newSummaryRequest(c.buildSummaryPrompt(activities, customPrompt)))
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeGeminiError, "Failed to marshal request", err)
+	}
+	
+	if c.rateLimiter != nil {
+		if err := c.rateLimiter.WaitForSlot(ctx); err != nil {
+			return nil, err
+		}
+	}
+	
+	req, err := c.createRequest(ctx, "POST", c.streamEndpoint(), reqBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	
+	resp, err := c.httpClient.DoRequest(req)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeGeminiError, "Failed to make request")
+	}
+	
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		return nil, c.handleErrorResponse(resp, "Streaming content generation failed")
+	}
+	
+	chunks := make(chan SummaryChunk)
+	go c.readSummaryStream(ctx, resp, chunks)
+	
+	return chunks, nil
+}
+
+// readSummaryStream parses server-sent events from resp and forwards them on chunks
+func (c *Client) readSummaryStream(ctx context.Context, resp *http.Response, chunks chan<- SummaryChunk) {
+	defer close(chunks)
+	defer resp.Body.Close()
+	
+	// send delivers a chunk unless the caller has gone away
+	send := func(chunk SummaryChunk) bool {
+		select {
+		case chunks <- chunk:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	
+	var usage *UsageMetadata
+	totalLength := 0
+	
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxStreamEventSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue // Blank separators, comments and other SSE fields
+		}
+		
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "" || data == "[DONE]" {
+			continue
+		}
+		
+		// Errors raised after the stream has started arrive as an error payload
+		var errorResponse ErrorResponse
+		if err := json.Unmarshal([]byte(data), &errorResponse); err == nil && errorResponse.ErrorInfo.Message != "" {
+			send(SummaryChunk{Err: utils.NewAppError(utils.ErrorCodeGeminiError, errorResponse.ErrorInfo.Message, nil).
+				WithService("gemini").
+				WithExtra("status_code", errorResponse.ErrorInfo.Code).
+				WithExtra("status", errorResponse.ErrorInfo.Status)})
+			return
+		}
+		
+		var response GenerateResponse
+		if err := json.Unmarshal([]byte(data), &response); err != nil {
+			send(SummaryChunk{Err: utils.NewAppError(utils.ErrorCodeGeminiError, "Failed to parse stream event", err).
+				WithService("gemini")})
+			return
+		}
+		
+		if err := checkSafetyBlock(&response); err != nil {
+			send(SummaryChunk{Err: err})
+			return
+		}
+		
+		// Usage metadata is cumulative, so the last event carries the final counts
+		if response.UsageMetadata != nil {
+			usage = response.UsageMetadata
+		}
+		
+		if len(response.Candidates) == 0 {
+			continue
+		}
+		
+		var text strings.Builder
+		for _, part := range response.Candidates[0].Content.Parts {
+			text.WriteString(part.Text)
+		}
+		if text.Len() == 0 {
+			continue
+		}
+		
+		totalLength += text.Len()
+		if !send(SummaryChunk{Text: text.String()}) {
+			return
+		}
+	}
+	
+	if err := scanner.Err(); err != nil {
+		if ctx.Err() != nil {
+			return
+		}
+		send(SummaryChunk{Err: utils.NewAppError(utils.ErrorCodeNetworkError, "Failed to read summary stream", err).
+			WithService("gemini")})
+		return
+	}
+	
+	if ctx.Err() != nil {
+		return
+	}
+	
+	c.log(ctx).Info("Streamed executive summary",
+		utils.NewField("summary_length", totalLength),
+		utils.NewField("model", c.model),
+	)
+	
+	send(SummaryChunk{Usage: usage, Done: true})
+}
@@ -0,0 +1,204 @@
+package gemini
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newStreamTestClient creates a client pointed at server with test credentials stored
+func newStreamTestClient(t *testing.T, server *httptest.Server) *Client {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	cfg.Gemini.Model = ModelGeminiPro
+
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	err := authManager.GetCredentialStore().SetGeminiCredentials(security.GeminiCredentials{
+		APIKey: "test_api_key",
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		authManager.GetCredentialStore().ClearAllCredentials()
+	})
+
+	client := NewClient(cfg, authManager, logger)
+	client.baseURL = server.URL
+	return client
+}
+
+func streamTestActivities() []models.Activity {
+	return []models.Activity{
+		{ID: "1", Key: "TEST-1", Summary: "Ship the login page", Status: "Done"},
+	}
+}
+
+// collectChunks drains a summary stream, failing the test if it does not close in time
+func collectChunks(t *testing.T, chunks <-chan SummaryChunk) []SummaryChunk {
+	var collected []SummaryChunk
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case chunk, ok := <-chunks:
+			if !ok {
+				return collected
+			}
+			collected = append(collected, chunk)
+		case <-timeout:
+			t.Fatal("summary stream was not closed")
+			return nil
+		}
+	}
+}
+
+func TestClient_GenerateSummaryStream(t *testing.T) {
+	events := []string{
+		`{"candidates":[{"content":{"parts":[{"text":"The team "}]}}]}`,
+		`{"candidates":[{"content":{"parts":[{"text":"shipped the "}]}}]}`,
+		`{"candidates":[{"content":{"parts":[{"text":"login page."}]},"finishReason":"STOP"}],"usageMetadata":{"promptTokenCount":120,"candidatesTokenCount":9,"totalTokenCount":129}}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/models/gemini-pro:streamGenerateContent", r.URL.Path)
+		assert.Equal(t, "sse", r.URL.Query().Get("alt"))
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		flusher := w.(http.Flusher)
+		for _, event := range events {
+			fmt.Fprintf(w, "data: %s\r\n\r\n", event)
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	chunks, err := client.GenerateSummaryStream(context.Background(), streamTestActivities(), "")
+	require.NoError(t, err)
+
+	collected := collectChunks(t, chunks)
+	require.Len(t, collected, 4)
+
+	assert.Equal(t, "The team ", collected[0].Text)
+	assert.Equal(t, "shipped the ", collected[1].Text)
+	assert.Equal(t, "login page.", collected[2].Text)
+	for _, chunk := range collected[:3] {
+		assert.False(t, chunk.Done)
+		assert.NoError(t, chunk.Err)
+	}
+
+	final := collected[3]
+	assert.True(t, final.Done)
+	assert.NoError(t, final.Err)
+	require.NotNil(t, final.Usage)
+	assert.Equal(t, 120, final.Usage.PromptTokenCount)
+	assert.Equal(t, 9, final.Usage.CandidatesTokenCount)
+	assert.Equal(t, 129, final.Usage.TotalTokenCount)
+}
+
+func TestClient_GenerateSummaryStream_MidStreamError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"Partial\"}]}}]}\n\n")
+		fmt.Fprint(w, "data: {\"error\":{\"code\":503,\"message\":\"The model is overloaded\",\"status\":\"UNAVAILABLE\"}}\n\n")
+	}))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	chunks, err := client.GenerateSummaryStream(context.Background(), streamTestActivities(), "")
+	require.NoError(t, err)
+
+	collected := collectChunks(t, chunks)
+	require.Len(t, collected, 2)
+	assert.Equal(t, "Partial", collected[0].Text)
+
+	require.Error(t, collected[1].Err)
+	appErr, ok := collected[1].Err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeGeminiError, appErr.Code)
+	assert.Equal(t, "The model is overloaded", appErr.Message)
+	assert.Equal(t, 503, appErr.Context.Extra["status_code"])
+	assert.False(t, collected[1].Done)
+}
+
+func TestClient_GenerateSummaryStream_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":{"code":400,"message":"Prompt is too long","status":"INVALID_ARGUMENT"}}`)
+	}))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	chunks, err := client.GenerateSummaryStream(context.Background(), streamTestActivities(), "")
+	require.Error(t, err)
+	assert.Nil(t, chunks)
+
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeAPIBadRequest, appErr.Code)
+	assert.Equal(t, "Prompt is too long", appErr.Message)
+}
+
+func TestClient_GenerateSummaryStream_ContextCancelled(t *testing.T) {
+	requestDone := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(requestDone)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "data: {\"candidates\":[{\"content\":{\"parts\":[{\"text\":\"First\"}]}}]}\n\n")
+		w.(http.Flusher).Flush()
+
+		// Hold the stream open until the client goes away
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chunks, err := client.GenerateSummaryStream(ctx, streamTestActivities(), "")
+	require.NoError(t, err)
+
+	first := <-chunks
+	assert.Equal(t, "First", first.Text)
+
+	cancel()
+	collected := collectChunks(t, chunks)
+	for _, chunk := range collected {
+		assert.False(t, chunk.Done)
+	}
+
+	// Cancelling the context aborts the in-flight HTTP request
+	select {
+	case <-requestDone:
+	case <-time.After(5 * time.Second):
+		t.Fatal("HTTP request was not aborted")
+	}
+}
+
+func TestClient_GenerateSummaryStream_NoActivities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected")
+	}))
+	defer server.Close()
+
+	client := newStreamTestClient(t, server)
+
+	_, err := client.GenerateSummaryStream(context.Background(), nil, "")
+	require.Error(t, err)
+}
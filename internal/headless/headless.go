@@ -0,0 +1,343 @@
+// Package headless runs the summary pipeline from the command line without the GUI, so
+// summaries can be generated on servers and from cron.
+package headless
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/jira"
+	"github.com/company/eesa/internal/pipeline"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/internal/scheduler"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/internal/summarizer"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/company/eesa/pkg/validation"
+)
+
+// Flag selects headless mode on the command line
+const Flag = "--headless"
+
+// Output formats
+const (
+	OutputDoc    = "doc"    // Create the Google Doc and print its URL
+	OutputJSON   = "json"   // Print the full pipeline result as JSON without creating a document
+	OutputStdout = "stdout" // Print the AI summary text without creating a document
+)
+
+// dateLayout is the layout of date-only --start and --end values
+const dateLayout = "2006-01-02"
+
+// Options holds a parsed headless command line
+type Options struct {
+	TimeRange config.TimeRange
+	Users     []string
+	Output    string
+	Title     string
+	Schedule  string // Cron spec for repeated runs, empty runs once
+	Profile   string // Credential profile, empty for the default profile
+	DryRun    bool   // Preview the document writes instead of making them
+	PerProject bool   // Add a section per project after the roll-up summary
+}
+
+// Requested reports whether the command line asks for headless mode
+func Requested(args []string) bool {
+	for _, arg := range args {
+		if arg == Flag || arg == "-headless" || strings.HasPrefix(arg, Flag+"=") {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseArgs parses the headless command line. --start and --end take RFC 3339 timestamps or
+// YYYY-MM-DD dates, where an end date includes that whole day. A missing bound or user list
+// falls back to the configured defaults.
+func ParseArgs(args []string, cfg *config.Config) (Options, error) {
+	fs := flag.NewFlagSet("eesa", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Bool("headless", false, "Run the pipeline without the GUI")
+	start := fs.String("start", "", "Start of the time range (YYYY-MM-DD or RFC 3339)")
+	end := fs.String("end", "", "End of the time range, inclusive for dates (YYYY-MM-DD or RFC 3339)")
+	users := fs.String("users", "", "Comma separated Jira users, defaults to the configured users")
+	output := fs.String("output", OutputDoc, "Output format: doc, json or stdout")
+	title := fs.String("title", "", "Document title, defaults to one naming the time range")
+	schedule := fs.String("schedule", "", `Cron spec such as "0 9 * * MON" to run repeatedly instead of once`)
+	profile := fs.String("profile", cfg.Security.Profile, "Credential profile, defaults to the configured profile")
+	dryRun := fs.Bool("dry-run", false, "Fetch and process activities but only print the document writes that would be made")
+	perProject := fs.Bool("per-project", false, "Summarize each project in its own section after the roll-up of all projects")
+	
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return Options{}, err
+		}
+		return Options{}, utils.NewAppError(utils.ErrorCodeValidationError, "Invalid command line", err).
+			WithOperation("parse_args")
+	}
+	
+	opts := Options{
+		Users:  cfg.Defaults.Users,
+		Output:   *output,
+		Title:    *title,
+		Schedule: *schedule,
+		Profile:  strings.TrimSpace(*profile),
+		DryRun:   *dryRun,
+		PerProject: *perProject,
+	}
+	
+	switch opts.Output {
+	case OutputDoc, OutputJSON, OutputStdout:
+	default:
+		return Options{}, utils.NewAppError(utils.ErrorCodeValidationError,
+			fmt.Sprintf("Unknown output format %q, expected doc, json or stdout", opts.Output), nil).
+			WithOperation("parse_args").
+			WithExtra("output", opts.Output)
+	}
+	
+	if opts.Schedule != "" {
+		if _, err := scheduler.ParseSpec(opts.Schedule); err != nil {
+			return Options{}, err
+		}
+		// Each scheduled run covers the default range ending when it starts
+		if *start != "" || *end != "" {
+			return Options{}, utils.NewAppError(utils.ErrorCodeValidationError,
+				"--schedule cannot be combined with --start or --end", nil).
+				WithOperation("parse_args")
+		}
+	}
+	
+	if *users != "" {
+		opts.Users = nil
+		for _, user := range strings.Split(*users, ",") {
+			if user = strings.TrimSpace(user); user != "" {
+				opts.Users = append(opts.Users, user)
+			}
+		}
+	}
+	
+	timeRange, err := parseTimeRange(*start, *end, cfg.Defaults.TimeRange)
+	if err != nil {
+		return Options{}, err
+	}
+	opts.TimeRange = timeRange
+	
+	if opts.Title == "" {
+		opts.Title = fmt.Sprintf("Executive Summary %s to %s",
+			opts.TimeRange.Start.Format(dateLayout), opts.TimeRange.End.Add(-time.Nanosecond).Format(dateLayout))
+	}
+	
+	return opts, nil
+}
+
+// parseTimeRange builds the time range from the --start and --end values, using the default
+// range for any bound that is not set
+func parseTimeRange(start, end, defaultRange string) (config.TimeRange, error) {
+	var timeRange config.TimeRange
+	if start == "" || end == "" {
+		if defaultRange == "" {
+			defaultRange = "1w"
+		}
+		parsed, err := config.ParseTimeRange(defaultRange)
+		if err != nil {
+			return config.TimeRange{}, err
+		}
+		timeRange = parsed
+	}
+	
+	if start != "" {
+		parsed, _, err := parseTime(start)
+		if err != nil {
+			return config.TimeRange{}, invalidTimeError("start", start, err)
+		}
+		timeRange.Start = parsed
+	}
+	if end != "" {
+		parsed, dateOnly, err := parseTime(end)
+		if err != nil {
+			return config.TimeRange{}, invalidTimeError("end", end, err)
+		}
+		if dateOnly {
+			parsed = parsed.AddDate(0, 0, 1)
+		}
+		timeRange.End = parsed
+	}
+	
+	if !timeRange.End.After(timeRange.Start) {
+		return config.TimeRange{}, utils.NewAppError(utils.ErrorCodeValidationError,
+			"The end of the time range must be after its start", nil).
+			WithOperation("parse_args").
+			WithExtra("start", timeRange.Start).
+			WithExtra("end", timeRange.End)
+	}
+	
+	return timeRange, nil
+}
+
+// parseTime parses an RFC 3339 timestamp or a date, reporting whether the value was a date
+func parseTime(value string) (time.Time, bool, error) {
+	if t, err := time.Parse(dateLayout, value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
+// invalidTimeError reports a --start or --end value that is neither a date nor a timestamp
+func invalidTimeError(flagName, value string, cause error) error {
+	return utils.NewAppError(utils.ErrorCodeValidationError,
+		fmt.Sprintf("Invalid --%s value %q, expected YYYY-MM-DD or an RFC 3339 timestamp", flagName, value), cause).
+		WithOperation("parse_args").
+		WithExtra(flagName, value)
+}
+
+// ApplyProfile selects the credential profile given with --profile in cfg, so dependencies
+// built from it use that profile's credentials. Command line errors are left for Run to report.
+func ApplyProfile(args []string, cfg *config.Config) {
+	if opts, err := ParseArgs(args, cfg); err == nil {
+		cfg.Security.Profile = opts.Profile
+	}
+}
+
+// NewDependencies builds the pipeline dependencies from the configuration, using the
+// credentials of the configured profile stored in the keyring and the configured summary
+// provider
+func NewDependencies(cfg *config.Config, logger utils.Logger) (pipeline.Dependencies, error) {
+	authManager := security.NewAuthManager(security.NewAuthConfig(cfg), logger)
+	
+	summaries, err := summarizer.New(cfg.SummaryProvider, cfg, authManager, logger)
+	if err != nil {
+		return pipeline.Dependencies{}, err
+	}
+	
+	docsClient := gdocs.NewClient(cfg, authManager, logger)
+	docsClient.SetSectionDividers(cfg.Google.SectionDividers)
+	
+	return pipeline.Dependencies{
+		JiraClient:            jira.NewClient(cfg, authManager, logger),
+		Summarizer:            summaries,
+		DocsClient:            docsClient,
+		Validator:             validation.NewServiceValidationRules(logger),
+		StatusProgressWeights: cfg.Processing.StatusWeights,
+	}, nil
+}
+
+// Run parses the command line, runs the pipeline and writes the result to out. Only the doc
+// output creates a document; json and stdout print the summary without publishing it. With
+// --schedule the pipeline runs on that cadence until ctx is done, otherwise it runs once.
+// Each phase of a run is printed to progress, which may be nil to print nothing.
+func Run(ctx context.Context, args []string, cfg *config.Config, deps pipeline.Dependencies, out, progress io.Writer, logger utils.Logger) error {
+	opts, err := ParseArgs(args, cfg)
+	if errors.Is(err, flag.ErrHelp) {
+		fmt.Fprintln(out, "Usage: eesa --headless [--start DATE] [--end DATE] [--users a,b] [--output doc|json|stdout] [--title TITLE] [--schedule SPEC] [--profile NAME] [--dry-run] [--per-project]")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	
+	if opts.Schedule == "" {
+		return runOnce(ctx, opts, deps, out, progress, logger)
+	}
+	
+	s, err := scheduler.New(opts.Schedule, func(ctx context.Context) error {
+		// Parse again so the default time range and title follow the time of each run
+		opts, err := ParseArgs(args, cfg)
+		if err != nil {
+			return err
+		}
+		return runOnce(ctx, opts, deps, out, progress, logger)
+	}, logger)
+	if err != nil {
+		return err
+	}
+	
+	logger.Info("Running summaries on schedule", utils.NewField("schedule", opts.Schedule))
+	return s.Run(ctx)
+}
+
+// runOnce runs the pipeline for opts and writes the result to out
+func runOnce(ctx context.Context, opts Options, deps pipeline.Dependencies, out, progress io.Writer, logger utils.Logger) error {
+	pipelineOpts := pipelineOptions(opts)
+	if progress != nil {
+		pipelineOpts.Progress = PrintProgress(progress)
+	}
+	
+	result, err := pipeline.NewPipeline(deps, logger).Run(ctx, pipelineOpts)
+	if err != nil {
+		return err
+	}
+	if result.Canceled {
+		// An interrupted run has no result to print
+		return nil
+	}
+	
+	return writeResult(out, opts.Output, result)
+}
+
+// pipelineOptions maps the headless options onto a pipeline run, processed with the same
+// defaults as the GUI form
+func pipelineOptions(opts Options) pipeline.Options {
+	return pipeline.Options{
+		Users:      opts.Users,
+		TimeRange:  opts.TimeRange,
+		Processing: processor.DefaultProcessingOptions(),
+		Summary: processor.SummaryRequest{
+			Title:          opts.Title,
+			IncludeMetrics: true,
+			IncludeTrends:  true,
+			IncludeUsers:   true,
+			Format:         processor.FormatExecutive,
+		},
+		DocumentTitle:        opts.Title,
+		NoCompletionFallback: true,
+		SkipPublish:          opts.Output != OutputDoc,
+		DryRun:               opts.DryRun,
+		PerProject:           opts.PerProject,
+	}
+}
+
+// PrintProgress returns a progress callback that prints each phase of a run to w as a line
+// such as "[ 40%] Processing 12 activities"
+func PrintProgress(w io.Writer) pipeline.ProgressFunc {
+	return func(progress pipeline.Progress) {
+		fmt.Fprintf(w, "[%3d%%] %s\n", progress.Percent, progress.Message)
+	}
+}
+
+// writeResult prints a pipeline result in the requested output format
+func writeResult(out io.Writer, output string, result *pipeline.Result) error {
+	var err error
+	switch output {
+	case OutputJSON:
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(result)
+	case OutputStdout:
+		_, err = fmt.Fprintln(out, result.AISummary.Summary)
+	default:
+		if result.Preview != nil {
+			// A dry run lists the writes it skipped instead of a document URL
+			for _, action := range result.Preview.Actions {
+				if _, err = fmt.Fprintf(out, "Would: %s\n", action); err != nil {
+					break
+				}
+			}
+			break
+		}
+		_, err = fmt.Fprintln(out, gdocs.DocumentURL(result.Document.DocumentID))
+	}
+	
+	if err != nil {
+		return utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to write result")
+	}
+	return nil
+}
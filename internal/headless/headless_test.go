@@ -0,0 +1,334 @@
+package headless
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/jira"
+	"github.com/company/eesa/internal/pipeline"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJiraClient returns fixed activities and records the query
+type fakeJiraClient struct {
+	activities []models.Activity
+	users      []string
+	timeRange  config.TimeRange
+}
+
+func (f *fakeJiraClient) GetUserActivities(ctx context.Context, users []string, timeRange config.TimeRange) ([]models.Activity, error) {
+	f.users = users
+	f.timeRange = timeRange
+	return f.activities, nil
+}
+
+func (f *fakeJiraClient) ValidateConnection(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeJiraClient) SearchIssues(ctx context.Context, jql string, fields []string, startAt, maxResults int) (*jira.SearchResult, error) {
+	return &jira.SearchResult{}, nil
+}
+
+func (f *fakeJiraClient) SearchAllIssues(ctx context.Context, jql string, fields []string) (*jira.SearchResult, error) {
+	return &jira.SearchResult{}, nil
+}
+
+func (f *fakeJiraClient) GetIssue(ctx context.Context, issueKey string, fields []string) (*models.Activity, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeJiraClient) GetWorklog(ctx context.Context, issueKey string) ([]models.Worklog, error) {
+	return nil, nil
+}
+
+func (f *fakeJiraClient) GetComments(ctx context.Context, issueKey string) ([]models.Comment, error) {
+	return nil, nil
+}
+
+func (f *fakeJiraClient) GetIssueChangelog(ctx context.Context, issueKey string) ([]models.ChangelogEntry, error) {
+	return nil, nil
+}
+
+// fakeGeminiClient returns a canned summary
+type fakeGeminiClient struct{}
+
+func (f *fakeGeminiClient) GenerateSummary(ctx context.Context, activities []models.Activity, prompt string) (*gemini.SummaryResponse, error) {
+	return &gemini.SummaryResponse{
+		Summary:     "The team closed out the login work.",
+		Model:       "gemini-pro",
+		GeneratedAt: time.Now(),
+		Activities:  activities,
+	}, nil
+}
+
+func (f *fakeGeminiClient) ValidateAPIKey(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeGeminiClient) ListModels(ctx context.Context) (*gemini.ModelsResponse, error) {
+	return &gemini.ModelsResponse{}, nil
+}
+
+func (f *fakeGeminiClient) GenerateContent(ctx context.Context, request *gemini.GenerateRequest) (*gemini.GenerateResponse, error) {
+	return &gemini.GenerateResponse{}, nil
+}
+
+// fakeDocsClient records created documents
+type fakeDocsClient struct {
+	created []string
+}
+
+func (f *fakeDocsClient) CreateDocument(ctx context.Context, title string, content string) (*gdocs.DocumentResponse, error) {
+	return &gdocs.DocumentResponse{DocumentID: "doc-1", Title: title}, nil
+}
+
+func (f *fakeDocsClient) UpdateDocument(ctx context.Context, documentID string, requests []gdocs.Request) (*gdocs.BatchUpdateResponse, error) {
+	return &gdocs.BatchUpdateResponse{DocumentID: documentID}, nil
+}
+
+func (f *fakeDocsClient) GetDocument(ctx context.Context, documentID string) (*gdocs.DocumentResponse, error) {
+	return &gdocs.DocumentResponse{DocumentID: documentID}, nil
+}
+
+func (f *fakeDocsClient) ShareDocument(ctx context.Context, documentID string, emails []string, role string) error {
+	return nil
+}
+
+func (f *fakeDocsClient) DeleteDocument(ctx context.Context, documentID string) error {
+	return nil
+}
+
+func (f *fakeDocsClient) ValidateCredentials(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeDocsClient) CreateExecutiveSummaryDocument(ctx context.Context, title, summary string, metadata map[string]interface{}) (*gdocs.DocumentResponse, error) {
+	f.created = append(f.created, title)
+	return &gdocs.DocumentResponse{DocumentID: "doc-1", Title: title}, nil
+}
+
+func createTestActivities() []models.Activity {
+	created := time.Date(2024, 3, 5, 9, 0, 0, 0, time.UTC)
+	return []models.Activity{
+		{
+			ID:       "1",
+			Key:      "PROJ-1",
+			Summary:  "Fix login bug",
+			Type:     "Bug",
+			Status:   "Done",
+			Priority: "High",
+			Assignee: models.User{AccountID: "user1", DisplayName: "User One"},
+			Created:  created,
+			Updated:  created.Add(24 * time.Hour),
+		},
+	}
+}
+
+func createTestDependencies() (pipeline.Dependencies, *fakeJiraClient, *fakeDocsClient) {
+	jiraClient := &fakeJiraClient{activities: createTestActivities()}
+	docsClient := &fakeDocsClient{}
+	return pipeline.Dependencies{
+		JiraClient:   jiraClient,
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+		RunLocks:     pipeline.NewRunLocks(),
+	}, jiraClient, docsClient
+}
+
+func TestRequested(t *testing.T) {
+	assert.True(t, Requested([]string{"--headless"}))
+	assert.True(t, Requested([]string{"--output=json", "-headless"}))
+	assert.True(t, Requested([]string{"--headless=true"}))
+	assert.False(t, Requested(nil))
+	assert.False(t, Requested([]string{"--output=json"}))
+}
+
+func TestParseArgs(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Defaults.Users = []string{"default-user"}
+	
+	opts, err := ParseArgs([]string{"--headless", "--start", "2024-03-04", "--end=2024-03-10", "--users", "alice, bob,", "--output", "json"}, cfg)
+	require.NoError(t, err)
+	
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), opts.TimeRange.Start)
+	// A date-only end includes that whole day
+	assert.Equal(t, time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), opts.TimeRange.End)
+	assert.Equal(t, []string{"alice", "bob"}, opts.Users)
+	assert.Equal(t, OutputJSON, opts.Output)
+	assert.Equal(t, "Executive Summary 2024-03-04 to 2024-03-10", opts.Title)
+	
+	// Defaults come from the configuration
+	opts, err = ParseArgs([]string{"--headless"}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default-user"}, opts.Users)
+	assert.Equal(t, OutputDoc, opts.Output)
+	assert.WithinDuration(t, time.Now(), opts.TimeRange.End, time.Minute)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, -7), opts.TimeRange.Start, time.Minute)
+	assert.Empty(t, opts.Schedule)
+	
+	opts, err = ParseArgs([]string{"--headless", "--schedule", "0 9 * * MON"}, cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "0 9 * * MON", opts.Schedule)
+	assert.False(t, opts.PerProject)
+	
+	opts, err = ParseArgs([]string{"--headless", "--per-project"}, cfg)
+	require.NoError(t, err)
+	assert.True(t, opts.PerProject)
+	assert.True(t, pipelineOptions(opts).PerProject)
+	
+	// Headless runs collect the same breakdowns as the GUI by default
+	assert.Equal(t, processor.DefaultProcessingOptions(), pipelineOptions(opts).Processing)
+}
+
+func TestApplyProfile(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Security.Profile = "internal"
+	
+	// The configured profile applies without --profile
+	ApplyProfile([]string{"--headless"}, cfg)
+	assert.Equal(t, "internal", cfg.Security.Profile)
+	
+	ApplyProfile([]string{"--headless", "--profile", "client-a"}, cfg)
+	assert.Equal(t, "client-a", cfg.Security.Profile)
+	
+	// An invalid command line leaves the configuration alone
+	ApplyProfile([]string{"--headless", "--profile", "client-b", "--bogus"}, cfg)
+	assert.Equal(t, "client-a", cfg.Security.Profile)
+}
+
+func TestParseArgs_Errors(t *testing.T) {
+	cfg := config.DefaultConfig()
+	
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown flag", args: []string{"--headless", "--bogus"}},
+		{name: "unknown output", args: []string{"--headless", "--output", "pdf"}},
+		{name: "invalid start", args: []string{"--headless", "--start", "last week"}},
+		{name: "end before start", args: []string{"--headless", "--start", "2024-03-10", "--end", "2024-03-01"}},
+		{name: "invalid schedule", args: []string{"--headless", "--schedule", "every monday"}},
+		{name: "schedule with fixed range", args: []string{"--headless", "--schedule", "0 9 * * MON", "--start", "2024-03-04"}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseArgs(tt.args, cfg)
+			require.Error(t, err)
+			
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, utils.ErrorCodeValidationError, appErr.Code)
+		})
+	}
+}
+
+func TestRun_Doc(t *testing.T) {
+	deps, jiraClient, docsClient := createTestDependencies()
+	var out, progress bytes.Buffer
+	
+	err := Run(context.Background(), []string{"--headless", "--start", "2024-03-04", "--end", "2024-03-10", "--users", "alice"},
+		config.DefaultConfig(), deps, &out, &progress, utils.NewMockLogger())
+	require.NoError(t, err)
+	
+	assert.Equal(t, []string{"alice"}, jiraClient.users)
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), jiraClient.timeRange.Start)
+	assert.Equal(t, []string{"Executive Summary 2024-03-04 to 2024-03-10"}, docsClient.created)
+	assert.Equal(t, gdocs.DocumentURL("doc-1")+"\n", out.String())
+	
+	// Phases are printed apart from the result
+	assert.Equal(t, "[  0%] Fetching activities from Jira\n"+
+		"[ 40%] Processing 1 activities\n"+
+		"[ 50%] Generating summary\n"+
+		"[ 85%] Publishing document\n"+
+		"[100%] Done\n", progress.String())
+}
+
+func TestRun_JSON(t *testing.T) {
+	deps, _, docsClient := createTestDependencies()
+	var out bytes.Buffer
+	
+	err := Run(context.Background(), []string{"--headless", "--start", "2024-03-04", "--end", "2024-03-10", "--output=json"},
+		config.DefaultConfig(), deps, &out, nil, utils.NewMockLogger())
+	require.NoError(t, err)
+	
+	var result pipeline.Result
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, "The team closed out the login work.", result.AISummary.Summary)
+	assert.Len(t, result.Activities, 1)
+	assert.Nil(t, result.Document)
+	assert.Empty(t, docsClient.created, "json output must not publish a document")
+}
+
+func TestRun_Stdout(t *testing.T) {
+	deps, _, docsClient := createTestDependencies()
+	var out bytes.Buffer
+	
+	err := Run(context.Background(), []string{"--headless", "--start", "2024-03-04", "--end", "2024-03-10", "--output", "stdout"},
+		config.DefaultConfig(), deps, &out, nil, utils.NewMockLogger())
+	require.NoError(t, err)
+	
+	assert.Equal(t, "The team closed out the login work.\n", out.String())
+	assert.Empty(t, docsClient.created)
+}
+
+func TestRun_DryRun(t *testing.T) {
+	deps, jiraClient, docsClient := createTestDependencies()
+	var out bytes.Buffer
+	
+	err := Run(context.Background(), []string{"--headless", "--start", "2024-03-04", "--end", "2024-03-10", "--users", "alice", "--dry-run"},
+		config.DefaultConfig(), deps, &out, nil, utils.NewMockLogger())
+	require.NoError(t, err)
+	
+	// Activities are fetched but no document is created
+	assert.Equal(t, []string{"alice"}, jiraClient.users)
+	assert.Empty(t, docsClient.created)
+	assert.Equal(t, "Would: Create document \"Executive Summary 2024-03-04 to 2024-03-10\" with 0 formatting requests\n", out.String())
+}
+
+func TestRun_Canceled(t *testing.T) {
+	deps, jiraClient, docsClient := createTestDependencies()
+	var out bytes.Buffer
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	
+	// An interrupted run ends quietly without printing a result
+	err := Run(ctx, []string{"--headless", "--start", "2024-03-04", "--end", "2024-03-10", "--users", "alice"},
+		config.DefaultConfig(), deps, &out, nil, utils.NewMockLogger())
+	require.NoError(t, err)
+	assert.Nil(t, jiraClient.users)
+	assert.Empty(t, docsClient.created)
+	assert.Empty(t, out.String())
+}
+
+func TestRun_InvalidArgs(t *testing.T) {
+	deps, jiraClient, _ := createTestDependencies()
+	var out bytes.Buffer
+	
+	err := Run(context.Background(), []string{"--headless", "--output", "pdf"}, config.DefaultConfig(), deps, &out, nil, utils.NewMockLogger())
+	require.Error(t, err)
+	assert.Nil(t, jiraClient.users)
+	assert.Empty(t, out.String())
+}
+
+func TestRun_Help(t *testing.T) {
+	deps, jiraClient, _ := createTestDependencies()
+	var out bytes.Buffer
+	
+	err := Run(context.Background(), []string{"--headless", "--help"}, config.DefaultConfig(), deps, &out, nil, utils.NewMockLogger())
+	require.NoError(t, err)
+	assert.Nil(t, jiraClient.users)
+	assert.Contains(t, out.String(), "[--dry-run] [--per-project]")
+}
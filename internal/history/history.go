@@ -0,0 +1,188 @@
+// Package history keeps a record of the summaries that were generated, so earlier reports and
+// the documents they were published to can be found again.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/pipeline"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// FileName is the name of the history file in the configuration directory
+const FileName = "history.json"
+
+// Entry is the record of one generated summary
+type Entry struct {
+	ID          string                      `json:"id"` // Correlation ID of the run
+	GeneratedAt time.Time                   `json:"generated_at"`
+	Title       string                      `json:"title"`
+	Start       time.Time                   `json:"start"`
+	End         time.Time                   `json:"end"`
+	Users       []string                    `json:"users"`
+	DocumentID  string                      `json:"document_id,omitempty"`
+	DocumentURL string                      `json:"document_url,omitempty"`
+	Summary     string                      `json:"summary"`
+	KeyMetrics  processor.SummaryKeyMetrics `json:"key_metrics"`
+}
+
+// NewEntry records a finished pipeline run started with opts
+func NewEntry(opts pipeline.Options, result *pipeline.Result) Entry {
+	entry := Entry{
+		ID:          result.CorrelationID,
+		GeneratedAt: result.StartedAt,
+		Title:       opts.DocumentTitle,
+		Start:       opts.TimeRange.Start,
+		End:         opts.TimeRange.End,
+		Users:       opts.Users,
+	}
+	if result.AISummary != nil {
+		entry.Summary = result.AISummary.Summary
+	}
+	if result.Summary != nil {
+		entry.KeyMetrics = result.Summary.KeyMetrics
+	}
+	if result.Document != nil {
+		entry.DocumentID = result.Document.DocumentID
+		entry.DocumentURL = gdocs.DocumentURL(result.Document.DocumentID)
+	}
+	return entry
+}
+
+// Filter selects entries by when they were generated. A zero bound leaves that side open.
+type Filter struct {
+	Since time.Time // Entries generated at or after this time
+	Until time.Time // Entries generated before this time
+}
+
+// matches reports whether entry falls within the filter
+func (f Filter) matches(entry Entry) bool {
+	if !f.Since.IsZero() && entry.GeneratedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.GeneratedAt.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Store keeps the history in a JSON file. It is safe for concurrent use within a process.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewStore creates a store backed by the file at path, which is created on the first append
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// DefaultPath returns the history file beside the configuration file
+func DefaultPath() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return FileName
+	}
+	return filepath.Join(homeDir, ".config", "eesa", FileName)
+}
+
+// Path returns the file the store is backed by
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Append adds entry to the history
+func (s *Store) Append(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	entries, err := s.read()
+	if err != nil {
+		return err
+	}
+	return s.write(append(entries, entry))
+}
+
+// List returns the entries matching filter, most recently generated first
+func (s *Store) List(filter Filter) ([]Entry, error) {
+	s.mu.Lock()
+	entries, err := s.read()
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	
+	matched := make([]Entry, 0, len(entries))
+	for _, entry := range entries {
+		if filter.matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	sort.SliceStable(matched, func(i, j int) bool {
+		return matched[i].GeneratedAt.After(matched[j].GeneratedAt)
+	})
+	
+	return matched, nil
+}
+
+// read loads the entries from the file. A missing file is an empty history.
+func (s *Store) read() ([]Entry, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeInternalError, "Failed to read report history", err).
+			WithExtra("path", s.path)
+	}
+	
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeDataCorrupted, "Failed to parse report history", err).
+			WithExtra("path", s.path)
+	}
+	return entries, nil
+}
+
+// write replaces the file with entries. The new history is written to a temporary file that
+// is renamed over the old one, so a failed write never leaves a truncated history.
+func (s *Store) write(entries []Entry) error {
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to encode report history")
+	}
+	
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return utils.NewAppError(utils.ErrorCodeInternalError, "Failed to create report history directory", err).
+			WithExtra("path", s.path)
+	}
+	
+	temp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return utils.NewAppError(utils.ErrorCodeInternalError, "Failed to write report history", err).
+			WithExtra("path", s.path)
+	}
+	defer os.Remove(temp.Name())
+	
+	_, err = temp.Write(data)
+	if closeErr := temp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), s.path)
+	}
+	if err != nil {
+		return utils.NewAppError(utils.ErrorCodeInternalError, "Failed to write report history", err).
+			WithExtra("path", s.path)
+	}
+	
+	return nil
+}
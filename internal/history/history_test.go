@@ -0,0 +1,155 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/pipeline"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) *Store {
+	return NewStore(filepath.Join(t.TempDir(), "eesa", FileName))
+}
+
+func testEntry(id string, generatedAt time.Time) Entry {
+	return Entry{
+		ID:          id,
+		GeneratedAt: generatedAt,
+		Title:       "Weekly Report " + id,
+		Users:       []string{"alice"},
+		DocumentURL: gdocs.DocumentURL("doc-" + id),
+	}
+}
+
+func TestStore_Append(t *testing.T) {
+	store := newTestStore(t)
+	
+	// A store without a file is an empty history
+	entries, err := store.List(Filter{})
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	
+	entry := testEntry("run-1", time.Date(2024, 3, 8, 17, 0, 0, 0, time.UTC))
+	entry.KeyMetrics = processor.SummaryKeyMetrics{TotalActivities: 12, CompletionRate: 75}
+	require.NoError(t, store.Append(entry))
+	
+	// The entry survives in the file, readable only by the owner
+	entries, err = NewStore(store.Path()).List(Filter{})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, entry, entries[0])
+	
+	info, err := os.Stat(store.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	
+	// No temporary files are left behind
+	files, err := os.ReadDir(filepath.Dir(store.Path()))
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+}
+
+func TestStore_ListMostRecentFirst(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	
+	require.NoError(t, store.Append(testEntry("b", base.AddDate(0, 0, 7))))
+	require.NoError(t, store.Append(testEntry("a", base)))
+	require.NoError(t, store.Append(testEntry("c", base.AddDate(0, 0, 14))))
+	
+	entries, err := store.List(Filter{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c", "b", "a"}, entryIDs(entries))
+}
+
+func TestStore_ListFilter(t *testing.T) {
+	store := newTestStore(t)
+	base := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, id := range []string{"mar-1", "mar-8", "mar-15", "mar-22"} {
+		require.NoError(t, store.Append(testEntry(id, base.AddDate(0, 0, 7*i))))
+	}
+	
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"since is inclusive", Filter{Since: base.AddDate(0, 0, 7)}, []string{"mar-22", "mar-15", "mar-8"}},
+		{"until is exclusive", Filter{Until: base.AddDate(0, 0, 14)}, []string{"mar-8", "mar-1"}},
+		{"range", Filter{Since: base.AddDate(0, 0, 1), Until: base.AddDate(0, 0, 20)}, []string{"mar-15", "mar-8"}},
+		{"nothing in range", Filter{Since: base.AddDate(1, 0, 0)}, []string{}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := store.List(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, entryIDs(entries))
+		})
+	}
+}
+
+func TestStore_CorruptFile(t *testing.T) {
+	store := newTestStore(t)
+	require.NoError(t, os.MkdirAll(filepath.Dir(store.Path()), 0700))
+	require.NoError(t, os.WriteFile(store.Path(), []byte("{not json"), 0600))
+	
+	_, err := store.List(Filter{})
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeDataCorrupted, appErr.Code)
+	
+	// Appending does not overwrite a history it cannot read
+	assert.Error(t, store.Append(testEntry("run-1", time.Now())))
+	data, err := os.ReadFile(store.Path())
+	require.NoError(t, err)
+	assert.Equal(t, "{not json", string(data))
+}
+
+func TestNewEntry(t *testing.T) {
+	startedAt := time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC)
+	opts := pipeline.Options{
+		Users:         []string{"alice", "bob"},
+		TimeRange:     config.TimeRange{Start: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), End: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC)},
+		DocumentTitle: "Weekly Report",
+	}
+	result := &pipeline.Result{
+		CorrelationID: "run-7",
+		StartedAt:     startedAt,
+		Summary:       &processor.SummaryResponse{KeyMetrics: processor.SummaryKeyMetrics{TotalActivities: 3}},
+		AISummary:     &gemini.SummaryResponse{Summary: "All done."},
+		Document:      &gdocs.DocumentResponse{DocumentID: "doc-7"},
+	}
+	
+	assert.Equal(t, Entry{
+		ID:          "run-7",
+		GeneratedAt: startedAt,
+		Title:       "Weekly Report",
+		Start:       opts.TimeRange.Start,
+		End:         opts.TimeRange.End,
+		Users:       []string{"alice", "bob"},
+		DocumentID:  "doc-7",
+		DocumentURL: gdocs.DocumentURL("doc-7"),
+		Summary:     "All done.",
+		KeyMetrics:  processor.SummaryKeyMetrics{TotalActivities: 3},
+	}, NewEntry(opts, result))
+}
+
+// entryIDs returns the IDs of entries in order
+func entryIDs(entries []Entry) []string {
+	ids := make([]string, len(entries))
+	for i, entry := range entries {
+		ids[i] = entry.ID
+	}
+	return ids
+}
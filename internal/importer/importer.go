@@ -0,0 +1,282 @@
+// Package importer reads activities from CSV and JSON files so summaries can be generated
+// from sources other than Jira.
+package importer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// CSV columns. The header row names the columns in any order, case-insensitively, and unknown
+// columns are ignored.
+//
+//	key             Issue key, required
+//	summary         Issue summary, required
+//	type            Issue type, required
+//	status          Current status, required
+//	created         Creation time, required
+//	id              Issue ID, defaults to the key
+//	description     Issue description
+//	priority        Priority name
+//	assignee        Assignee display name
+//	assignee_id     Assignee account ID, defaults to the assignee display name
+//	assignee_email  Assignee email address
+//	reporter        Reporter display name
+//	reporter_id     Reporter account ID, defaults to the reporter display name
+//	project         Project key
+//	project_name    Project name
+//	updated         Last update time, defaults to the creation time
+//	resolved        Resolution time
+//	time_spent      Logged time in seconds
+//	components      Component names separated by semicolons
+//	labels          Labels separated by semicolons
+//
+// Times are RFC 3339 timestamps or YYYY-MM-DD dates in UTC.
+const (
+	ColumnKey           = "key"
+	ColumnSummary       = "summary"
+	ColumnType          = "type"
+	ColumnStatus        = "status"
+	ColumnCreated       = "created"
+	ColumnID            = "id"
+	ColumnDescription   = "description"
+	ColumnPriority      = "priority"
+	ColumnAssignee      = "assignee"
+	ColumnAssigneeID    = "assignee_id"
+	ColumnAssigneeEmail = "assignee_email"
+	ColumnReporter      = "reporter"
+	ColumnReporterID    = "reporter_id"
+	ColumnProject       = "project"
+	ColumnProjectName   = "project_name"
+	ColumnUpdated       = "updated"
+	ColumnResolved      = "resolved"
+	ColumnTimeSpent     = "time_spent"
+	ColumnComponents    = "components"
+	ColumnLabels        = "labels"
+)
+
+// ListSeparator separates multiple components or labels in a single CSV cell
+const ListSeparator = ";"
+
+// dateLayout is accepted for times without a time of day
+const dateLayout = "2006-01-02"
+
+// requiredColumns must be present in the CSV header and non-empty in every row
+var requiredColumns = []string{ColumnKey, ColumnSummary, ColumnType, ColumnStatus, ColumnCreated}
+
+// ImportActivitiesCSV reads activities from CSV data with a header row. Rows that are malformed
+// or miss a required field fail the import with an error naming the row, counting the header as row 1.
+func ImportActivitiesCSV(r io.Reader) ([]models.Activity, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, utils.NewAppError(utils.ErrorCodeDataMissing, "CSV data has no header row", nil)
+	}
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeParseError, "Failed to read CSV header", err).
+			WithExtra("row", 1)
+	}
+	
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, column := range requiredColumns {
+		if _, ok := columns[column]; !ok {
+			return nil, utils.NewAppError(utils.ErrorCodeDataInvalid,
+				fmt.Sprintf("CSV header is missing required column %q", column), nil).
+				WithExtra("column", column)
+		}
+	}
+	
+	var activities []models.Activity
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, utils.NewAppError(utils.ErrorCodeParseError,
+				fmt.Sprintf("Failed to read CSV row %d", row), err).
+				WithExtra("row", row)
+		}
+		
+		field := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		
+		activity, err := activityFromCSV(field)
+		if err != nil {
+			return nil, utils.NewAppError(utils.ErrorCodeDataInvalid,
+				fmt.Sprintf("Invalid CSV row %d: %s", row, err.Error()), err).
+				WithExtra("row", row)
+		}
+		activities = append(activities, activity)
+	}
+	
+	return activities, nil
+}
+
+// activityFromCSV builds an activity from the fields of one CSV row
+func activityFromCSV(field func(column string) string) (models.Activity, error) {
+	for _, column := range requiredColumns {
+		if field(column) == "" {
+			return models.Activity{}, fmt.Errorf("missing required field %q", column)
+		}
+	}
+	
+	activity := models.Activity{
+		ID:          field(ColumnID),
+		Key:         field(ColumnKey),
+		Summary:     field(ColumnSummary),
+		Description: field(ColumnDescription),
+		Type:        field(ColumnType),
+		Status:      field(ColumnStatus),
+		Priority:    field(ColumnPriority),
+		Assignee:    csvUser(field(ColumnAssigneeID), field(ColumnAssignee), field(ColumnAssigneeEmail)),
+		Reporter:    csvUser(field(ColumnReporterID), field(ColumnReporter), ""),
+		Project:     models.Project{Key: field(ColumnProject), Name: field(ColumnProjectName)},
+		Components:  splitList(field(ColumnComponents)),
+		Labels:      splitList(field(ColumnLabels)),
+	}
+	if activity.ID == "" {
+		activity.ID = activity.Key
+	}
+	
+	var err error
+	if activity.Created, err = parseTime(field(ColumnCreated)); err != nil {
+		return models.Activity{}, fmt.Errorf("invalid %q: %w", ColumnCreated, err)
+	}
+	
+	activity.Updated = activity.Created
+	if value := field(ColumnUpdated); value != "" {
+		if activity.Updated, err = parseTime(value); err != nil {
+			return models.Activity{}, fmt.Errorf("invalid %q: %w", ColumnUpdated, err)
+		}
+	}
+	
+	if value := field(ColumnResolved); value != "" {
+		resolved, err := parseTime(value)
+		if err != nil {
+			return models.Activity{}, fmt.Errorf("invalid %q: %w", ColumnResolved, err)
+		}
+		activity.Resolved = &resolved
+	}
+	
+	if value := field(ColumnTimeSpent); value != "" {
+		timeSpent, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || timeSpent < 0 {
+			return models.Activity{}, fmt.Errorf("invalid %q: %q is not a number of seconds", ColumnTimeSpent, value)
+		}
+		activity.TimeSpent = timeSpent
+	}
+	
+	return activity, nil
+}
+
+// ImportActivitiesJSON reads activities from a JSON array of objects using the field names of
+// models.Activity, for example as written by the pipeline result. Every activity needs a key,
+// summary, type, status and created time. The ID defaults to the key.
+func ImportActivitiesJSON(r io.Reader) ([]models.Activity, error) {
+	var activities []models.Activity
+	if err := json.NewDecoder(r).Decode(&activities); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, utils.NewAppError(utils.ErrorCodeDataMissing, "JSON data is empty", nil)
+		}
+		return nil, utils.NewAppError(utils.ErrorCodeParseError, "Failed to parse activities JSON", err)
+	}
+	
+	for i := range activities {
+		if err := validateJSONActivity(&activities[i]); err != nil {
+			return nil, utils.NewAppError(utils.ErrorCodeDataInvalid,
+				fmt.Sprintf("Invalid activity %d: %s", i+1, err.Error()), err).
+				WithExtra("row", i+1)
+		}
+	}
+	
+	return activities, nil
+}
+
+// validateJSONActivity checks the required fields of an imported activity and fills in defaults
+func validateJSONActivity(activity *models.Activity) error {
+	fields := []struct {
+		name  string
+		value string
+	}{
+		{ColumnKey, activity.Key},
+		{ColumnSummary, activity.Summary},
+		{ColumnType, activity.Type},
+		{ColumnStatus, activity.Status},
+	}
+	for _, field := range fields {
+		if strings.TrimSpace(field.value) == "" {
+			return fmt.Errorf("missing required field %q", field.name)
+		}
+	}
+	if activity.Created.IsZero() {
+		return fmt.Errorf("missing required field %q", ColumnCreated)
+	}
+	
+	if activity.ID == "" {
+		activity.ID = activity.Key
+	}
+	if activity.Updated.IsZero() {
+		activity.Updated = activity.Created
+	}
+	
+	return nil
+}
+
+// csvUser builds a user from CSV fields, using the display name as the account ID when none is given
+func csvUser(accountID, displayName, email string) models.User {
+	if accountID == "" {
+		accountID = displayName
+	}
+	return models.User{
+		AccountID:    accountID,
+		DisplayName:  displayName,
+		EmailAddress: email,
+		Active:       accountID != "",
+	}
+}
+
+// splitList splits a separated CSV cell into trimmed, non-empty values
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	
+	var values []string
+	for _, part := range strings.Split(value, ListSeparator) {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// parseTime parses an RFC 3339 timestamp or a YYYY-MM-DD date
+func parseTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	parsed, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 timestamp or YYYY-MM-DD date", value)
+	}
+	return parsed, nil
+}
@@ -0,0 +1,192 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const sampleCSV = `Key,Summary,Type,Status,Created,Updated,Resolved,Priority,Assignee,Assignee_ID,Project,Time_Spent,Components,Labels,Notes
+OPS-1,Rotate TLS certificates,Task,Done,2024-03-04T09:00:00Z,2024-03-05T17:00:00Z,2024-03-05T16:30:00Z,High,Alex Doe,acc-1,OPS,5400,Infra; Security,security;customer,ignored
+OPS-2,"Upgrade database, phase 1",Story,In Progress,2024-03-06,,,Medium,Sam Roe,,OPS,,,,
+`
+
+func TestImportActivitiesCSV(t *testing.T) {
+	activities, err := ImportActivitiesCSV(strings.NewReader(sampleCSV))
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	
+	first := activities[0]
+	assert.Equal(t, "OPS-1", first.ID)
+	assert.Equal(t, "OPS-1", first.Key)
+	assert.Equal(t, "Rotate TLS certificates", first.Summary)
+	assert.Equal(t, "Task", first.Type)
+	assert.Equal(t, "Done", first.Status)
+	assert.Equal(t, "High", first.Priority)
+	assert.Equal(t, "acc-1", first.Assignee.AccountID)
+	assert.Equal(t, "Alex Doe", first.Assignee.DisplayName)
+	assert.Equal(t, "OPS", first.Project.Key)
+	assert.Equal(t, int64(5400), first.TimeSpent)
+	assert.Equal(t, []string{"Infra", "Security"}, first.Components)
+	assert.Equal(t, []string{"security", "customer"}, first.Labels)
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC), first.Created)
+	assert.Equal(t, time.Date(2024, 3, 5, 17, 0, 0, 0, time.UTC), first.Updated)
+	require.NotNil(t, first.Resolved)
+	assert.Equal(t, time.Date(2024, 3, 5, 16, 30, 0, 0, time.UTC), *first.Resolved)
+	
+	second := activities[1]
+	assert.Equal(t, "Upgrade database, phase 1", second.Summary)
+	assert.Equal(t, "Sam Roe", second.Assignee.AccountID)
+	assert.Equal(t, time.Date(2024, 3, 6, 0, 0, 0, 0, time.UTC), second.Created)
+	assert.Equal(t, second.Created, second.Updated)
+	assert.Nil(t, second.Resolved)
+	assert.Zero(t, second.TimeSpent)
+	assert.Nil(t, second.Labels)
+}
+
+func TestImportActivitiesCSV_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		code    utils.ErrorCode
+		row     interface{}
+		message string
+	}{
+		{
+			name:    "empty input",
+			data:    "",
+			code:    utils.ErrorCodeDataMissing,
+			message: "no header row",
+		},
+		{
+			name:    "missing required column",
+			data:    "key,summary,type,status\nOPS-1,Fix,Bug,Done\n",
+			code:    utils.ErrorCodeDataInvalid,
+			message: `missing required column "created"`,
+		},
+		{
+			name:    "missing required field",
+			data:    "key,summary,type,status,created\nOPS-1,Fix,Bug,Done,2024-03-04\nOPS-2,,Bug,Done,2024-03-04\n",
+			code:    utils.ErrorCodeDataInvalid,
+			row:     3,
+			message: `Invalid CSV row 3: missing required field "summary"`,
+		},
+		{
+			name:    "invalid time",
+			data:    "key,summary,type,status,created\nOPS-1,Fix,Bug,Done,yesterday\n",
+			code:    utils.ErrorCodeDataInvalid,
+			row:     2,
+			message: `Invalid CSV row 2: invalid "created"`,
+		},
+		{
+			name:    "invalid time spent",
+			data:    "key,summary,type,status,created,time_spent\nOPS-1,Fix,Bug,Done,2024-03-04,1h\n",
+			code:    utils.ErrorCodeDataInvalid,
+			row:     2,
+			message: `invalid "time_spent"`,
+		},
+		{
+			name:    "wrong number of fields",
+			data:    "key,summary,type,status,created\nOPS-1,Fix,Bug,Done,2024-03-04\nOPS-2,Fix,Bug\n",
+			code:    utils.ErrorCodeParseError,
+			row:     3,
+			message: "Failed to read CSV row 3",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activities, err := ImportActivitiesCSV(strings.NewReader(tt.data))
+			require.Error(t, err)
+			assert.Nil(t, activities)
+			
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Contains(t, appErr.Message, tt.message)
+			if tt.row != nil {
+				assert.Equal(t, tt.row, appErr.Context.Extra["row"])
+			}
+		})
+	}
+}
+
+func TestImportActivitiesJSON(t *testing.T) {
+	data := `[
+		{
+			"key": "WEB-1",
+			"summary": "Ship the login page",
+			"type": "Story",
+			"status": "Done",
+			"priority": "High",
+			"assignee": {"account_id": "acc-1", "display_name": "Alex Doe"},
+			"project": {"key": "WEB"},
+			"labels": ["customer"],
+			"created": "2024-03-04T09:00:00Z",
+			"updated": "2024-03-06T12:00:00Z",
+			"time_spent": 7200
+		},
+		{
+			"id": "10002",
+			"key": "WEB-2",
+			"summary": "Fix the footer",
+			"type": "Bug",
+			"status": "To Do",
+			"created": "2024-03-05T09:00:00Z"
+		}
+	]`
+	
+	activities, err := ImportActivitiesJSON(strings.NewReader(data))
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	
+	assert.Equal(t, "WEB-1", activities[0].ID)
+	assert.Equal(t, "acc-1", activities[0].Assignee.AccountID)
+	assert.Equal(t, []string{"customer"}, activities[0].Labels)
+	assert.Equal(t, int64(7200), activities[0].TimeSpent)
+	assert.Equal(t, time.Date(2024, 3, 6, 12, 0, 0, 0, time.UTC), activities[0].Updated)
+	
+	assert.Equal(t, "10002", activities[1].ID)
+	assert.Equal(t, activities[1].Created, activities[1].Updated)
+}
+
+func TestImportActivitiesJSON_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		code    utils.ErrorCode
+		message string
+	}{
+		{name: "empty input", data: "", code: utils.ErrorCodeDataMissing, message: "empty"},
+		{name: "malformed", data: `[{"key": "WEB-1",`, code: utils.ErrorCodeParseError, message: "Failed to parse"},
+		{name: "not an array", data: `{"key": "WEB-1"}`, code: utils.ErrorCodeParseError, message: "Failed to parse"},
+		{
+			name:    "missing field",
+			data:    `[{"key": "WEB-1", "summary": "A", "type": "Bug", "status": "Done", "created": "2024-03-04T09:00:00Z"}, {"key": "WEB-2", "summary": "B", "type": "Bug", "created": "2024-03-04T09:00:00Z"}]`,
+			code:    utils.ErrorCodeDataInvalid,
+			message: `Invalid activity 2: missing required field "status"`,
+		},
+		{
+			name:    "missing created",
+			data:    `[{"key": "WEB-1", "summary": "A", "type": "Bug", "status": "Done"}]`,
+			code:    utils.ErrorCodeDataInvalid,
+			message: `Invalid activity 1: missing required field "created"`,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ImportActivitiesJSON(strings.NewReader(tt.data))
+			require.Error(t, err)
+			
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tt.code, appErr.Code)
+			assert.Contains(t, appErr.Message, tt.message)
+		})
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/company/eesa/internal/config"
@@ -16,14 +18,37 @@ import (
 	"github.com/company/eesa/pkg/utils"
 )
 
+const (
+	// SearchPageSize is the number of issues requested per search page
+	SearchPageSize = 100
+	
+	// DefaultMaxSearchIssues caps how many issues SearchAllIssues will collect
+	DefaultMaxSearchIssues = 10000
+	
+	// ChangelogPageSize is the number of histories requested per changelog page
+	ChangelogPageSize = 100
+	
+	// DefaultDetailsConcurrency is the number of issues whose worklogs and comments are fetched
+	// at the same time
+	DefaultDetailsConcurrency = 4
+	
+	// DefaultIssueDetailsTimeout bounds fetching the worklogs, comments and changelog of one issue
+	DefaultIssueDetailsTimeout = 2 * time.Minute
+	
+	// StoryPointsField is the logical name in jira.custom_fields of the field holding story points
+	StoryPointsField = "story_points"
+)
+
 // JiraClientInterface defines the interface for Jira client
 type JiraClientInterface interface {
 	GetUserActivities(ctx context.Context, users []string, timeRange config.TimeRange) ([]models.Activity, error)
 	ValidateConnection(ctx context.Context) error
 	SearchIssues(ctx context.Context, jql string, fields []string, startAt, maxResults int) (*SearchResult, error)
+	SearchAllIssues(ctx context.Context, jql string, fields []string) (*SearchResult, error)
 	GetIssue(ctx context.Context, issueKey string, fields []string) (*models.Activity, error)
 	GetWorklog(ctx context.Context, issueKey string) ([]models.Worklog, error)
 	GetComments(ctx context.Context, issueKey string) ([]models.Comment, error)
+	GetIssueChangelog(ctx context.Context, issueKey string) ([]models.ChangelogEntry, error)
 }
 
 // Client represents a Jira API client
@@ -35,6 +60,14 @@ type Client struct {
 	rateLimiter  *utils.RateLimiter
 	retryConfig  *utils.RetryConfig
 	logger       utils.Logger
+	maxSearchIssues int
+	storyPointsField string
+	customFields    map[string]string // Logical names mapped to Jira field IDs
+	expandChangelog bool
+	detailsConcurrency  int
+	issueDetailsTimeout time.Duration
+	userCacheMu         sync.Mutex
+	userCache           map[string]string // Lower-cased identifiers mapped to account IDs
 }
 
 // NewClient creates a new Jira client
@@ -54,9 +87,54 @@ func NewClient(cfg *config.Config, authManager *security.AuthManager, logger uti
 		rateLimiter: rateLimiter,
 		retryConfig: retryConfig,
 		logger:      logger,
+		maxSearchIssues: DefaultMaxSearchIssues,
+		storyPointsField: cfg.Jira.CustomFields[StoryPointsField],
+		expandChangelog: true,
+		customFields:    cfg.Jira.CustomFields,
+		detailsConcurrency:  DefaultDetailsConcurrency,
+		issueDetailsTimeout: DefaultIssueDetailsTimeout,
+		userCache:           make(map[string]string),
 	}
 }
 
+// SetMaxSearchIssues sets the safety limit on issues collected by SearchAllIssues
+func (c *Client) SetMaxSearchIssues(limit int) {
+	c.maxSearchIssues = limit
+}
+
+// SetDetailsConcurrency sets how many issues have their worklogs and comments fetched at the
+// same time. Values below 1 fetch one issue at a time. Every request still waits for the rate
+// limiter, so more workers do not raise the request rate above the quota.
+func (c *Client) SetDetailsConcurrency(workers int) {
+	c.detailsConcurrency = workers
+}
+
+// SetIssueDetailsTimeout sets how long fetching the details of a single issue may take before it
+// is abandoned and the issue is kept without them. Zero disables the timeout.
+func (c *Client) SetIssueDetailsTimeout(timeout time.Duration) {
+	c.issueDetailsTimeout = timeout
+}
+
+// SetCustomFields sets the custom fields to fetch, mapping the logical names they are reported
+// under in Activity.CustomFields to Jira field IDs such as "customfield_10001"
+func (c *Client) SetCustomFields(fields map[string]string) {
+	c.customFields = fields
+}
+
+// SetStoryPointsField sets the custom field holding story points, such as "customfield_10016".
+// The field differs between Jira sites, so story points are not read until it is set, either
+// here or under StoryPointsField in jira.custom_fields.
+func (c *Client) SetStoryPointsField(field string) {
+	c.storyPointsField = field
+}
+
+// SetExpandChangelog controls whether searches expand each issue's changelog inline. It is on
+// by default; turning it off makes searches lighter but leaves Changelog and StatusHistory empty
+// unless they are fetched separately with GetIssueChangelog.
+func (c *Client) SetExpandChangelog(enabled bool) {
+	c.expandChangelog = enabled
+}
+
 // ValidateConnection validates the connection to Jira
 func (c *Client) ValidateConnection(ctx context.Context) error {
 	return utils.RetryWithRateLimit(ctx, c.retryConfig, c.rateLimiter, func() error {
@@ -75,7 +153,7 @@ func (c *Client) ValidateConnection(ctx context.Context) error {
 			return c.handleErrorResponse(resp, "Connection validation failed")
 		}
 		
-		c.logger.Info("Jira connection validated successfully")
+		c.log(ctx).Info("Jira connection validated successfully")
 		return nil
 	}, c.logger)
 }
@@ -84,37 +162,30 @@ func (c *Client) ValidateConnection(ctx context.Context) error {
 func (c *Client) GetUserActivities(ctx context.Context, users []string, timeRange config.TimeRange) ([]models.Activity, error) {
 	var allActivities []models.Activity
 	
+	// Jira Cloud matches users by account ID only
+	accountIDs, err := c.ResolveUsers(ctx, users)
+	if err != nil {
+		return nil, err
+	}
+	
 	// Build JQL query
-	jql := c.buildUserActivitiesJQL(users, timeRange)
-	c.logger.Debug("Built JQL query", utils.NewField("jql", jql))
+	jql := c.buildUserActivitiesJQL(accountIDs, timeRange)
+	c.log(ctx).Debug("Built JQL query", utils.NewField("jql", jql))
 	
-	// Search for issues with pagination
-	startAt := 0
-	maxResults := 100
+	// Search for all matching issues
+	searchResult, err := c.SearchAllIssues(ctx, jql, c.getDefaultFields())
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeJiraError, "Failed to search for user activities")
+	}
 	
-	for {
-		searchResult, err := c.SearchIssues(ctx, jql, c.getDefaultFields(), startAt, maxResults)
-		if err != nil {
-			return nil, utils.WrapError(err, utils.ErrorCodeJiraError, "Failed to search for user activities")
-		}
-		
-		// Convert search results to activities
-		activities, err := c.convertSearchResultToActivities(ctx, searchResult)
-		if err != nil {
-			return nil, utils.WrapError(err, utils.ErrorCodeJiraError, "Failed to convert search results")
-		}
-		
-		allActivities = append(allActivities, activities...)
-		
-		// Check if we've retrieved all results
-		if startAt+len(searchResult.Issues) >= searchResult.Total {
-			break
-		}
-		
-		startAt += maxResults
+	// Convert search results to activities
+	activities, err := c.convertSearchResultToActivities(ctx, searchResult)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeJiraError, "Failed to convert search results")
 	}
+	allActivities = append(allActivities, activities...)
 	
-	c.logger.Info("Retrieved user activities",
+	c.log(ctx).Info("Retrieved user activities",
 		utils.NewField("total_activities", len(allActivities)),
 		utils.NewField("users", users),
 		utils.NewField("time_range", fmt.Sprintf("%v to %v", timeRange.Start, timeRange.End)),
@@ -133,7 +204,9 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, fields []string,
 			StartAt:    startAt,
 			MaxResults: maxResults,
 			Fields:     fields,
-			Expand:     []string{"changelog"},
+		}
+		if c.expandChangelog {
+			searchRequest.Expand = []string{"changelog"}
 		}
 		
 		reqBody, err := json.Marshal(searchRequest)
@@ -156,7 +229,7 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, fields []string,
 			return c.handleErrorResponse(resp, "Issue search failed")
 		}
 		
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to read search response", err)
 		}
@@ -176,6 +249,49 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, fields []string,
 	return result, nil
 }
 
+// SearchAllIssues pages through every issue matching the JQL query and returns them in order.
+// It fails with ErrorCodeSearchLimitExceeded rather than collecting more than the configured maximum.
+func (c *Client) SearchAllIssues(ctx context.Context, jql string, fields []string) (*SearchResult, error) {
+	all := &SearchResult{}
+	
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		page, err := c.SearchIssues(ctx, jql, fields, len(all.Issues), SearchPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all.Total = page.Total
+		
+		if c.maxSearchIssues > 0 && page.Total > c.maxSearchIssues {
+			return nil, utils.NewAppError(utils.ErrorCodeSearchLimitExceeded,
+				fmt.Sprintf("Search matched %d issues, more than the limit of %d; narrow the query or time range", page.Total, c.maxSearchIssues), nil).
+				WithService("jira").
+				WithExtra("total", page.Total).
+				WithExtra("max_issues", c.maxSearchIssues).
+				WithExtra("jql", jql)
+		}
+		
+		all.Issues = append(all.Issues, page.Issues...)
+		
+		// Stop once everything is collected, or if the server returns an empty page
+		if len(all.Issues) >= page.Total || len(page.Issues) == 0 {
+			break
+		}
+	}
+	
+	all.MaxResults = len(all.Issues)
+	
+	c.log(ctx).Debug("Collected all search results",
+		utils.NewField("total", all.Total),
+		utils.NewField("collected", len(all.Issues)),
+	)
+	
+	return all, nil
+}
+
 // GetIssue retrieves a single issue by key
 func (c *Client) GetIssue(ctx context.Context, issueKey string, fields []string) (*models.Activity, error) {
 	var activity *models.Activity
@@ -204,7 +320,7 @@ func (c *Client) GetIssue(ctx context.Context, issueKey string, fields []string)
 			return c.handleErrorResponse(resp, "Failed to get issue")
 		}
 		
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to read issue response", err)
 		}
@@ -252,7 +368,7 @@ func (c *Client) GetWorklog(ctx context.Context, issueKey string) ([]models.Work
 			return c.handleErrorResponse(resp, "Failed to get worklog")
 		}
 		
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to read worklog response", err)
 		}
@@ -266,7 +382,7 @@ func (c *Client) GetWorklog(ctx context.Context, issueKey string) ([]models.Work
 		for _, entry := range worklogResponse.Worklogs {
 			worklogEntry, err := c.convertWorklogEntry(&entry)
 			if err != nil {
-				c.logger.Warn("Failed to convert worklog entry", utils.NewField("entry_id", entry.ID))
+				c.log(ctx).Warn("Failed to convert worklog entry", utils.NewField("entry_id", entry.ID))
 				continue
 			}
 			worklog = append(worklog, *worklogEntry)
@@ -304,7 +420,7 @@ func (c *Client) GetComments(ctx context.Context, issueKey string) ([]models.Com
 			return c.handleErrorResponse(resp, "Failed to get comments")
 		}
 		
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to read comments response", err)
 		}
@@ -318,7 +434,7 @@ func (c *Client) GetComments(ctx context.Context, issueKey string) ([]models.Com
 		for _, comment := range commentsResponse.Comments {
 			commentEntry, err := c.convertComment(&comment)
 			if err != nil {
-				c.logger.Warn("Failed to convert comment", utils.NewField("comment_id", comment.ID))
+				c.log(ctx).Warn("Failed to convert comment", utils.NewField("comment_id", comment.ID))
 				continue
 			}
 			comments = append(comments, *commentEntry)
@@ -334,6 +450,88 @@ func (c *Client) GetComments(ctx context.Context, issueKey string) ([]models.Com
 	return comments, nil
 }
 
+// GetIssueChangelog retrieves the complete change history of an issue. The issue endpoint only
+// embeds the first page of histories, so the rest are read from the paginated changelog endpoint.
+func (c *Client) GetIssueChangelog(ctx context.Context, issueKey string) ([]models.ChangelogEntry, error) {
+	var issue IssueResponse
+	endpoint := fmt.Sprintf("/rest/api/2/issue/%s?fields=created&expand=changelog", issueKey)
+	if err := c.getJSON(ctx, endpoint, "Failed to get issue changelog", &issue); err != nil {
+		return nil, err
+	}
+	
+	var histories []ChangelogHistory
+	total := 0
+	if issue.Changelog != nil {
+		histories = issue.Changelog.Histories
+		total = issue.Changelog.Total
+	}
+	
+	for len(histories) < total {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		var page ChangelogPage
+		endpoint := fmt.Sprintf("/rest/api/2/issue/%s/changelog?startAt=%d&maxResults=%d", issueKey, len(histories), ChangelogPageSize)
+		if err := c.getJSON(ctx, endpoint, "Failed to get issue changelog", &page); err != nil {
+			return nil, err
+		}
+		
+		histories = append(histories, page.Values...)
+		if page.IsLast || len(page.Values) == 0 {
+			break
+		}
+	}
+	
+	c.log(ctx).Debug("Retrieved issue changelog",
+		utils.NewField("issue_key", issueKey),
+		utils.NewField("history_count", len(histories)),
+	)
+	
+	return c.convertChangelog(issueKey, histories), nil
+}
+
+// getJSON performs a rate limited, retried GET request and decodes the JSON response into target
+func (c *Client) getJSON(ctx context.Context, endpoint, operation string, target interface{}) error {
+	return utils.RetryWithRateLimit(ctx, c.retryConfig, c.rateLimiter, func() error {
+		req, err := c.createRequest(ctx, "GET", endpoint, nil)
+		if err != nil {
+			return err
+		}
+		
+		resp, err := c.httpClient.DoRequest(req)
+		if err != nil {
+			return utils.WrapError(err, utils.ErrorCodeJiraError, operation)
+		}
+		defer resp.Body.Close()
+		
+		if resp.StatusCode == http.StatusNotFound {
+			return utils.NewAppError(utils.ErrorCodeAPINotFound, "Issue not found", nil).
+				WithExtra("endpoint", endpoint)
+		}
+		
+		if resp.StatusCode != http.StatusOK {
+			return c.handleErrorResponse(resp, operation)
+		}
+		
+		body, err := c.httpClient.ReadBody(resp)
+		if err != nil {
+			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to read response", err)
+		}
+		
+		if err := json.Unmarshal(body, target); err != nil {
+			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to parse response", err)
+		}
+		
+		return nil
+	}, c.logger)
+}
+
+// log returns the client's logger carrying the correlation ID of the run in ctx
+func (c *Client) log(ctx context.Context) utils.Logger {
+	return utils.LoggerWithContext(ctx, c.logger)
+}
+
 // createRequest creates an authenticated HTTP request
 func (c *Client) createRequest(ctx context.Context, method, endpoint string, body []byte) (*http.Request, error) {
 	fullURL := c.baseURL + endpoint
@@ -364,7 +562,7 @@ func (c *Client) createRequest(ctx context.Context, method, endpoint string, bod
 
 // handleErrorResponse handles HTTP error responses
 func (c *Client) handleErrorResponse(resp *http.Response, message string) error {
-	body, _ := io.ReadAll(resp.Body)
+	body, _ := c.httpClient.ReadBody(resp)
 	
 	var errorCode utils.ErrorCode
 	switch resp.StatusCode {
@@ -386,10 +584,23 @@ func (c *Client) handleErrorResponse(resp *http.Response, message string) error
 		}
 	}
 	
-	return utils.NewAppError(errorCode, message, nil).
+	if !json.Valid(body) {
+		message = fmt.Sprintf("%s: %s", message, utils.DescribeErrorBody(resp.StatusCode, body))
+	}
+	
+	appErr := utils.NewAppError(errorCode, message, nil).
 		WithService("jira").
 		WithExtra("status_code", resp.StatusCode).
 		WithExtra("response_body", string(body))
+	
+	// Respect the server's requested backoff on rate limit responses
+	if resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, ok := utils.ParseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			appErr = appErr.WithExtra(utils.RetryAfterKey, retryAfter)
+		}
+	}
+	
+	return appErr
 }
 
 // buildUserActivitiesJQL builds a JQL query for user activities
@@ -400,15 +611,16 @@ func (c *Client) buildUserActivitiesJQL(users []string, timeRange config.TimeRan
 	if len(users) > 0 {
 		userConditions := make([]string, len(users))
 		for i, user := range users {
-			userConditions[i] = fmt.Sprintf("assignee = '%s' OR reporter = '%s'", user, user)
+			quoted := quoteJQLValue(user)
+			userConditions[i] = fmt.Sprintf("assignee = %s OR reporter = %s", quoted, quoted)
 		}
 		conditions = append(conditions, fmt.Sprintf("(%s)", strings.Join(userConditions, " OR ")))
 	}
 	
 	// Add time range conditions
-	startDate := timeRange.Start.Format("2006-01-02")
-	endDate := timeRange.End.Format("2006-01-02")
-	conditions = append(conditions, fmt.Sprintf("updated >= '%s' AND updated <= '%s'", startDate, endDate))
+	startDate := quoteJQLValue(timeRange.Start.Format("2006-01-02"))
+	endDate := quoteJQLValue(timeRange.End.Format("2006-01-02"))
+	conditions = append(conditions, fmt.Sprintf("updated >= %s AND updated <= %s", startDate, endDate))
 	
 	// Combine conditions
 	jql := strings.Join(conditions, " AND ")
@@ -419,9 +631,24 @@ func (c *Client) buildUserActivitiesJQL(users []string, timeRange config.TimeRan
 	return jql
 }
 
+// jqlEscaper escapes characters that would terminate or alter a JQL string literal
+var jqlEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	`'`, `\'`,
+	`"`, `\"`,
+	"\n", `\n`,
+	"\r", `\r`,
+	"\t", `\t`,
+)
+
+// quoteJQLValue wraps a user supplied value in a single quoted JQL string literal
+func quoteJQLValue(value string) string {
+	return "'" + jqlEscaper.Replace(value) + "'"
+}
+
 // getDefaultFields returns the default fields to retrieve
 func (c *Client) getDefaultFields() []string {
-	return []string{
+	fields := []string{
 		"id",
 		"key",
 		"summary",
@@ -433,54 +660,155 @@ func (c *Client) getDefaultFields() []string {
 		"assignee",
 		"created",
 		"updated",
+		"resolutiondate",
 		"project",
+		"components",
+		"labels",
 		"timetracking",
 		"worklog",
 		"comment",
 	}
+	
+	if c.storyPointsField != "" {
+		fields = append(fields, c.storyPointsField)
+	}
+	
+	// Request each configured custom field once, in a stable order
+	requested := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		requested[field] = true
+	}
+	for _, name := range sortedKeys(c.customFields) {
+		fieldID := c.customFields[name]
+		if fieldID != "" && !requested[fieldID] {
+			fields = append(fields, fieldID)
+			requested[fieldID] = true
+		}
+	}
+	
+	return fields
 }
 
-// convertSearchResultToActivities converts search results to activities
+// convertSearchResultToActivities converts search results to activities, fetching the worklogs,
+// comments and any truncated changelog of the issues concurrently. Activities keep the order of
+// the search results.
 func (c *Client) convertSearchResultToActivities(ctx context.Context, searchResult *SearchResult) ([]models.Activity, error) {
-	activities := make([]models.Activity, 0, len(searchResult.Issues))
+	converted := make([]*models.Activity, len(searchResult.Issues))
 	
-	for _, issue := range searchResult.Issues {
-		activity, err := c.convertIssueToActivity(&issue)
-		if err != nil {
-			c.logger.Warn("Failed to convert issue to activity",
-				utils.NewField("issue_key", issue.Key),
-				utils.NewField("error", err.Error()),
-			)
-			continue
-		}
-		
-		// Get additional data (worklog and comments)
-		worklog, err := c.GetWorklog(ctx, issue.Key)
-		if err != nil {
-			c.logger.Warn("Failed to get worklog for issue",
-				utils.NewField("issue_key", issue.Key),
-				utils.NewField("error", err.Error()),
-			)
-		} else {
-			activity.Worklog = worklog
-			// Calculate total time spent
-			for _, entry := range worklog {
-				activity.TimeSpent += entry.TimeSpent
+	workers := c.detailsConcurrency
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(searchResult.Issues) {
+		workers = len(searchResult.Issues)
+	}
+	
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				converted[i] = c.convertIssueWithDetails(ctx, &searchResult.Issues[i])
 			}
+		}()
+	}
+	
+	// Stop handing out issues once the context is done, the workers finish the ones they hold
+feed:
+	for i := range searchResult.Issues {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
 		}
-		
-		comments, err := c.GetComments(ctx, issue.Key)
+	}
+	close(indexes)
+	wg.Wait()
+	
+	if err := ctx.Err(); err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeTimeoutError, "Stopped fetching issue details").
+			WithService("jira")
+	}
+	
+	activities := make([]models.Activity, 0, len(searchResult.Issues))
+	for _, activity := range converted {
+		if activity != nil {
+			activities = append(activities, *activity)
+		}
+	}
+	
+	return activities, nil
+}
+
+// convertIssueWithDetails converts an issue and attaches its worklogs, comments and full
+// changelog. Details that cannot be fetched are logged and left out; nil is returned only when
+// the issue itself cannot be converted.
+func (c *Client) convertIssueWithDetails(ctx context.Context, issue *IssueResponse) *models.Activity {
+	activity, err := c.convertIssueToActivity(issue)
+	if err != nil {
+		c.log(ctx).Warn("Failed to convert issue to activity",
+			utils.NewField("issue_key", issue.Key),
+			utils.NewField("error", err.Error()),
+		)
+		return nil
+	}
+	
+	if c.issueDetailsTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.issueDetailsTimeout)
+		defer cancel()
+	}
+	
+	// Search results only embed the first page of a long changelog
+	if issue.Changelog != nil && issue.Changelog.Total > len(issue.Changelog.Histories) {
+		changelog, err := c.GetIssueChangelog(ctx, issue.Key)
 		if err != nil {
-			c.logger.Warn("Failed to get comments for issue",
+			c.log(ctx).Warn("Failed to get full changelog for issue",
 				utils.NewField("issue_key", issue.Key),
 				utils.NewField("error", err.Error()),
 			)
 		} else {
-			activity.Comments = comments
+			activity.Changelog = changelog
+			activity.StatusHistory = models.StatusChanges(changelog)
 		}
-		
-		activities = append(activities, *activity)
 	}
 	
-	return activities, nil
-}
\ No newline at end of file
+	// Get additional data (worklog and comments)
+	worklog, err := c.GetWorklog(ctx, issue.Key)
+	if err != nil {
+		c.log(ctx).Warn("Failed to get worklog for issue",
+			utils.NewField("issue_key", issue.Key),
+			utils.NewField("error", err.Error()),
+		)
+	} else {
+		activity.Worklog = worklog
+		// Calculate total time spent
+		for _, entry := range worklog {
+			activity.TimeSpent += entry.TimeSpent
+		}
+	}
+	
+	comments, err := c.GetComments(ctx, issue.Key)
+	if err != nil {
+		c.log(ctx).Warn("Failed to get comments for issue",
+			utils.NewField("issue_key", issue.Key),
+			utils.NewField("error", err.Error()),
+		)
+	} else {
+		activity.Comments = comments
+	}
+	
+	return activity
+}
+
+// sortedKeys returns the keys of m in sorted order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
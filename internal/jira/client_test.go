@@ -3,15 +3,18 @@ package jira
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/company/eesa/internal/config"
 	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/models"
 	"github.com/company/eesa/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,8 +24,9 @@ func TestNewClient(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      "https://test.atlassian.net",
 			Username: "testuser",
@@ -48,8 +52,9 @@ func TestClient_buildUserActivitiesJQL(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      "https://test.atlassian.net",
 			Username: "testuser",
@@ -74,12 +79,91 @@ func TestClient_buildUserActivitiesJQL(t *testing.T) {
 	assert.Contains(t, jql, "ORDER BY updated DESC")
 }
 
+// parseJQLStringLiterals extracts and unescapes the single quoted literals in a JQL query,
+// failing if a literal is left unterminated
+func parseJQLStringLiterals(t *testing.T, jql string) []string {
+	var literals []string
+	runes := []rune(jql)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '\'' {
+			continue
+		}
+		
+		var literal strings.Builder
+		closed := false
+		for i++; i < len(runes); i++ {
+			switch runes[i] {
+			case '\\':
+				i++
+				require.Less(t, i, len(runes), "dangling escape in %q", jql)
+				unescaped := map[rune]rune{'n': '\n', 'r': '\r', 't': '\t'}
+				if r, ok := unescaped[runes[i]]; ok {
+					literal.WriteRune(r)
+				} else {
+					literal.WriteRune(runes[i])
+				}
+				continue
+			case '\'':
+				closed = true
+			default:
+				literal.WriteRune(runes[i])
+				continue
+			}
+			break
+		}
+		require.True(t, closed, "unterminated literal in %q", jql)
+		literals = append(literals, literal.String())
+	}
+	return literals
+}
+
+func TestClient_buildUserActivitiesJQL_Escaping(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	
+	timeRange := config.TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 7, 0, 0, 0, 0, time.UTC),
+	}
+	
+	tests := []struct {
+		name     string
+		user     string
+		expected string
+	}{
+		{name: "apostrophe", user: "O'Brien", expected: `assignee = 'O\'Brien' OR reporter = 'O\'Brien'`},
+		{name: "backslash", user: `domain\jdoe`, expected: `assignee = 'domain\\jdoe'`},
+		{name: "trailing backslash", user: `jdoe\`, expected: `assignee = 'jdoe\\'`},
+		{name: "double quote", user: `say "hi"`, expected: `assignee = 'say \"hi\"'`},
+		{name: "unicode", user: "José Müller 李", expected: "assignee = 'José Müller 李'"},
+		{name: "newline", user: "a\nb", expected: `assignee = 'a\nb'`},
+		{name: "injection attempt", user: "x' OR project = 'SECRET", expected: `assignee = 'x\' OR project = \'SECRET'`},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jql := client.buildUserActivitiesJQL([]string{tt.user}, timeRange)
+			
+			assert.Contains(t, jql, tt.expected)
+			assert.True(t, strings.HasSuffix(jql, " ORDER BY updated DESC"))
+			
+			// Every literal round trips, so nothing leaked out of its quotes
+			literals := parseJQLStringLiterals(t, jql)
+			assert.Equal(t, []string{tt.user, tt.user, "2023-01-01", "2023-01-07"}, literals)
+		})
+	}
+}
+
 func TestClient_getDefaultFields(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      "https://test.atlassian.net",
 			Username: "testuser",
@@ -95,7 +179,8 @@ func TestClient_getDefaultFields(t *testing.T) {
 	expectedFields := []string{
 		"id", "key", "summary", "description", "issuetype",
 		"status", "priority", "reporter", "assignee", "created",
-		"updated", "project", "timetracking", "worklog", "comment",
+		"updated", "resolutiondate", "project", "components", "labels",
+		"timetracking", "worklog", "comment",
 	}
 	
 	assert.ElementsMatch(t, expectedFields, fields)
@@ -105,8 +190,9 @@ func TestClient_handleErrorResponse(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      "https://test.atlassian.net",
 			Username: "testuser",
@@ -194,12 +280,124 @@ func TestClient_handleErrorResponse(t *testing.T) {
 	}
 }
 
+func TestClient_handleErrorResponse_RetryAfter(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Jira: struct {
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
+		}{
+			URL:      "https://test.atlassian.net",
+			Username: "testuser",
+		},
+	}
+	
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	
+	resp := &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     http.Header{"Retry-After": []string{"5"}},
+		Body:       io.NopCloser(strings.NewReader(`{"errorMessages":["Rate limit exceeded"]}`)),
+	}
+	
+	err := client.handleErrorResponse(resp, "Test error")
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeAPIRateLimit, appErr.Code)
+	
+	retryAfter, ok := utils.RetryAfter(err)
+	require.True(t, ok)
+	assert.GreaterOrEqual(t, retryAfter, 5*time.Second)
+	
+	// Other statuses ignore the header
+	resp = &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Header:     http.Header{"Retry-After": []string{"5"}},
+		Body:       io.NopCloser(strings.NewReader(`{"errorMessages":["Rate limit exceeded"]}`)),
+	}
+	_, ok = utils.RetryAfter(client.handleErrorResponse(resp, "Test error"))
+	assert.False(t, ok)
+}
+
+func TestClient_handleErrorResponse_NonJSONBody(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Jira: struct {
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
+		}{
+			URL:      "https://test.atlassian.net",
+			Username: "testuser",
+		},
+	}
+	
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	
+	tests := []struct {
+		name          string
+		statusCode    int
+		responseBody  string
+		expectedError utils.ErrorCode
+		contains      []string
+	}{
+		{
+			name:          "plain text rate limit",
+			statusCode:    http.StatusTooManyRequests,
+			responseBody:  "Rate limit exceeded. Retry later.",
+			expectedError: utils.ErrorCodeAPIRateLimit,
+			contains:      []string{"Test error", "429 Too Many Requests", "Rate limit exceeded. Retry later."},
+		},
+		{
+			name:          "html service unavailable",
+			statusCode:    http.StatusServiceUnavailable,
+			responseBody:  "<html><body><h1>503 Service Temporarily Unavailable</h1></body></html>",
+			expectedError: utils.ErrorCodeAPIServerError,
+			contains:      []string{"Test error", "503 Service Unavailable", "<h1>503 Service Temporarily Unavailable</h1>"},
+		},
+		{
+			name:          "empty body",
+			statusCode:    http.StatusTooManyRequests,
+			responseBody:  "",
+			expectedError: utils.ErrorCodeAPIRateLimit,
+			contains:      []string{"Test error", "429 Too Many Requests"},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: tt.statusCode,
+				Body:       io.NopCloser(strings.NewReader(tt.responseBody)),
+			}
+	
+			err := client.handleErrorResponse(resp, "Test error")
+	
+			require.Error(t, err)
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedError, appErr.Code)
+			for _, expected := range tt.contains {
+				assert.Contains(t, appErr.Message, expected)
+			}
+			assert.Equal(t, tt.responseBody, appErr.Context.Extra["response_body"])
+		})
+	}
+}
+
 func TestClient_convertIssueToActivity(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      "https://test.atlassian.net",
 			Username: "testuser",
@@ -241,6 +439,8 @@ func TestClient_convertIssueToActivity(t *testing.T) {
 			TimeTracking: TimeTracking{
 				TimeSpentSeconds: 3600,
 			},
+			Components: []ComponentField{{ID: "c1", Name: "Backend"}, {ID: "c2", Name: "API"}},
+			Labels:     []string{"security", "customer"},
 		},
 	}
 	
@@ -264,17 +464,148 @@ func TestClient_convertIssueToActivity(t *testing.T) {
 	assert.Equal(t, "proj123", activity.Project.ID)
 	assert.Equal(t, "TEST", activity.Project.Key)
 	assert.Equal(t, "Test Project", activity.Project.Name)
+	assert.Equal(t, []string{"Backend", "API"}, activity.Components)
+	assert.Equal(t, []string{"security", "customer"}, activity.Labels)
 	
 	assert.Equal(t, time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC), activity.Created)
 	assert.Equal(t, time.Date(2023, 1, 2, 15, 30, 0, 0, time.UTC), activity.Updated)
+	assert.Nil(t, activity.Resolved)
+}
+
+func TestClient_convertIssueToActivity_Changelog(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Jira: struct {
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
+		}{
+			URL:      "https://test.atlassian.net",
+			Username: "testuser",
+		},
+	}
+	
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	
+	issue := &IssueResponse{
+		ID:  "12345",
+		Key: "TEST-123",
+		Fields: IssueFields{
+			Summary: "Test issue",
+			Created: "2023-01-01T10:00:00.000Z",
+			Updated: "2023-01-05T15:30:00.000Z",
+			ResolutionDate: "2023-01-04T12:00:00.000Z",
+		},
+		Changelog: &Changelog{
+			Histories: []ChangelogHistory{
+				{
+					ID:      "100",
+					Author:  UserField{AccountID: "user1", DisplayName: "User One"},
+					Created: "2023-01-03T09:00:00.000Z",
+					Items: []ChangelogItem{
+						{Field: "Sprint", FromString: "", ToString: "Sprint 1"},
+						{Field: "status", FromString: "To Do", ToString: "In Progress"},
+					},
+				},
+				{
+					ID:      "101",
+					Created: "not a timestamp",
+					Items:   []ChangelogItem{{Field: "status", FromString: "In Progress", ToString: "Done"}},
+				},
+			},
+		},
+	}
+	
+	activity, err := client.convertIssueToActivity(issue)
+	require.NoError(t, err)
+	
+	require.NotNil(t, activity.Resolved)
+	assert.Equal(t, time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC), *activity.Resolved)
+	
+	require.Len(t, activity.Changelog, 2)
+	assert.Equal(t, "Sprint", activity.Changelog[0].Field)
+	assert.Equal(t, "", activity.Changelog[0].From)
+	assert.Equal(t, "Sprint 1", activity.Changelog[0].To)
+	assert.Equal(t, "user1", activity.Changelog[0].Author.AccountID)
+	assert.Equal(t, time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC), activity.Changelog[0].Created)
+	assert.Equal(t, "status", activity.Changelog[1].Field)
+	assert.Equal(t, "In Progress", activity.Changelog[1].To)
+	
+	// The history with an unreadable timestamp is skipped with a warning
+	assert.Len(t, logger.GetEntriesByLevel(utils.LogLevelWarn), 1)
+	
+	require.Len(t, activity.StatusHistory, 1)
+	assert.Equal(t, models.StatusChange{
+		From:    "To Do",
+		To:      "In Progress",
+		Changed: time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC),
+	}, activity.StatusHistory[0])
+}
+
+func TestClient_convertIssueToActivity_StatusHistory(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Jira: struct {
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
+		}{
+			URL:      "https://test.atlassian.net",
+			Username: "testuser",
+		},
+	}
+	
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	
+	// Histories arrive newest first
+	issue := &IssueResponse{
+		ID:  "12345",
+		Key: "TEST-123",
+		Fields: IssueFields{
+			Summary: "Test issue",
+			Created: "2023-01-01T10:00:00.000Z",
+			Updated: "2023-01-05T15:30:00.000Z",
+		},
+		Changelog: &Changelog{
+			Histories: []ChangelogHistory{
+				{
+					ID:      "102",
+					Created: "2023-01-05T15:30:00.000Z",
+					Items:   []ChangelogItem{{Field: "status", FromString: "Done", ToString: "Reopened"}},
+				},
+				{
+					ID:      "101",
+					Created: "2023-01-04T12:00:00.000Z",
+					Items: []ChangelogItem{
+						{Field: "resolution", FromString: "", ToString: "Fixed"},
+						{Field: "status", FromString: "In Progress", ToString: "Done"},
+					},
+				},
+			},
+		},
+	}
+	
+	activity, err := client.convertIssueToActivity(issue)
+	require.NoError(t, err)
+	
+	assert.Len(t, activity.Changelog, 3)
+	require.Len(t, activity.StatusHistory, 2)
+	assert.Equal(t, "Done", activity.StatusHistory[0].To)
+	assert.Equal(t, "Done", activity.StatusHistory[1].From)
+	assert.Equal(t, "Reopened", activity.StatusHistory[1].To)
 }
 
 func TestClient_convertWorklogEntry(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      "https://test.atlassian.net",
 			Username: "testuser",
@@ -317,8 +648,9 @@ func TestClient_convertComment(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      "https://test.atlassian.net",
 			Username: "testuser",
@@ -559,8 +891,9 @@ func TestClient_Integration(t *testing.T) {
 	logger := utils.NewMockLogger()
 	cfg := &config.Config{
 		Jira: struct {
-			URL      string `yaml:"url"`
-			Username string `yaml:"username"`
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
 		}{
 			URL:      server.URL,
 			Username: "testuser",
@@ -598,4 +931,504 @@ func TestClient_Integration(t *testing.T) {
 	
 	// Clean up
 	authManager.GetCredentialStore().ClearAllCredentials()
-}
\ No newline at end of file
+}
+// createPagedSearchServer serves total issues in pages, recording each requested startAt
+func createPagedSearchServer(t *testing.T, total int, startAts *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		
+		var searchRequest SearchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&searchRequest))
+		*startAts = append(*startAts, searchRequest.StartAt)
+		
+		issues := []IssueResponse{}
+		for i := searchRequest.StartAt; i < total && i < searchRequest.StartAt+searchRequest.MaxResults; i++ {
+			issues = append(issues, IssueResponse{ID: fmt.Sprintf("%d", i), Key: fmt.Sprintf("TEST-%d", i)})
+		}
+		
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(SearchResult{
+			StartAt:    searchRequest.StartAt,
+			MaxResults: searchRequest.MaxResults,
+			Total:      total,
+			Issues:     issues,
+		})
+	}))
+}
+
+func newSearchTestClient(t *testing.T, serverURL string) (*Client, *security.AuthManager) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Jira: struct {
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
+		}{
+			URL:      serverURL,
+			Username: "testuser",
+		},
+	}
+	
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	err := authManager.GetCredentialStore().SetJiraCredentials(security.JiraCredentials{
+		Token: "test_token",
+	})
+	require.NoError(t, err)
+	
+	return NewClient(cfg, authManager, logger), authManager
+}
+
+func TestClient_SearchAllIssues(t *testing.T) {
+	var startAts []int
+	total := 2*SearchPageSize + 50
+	server := createPagedSearchServer(t, total, &startAts)
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	result, err := client.SearchAllIssues(context.Background(), "project = TEST", []string{"key"})
+	require.NoError(t, err)
+	
+	// Three pages were requested and concatenated in order
+	assert.Equal(t, []int{0, SearchPageSize, 2 * SearchPageSize}, startAts)
+	assert.Equal(t, total, result.Total)
+	require.Len(t, result.Issues, total)
+	for i, issue := range result.Issues {
+		assert.Equal(t, fmt.Sprintf("TEST-%d", i), issue.Key)
+	}
+}
+
+func TestClient_SearchAllIssues_LimitExceeded(t *testing.T) {
+	var startAts []int
+	server := createPagedSearchServer(t, 250, &startAts)
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	client.SetMaxSearchIssues(200)
+	
+	result, err := client.SearchAllIssues(context.Background(), "project = TEST", []string{"key"})
+	require.Error(t, err)
+	assert.Nil(t, result)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeSearchLimitExceeded, appErr.Code)
+	assert.Equal(t, 250, appErr.Context.Extra["total"])
+	assert.Equal(t, 200, appErr.Context.Extra["max_issues"])
+	
+	// The limit is detected from the first page
+	assert.Len(t, startAts, 1)
+}
+
+func TestClient_SearchAllIssues_ContextCancelled(t *testing.T) {
+	var startAts []int
+	server := createPagedSearchServer(t, 250, &startAts)
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	
+	_, err := client.SearchAllIssues(ctx, "project = TEST", []string{"key"})
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Empty(t, startAts)
+}
+
+func TestClient_convertIssueToActivity_StoryPoints(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{
+		Jira: struct {
+			URL          string            `yaml:"url"`
+			Username     string            `yaml:"username"`
+			CustomFields map[string]string `yaml:"custom_fields"`
+		}{
+			URL:      "https://test.atlassian.net",
+			Username: "testuser",
+		},
+	}
+	
+	authConfig := security.DefaultAuthConfig()
+	authManager := security.NewAuthManager(authConfig, logger)
+	client := NewClient(cfg, authManager, logger)
+	
+	data := `{"id": "1", "key": "TEST-1", "fields": {"summary": "Story", "created": "2023-01-01T10:00:00.000Z", "updated": "2023-01-02T10:00:00.000Z", "customfield_10016": 5.5, "customfield_10020": null}}`
+	var issue IssueResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &issue))
+	assert.Equal(t, "Story", issue.Fields.Summary)
+	assert.Len(t, issue.Fields.CustomFields, 2)
+	
+	// Story points are ignored until the field is configured
+	activity, err := client.convertIssueToActivity(&issue)
+	require.NoError(t, err)
+	assert.Zero(t, activity.StoryPoints)
+	assert.NotContains(t, client.getDefaultFields(), "customfield_10016")
+	
+	client.SetStoryPointsField("customfield_10016")
+	assert.Contains(t, client.getDefaultFields(), "customfield_10016")
+	
+	activity, err = client.convertIssueToActivity(&issue)
+	require.NoError(t, err)
+	assert.Equal(t, 5.5, activity.StoryPoints)
+	
+	// A null value leaves the story points unset
+	client.SetStoryPointsField("customfield_10020")
+	activity, err = client.convertIssueToActivity(&issue)
+	require.NoError(t, err)
+	assert.Zero(t, activity.StoryPoints)
+}
+
+func TestNewClient_StoryPointsFieldFromConfig(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := &config.Config{}
+	cfg.Jira.URL = "https://test.atlassian.net"
+	cfg.Jira.CustomFields = map[string]string{StoryPointsField: "customfield_10016"}
+	
+	client := NewClient(cfg, security.NewAuthManager(security.DefaultAuthConfig(), logger), logger)
+	assert.Contains(t, client.getDefaultFields(), "customfield_10016")
+	
+	data := `{"id": "1", "key": "TEST-1", "fields": {"summary": "Story", "created": "2023-01-01T10:00:00.000Z", "updated": "2023-01-02T10:00:00.000Z", "customfield_10016": 3}}`
+	var issue IssueResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &issue))
+	
+	activity, err := client.convertIssueToActivity(&issue)
+	require.NoError(t, err)
+	assert.Equal(t, 3.0, activity.StoryPoints)
+}
+
+func TestClient_convertIssueToActivity_CustomFields(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	cfg.Jira.URL = "https://test.atlassian.net"
+	cfg.Jira.CustomFields = map[string]string{
+		"story_points": "customfield_10016",
+		"team":         "customfield_10001",
+		"sprints":      "customfield_10020",
+		"epic_link":    "customfield_10014",
+		"reviewer":     "customfield_10050",
+	}
+	
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	client := NewClient(cfg, authManager, logger)
+	
+	// Configured fields are requested once each after the standard fields, story points first
+	fields := client.getDefaultFields()
+	assert.Equal(t, []string{"customfield_10016", "customfield_10014", "customfield_10050", "customfield_10020", "customfield_10001"},
+		fields[len(fields)-5:])
+	
+	data := `{"id": "1", "key": "TEST-1", "fields": {
+		"summary": "Story", "created": "2023-01-01T10:00:00.000Z", "updated": "2023-01-02T10:00:00.000Z",
+		"customfield_10016": 8,
+		"customfield_10001": {"self": "https://test.atlassian.net/rest/api/2/customFieldOption/10100", "value": "Platform", "id": "10100"},
+		"customfield_10020": [{"id": 7, "name": "Sprint 7", "state": "closed"}, {"id": 8, "name": "Sprint 8", "state": "active"}],
+		"customfield_10014": "PROJ-100",
+		"customfield_10050": null,
+		"customfield_10099": "not configured"
+	}}`
+	var issue IssueResponse
+	require.NoError(t, json.Unmarshal([]byte(data), &issue))
+	
+	activity, err := client.convertIssueToActivity(&issue)
+	require.NoError(t, err)
+	
+	assert.Equal(t, map[string]interface{}{
+		"story_points": float64(8),
+		"team":         "Platform",
+		"sprints":      []interface{}{"Sprint 7", "Sprint 8"},
+		"epic_link":    "PROJ-100",
+	}, activity.CustomFields)
+	assert.Equal(t, 8.0, activity.StoryPoints)
+}
+
+func TestCustomFieldValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected interface{}
+	}{
+		{"number", `5.5`, 5.5},
+		{"string", `"PROJ-1"`, "PROJ-1"},
+		{"boolean", `true`, true},
+		{"null", `null`, nil},
+		{"select option", `{"value": "High", "id": "1"}`, "High"},
+		{"user", `{"accountId": "abc", "displayName": "Alice"}`, "Alice"},
+		{"object without display value", `{"id": 3, "rank": 2}`, map[string]interface{}{"id": float64(3), "rank": float64(2)}},
+		{"multi select", `[{"value": "iOS"}, {"value": "Android"}]`, []interface{}{"iOS", "Android"}},
+		{"empty array", `[]`, nil},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := customFieldValue(json.RawMessage(tt.raw))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+// sampleChangelogIssue is an issue response embedding the first page of a three entry changelog
+const sampleChangelogIssue = `{
+	"id": "10001",
+	"key": "TEST-1",
+	"fields": {"created": "2023-01-01T10:00:00.000+0000"},
+	"changelog": {
+		"startAt": 0,
+		"maxResults": 1,
+		"total": 3,
+		"histories": [
+			{
+				"id": "100",
+				"author": {"accountId": "user1", "displayName": "User One"},
+				"created": "2023-01-02T09:00:00.000+0000",
+				"items": [
+					{"field": "status", "fieldtype": "jira", "from": "1", "fromString": "To Do", "to": "3", "toString": "In Progress"},
+					{"field": "assignee", "fieldtype": "jira", "from": null, "fromString": null, "to": "user1", "toString": "User One"}
+				]
+			}
+		]
+	}
+}`
+
+// sampleChangelogPage is the remaining page served by the paginated changelog endpoint
+const sampleChangelogPage = `{
+	"startAt": 1,
+	"maxResults": 100,
+	"total": 3,
+	"isLast": true,
+	"values": [
+		{
+			"id": "101",
+			"author": {"accountId": "user1", "displayName": "User One"},
+			"created": "2023-01-03T17:30:00.000+0000",
+			"items": [{"field": "status", "fieldtype": "jira", "fromString": "In Progress", "toString": "Done"}]
+		},
+		{
+			"id": "102",
+			"author": {"accountId": "user2", "displayName": "User Two"},
+			"created": "2023-01-05T11:00:00.000+0000",
+			"items": [{"field": "status", "fieldtype": "jira", "fromString": "Done", "toString": "Reopened"}]
+		}
+	]
+}`
+
+func TestClient_GetIssueChangelog(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/rest/api/2/issue/TEST-1":
+			assert.Equal(t, "changelog", r.URL.Query().Get("expand"))
+			fmt.Fprint(w, sampleChangelogIssue)
+		case "/rest/api/2/issue/TEST-1/changelog":
+			assert.Equal(t, "1", r.URL.Query().Get("startAt"))
+			fmt.Fprint(w, sampleChangelogPage)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	changelog, err := client.GetIssueChangelog(context.Background(), "TEST-1")
+	require.NoError(t, err)
+	
+	// The embedded page is followed by the paginated endpoint
+	assert.Len(t, paths, 2)
+	require.Len(t, changelog, 4)
+	assert.Equal(t, "assignee", changelog[1].Field)
+	assert.Equal(t, "User One", changelog[1].To)
+	assert.Equal(t, "user2", changelog[3].Author.AccountID)
+	
+	history := models.StatusChanges(changelog)
+	assert.Equal(t, []models.StatusChange{
+		{From: "To Do", To: "In Progress", Changed: time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{From: "In Progress", To: "Done", Changed: time.Date(2023, 1, 3, 17, 30, 0, 0, time.UTC)},
+		{From: "Done", To: "Reopened", Changed: time.Date(2023, 1, 5, 11, 0, 0, 0, time.UTC)},
+	}, normalizeStatusChanges(history))
+}
+
+func TestClient_GetIssueChangelog_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	_, err := client.GetIssueChangelog(context.Background(), "TEST-404")
+	require.Error(t, err)
+	assert.Equal(t, utils.ErrorCodeAPINotFound, err.(*utils.AppError).Code)
+}
+
+func TestClient_convertSearchResultToActivities_Concurrent(t *testing.T) {
+	const issueCount = 8
+	const workers = 3
+	
+	var mu sync.Mutex
+	requests, inFlight, maxInFlight := 0, 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		
+		// Hold each request briefly so the workers overlap
+		time.Sleep(10 * time.Millisecond)
+		
+		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/"), "/")
+		require.Len(t, parts, 2)
+		issueKey := parts[0]
+		switch parts[1] {
+		case "worklog":
+			var number int
+			fmt.Sscanf(issueKey, "TEST-%d", &number)
+			json.NewEncoder(w).Encode(WorklogResponse{Total: 1, Worklogs: []WorklogEntry{{
+				ID:               issueKey + "-worklog",
+				TimeSpentSeconds: int64(number) * 60,
+				Started:          "2023-01-02T10:00:00.000Z",
+				Created:          "2023-01-02T10:00:00.000Z",
+				Updated:          "2023-01-02T10:00:00.000Z",
+			}}})
+		case "comment":
+			json.NewEncoder(w).Encode(CommentsResponse{Total: 1, Comments: []CommentEntry{{
+				ID:      issueKey + "-comment",
+				Body:    "Comment on " + issueKey,
+				Created: "2023-01-02T10:00:00.000Z",
+				Updated: "2023-01-02T10:00:00.000Z",
+			}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	client.SetDetailsConcurrency(workers)
+	
+	searchResult := &SearchResult{Total: issueCount}
+	for i := 1; i <= issueCount; i++ {
+		searchResult.Issues = append(searchResult.Issues, IssueResponse{
+			ID:  fmt.Sprintf("%d", i),
+			Key: fmt.Sprintf("TEST-%d", i),
+			Fields: IssueFields{
+				Summary: fmt.Sprintf("Issue %d", i),
+				Created: "2023-01-01T10:00:00.000Z",
+				Updated: "2023-01-02T15:30:00.000Z",
+			},
+		})
+	}
+	
+	activities, err := client.convertSearchResultToActivities(context.Background(), searchResult)
+	require.NoError(t, err)
+	
+	// Every activity keeps its position and carries its own worklog and comments
+	require.Len(t, activities, issueCount)
+	for i, activity := range activities {
+		key := fmt.Sprintf("TEST-%d", i+1)
+		assert.Equal(t, key, activity.Key)
+		require.Len(t, activity.Worklog, 1)
+		assert.Equal(t, key+"-worklog", activity.Worklog[0].ID)
+		assert.Equal(t, int64(i+1)*60, activity.TimeSpent)
+		require.Len(t, activity.Comments, 1)
+		assert.Equal(t, "Comment on "+key, activity.Comments[0].Body)
+	}
+	
+	// Requests overlapped without exceeding the pool, and each one took a rate limiter slot
+	assert.Equal(t, 2*issueCount, requests)
+	assert.Greater(t, maxInFlight, 1)
+	assert.LessOrEqual(t, maxInFlight, workers)
+	assert.Equal(t, requests, client.rateLimiter.GetCurrentRequestCount())
+}
+
+func TestClient_convertSearchResultToActivities_Canceled(t *testing.T) {
+	const issueCount = 20
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		
+		// The first request cancels the run, as a user stopping it would
+		cancel()
+		json.NewEncoder(w).Encode(WorklogResponse{})
+	}))
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	client.SetDetailsConcurrency(1)
+	
+	searchResult := &SearchResult{Total: issueCount}
+	for i := 1; i <= issueCount; i++ {
+		searchResult.Issues = append(searchResult.Issues, IssueResponse{
+			ID:     fmt.Sprintf("%d", i),
+			Key:    fmt.Sprintf("TEST-%d", i),
+			Fields: IssueFields{Summary: fmt.Sprintf("Issue %d", i)},
+		})
+	}
+	
+	activities, err := client.convertSearchResultToActivities(ctx, searchResult)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, activities)
+	
+	// The remaining issues are not handed out once the context is done
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Less(t, requests, 2*issueCount)
+}
+
+func TestClient_SearchIssues_ExpandChangelog(t *testing.T) {
+	var expands [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var searchRequest SearchRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&searchRequest))
+		expands = append(expands, searchRequest.Expand)
+		json.NewEncoder(w).Encode(SearchResult{})
+	}))
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	_, err := client.SearchAllIssues(context.Background(), "project = TEST", []string{"key"})
+	require.NoError(t, err)
+	
+	client.SetExpandChangelog(false)
+	_, err = client.SearchAllIssues(context.Background(), "project = TEST", []string{"key"})
+	require.NoError(t, err)
+	
+	require.Len(t, expands, 2)
+	assert.Equal(t, []string{"changelog"}, expands[0])
+	assert.Empty(t, expands[1])
+}
+
+// normalizeStatusChanges converts change timestamps to UTC so they compare by value
+func normalizeStatusChanges(changes []models.StatusChange) []models.StatusChange {
+	for i := range changes {
+		changes[i].Changed = changes[i].Changed.UTC()
+	}
+	return changes
+}
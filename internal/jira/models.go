@@ -4,7 +4,6 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -36,6 +35,7 @@ type IssueResponse struct {
 	ID     string     `json:"id"`
 	Key    string     `json:"key"`
 	Fields IssueFields `json:"fields"`
+	Changelog *Changelog `json:"changelog,omitempty"`
 }
 
 // IssueFields represents the fields of a Jira issue
@@ -49,8 +49,72 @@ type IssueFields struct {
 	Assignee    UserField   `json:"assignee"`
 	Created     string      `json:"created"`
 	Updated     string      `json:"updated"`
+	ResolutionDate string   `json:"resolutiondate,omitempty"`
 	Project     ProjectField `json:"project"`
+	Components  []ComponentField `json:"components,omitempty"`
+	Labels      []string    `json:"labels,omitempty"`
 	TimeTracking TimeTracking `json:"timetracking"`
+	CustomFields map[string]json.RawMessage `json:"-"` // Raw values of customfield_* fields
+}
+
+// UnmarshalJSON decodes the known issue fields and keeps custom fields in their raw form
+func (f *IssueFields) UnmarshalJSON(data []byte) error {
+	type issueFields IssueFields
+	if err := json.Unmarshal(data, (*issueFields)(f)); err != nil {
+		return err
+	}
+	
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	
+	for name, value := range raw {
+		if !strings.HasPrefix(name, "customfield_") {
+			continue
+		}
+		if f.CustomFields == nil {
+			f.CustomFields = make(map[string]json.RawMessage)
+		}
+		f.CustomFields[name] = value
+	}
+	
+	return nil
+}
+
+// Changelog represents the expanded change history of an issue
+type Changelog struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	Histories  []ChangelogHistory `json:"histories"`
+}
+
+// ChangelogPage represents a page of the paginated issue changelog endpoint
+type ChangelogPage struct {
+	StartAt    int                `json:"startAt"`
+	MaxResults int                `json:"maxResults"`
+	Total      int                `json:"total"`
+	IsLast     bool               `json:"isLast"`
+	Values     []ChangelogHistory `json:"values"`
+}
+
+// ChangelogHistory represents a group of field changes made together
+type ChangelogHistory struct {
+	ID      string          `json:"id"`
+	Author  UserField       `json:"author"`
+	Created string          `json:"created"`
+	Items   []ChangelogItem `json:"items"`
+}
+
+// ChangelogItem represents a single field change
+type ChangelogItem struct {
+	Field      string `json:"field"`
+	FieldType  string `json:"fieldtype"`
+	From       string `json:"from"`
+	FromString string `json:"fromString"`
+	To         string `json:"to"`
+	ToString   string `json:"toString"`
 }
 
 // IssueType represents an issue type
@@ -90,6 +154,12 @@ type ProjectField struct {
 	Lead        UserField `json:"lead"`
 }
 
+// ComponentField represents a component field in Jira
+type ComponentField struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // TimeTracking represents time tracking information
 type TimeTracking struct {
 	TimeSpent         string `json:"timeSpent"`
@@ -167,6 +237,14 @@ func (c *Client) convertIssueToActivity(issue *IssueResponse) (*models.Activity,
 		return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Failed to parse updated date", err)
 	}
 	
+	if issue.Fields.ResolutionDate != "" {
+		resolved, err := parseJiraTimestamp(issue.Fields.ResolutionDate)
+		if err != nil {
+			return nil, utils.NewAppError(utils.ErrorCodeDataInvalid, "Failed to parse resolution date", err)
+		}
+		activity.Resolved = &resolved
+	}
+	
 	// Convert users
 	activity.Reporter = convertUserField(issue.Fields.Reporter)
 	activity.Assignee = convertUserField(issue.Fields.Assignee)
@@ -174,9 +252,126 @@ func (c *Client) convertIssueToActivity(issue *IssueResponse) (*models.Activity,
 	// Convert project
 	activity.Project = convertProjectField(issue.Fields.Project)
 	
+	// Convert components and labels
+	for _, component := range issue.Fields.Components {
+		activity.Components = append(activity.Components, component.Name)
+	}
+	activity.Labels = issue.Fields.Labels
+	
+	// Convert story points from the configured custom field
+	if c.storyPointsField != "" {
+		if value, ok := issue.Fields.CustomFields[c.storyPointsField]; ok && string(value) != "null" {
+			if err := json.Unmarshal(value, &activity.StoryPoints); err != nil {
+				c.logger.Warn("Ignoring story points that are not a number",
+					utils.NewField("issue_key", issue.Key),
+					utils.NewField("field", c.storyPointsField),
+				)
+			}
+		}
+	}
+	
+	// Convert the configured custom fields, keyed by their logical names
+	for name, fieldID := range c.customFields {
+		raw, ok := issue.Fields.CustomFields[fieldID]
+		if !ok {
+			continue
+		}
+		value, err := customFieldValue(raw)
+		if err != nil {
+			c.logger.Warn("Ignoring custom field that is not valid JSON",
+				utils.NewField("issue_key", issue.Key),
+				utils.NewField("field", fieldID),
+			)
+			continue
+		}
+		if value == nil {
+			continue
+		}
+		if activity.CustomFields == nil {
+			activity.CustomFields = make(map[string]interface{})
+		}
+		activity.CustomFields[name] = value
+	}
+	
+	// Convert change history, skipping entries with unreadable timestamps
+	if issue.Changelog != nil {
+		activity.Changelog = c.convertChangelog(issue.Key, issue.Changelog.Histories)
+		activity.StatusHistory = models.StatusChanges(activity.Changelog)
+	}
+	
 	return activity, nil
 }
 
+// customFieldDisplayKeys are the properties that hold the display value of object custom
+// fields, in order of preference: select options use "value", teams, versions and sprints
+// "name", and users "displayName"
+var customFieldDisplayKeys = []string{"value", "name", "displayName", "key"}
+
+// customFieldValue decodes a raw custom field value. Scalars are kept as numbers, strings and
+// booleans, objects are reduced to their display value when they have one, and arrays are
+// converted element by element. Null and empty arrays yield nil.
+func customFieldValue(raw json.RawMessage) (interface{}, error) {
+	var value interface{}
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return nil, err
+	}
+	return simplifyCustomFieldValue(value), nil
+}
+
+// simplifyCustomFieldValue reduces a decoded custom field value as customFieldValue describes
+func simplifyCustomFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, key := range customFieldDisplayKeys {
+			if display, ok := v[key]; ok && display != nil {
+				return display
+			}
+		}
+		return v
+	case []interface{}:
+		var items []interface{}
+		for _, item := range v {
+			if item = simplifyCustomFieldValue(item); item != nil {
+				items = append(items, item)
+			}
+		}
+		if len(items) == 0 {
+			return nil
+		}
+		return items
+	default:
+		return v
+	}
+}
+
+// convertChangelog flattens changelog histories into one entry per field change, skipping
+// histories with unreadable timestamps
+func (c *Client) convertChangelog(issueKey string, histories []ChangelogHistory) []models.ChangelogEntry {
+	var entries []models.ChangelogEntry
+	for _, history := range histories {
+		created, err := parseJiraTimestamp(history.Created)
+		if err != nil {
+			c.logger.Warn("Skipping changelog entry with invalid timestamp",
+				utils.NewField("issue_key", issueKey),
+				utils.NewField("history_id", history.ID),
+			)
+			continue
+		}
+		
+		for _, item := range history.Items {
+			entries = append(entries, models.ChangelogEntry{
+				Field:   item.Field,
+				From:    item.FromString,
+				To:      item.ToString,
+				Author:  convertUserField(history.Author),
+				Created: created,
+			})
+		}
+	}
+	
+	return entries
+}
+
 // convertWorklogEntry converts a Jira worklog entry to a worklog model
 func (c *Client) convertWorklogEntry(entry *WorklogEntry) (*models.Worklog, error) {
 	worklog := &models.Worklog{
@@ -385,7 +580,7 @@ func (c *Client) GetServerInfo(ctx context.Context) (*ServerInfo, error) {
 			return c.handleErrorResponse(resp, "Failed to get server info")
 		}
 		
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to read server info response", err)
 		}
@@ -425,7 +620,7 @@ func (c *Client) GetCurrentUser(ctx context.Context) (*UserInfo, error) {
 			return c.handleErrorResponse(resp, "Failed to get current user")
 		}
 		
-		body, err := io.ReadAll(resp.Body)
+		body, err := c.httpClient.ReadBody(resp)
 		if err != nil {
 			return utils.NewAppError(utils.ErrorCodeJiraError, "Failed to read current user response", err)
 		}
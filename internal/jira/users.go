@@ -0,0 +1,111 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// accountIDPattern matches Jira Cloud account IDs, either the legacy 24 hex digit form or the
+// "<site>:<uuid>" form, which are used as given rather than searched for
+var accountIDPattern = regexp.MustCompile(`^([0-9a-fA-F]{24}|[0-9]+:[0-9a-fA-F-]{36})$`)
+
+// ResolveUsers maps user identifiers, such as email addresses or display names, to Jira account
+// IDs, keeping their order. Identifiers that are already account IDs are passed through.
+// Others are looked up with the user search endpoint and must match exactly one user's email
+// address or display name, ignoring case. Resolved identifiers are cached for the lifetime of
+// the client. The error lists every identifier that could not be resolved.
+func (c *Client) ResolveUsers(ctx context.Context, identifiers []string) ([]string, error) {
+	accountIDs := make([]string, 0, len(identifiers))
+	var unresolved []string
+	
+	for _, identifier := range identifiers {
+		identifier = strings.TrimSpace(identifier)
+		if identifier == "" {
+			continue
+		}
+		
+		if accountIDPattern.MatchString(identifier) {
+			accountIDs = append(accountIDs, identifier)
+			continue
+		}
+		
+		if accountID, ok := c.cachedAccountID(identifier); ok {
+			accountIDs = append(accountIDs, accountID)
+			continue
+		}
+		
+		accountID, err := c.searchAccountID(ctx, identifier)
+		if err != nil {
+			return nil, err
+		}
+		if accountID == "" {
+			unresolved = append(unresolved, identifier)
+			continue
+		}
+		
+		c.cacheAccountID(identifier, accountID)
+		accountIDs = append(accountIDs, accountID)
+	}
+	
+	if len(unresolved) > 0 {
+		return nil, utils.NewAppError(utils.ErrorCodeValidationError,
+			fmt.Sprintf("Could not resolve Jira users: %s", strings.Join(unresolved, ", ")), nil).
+			WithService("jira").
+			WithExtra("unresolved_users", unresolved)
+	}
+	
+	return accountIDs, nil
+}
+
+// searchAccountID looks up identifier with the user search endpoint, returning an empty
+// account ID when no single user matches it exactly
+func (c *Client) searchAccountID(ctx context.Context, identifier string) (string, error) {
+	var users []UserField
+	endpoint := "/rest/api/2/user/search?query=" + url.QueryEscape(identifier)
+	if err := c.getJSON(ctx, endpoint, "Failed to search users", &users); err != nil {
+		return "", err
+	}
+	
+	var matches []string
+	for _, user := range users {
+		if user.AccountID == "" {
+			continue
+		}
+		if strings.EqualFold(user.EmailAddress, identifier) || strings.EqualFold(user.DisplayName, identifier) {
+			matches = append(matches, user.AccountID)
+		}
+	}
+	
+	if len(matches) != 1 {
+		c.log(ctx).Warn("Jira user not resolved",
+			utils.NewField("identifier", identifier),
+			utils.NewField("search_results", len(users)),
+			utils.NewField("exact_matches", len(matches)),
+		)
+		return "", nil
+	}
+	
+	return matches[0], nil
+}
+
+// cachedAccountID returns the account ID previously resolved for identifier
+func (c *Client) cachedAccountID(identifier string) (string, bool) {
+	c.userCacheMu.Lock()
+	defer c.userCacheMu.Unlock()
+	
+	accountID, ok := c.userCache[strings.ToLower(identifier)]
+	return accountID, ok
+}
+
+// cacheAccountID records the account ID resolved for identifier
+func (c *Client) cacheAccountID(identifier, accountID string) {
+	c.userCacheMu.Lock()
+	defer c.userCacheMu.Unlock()
+	
+	c.userCache[strings.ToLower(identifier)] = accountID
+}
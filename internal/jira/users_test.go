@@ -0,0 +1,130 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockUserDirectory is the set of users the mock user search endpoint matches against
+var mockUserDirectory = []UserField{
+	{AccountID: "5b10a2844c20165700ede21g", DisplayName: "Alice Smith", EmailAddress: "alice@example.com", Active: true},
+	{AccountID: "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077", DisplayName: "Bob Jones", EmailAddress: "bob@example.com", Active: true},
+	{AccountID: "5b10ac8d82e05b22cc7d4ef5", DisplayName: "Sam Lee", EmailAddress: "sam.lee@example.com", Active: true},
+	{AccountID: "5b10ac8d82e05b22cc7d4ef6", DisplayName: "Sam Lee", EmailAddress: "sam.lee2@example.com", Active: true},
+}
+
+// createUserSearchServer serves user searches by substring over mockUserDirectory, recording
+// each query, and records the JQL of issue searches
+func createUserSearchServer(t *testing.T, queries *[]string, jqls *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/user/search":
+			query := r.URL.Query().Get("query")
+			*queries = append(*queries, query)
+			
+			users := []UserField{}
+			for _, user := range mockUserDirectory {
+				if strings.Contains(strings.ToLower(user.DisplayName+" "+user.EmailAddress), strings.ToLower(query)) {
+					users = append(users, user)
+				}
+			}
+			json.NewEncoder(w).Encode(users)
+		case "/rest/api/2/search":
+			var searchRequest SearchRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&searchRequest))
+			*jqls = append(*jqls, searchRequest.JQL)
+			json.NewEncoder(w).Encode(SearchResult{Issues: []IssueResponse{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_ResolveUsers(t *testing.T) {
+	var queries, jqls []string
+	server := createUserSearchServer(t, &queries, &jqls)
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	accountIDs, err := client.ResolveUsers(context.Background(), []string{
+		"ALICE@example.com",
+		"Bob Jones",
+		"5b10ac8d82e05b22cc7d4ef5", // Already an account ID
+		" ",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"5b10a2844c20165700ede21g",
+		"557058:f58131cb-b67d-43c7-b30d-6b58d40bd077",
+		"5b10ac8d82e05b22cc7d4ef5",
+	}, accountIDs)
+	assert.Equal(t, []string{"ALICE@example.com", "Bob Jones"}, queries)
+	
+	// Resolved identifiers are served from the cache, ignoring case
+	accountIDs, err = client.ResolveUsers(context.Background(), []string{"alice@example.com", "bob jones"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"5b10a2844c20165700ede21g", "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077"}, accountIDs)
+	assert.Len(t, queries, 2)
+}
+
+func TestClient_ResolveUsers_Unresolved(t *testing.T) {
+	var queries, jqls []string
+	server := createUserSearchServer(t, &queries, &jqls)
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	// A partial match, an unknown user and an ambiguous display name all fail to resolve
+	_, err := client.ResolveUsers(context.Background(), []string{"Alice", "alice@example.com", "carol@example.com", "Sam Lee"})
+	require.Error(t, err)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeValidationError, appErr.Code)
+	assert.Equal(t, "Could not resolve Jira users: Alice, carol@example.com, Sam Lee", appErr.Message)
+	assert.Equal(t, []string{"Alice", "carol@example.com", "Sam Lee"}, appErr.Context.Extra["unresolved_users"])
+	
+	// Unresolved identifiers are not cached, so a later call searches again
+	_, err = client.ResolveUsers(context.Background(), []string{"carol@example.com"})
+	require.Error(t, err)
+	assert.Equal(t, 5, len(queries))
+}
+
+func TestClient_GetUserActivities_ResolvesUsers(t *testing.T) {
+	var queries, jqls []string
+	server := createUserSearchServer(t, &queries, &jqls)
+	defer server.Close()
+	
+	client, authManager := newSearchTestClient(t, server.URL)
+	defer authManager.GetCredentialStore().ClearAllCredentials()
+	
+	timeRange := config.TimeRange{
+		Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC),
+	}
+	
+	_, err := client.GetUserActivities(context.Background(), []string{"alice@example.com"}, timeRange)
+	require.NoError(t, err)
+	require.Len(t, jqls, 1)
+	assert.Contains(t, jqls[0], "assignee = '5b10a2844c20165700ede21g' OR reporter = '5b10a2844c20165700ede21g'")
+	assert.NotContains(t, jqls[0], "alice@example.com")
+	
+	// Unresolvable users fail before any issue search
+	_, err = client.GetUserActivities(context.Background(), []string{"nobody@example.com"}, timeRange)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nobody@example.com")
+	assert.Len(t, jqls, 1)
+}
@@ -418,6 +418,30 @@ func (m *MockGoogleDocsClient) ShareDocument(ctx context.Context, documentID str
 	return nil
 }
 
+func (m *MockGoogleDocsClient) DeleteDocument(ctx context.Context, documentID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	m.lastDocumentID = documentID
+	
+	if err := m.simulateCommonBehavior(ctx); err != nil {
+		return err
+	}
+	
+	if _, exists := m.documents[documentID]; !exists {
+		return utils.NewAppError(utils.ErrorCodeGoogleError, "Document not found", nil)
+	}
+	
+	delete(m.documents, documentID)
+	delete(m.sharedWith, documentID)
+	
+	m.logger.Info("Mock Google Docs document deleted",
+		utils.NewField("document_id", documentID),
+	)
+	
+	return nil
+}
+
 func (m *MockGoogleDocsClient) ValidateCredentials(ctx context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
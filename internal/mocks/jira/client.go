@@ -309,6 +309,10 @@ func (m *MockJiraClient) SearchIssues(ctx context.Context, jql string, fields []
 	return result, nil
 }
 
+func (m *MockJiraClient) SearchAllIssues(ctx context.Context, jql string, fields []string) (*jira.SearchResult, error) {
+	return m.SearchIssues(ctx, jql, fields, 0, m.searchResults.Total)
+}
+
 func (m *MockJiraClient) GetIssue(ctx context.Context, issueKey string, fields []string) (*models.Activity, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -418,5 +422,30 @@ func (m *MockJiraClient) GetComments(ctx context.Context, issueKey string) ([]mo
 	return filteredComments, nil
 }
 
+func (m *MockJiraClient) GetIssueChangelog(ctx context.Context, issueKey string) ([]models.ChangelogEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
+	m.issueCalls++
+	m.lastIssueKey = issueKey
+	
+	if err := m.simulateCommonBehavior(ctx); err != nil {
+		return nil, err
+	}
+	
+	if m.shouldFailIssue {
+		return nil, utils.NewAppError(utils.ErrorCodeJiraError, "Mock changelog retrieval failed", nil)
+	}
+	
+	// Return the changelog of the matching mock activity
+	for _, activity := range m.userActivities {
+		if activity.Key == issueKey {
+			return activity.Changelog, nil
+		}
+	}
+	
+	return nil, nil
+}
+
 // Verify that MockJiraClient implements the JiraClientInterface
 var _ jira.JiraClientInterface = (*MockJiraClient)(nil)
\ No newline at end of file
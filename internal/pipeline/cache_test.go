@@ -0,0 +1,47 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline_Run_SummaryCacheHit(t *testing.T) {
+	logger := utils.NewMockLogger()
+	geminiClient := &fakeGeminiClient{cached: true}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: geminiClient,
+		DocsClient:   &fakeDocsClient{},
+		RunLocks:     NewRunLocks(),
+	}, logger)
+	
+	// A summary the client served from its cache is reported and still published
+	result, err := p.Run(context.Background(), createTestOptions())
+	require.NoError(t, err)
+	assert.True(t, result.SummaryCacheHit)
+	assert.True(t, result.AISummary.Cached)
+	assert.Equal(t, "doc-1", result.Document.DocumentID)
+}
+
+func TestPipeline_Run_SummaryCacheDisabled(t *testing.T) {
+	logger := utils.NewMockLogger()
+	geminiClient := &fakeGeminiClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: geminiClient,
+		DocsClient:   &fakeDocsClient{},
+		RunLocks:     NewRunLocks(),
+	}, logger)
+	
+	opts := createTestOptions()
+	for i := 0; i < 2; i++ {
+		result, err := p.Run(context.Background(), opts)
+		require.NoError(t, err)
+		assert.False(t, result.SummaryCacheHit)
+	}
+	assert.Equal(t, 2, geminiClient.calls)
+}
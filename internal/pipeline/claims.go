@@ -0,0 +1,192 @@
+package pipeline
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/company/eesa/internal/processor"
+)
+
+// DefaultClaimTolerance is the allowed difference between a figure in the AI summary and a
+// computed metric, which covers figures the model rounded to a whole number
+const DefaultClaimTolerance = 0.5
+
+// claimContextRadius is how many characters around a flagged figure are kept for context
+const claimContextRadius = 40
+
+// numberPattern matches plain numbers and percentages such as 12, 1,024, 87.5 and 40%
+var numberPattern = regexp.MustCompile(`\d[\d,]*(?:\.\d+)?(\s*%)?`)
+
+// ClaimDiscrepancy is a figure in the AI summary that matches none of the computed metrics
+type ClaimDiscrepancy struct {
+	Claim   string  `json:"claim"`   // The figure as written, e.g. "90%"
+	Value   float64 `json:"value"`
+	Percent bool    `json:"percent"`
+	Context string  `json:"context"` // Text surrounding the figure
+}
+
+// CheckNumericClaims extracts the figures stated in text and returns those that are not within
+// tolerance of a metric computed by the processor. Percentages are compared against rates and
+// shares, plain numbers against every metric. Dates, years, issue keys, list markers and
+// figures followed directly by letters such as "Q1" or "2nd" are not treated as claims.
+// A tolerance of zero or less uses DefaultClaimTolerance.
+func CheckNumericClaims(text string, metrics *processor.ProcessingResult, tolerance float64) []ClaimDiscrepancy {
+	if metrics == nil {
+		return nil
+	}
+	if tolerance <= 0 {
+		tolerance = DefaultClaimTolerance
+	}
+	
+	counts, percentages := knownFigures(metrics)
+	all := append(append([]float64{}, counts...), percentages...)
+	
+	var discrepancies []ClaimDiscrepancy
+	for _, match := range numberPattern.FindAllStringSubmatchIndex(text, -1) {
+		start, end := match[0], match[1]
+		percent := match[2] >= 0
+		digitsEnd := end
+		if percent {
+			digitsEnd = match[2]
+		}
+		
+		digits := strings.TrimRight(text[start:digitsEnd], ",")
+		if !isClaimFigure(text, start, start+len(digits), percent) {
+			continue
+		}
+		
+		value, err := strconv.ParseFloat(strings.ReplaceAll(digits, ",", ""), 64)
+		if err != nil {
+			continue
+		}
+		if !percent && value >= 1900 && value <= 2100 && !strings.Contains(digits, ".") {
+			continue // A year
+		}
+		
+		candidates := all
+		if percent {
+			candidates = percentages
+		}
+		if matchesFigure(value, candidates, tolerance) {
+			continue
+		}
+		
+		claimEnd := start + len(digits)
+		if percent {
+			claimEnd = end
+		}
+		discrepancies = append(discrepancies, ClaimDiscrepancy{
+			Claim:   text[start:claimEnd],
+			Value:   value,
+			Percent: percent,
+			Context: claimContext(text, start, claimEnd),
+		})
+	}
+	
+	return discrepancies
+}
+
+// isClaimFigure reports whether the number at text[start:end] reads as a stated quantity
+func isClaimFigure(text string, start, end int, percent bool) bool {
+	if start > 0 {
+		previous, _ := utf8.DecodeLastRuneInString(text[:start])
+		if unicode.IsLetter(previous) || strings.ContainsRune("-/:.#", previous) {
+			return false // Part of an issue key, date, time, version or reference
+		}
+	}
+	
+	if percent || end >= len(text) {
+		return true
+	}
+	
+	next, _ := utf8.DecodeRuneInString(text[end:])
+	if unicode.IsLetter(next) || strings.ContainsRune("-/:", next) {
+		return false
+	}
+	
+	// Numbered list markers such as "1." or "2)" at the start of a line
+	if next == '.' || next == ')' {
+		lineStart := strings.LastIndex(text[:start], "\n") + 1
+		if strings.TrimSpace(text[lineStart:start]) == "" {
+			return false
+		}
+	}
+	
+	return true
+}
+
+// matchesFigure reports whether value is within tolerance of any candidate
+func matchesFigure(value float64, candidates []float64, tolerance float64) bool {
+	for _, candidate := range candidates {
+		if math.Abs(value-candidate) <= tolerance {
+			return true
+		}
+	}
+	return false
+}
+
+// claimContext returns the text around a figure with whitespace collapsed
+func claimContext(text string, start, end int) string {
+	from := start - claimContextRadius
+	if from < 0 {
+		from = 0
+	}
+	to := end + claimContextRadius
+	if to > len(text) {
+		to = len(text)
+	}
+	
+	context := strings.Join(strings.Fields(text[from:to]), " ")
+	return strings.TrimSpace(context)
+}
+
+// knownFigures collects the counts and percentages computed by the processor
+func knownFigures(metrics *processor.ProcessingResult) (counts, percentages []float64) {
+	summary := metrics.Summary
+	completed := math.Round(float64(summary.TotalActivities) * summary.CompletionRate / 100)
+	counts = append(counts,
+		float64(summary.TotalActivities),
+		float64(summary.TotalUsers),
+		completed,
+		float64(summary.TotalActivities)-completed,
+		float64(summary.ReopenCount),
+		summary.ProductivityScore,
+		float64(summary.TotalTimeSpent)/3600,
+	)
+	percentages = append(percentages, summary.CompletionRate, summary.ReworkRate)
+	
+	for _, user := range metrics.UserMetrics {
+		counts = append(counts, float64(user.TotalActivities), float64(user.CompletedActivities),
+			float64(user.TotalTimeSpent)/3600, float64(user.ReopenCount))
+		percentages = append(percentages, user.CompletionRate, user.ReworkRate)
+	}
+	for _, priority := range metrics.PriorityBreakdown {
+		counts = append(counts, float64(priority.Count), float64(priority.CompletedCount))
+		percentages = append(percentages, priority.CompletionRate)
+	}
+	for _, status := range metrics.StatusBreakdown {
+		counts = append(counts, float64(status.Count), float64(status.RecentChanges))
+	}
+	for _, issueType := range metrics.TypeBreakdown {
+		counts = append(counts, float64(issueType.Count), float64(issueType.CompletedCount))
+		percentages = append(percentages, issueType.CompletionRate, issueType.Share)
+	}
+	for _, breakdown := range []map[string]processor.GroupMetrics{metrics.ProjectBreakdown, metrics.ComponentBreakdown, metrics.LabelBreakdown} {
+		for _, group := range breakdown {
+			counts = append(counts, float64(group.Count), float64(group.CompletedCount))
+			percentages = append(percentages, group.CompletionRate, group.Share)
+		}
+	}
+	if velocity := metrics.VelocityMetrics; velocity != nil {
+		counts = append(counts, velocity.CurrentVelocity, velocity.AverageVelocity)
+		for _, userVelocity := range velocity.UserVelocities {
+			counts = append(counts, userVelocity)
+		}
+	}
+	
+	return counts, percentages
+}
@@ -0,0 +1,115 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func claimTestMetrics() *processor.ProcessingResult {
+	return &processor.ProcessingResult{
+		Summary: processor.ProcessingSummary{
+			TotalActivities: 40,
+			TotalUsers:      4,
+			CompletionRate:  72.5,
+			TotalTimeSpent:  36000,
+		},
+		PriorityBreakdown: map[string]processor.PriorityMetrics{
+			"High": {Priority: "High", Count: 12, CompletedCount: 9, CompletionRate: 75},
+		},
+	}
+}
+
+func TestCheckNumericClaims(t *testing.T) {
+	metrics := claimTestMetrics()
+	
+	tests := []struct {
+		name   string
+		text   string
+		claims []string
+	}{
+		{
+			name: "figures matching metrics",
+			text: "The 4 engineers worked 40 issues and completed 29 of them, a completion rate of 72.5%. High priority work reached 75%.",
+		},
+		{
+			name: "rounded figures are within tolerance",
+			text: "Roughly 73% of work was completed across 10 hours of logged time.",
+		},
+		{
+			name:   "wrong completion rate",
+			text:   "The team completed 40 issues with a completion rate of 90%.",
+			claims: []string{"90%"},
+		},
+		{
+			name:   "percentages only match rates",
+			text:   "Completion reached 40 %, and 12 high priority items were resolved.",
+			claims: []string{"40 %"},
+		},
+		{
+			name:   "unknown counts",
+			text:   "Overall 1,250 story points were delivered by 4 people.",
+			claims: []string{"1,250"},
+		},
+		{
+			name: "dates, keys and list markers are not claims",
+			text: "1. PROJ-123 shipped on 2023-01-02 at 10:30 in Q1 2023.\n2) The 3rd milestone is v2.1.",
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			discrepancies := CheckNumericClaims(tt.text, metrics, 0)
+			claims := make([]string, 0, len(discrepancies))
+			for _, discrepancy := range discrepancies {
+				claims = append(claims, discrepancy.Claim)
+			}
+			assert.Equal(t, len(tt.claims), len(claims), claims)
+			if len(tt.claims) > 0 {
+				assert.Equal(t, tt.claims, claims)
+			}
+		})
+	}
+}
+
+func TestCheckNumericClaims_Tolerance(t *testing.T) {
+	metrics := claimTestMetrics()
+	
+	discrepancies := CheckNumericClaims("Completion was 74%.", metrics, 0)
+	require.Len(t, discrepancies, 1)
+	assert.Equal(t, 74.0, discrepancies[0].Value)
+	assert.True(t, discrepancies[0].Percent)
+	assert.Equal(t, "Completion was 74%.", discrepancies[0].Context)
+	
+	// A wider tolerance accepts the figure
+	assert.Empty(t, CheckNumericClaims("Completion was 74%.", metrics, 2))
+	assert.Nil(t, CheckNumericClaims("Completion was 74%.", nil, 0))
+}
+
+func TestPipeline_Run_VerifyClaims(t *testing.T) {
+	logger := utils.NewMockLogger()
+	geminiClient := &fakeGeminiClient{summary: "The team worked 2 issues and hit a completion rate of 90%."}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: geminiClient,
+		DocsClient:   &fakeDocsClient{},
+	}, logger)
+	
+	opts := createTestOptions()
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Empty(t, result.ClaimDiscrepancies) // Disabled by default
+	
+	opts.VerifyClaims = true
+	result, err = p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	// The processor computed a 50% completion rate
+	require.Len(t, result.ClaimDiscrepancies, 1)
+	assert.Equal(t, "90%", result.ClaimDiscrepancies[0].Claim)
+	assert.NotEmpty(t, logger.GetEntriesByLevel(utils.LogLevelWarn))
+}
@@ -0,0 +1,96 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// RunLocks serializes pipeline runs that share a lock key, such as a manual and a scheduled
+// run publishing to the same rolling document
+type RunLocks struct {
+	mu    sync.Mutex
+	slots map[string]*runSlot
+}
+
+// runSlot guards one lock key. users counts the holder and waiters, so the slot can be dropped
+// once nobody needs it.
+type runSlot struct {
+	held  chan struct{}
+	users int
+}
+
+// defaultRunLocks is shared by every pipeline that is not given its own RunLocks
+var defaultRunLocks = NewRunLocks()
+
+// NewRunLocks creates an empty set of run locks
+func NewRunLocks() *RunLocks {
+	return &RunLocks{
+		slots: make(map[string]*runSlot),
+	}
+}
+
+// Acquire takes the lock for key and returns the function that releases it. When wait is false
+// and another run holds the lock an ErrorCodeConflict error is returned immediately, otherwise
+// Acquire blocks until the lock is free or ctx is done. A canceled wait returns ctx.Err() as is.
+func (l *RunLocks) Acquire(ctx context.Context, key string, wait bool) (func(), error) {
+	slot := l.join(key)
+	release := func() {
+		<-slot.held
+		l.leave(key, slot)
+	}
+	
+	select {
+	case slot.held <- struct{}{}:
+		return release, nil
+	default:
+	}
+	
+	if !wait {
+		l.leave(key, slot)
+		return nil, utils.NewAppError(utils.ErrorCodeConflict,
+			fmt.Sprintf("Another pipeline run is already in progress for %q", key), nil).
+			WithOperation("pipeline_run").
+			WithExtra("lock_key", key)
+	}
+	
+	select {
+	case slot.held <- struct{}{}:
+		return release, nil
+	case <-ctx.Done():
+		l.leave(key, slot)
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, ctx.Err()
+		}
+		return nil, utils.WrapError(ctx.Err(), utils.ErrorCodeTimeoutError, "Timed out waiting for a concurrent pipeline run").
+			WithExtra("lock_key", key)
+	}
+}
+
+// join returns the slot guarding key, creating it if needed, and counts the caller as a user
+func (l *RunLocks) join(key string) *runSlot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	slot, ok := l.slots[key]
+	if !ok {
+		slot = &runSlot{held: make(chan struct{}, 1)}
+		l.slots[key] = slot
+	}
+	slot.users++
+	return slot
+}
+
+// leave stops counting the caller as a user of slot and drops the slot when it was the last
+func (l *RunLocks) leave(key string, slot *runSlot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	
+	slot.users--
+	if slot.users == 0 {
+		delete(l.slots, key)
+	}
+}
@@ -0,0 +1,185 @@
+package pipeline
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// blockingJiraClient holds GetUserActivities open until released
+type blockingJiraClient struct {
+	fakeJiraClient
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingJiraClient) GetUserActivities(ctx context.Context, users []string, timeRange config.TimeRange) ([]models.Activity, error) {
+	b.started <- struct{}{}
+	<-b.release
+	return b.activities, nil
+}
+
+func TestRunLocks_MutualExclusion(t *testing.T) {
+	locks := NewRunLocks()
+	
+	var active, maxActive, completed int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release, err := locks.Acquire(context.Background(), "weekly-doc", true)
+			if !assert.NoError(t, err) {
+				return
+			}
+			defer release()
+			
+			current := atomic.AddInt32(&active, 1)
+			for {
+				seen := atomic.LoadInt32(&maxActive)
+				if current <= seen || atomic.CompareAndSwapInt32(&maxActive, seen, current) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			atomic.AddInt32(&active, -1)
+			atomic.AddInt32(&completed, 1)
+		}()
+	}
+	wg.Wait()
+	
+	assert.Equal(t, int32(1), maxActive)
+	assert.Equal(t, int32(20), completed)
+	
+	// Slots are dropped once nobody holds or waits for them
+	assert.Empty(t, locks.slots)
+}
+
+func TestRunLocks_FailFast(t *testing.T) {
+	locks := NewRunLocks()
+	
+	release, err := locks.Acquire(context.Background(), "weekly-doc", false)
+	require.NoError(t, err)
+	
+	_, err = locks.Acquire(context.Background(), "weekly-doc", false)
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeConflict, appErr.Code)
+	assert.Equal(t, "weekly-doc", appErr.Context.Extra["lock_key"])
+	
+	// Other keys are not blocked
+	releaseOther, err := locks.Acquire(context.Background(), "monthly-doc", false)
+	require.NoError(t, err)
+	releaseOther()
+	
+	// The lock can be taken again once released
+	release()
+	release, err = locks.Acquire(context.Background(), "weekly-doc", false)
+	require.NoError(t, err)
+	release()
+	assert.Empty(t, locks.slots)
+}
+
+func TestRunLocks_WaitHonorsContext(t *testing.T) {
+	locks := NewRunLocks()
+	
+	release, err := locks.Acquire(context.Background(), "weekly-doc", false)
+	require.NoError(t, err)
+	defer release()
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	
+	_, err = locks.Acquire(ctx, "weekly-doc", true)
+	require.Error(t, err)
+	assert.True(t, err.(*utils.AppError).IsType(utils.ErrorCodeTimeoutError))
+}
+
+func TestRunLocks_WaitCanceled(t *testing.T) {
+	locks := NewRunLocks()
+	
+	release, err := locks.Acquire(context.Background(), "weekly-doc", false)
+	require.NoError(t, err)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+	
+	_, err = locks.Acquire(ctx, "weekly-doc", true)
+	assert.Equal(t, context.Canceled, err)
+	
+	// The canceled waiter no longer keeps the slot alive
+	release()
+	assert.Empty(t, locks.slots)
+}
+
+func TestOptions_LockKey(t *testing.T) {
+	assert.Equal(t, "Weekly Summary", Options{Summary: processor.SummaryRequest{Title: "Weekly Summary"}}.lockKey())
+	assert.Equal(t, "Rolling", Options{DocumentTitle: "Rolling", Summary: processor.SummaryRequest{Title: "Weekly Summary"}}.lockKey())
+	assert.Equal(t, "team-a", Options{LockKey: "team-a", DocumentTitle: "Rolling"}.lockKey())
+}
+
+func TestPipeline_Run_ConcurrentRunConflict(t *testing.T) {
+	logger := utils.NewMockLogger()
+	jiraClient := &blockingJiraClient{
+		fakeJiraClient: fakeJiraClient{activities: createTestActivities()},
+		started:        make(chan struct{}),
+		release:        make(chan struct{}),
+	}
+	deps := Dependencies{
+		JiraClient:   jiraClient,
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   &fakeDocsClient{},
+		RunLocks:     NewRunLocks(),
+	}
+	p := NewPipeline(deps, logger)
+	
+	opts := createTestOptions()
+	opts.DocumentTitle = "Rolling Weekly Summary"
+	
+	firstDone := make(chan error, 1)
+	go func() {
+		_, err := p.Run(context.Background(), opts)
+		firstDone <- err
+	}()
+	<-jiraClient.started
+	
+	// A second run for the same document fails fast while the first is in progress
+	_, err := p.Run(context.Background(), opts)
+	require.Error(t, err)
+	assert.True(t, err.(*utils.AppError).IsType(utils.ErrorCodeConflict))
+	
+	// A run canceled while waiting ends as a canceled run rather than a failure
+	opts.WaitForLock = true
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err := p.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.True(t, result.Canceled)
+	
+	// A waiting run proceeds once the first run finishes
+	secondDone := make(chan error, 1)
+	go func() {
+		_, err := p.Run(context.Background(), opts)
+		secondDone <- err
+	}()
+	
+	jiraClient.release <- struct{}{}
+	require.NoError(t, <-firstDone)
+	
+	<-jiraClient.started
+	jiraClient.release <- struct{}{}
+	require.NoError(t, <-secondDone)
+}
@@ -0,0 +1,553 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/jira"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/internal/summarizer"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/company/eesa/pkg/validation"
+)
+
+// FallbackSummaryModel is reported as the model when the deterministic no-completion summary is published
+const FallbackSummaryModel = "no-completion-fallback"
+
+// DryRunSummaryModel is reported as the model of the deterministic summary a dry run uses in
+// place of the AI summary
+const DryRunSummaryModel = "dry-run"
+
+// Dependencies holds the external services used by the pipeline
+type Dependencies struct {
+	JiraClient   jira.JiraClientInterface
+	GeminiClient gemini.GeminiClientInterface
+	Summarizer   summarizer.Summarizer              // Optional, defaults to summarizing with GeminiClient
+	DocsClient   gdocs.GoogleDocsClientInterface
+	Validator    *validation.ServiceValidationRules // Optional, disables validation when nil
+	RunLocks     *RunLocks                          // Optional, defaults to locks shared by the whole process
+	// StatusProgressWeights optionally replaces processor.DefaultStatusProgressWeights
+	StatusProgressWeights map[string]float64
+}
+
+// Options configures a single pipeline run
+type Options struct {
+	Users               []string
+	TimeRange           config.TimeRange
+	Processing          processor.ProcessingOptions
+	Summary             processor.SummaryRequest
+	CustomPrompt        string
+	DocumentTitle       string
+	ShareWith           []string
+	ShareRole           string
+	MaxValidationErrors int // Abort when validation errors exceed this count, 0 disables the check
+	NoCompletionFallback bool // Publish the deterministic summary instead of calling Gemini when nothing was completed
+	LockKey             string // Runs with the same key never overlap, defaults to the document title
+	WaitForLock         bool   // Wait for an overlapping run to finish instead of failing with ErrorCodeConflict
+	// VerifyClaims compares the figures stated in the AI summary with the computed metrics and
+	// records those that match none of them in Result.ClaimDiscrepancies
+	VerifyClaims        bool
+	ClaimTolerance      float64 // Allowed difference for a figure to match a metric, 0 uses DefaultClaimTolerance
+	SkipPublish         bool    // Stop after summarizing without creating a document, leaving Result.Document nil
+	// DryRun fetches and processes activities but writes nothing: the deterministic summary
+	// stands in for the AI summary, and the document creation and sharing a run would do are
+	// logged and described in Result.Preview instead of being carried out
+	DryRun              bool
+	Progress            ProgressFunc // Told about each phase of the run, may be nil
+	// PerProject adds a summary of each project's activities after the roll-up of all of them,
+	// in Result.ProjectSummaries and as sections of the summary keyed by project
+	PerProject             bool
+	MaxConcurrentSummaries int // Project summaries generated at once, 0 uses DefaultMaxConcurrentSummaries
+}
+
+// lockKey returns the key that serializes runs publishing to the same document
+func (o Options) lockKey() string {
+	if o.LockKey != "" {
+		return o.LockKey
+	}
+	return documentTitle(o)
+}
+
+// inReportingZone converts t to the reporting time zone of the processing options, UTC when
+// none is set, as the processor does
+func (o Options) inReportingZone(t time.Time) time.Time {
+	if o.Processing.Location == nil {
+		return t.UTC()
+	}
+	return t.In(o.Processing.Location)
+}
+
+// Result contains the output of a pipeline run
+type Result struct {
+	Activities       []models.Activity              `json:"activities"`
+	Processing       *processor.ProcessingResult    `json:"processing"`
+	Summary          *processor.SummaryResponse     `json:"summary"`
+	AISummary        *gemini.SummaryResponse        `json:"ai_summary"`
+	Document         *gdocs.DocumentResponse        `json:"document,omitempty"`
+	Preview          *PublishPreview                `json:"preview,omitempty"` // What a dry run would have published
+	ValidationErrors []validation.ValidationError   `json:"validation_errors,omitempty"`
+	SummaryCacheHit  bool                           `json:"summary_cache_hit"` // The AI summary was reused from an earlier run
+	ClaimDiscrepancies []ClaimDiscrepancy           `json:"claim_discrepancies,omitempty"` // AI figures that match no computed metric
+	ProjectSummaries []ProjectSummary               `json:"project_summaries,omitempty"` // Per-project summaries, in project key order
+	// Canceled is set when the run's context was canceled before it finished. The result keeps
+	// what the run got to, and nothing was published.
+	Canceled         bool                           `json:"canceled,omitempty"`
+	CorrelationID    string                         `json:"correlation_id"` // Logged with every line of the run
+	StartedAt        time.Time                      `json:"started_at"`
+	Duration         time.Duration                  `json:"duration"`
+}
+
+// PublishPreview describes the document a dry run would have created and shared
+type PublishPreview struct {
+	Title     string          `json:"title"`
+	Requests  []gdocs.Request `json:"requests,omitempty"` // Formatting requests, when the Docs client can preview them
+	ShareWith []string        `json:"share_with,omitempty"`
+	ShareRole string          `json:"share_role,omitempty"`
+	Actions   []string        `json:"actions"` // The skipped writes, in the order they would run
+}
+
+// requestPreviewer is implemented by Docs clients that can build the formatting requests of a
+// summary document without calling the API
+type requestPreviewer interface {
+	PreviewExecutiveSummaryRequests(title, summary string, metadata map[string]interface{}) ([]gdocs.Request, error)
+}
+
+// Pipeline runs the fetch, process, summarize and publish steps end to end
+type Pipeline struct {
+	jira       jira.JiraClientInterface
+	summarizer summarizer.Summarizer
+	docs       gdocs.GoogleDocsClientInterface
+	validator  *validation.ServiceValidationRules
+	processor  *processor.DataProcessor
+	summaries  *processor.SummaryGenerator
+	locks      *RunLocks
+	logger     utils.Logger
+}
+
+// NewPipeline creates a new pipeline from its dependencies
+func NewPipeline(deps Dependencies, logger utils.Logger) *Pipeline {
+	locks := deps.RunLocks
+	if locks == nil {
+		locks = defaultRunLocks
+	}
+	
+	aiSummarizer := deps.Summarizer
+	if aiSummarizer == nil && deps.GeminiClient != nil {
+		aiSummarizer = summarizer.NewGeminiSummarizer(deps.GeminiClient)
+	}
+	
+	dataProcessor := processor.NewDataProcessor(logger)
+	if len(deps.StatusProgressWeights) > 0 {
+		dataProcessor.SetStatusProgressWeights(deps.StatusProgressWeights)
+	}
+	
+	return &Pipeline{
+		jira:       deps.JiraClient,
+		summarizer: aiSummarizer,
+		docs:       deps.DocsClient,
+		validator:  deps.Validator,
+		processor:  dataProcessor,
+		summaries:  processor.NewSummaryGenerator(logger),
+		locks:      locks,
+		logger:     logger,
+	}
+}
+
+// Run executes the pipeline for the given options
+func (p *Pipeline) Run(ctx context.Context, opts Options) (*Result, error) {
+	ctx, correlationID := utils.EnsureCorrelationID(ctx)
+	
+	result := &Result{
+		StartedAt:     time.Now(),
+		CorrelationID: correlationID,
+	}
+	
+	// Keep overlapping runs from writing to the same document
+	release, err := p.locks.Acquire(ctx, opts.lockKey(), opts.WaitForLock)
+	if err != nil {
+		return p.endRun(ctx, result, err)
+	}
+	defer release()
+	
+	p.log(ctx).Info("Starting pipeline run",
+		utils.NewField("users", opts.Users),
+		utils.NewField("time_range", fmt.Sprintf("%v to %v", opts.TimeRange.Start, opts.TimeRange.End)),
+	)
+	
+	// Fetch activities from Jira
+	if err := p.startPhase(ctx, opts, PhaseFetch, "Fetching activities from Jira"); err != nil {
+		return p.endRun(ctx, result, err)
+	}
+	activities, err := p.jira.GetUserActivities(ctx, opts.Users, opts.TimeRange)
+	if err != nil {
+		return p.endRun(ctx, result, utils.WrapError(err, utils.ErrorCodeJiraError, "Failed to fetch activities"))
+	}
+	
+	_, err = p.summarize(ctx, opts, activities, result)
+	return p.endRun(ctx, result, err)
+}
+
+// RunActivities executes the process, summarize and publish steps for activities supplied by
+// the caller, such as activities imported from a file, without fetching anything from Jira
+func (p *Pipeline) RunActivities(ctx context.Context, activities []models.Activity, opts Options) (*Result, error) {
+	ctx, correlationID := utils.EnsureCorrelationID(ctx)
+	
+	result := &Result{
+		StartedAt:     time.Now(),
+		CorrelationID: correlationID,
+	}
+	
+	release, err := p.locks.Acquire(ctx, opts.lockKey(), opts.WaitForLock)
+	if err != nil {
+		return p.endRun(ctx, result, err)
+	}
+	defer release()
+	
+	p.log(ctx).Info("Starting pipeline run from supplied activities",
+		utils.NewField("activity_count", len(activities)),
+	)
+	
+	_, err = p.summarize(ctx, opts, activities, result)
+	return p.endRun(ctx, result, err)
+}
+
+// endRun returns the outcome of a run that ended with err. A run stopped because its context
+// was canceled is not a failure: it returns result marked as canceled instead of the error.
+// Deadlines still fail the run.
+func (p *Pipeline) endRun(ctx context.Context, result *Result, err error) (*Result, error) {
+	if err == nil {
+		return result, nil
+	}
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		return nil, err
+	}
+	
+	result.Canceled = true
+	result.Duration = time.Since(result.StartedAt)
+	p.log(ctx).Info("Pipeline run canceled",
+		utils.NewField("activity_count", len(result.Activities)),
+		utils.NewField("duration", result.Duration),
+	)
+	return result, nil
+}
+
+// summarize validates, processes, summarizes and publishes activities into result
+func (p *Pipeline) summarize(ctx context.Context, opts Options, activities []models.Activity, result *Result) (*Result, error) {
+	result.Activities = activities
+	
+	if err := p.startPhase(ctx, opts, PhaseProcess, fmt.Sprintf("Processing %d activities", len(activities))); err != nil {
+		return nil, err
+	}
+	
+	// Validate activities against the data contract
+	validationErrors := p.validateActivities(activities)
+	result.ValidationErrors = validationErrors
+	if err := p.checkValidationThreshold(ctx, validationErrors, opts.MaxValidationErrors); err != nil {
+		return nil, err
+	}
+	
+	// Process activities into metrics
+	processingResult, err := p.processor.ProcessActivities(ctx, activities, opts.Processing)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to process activities")
+	}
+	result.Processing = processingResult
+	
+	// Build the structured summary
+	summary, err := p.summaries.GenerateSummary(ctx, processingResult, opts.Summary)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to generate summary")
+	}
+	result.Summary = summary
+	
+	// Generate the narrative summary
+	if err := p.startPhase(ctx, opts, PhaseSummarize, "Generating summary"); err != nil {
+		return nil, err
+	}
+	aiSummary, cacheHit, err := p.narrativeSummary(ctx, opts, activities, summary)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Summarize each project on its own, published as sections after the roll-up
+	if opts.PerProject {
+		projects, err := p.projectSummaries(ctx, opts, activities)
+		if err != nil {
+			return nil, err
+		}
+		result.ProjectSummaries = projects
+		for _, project := range projects {
+			summary.Sections[project.Key] = project.Summary
+		}
+		aiSummary = withProjectSections(aiSummary, projects)
+	}
+	result.AISummary = aiSummary
+	result.SummaryCacheHit = cacheHit
+	
+	// Flag figures the AI stated that the processor did not compute
+	if opts.VerifyClaims && aiSummary.Model != FallbackSummaryModel && aiSummary.Model != DryRunSummaryModel {
+		result.ClaimDiscrepancies = p.verifyClaims(ctx, aiSummary.Summary, processingResult, opts.ClaimTolerance)
+	}
+	
+	// Publish the document
+	documentID := ""
+	if opts.DryRun && !opts.SkipPublish {
+		if err := p.startPhase(ctx, opts, PhasePublish, "Previewing document"); err != nil {
+			return nil, err
+		}
+		preview, err := p.previewPublish(ctx, opts, aiSummary)
+		if err != nil {
+			return nil, err
+		}
+		result.Preview = preview
+	} else if !opts.SkipPublish {
+		if err := p.startPhase(ctx, opts, PhasePublish, "Publishing document"); err != nil {
+			return nil, err
+		}
+		document, err := p.publish(ctx, opts, aiSummary)
+		if err != nil {
+			return nil, err
+		}
+		result.Document = document
+		documentID = document.DocumentID
+	}
+	
+	result.Duration = time.Since(result.StartedAt)
+	
+	p.log(ctx).Info("Pipeline run completed",
+		utils.NewField("activity_count", len(activities)),
+		utils.NewField("validation_errors", len(validationErrors)),
+		utils.NewField("document_id", documentID),
+		utils.NewField("duration", result.Duration),
+	)
+	
+	p.report(ctx, opts, PhaseDone, "Done")
+	
+	return result, nil
+}
+
+// narrativeSummary generates the AI summary, or uses the deterministic fallback when
+// nothing was completed and the fallback is enabled. The returned flag reports a summary the
+// summarizer reused from its cache, such as the Gemini client's configured summary cache.
+func (p *Pipeline) narrativeSummary(ctx context.Context, opts Options, activities []models.Activity, summary *processor.SummaryResponse) (*gemini.SummaryResponse, bool, error) {
+	if opts.DryRun {
+		p.log(ctx).Info("Dry run, using the deterministic summary instead of generating one",
+			utils.NewField("activity_count", len(activities)),
+		)
+		return &gemini.SummaryResponse{
+			Summary:     summary.ExecutiveSummary,
+			Model:       DryRunSummaryModel,
+			GeneratedAt: summary.GeneratedAt,
+			Activities:  activities,
+		}, false, nil
+	}
+	
+	if opts.NoCompletionFallback && summary.FallbackUsed {
+		p.log(ctx).Info("No completed activities, using fallback summary",
+			utils.NewField("activity_count", len(activities)),
+		)
+		return &gemini.SummaryResponse{
+			Summary:     summary.ExecutiveSummary,
+			Model:       FallbackSummaryModel,
+			GeneratedAt: summary.GeneratedAt,
+			Activities:  activities,
+		}, false, nil
+	}
+	
+	aiSummary, err := p.summarizer.GenerateSummary(ctx, activities, summarizer.Options{
+		CustomPrompt: summaryPrompt(opts),
+	})
+	if err != nil {
+		return nil, false, utils.WrapError(err, utils.ErrorCodeSummarizerError, "Failed to generate AI summary")
+	}
+	
+	return aiSummary, aiSummary.Cached, nil
+}
+
+// verifyClaims checks the figures in the AI summary against the computed metrics and logs
+// every discrepancy
+func (p *Pipeline) verifyClaims(ctx context.Context, text string, processingResult *processor.ProcessingResult, tolerance float64) []ClaimDiscrepancy {
+	discrepancies := CheckNumericClaims(text, processingResult, tolerance)
+	for _, discrepancy := range discrepancies {
+		p.log(ctx).Warn("AI summary states a figure that matches no computed metric",
+			utils.NewField("claim", discrepancy.Claim),
+			utils.NewField("context", discrepancy.Context),
+		)
+	}
+	
+	return discrepancies
+}
+
+// log returns the pipeline's logger carrying the correlation ID of the run in ctx
+func (p *Pipeline) log(ctx context.Context) utils.Logger {
+	return utils.LoggerWithContext(ctx, p.logger)
+}
+
+// summaryPrompt returns the custom prompt for the AI summary, asking for the requested
+// summary language when one is set
+func summaryPrompt(opts Options) string {
+	if opts.Summary.Language == "" {
+		return opts.CustomPrompt
+	}
+	
+	instruction := fmt.Sprintf("Write the entire summary, including section headings, in %s.",
+		processor.LanguageName(opts.Summary.Language))
+	if opts.CustomPrompt == "" {
+		return instruction
+	}
+	return opts.CustomPrompt + "\n" + instruction
+}
+
+// validateActivities checks each activity against the registered activity rules
+func (p *Pipeline) validateActivities(activities []models.Activity) []validation.ValidationError {
+	if p.validator == nil {
+		return nil
+	}
+	
+	var validationErrors []validation.ValidationError
+	for _, activity := range activities {
+		data, err := toValidationData(activity)
+		if err != nil {
+			validationErrors = append(validationErrors, validation.ValidationError{
+				Field:   activity.Key,
+				Type:    "encoding",
+				Message: fmt.Sprintf("Failed to encode activity for validation: %s", err.Error()),
+			})
+			continue
+		}
+		
+		validationResult := p.validator.ValidateActivity(data)
+		for _, validationErr := range validationResult.Errors {
+			validationErr.Field = fmt.Sprintf("%s.%s", activity.Key, validationErr.Field)
+			validationErrors = append(validationErrors, validationErr)
+		}
+	}
+	
+	return validationErrors
+}
+
+// checkValidationThreshold fails the run when validation errors exceed the configured maximum
+func (p *Pipeline) checkValidationThreshold(ctx context.Context, validationErrors []validation.ValidationError, maxErrors int) error {
+	if len(validationErrors) == 0 {
+		return nil
+	}
+	
+	if maxErrors <= 0 || len(validationErrors) <= maxErrors {
+		p.log(ctx).Warn("Activities failed validation",
+			utils.NewField("error_count", len(validationErrors)),
+			utils.NewField("max_validation_errors", maxErrors),
+		)
+		return nil
+	}
+	
+	return utils.NewAppError(utils.ErrorCodeValidationError,
+		fmt.Sprintf("%d validation errors exceed the maximum of %d", len(validationErrors), maxErrors), nil).
+		WithOperation("validate_activities").
+		WithExtra("error_count", len(validationErrors)).
+		WithExtra("max_validation_errors", maxErrors).
+		WithExtra("validation_errors", validationErrors)
+}
+
+// publish creates the summary document and shares it with the requested recipients
+func (p *Pipeline) publish(ctx context.Context, opts Options, aiSummary *gemini.SummaryResponse) (*gdocs.DocumentResponse, error) {
+	title := documentTitle(opts)
+	metadata := documentMetadata(opts, aiSummary)
+	
+	document, err := p.docs.CreateExecutiveSummaryDocument(ctx, title, aiSummary.Summary, metadata)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to create summary document")
+	}
+	
+	if len(opts.ShareWith) > 0 {
+		if err := p.docs.ShareDocument(ctx, document.DocumentID, opts.ShareWith, shareRole(opts)); err != nil {
+			return nil, utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to share summary document")
+		}
+	}
+	
+	return document, nil
+}
+
+// previewPublish describes what publish would do for a dry run, logging each skipped write
+func (p *Pipeline) previewPublish(ctx context.Context, opts Options, aiSummary *gemini.SummaryResponse) (*PublishPreview, error) {
+	title := documentTitle(opts)
+	preview := &PublishPreview{Title: title}
+	
+	if previewer, ok := p.docs.(requestPreviewer); ok {
+		requests, err := previewer.PreviewExecutiveSummaryRequests(title, aiSummary.Summary, documentMetadata(opts, aiSummary))
+		if err != nil {
+			return nil, utils.WrapError(err, utils.ErrorCodeGoogleError, "Failed to preview summary document")
+		}
+		preview.Requests = requests
+	}
+	preview.Actions = append(preview.Actions,
+		fmt.Sprintf("Create document %q with %d formatting requests", title, len(preview.Requests)))
+	
+	if len(opts.ShareWith) > 0 {
+		preview.ShareWith = opts.ShareWith
+		preview.ShareRole = shareRole(opts)
+		preview.Actions = append(preview.Actions,
+			fmt.Sprintf("Share the document as %s with %s", preview.ShareRole, strings.Join(opts.ShareWith, ", ")))
+	}
+	
+	for _, action := range preview.Actions {
+		p.log(ctx).Info("Dry run, skipping write", utils.NewField("would", action))
+	}
+	
+	return preview, nil
+}
+
+// documentTitle returns the title of the summary document
+func documentTitle(opts Options) string {
+	if opts.DocumentTitle != "" {
+		return opts.DocumentTitle
+	}
+	return opts.Summary.Title
+}
+
+// shareRole returns the role the summary document is shared with
+func shareRole(opts Options) string {
+	if opts.ShareRole != "" {
+		return opts.ShareRole
+	}
+	return gdocs.RoleReader
+}
+
+// documentMetadata returns the metadata written with the summary document
+func documentMetadata(opts Options, aiSummary *gemini.SummaryResponse) map[string]interface{} {
+	metadata := map[string]interface{}{
+		"generated_at":   opts.inReportingZone(aiSummary.GeneratedAt),
+		"model":          aiSummary.Model,
+		"tokens_used":    aiSummary.TokensUsed,
+		"activity_count": len(aiSummary.Activities),
+		"time_range":     fmt.Sprintf("%s to %s", opts.inReportingZone(opts.TimeRange.Start).Format("2006-01-02"), opts.inReportingZone(opts.TimeRange.End).Format("2006-01-02")),
+		gdocs.MetadataKeyIssues: aiSummary.Activities, // Listed in the appendix when enabled
+	}
+	if opts.Summary.Language != "" {
+		metadata[gdocs.MetadataKeyLanguage] = opts.Summary.Language
+	}
+	
+	return metadata
+}
+
+// toValidationData converts a value into the generic JSON form used by the validator
+func toValidationData(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	
+	var data interface{}
+	if err := json.Unmarshal(encoded, &data); err != nil {
+		return nil, err
+	}
+	
+	return data, nil
+}
@@ -0,0 +1,808 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/jira"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/internal/summarizer"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/company/eesa/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeJiraClient returns a fixed set of activities
+type fakeJiraClient struct {
+	activities []models.Activity
+	err        error
+	calls      int
+	cancel     context.CancelFunc // Cancels the run while fetching, as a user stopping it would
+}
+
+func (f *fakeJiraClient) GetUserActivities(ctx context.Context, users []string, timeRange config.TimeRange) ([]models.Activity, error) {
+	f.calls++
+	if f.cancel != nil {
+		f.cancel()
+		return nil, ctx.Err()
+	}
+	return f.activities, f.err
+}
+
+func (f *fakeJiraClient) ValidateConnection(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeJiraClient) SearchIssues(ctx context.Context, jql string, fields []string, startAt, maxResults int) (*jira.SearchResult, error) {
+	return &jira.SearchResult{}, nil
+}
+
+func (f *fakeJiraClient) SearchAllIssues(ctx context.Context, jql string, fields []string) (*jira.SearchResult, error) {
+	return &jira.SearchResult{}, nil
+}
+
+func (f *fakeJiraClient) GetIssue(ctx context.Context, issueKey string, fields []string) (*models.Activity, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeJiraClient) GetWorklog(ctx context.Context, issueKey string) ([]models.Worklog, error) {
+	return nil, nil
+}
+
+func (f *fakeJiraClient) GetComments(ctx context.Context, issueKey string) ([]models.Comment, error) {
+	return nil, nil
+}
+
+func (f *fakeJiraClient) GetIssueChangelog(ctx context.Context, issueKey string) ([]models.ChangelogEntry, error) {
+	return nil, nil
+}
+
+// fakeGeminiClient returns a canned summary
+type fakeGeminiClient struct {
+	err           error
+	calls         int
+	summary       string // Overrides the canned summary text when set
+	prompt        string // Custom prompt of the last call
+	correlationID string // Correlation ID of the last call's context
+	cached        bool   // Reports every summary as reused from the summary cache
+}
+
+func (f *fakeGeminiClient) GenerateSummary(ctx context.Context, activities []models.Activity, prompt string) (*gemini.SummaryResponse, error) {
+	f.calls++
+	f.prompt = prompt
+	f.correlationID = utils.CorrelationID(ctx)
+	if f.err != nil {
+		return nil, f.err
+	}
+	summary := f.summary
+	if summary == "" {
+		summary = "The team closed out the login work."
+	}
+	return &gemini.SummaryResponse{
+		Summary:     summary,
+		TokensUsed:  42,
+		Model:       gemini.ModelGeminiPro,
+		GeneratedAt: time.Now(),
+		Activities:  activities,
+		Cached:      f.cached,
+	}, nil
+}
+
+func (f *fakeGeminiClient) ValidateAPIKey(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeGeminiClient) ListModels(ctx context.Context) (*gemini.ModelsResponse, error) {
+	return &gemini.ModelsResponse{}, nil
+}
+
+func (f *fakeGeminiClient) GenerateContent(ctx context.Context, request *gemini.GenerateRequest) (*gemini.GenerateResponse, error) {
+	return &gemini.GenerateResponse{}, nil
+}
+
+// fakeSummarizer is a non-Gemini summary provider
+type fakeSummarizer struct {
+	err   error
+	calls int
+	opts  summarizer.Options // Options of the last call
+}
+
+func (f *fakeSummarizer) GenerateSummary(ctx context.Context, activities []models.Activity, opts summarizer.Options) (*summarizer.Summary, error) {
+	f.calls++
+	f.opts = opts
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &summarizer.Summary{
+		Summary:     "Summarized by another model.",
+		Model:       "self-hosted",
+		GeneratedAt: time.Now(),
+		Activities:  activities,
+	}, nil
+}
+
+// fakeDocsClient records document writes
+type fakeDocsClient struct {
+	created    []string
+	sharedWith []string
+	metadata   map[string]interface{} // Metadata of the last created summary document
+	writes     int                    // Calls that would change a document
+	err        error
+}
+
+func (f *fakeDocsClient) CreateDocument(ctx context.Context, title string, content string) (*gdocs.DocumentResponse, error) {
+	f.writes++
+	return &gdocs.DocumentResponse{DocumentID: "doc-1", Title: title}, nil
+}
+
+func (f *fakeDocsClient) UpdateDocument(ctx context.Context, documentID string, requests []gdocs.Request) (*gdocs.BatchUpdateResponse, error) {
+	f.writes++
+	return &gdocs.BatchUpdateResponse{DocumentID: documentID}, nil
+}
+
+func (f *fakeDocsClient) GetDocument(ctx context.Context, documentID string) (*gdocs.DocumentResponse, error) {
+	return &gdocs.DocumentResponse{DocumentID: documentID}, nil
+}
+
+func (f *fakeDocsClient) ShareDocument(ctx context.Context, documentID string, emails []string, role string) error {
+	f.writes++
+	f.sharedWith = append(f.sharedWith, emails...)
+	return nil
+}
+
+func (f *fakeDocsClient) DeleteDocument(ctx context.Context, documentID string) error {
+	f.writes++
+	return nil
+}
+
+func (f *fakeDocsClient) ValidateCredentials(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeDocsClient) CreateExecutiveSummaryDocument(ctx context.Context, title, summary string, metadata map[string]interface{}) (*gdocs.DocumentResponse, error) {
+	f.writes++
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.created = append(f.created, title)
+	f.metadata = metadata
+	return &gdocs.DocumentResponse{DocumentID: "doc-1", Title: title}, nil
+}
+
+// previewingDocsClient records writes like fakeDocsClient and builds formatting previews with a
+// real Docs client, which makes no API calls to do so
+type previewingDocsClient struct {
+	*fakeDocsClient
+	previewer *gdocs.Client
+}
+
+func newPreviewingDocsClient(logger utils.Logger) *previewingDocsClient {
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	return &previewingDocsClient{
+		fakeDocsClient: &fakeDocsClient{},
+		previewer:      gdocs.NewClient(config.DefaultConfig(), authManager, logger),
+	}
+}
+
+func (f *previewingDocsClient) PreviewExecutiveSummaryRequests(title, summary string, metadata map[string]interface{}) ([]gdocs.Request, error) {
+	return f.previewer.PreviewExecutiveSummaryRequests(title, summary, metadata)
+}
+
+func createTestActivities() []models.Activity {
+	now := time.Now()
+	return []models.Activity{
+		{
+			ID:       "1",
+			Key:      "PROJ-1",
+			Summary:  "Fix login bug",
+			Type:     "Bug",
+			Status:   "Done",
+			Priority: "High",
+			Assignee: models.User{AccountID: "user1", DisplayName: "User One"},
+			Created:  now.Add(-72 * time.Hour),
+			Updated:  now.Add(-24 * time.Hour),
+		},
+		{
+			ID:       "2",
+			Key:      "PROJ-2",
+			Summary:  "Add password reset",
+			Type:     "Story",
+			Status:   "In Progress",
+			Priority: "Medium",
+			Assignee: models.User{AccountID: "user2", DisplayName: "User Two"},
+			Created:  now.Add(-48 * time.Hour),
+			Updated:  now.Add(-2 * time.Hour),
+		},
+	}
+}
+
+// createInvalidActivities returns activities that each break one activity rule
+func createInvalidActivities(count int) []models.Activity {
+	activities := make([]models.Activity, 0, count)
+	for i := 0; i < count; i++ {
+		activity := createTestActivities()[0]
+		activity.Summary = ""
+		activities = append(activities, activity)
+	}
+	return activities
+}
+
+func createTestOptions() Options {
+	return Options{
+		Users: []string{"user1", "user2"},
+		TimeRange: config.TimeRange{
+			Start: time.Now().Add(-7 * 24 * time.Hour),
+			End:   time.Now(),
+		},
+		Processing: processor.ProcessingOptions{GroupByUser: true},
+		Summary: processor.SummaryRequest{
+			Title:  "Weekly Summary",
+			Period: "weekly",
+			Format: processor.FormatExecutive,
+		},
+	}
+}
+
+func TestNewPipeline(t *testing.T) {
+	logger := utils.NewMockLogger()
+	deps := Dependencies{
+		JiraClient:   &fakeJiraClient{},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   &fakeDocsClient{},
+	}
+	
+	p := NewPipeline(deps, logger)
+	
+	assert.NotNil(t, p)
+	assert.NotNil(t, p.processor)
+	assert.NotNil(t, p.summaries)
+	assert.Nil(t, p.validator)
+}
+
+func TestNewPipeline_StatusProgressWeights(t *testing.T) {
+	logger := utils.NewMockLogger()
+	now := time.Now()
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "In Review", Priority: "Low", Created: now, Updated: now},
+	}
+	options := processor.DefaultProcessingOptions()
+	
+	defaults, err := NewPipeline(Dependencies{}, logger).processor.ProcessActivities(context.Background(), activities, options)
+	require.NoError(t, err)
+	
+	// The configured weights replace the defaults, so In Review earns less credit
+	weighted := NewPipeline(Dependencies{StatusProgressWeights: map[string]float64{"In Review": 0.2}}, logger)
+	result, err := weighted.processor.ProcessActivities(context.Background(), activities, options)
+	require.NoError(t, err)
+	
+	assert.Less(t, result.Summary.ProductivityScore, defaults.Summary.ProductivityScore)
+}
+
+func TestPipeline_Run(t *testing.T) {
+	logger := utils.NewMockLogger()
+	jiraClient := &fakeJiraClient{activities: createTestActivities()}
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   jiraClient,
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.ShareWith = []string{"exec@example.com"}
+	
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	assert.Len(t, result.Activities, 2)
+	assert.Empty(t, result.ValidationErrors)
+	assert.Equal(t, 2, result.Processing.Summary.TotalActivities)
+	assert.Equal(t, "Weekly Summary", result.Summary.Title)
+	assert.Equal(t, "The team closed out the login work.", result.AISummary.Summary)
+	assert.Equal(t, "doc-1", result.Document.DocumentID)
+	assert.Equal(t, []string{"Weekly Summary"}, docsClient.created)
+	assert.Equal(t, []string{"exec@example.com"}, docsClient.sharedWith)
+}
+
+func TestPipeline_Run_CorrelationID(t *testing.T) {
+	logger := utils.NewMockLogger()
+	geminiClient := &fakeGeminiClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: geminiClient,
+		DocsClient:   &fakeDocsClient{},
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	result, err := p.Run(context.Background(), createTestOptions())
+	require.NoError(t, err)
+	
+	// The run seeds an ID, logs it on every line and passes it on to the clients
+	require.NotEmpty(t, result.CorrelationID)
+	assert.Equal(t, result.CorrelationID, geminiClient.correlationID)
+	for _, message := range []string{"Starting pipeline run", "Pipeline run completed"} {
+		entries := logger.GetEntriesByMessage(message)
+		require.Len(t, entries, 1, message)
+		assert.Contains(t, entries[0].Fields, utils.NewField(utils.CorrelationIDField, result.CorrelationID))
+	}
+	
+	// An ID set by the caller is kept
+	ctx := utils.WithCorrelationID(context.Background(), "scheduled-run-7")
+	result, err = p.Run(ctx, createTestOptions())
+	require.NoError(t, err)
+	assert.Equal(t, "scheduled-run-7", result.CorrelationID)
+	assert.Equal(t, "scheduled-run-7", geminiClient.correlationID)
+	assert.True(t, logger.HasFieldValue(utils.CorrelationIDField, "scheduled-run-7"))
+}
+
+func TestPipeline_Run_SkipPublish(t *testing.T) {
+	logger := utils.NewMockLogger()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.SkipPublish = true
+	opts.ShareWith = []string{"exec@example.com"}
+	
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	assert.Equal(t, "The team closed out the login work.", result.AISummary.Summary)
+	assert.Nil(t, result.Document)
+	assert.Empty(t, docsClient.created)
+	assert.Empty(t, docsClient.sharedWith)
+}
+
+func TestPipeline_Run_DryRun(t *testing.T) {
+	logger := utils.NewMockLogger()
+	jiraClient := &fakeJiraClient{activities: createTestActivities()}
+	geminiClient := &fakeGeminiClient{}
+	docsClient := newPreviewingDocsClient(logger)
+	p := NewPipeline(Dependencies{
+		JiraClient:   jiraClient,
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.DryRun = true
+	opts.ShareWith = []string{"exec@example.com", "cto@example.com"}
+	
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	// Activities are still fetched and processed
+	assert.Equal(t, 1, jiraClient.calls)
+	assert.Equal(t, 2, result.Processing.Summary.TotalActivities)
+	
+	// The deterministic summary stands in for the AI summary
+	assert.Zero(t, geminiClient.calls)
+	assert.Equal(t, DryRunSummaryModel, result.AISummary.Model)
+	assert.Equal(t, result.Summary.ExecutiveSummary, result.AISummary.Summary)
+	
+	// Nothing is written, but the intended document is described
+	assert.Zero(t, docsClient.writes)
+	assert.Nil(t, result.Document)
+	require.NotNil(t, result.Preview)
+	assert.Equal(t, "Weekly Summary", result.Preview.Title)
+	assert.NotEmpty(t, result.Preview.Requests)
+	assert.Equal(t, []string{"exec@example.com", "cto@example.com"}, result.Preview.ShareWith)
+	assert.Equal(t, gdocs.RoleReader, result.Preview.ShareRole)
+	require.Len(t, result.Preview.Actions, 2)
+	assert.Contains(t, result.Preview.Actions[0], `Create document "Weekly Summary"`)
+	assert.Contains(t, result.Preview.Actions[1], "exec@example.com, cto@example.com")
+	assert.Len(t, logger.GetEntriesByMessage("Dry run, skipping write"), 2)
+	
+	// A later real run is not served a cached dry-run summary
+	opts.DryRun = false
+	result, err = p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, geminiClient.calls)
+	assert.False(t, result.SummaryCacheHit)
+	assert.Equal(t, "doc-1", result.Document.DocumentID)
+	assert.Nil(t, result.Preview)
+}
+
+func TestPipeline_Run_DryRunWithoutPreviewer(t *testing.T) {
+	logger := utils.NewMockLogger()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.DryRun = true
+	
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	// Docs clients that cannot preview requests still report the skipped creation
+	assert.Zero(t, docsClient.writes)
+	require.NotNil(t, result.Preview)
+	assert.Empty(t, result.Preview.Requests)
+	assert.Equal(t, []string{`Create document "Weekly Summary" with 0 formatting requests`}, result.Preview.Actions)
+}
+
+func TestPipeline_Run_ValidationThresholdExceeded(t *testing.T) {
+	logger := utils.NewMockLogger()
+	geminiClient := &fakeGeminiClient{}
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createInvalidActivities(3)},
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.MaxValidationErrors = 2
+	
+	result, err := p.Run(context.Background(), opts)
+	require.Error(t, err)
+	assert.Nil(t, result)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeValidationError, appErr.Code)
+	assert.Equal(t, 3, appErr.Context.Extra["error_count"])
+	assert.Len(t, appErr.Context.Extra["validation_errors"], 3)
+	
+	// The run aborts before anything is summarized or published
+	assert.Equal(t, 0, geminiClient.calls)
+	assert.Empty(t, docsClient.created)
+}
+
+func TestPipeline_Run_ValidationBelowThreshold(t *testing.T) {
+	logger := utils.NewMockLogger()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: append(createTestActivities(), createInvalidActivities(2)...)},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.MaxValidationErrors = 2
+	
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	assert.Len(t, result.ValidationErrors, 2)
+	assert.Equal(t, "PROJ-1.summary", result.ValidationErrors[0].Field)
+	assert.Len(t, docsClient.created, 1)
+	
+	warnEntries := logger.GetEntriesByLevel(utils.LogLevelWarn)
+	assert.NotEmpty(t, warnEntries)
+}
+
+func TestPipeline_Run_ValidationThresholdDisabled(t *testing.T) {
+	logger := utils.NewMockLogger()
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createInvalidActivities(5)},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   &fakeDocsClient{},
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	result, err := p.Run(context.Background(), createTestOptions())
+	require.NoError(t, err)
+	assert.Len(t, result.ValidationErrors, 5)
+}
+
+func TestPipeline_Run_Summarizer(t *testing.T) {
+	logger := utils.NewMockLogger()
+	geminiClient := &fakeGeminiClient{}
+	summaries := &fakeSummarizer{}
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: geminiClient,
+		Summarizer:   summaries,
+		DocsClient:   docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.CustomPrompt = "Focus on risks"
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	// The configured summarizer is used instead of the Gemini client
+	assert.Equal(t, 1, summaries.calls)
+	assert.Equal(t, 0, geminiClient.calls)
+	assert.Equal(t, "Focus on risks", summaries.opts.CustomPrompt)
+	assert.Equal(t, "self-hosted", result.AISummary.Model)
+	assert.Equal(t, "self-hosted", docsClient.metadata["model"])
+}
+
+func TestPipeline_Run_SummarizerError(t *testing.T) {
+	logger := utils.NewMockLogger()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient: &fakeJiraClient{activities: createTestActivities()},
+		Summarizer: &fakeSummarizer{err: errors.New("model unavailable")},
+		DocsClient: docsClient,
+	}, logger)
+	
+	_, err := p.Run(context.Background(), createTestOptions())
+	require.Error(t, err)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeSummarizerError, appErr.Code)
+	assert.Empty(t, docsClient.created)
+}
+
+func TestPipeline_Run_JiraError(t *testing.T) {
+	logger := utils.NewMockLogger()
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{err: errors.New("connection refused")},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   &fakeDocsClient{},
+	}, logger)
+	
+	_, err := p.Run(context.Background(), createTestOptions())
+	require.Error(t, err)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeJiraError, appErr.Code)
+}
+
+func TestPipeline_Run_Canceled(t *testing.T) {
+	logger := utils.NewMockLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	geminiClient := &fakeGeminiClient{}
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities(), cancel: cancel},
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+	}, logger)
+	
+	// Canceling is not a failure: the run stops and says so
+	result, err := p.Run(ctx, createTestOptions())
+	require.NoError(t, err)
+	assert.True(t, result.Canceled)
+	assert.NotEmpty(t, result.CorrelationID)
+	assert.Nil(t, result.Summary)
+	assert.Nil(t, result.Document)
+	assert.Zero(t, geminiClient.calls)
+	assert.Zero(t, docsClient.writes)
+	assert.Len(t, logger.GetEntriesByMessage("Pipeline run canceled"), 1)
+	assert.Empty(t, logger.GetEntriesByMessage("Pipeline run completed"))
+}
+
+func TestPipeline_Run_CanceledWhileSummarizing(t *testing.T) {
+	logger := utils.NewMockLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient: &fakeJiraClient{activities: createTestActivities()},
+		Summarizer: &cancelingSummarizer{cancel: cancel},
+		DocsClient: docsClient,
+	}, logger)
+	
+	result, err := p.Run(ctx, createTestOptions())
+	require.NoError(t, err)
+	
+	// What was done before the cancellation is kept, but no document is created
+	assert.True(t, result.Canceled)
+	assert.Len(t, result.Activities, 2)
+	assert.NotNil(t, result.Processing)
+	assert.Nil(t, result.Document)
+	assert.Empty(t, docsClient.created)
+	assert.Zero(t, docsClient.writes)
+}
+
+func TestPipeline_Run_DeadlineExceeded(t *testing.T) {
+	logger := utils.NewMockLogger()
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   &fakeDocsClient{},
+	}, logger)
+	
+	// Running out of time is still a failure
+	result, err := p.Run(ctx, createTestOptions())
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestPipeline_Run_NoCompletionFallback(t *testing.T) {
+	logger := utils.NewMockLogger()
+	activities := createTestActivities()
+	activities[0].Status = "Blocked"
+	
+	geminiClient := &fakeGeminiClient{}
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: activities},
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.Processing.GroupByStatus = true
+	opts.NoCompletionFallback = true
+	
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	assert.True(t, result.Summary.FallbackUsed)
+	assert.Equal(t, 0, geminiClient.calls)
+	assert.Equal(t, FallbackSummaryModel, result.AISummary.Model)
+	assert.Equal(t, result.Summary.ExecutiveSummary, result.AISummary.Summary)
+	assert.Contains(t, result.AISummary.Summary, "1 item is blocked or waiting")
+	assert.Len(t, docsClient.created, 1)
+	
+	// Without the option the AI summary is still requested
+	opts.NoCompletionFallback = false
+	result, err = p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, 1, geminiClient.calls)
+	assert.Equal(t, gemini.ModelGeminiPro, result.AISummary.Model)
+}
+
+func TestPipeline_Run_ReportingTimeZone(t *testing.T) {
+	logger := utils.NewMockLogger()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.TimeRange.Start = time.Date(2024, 1, 8, 2, 0, 0, 0, time.UTC)
+	opts.TimeRange.End = time.Date(2024, 1, 15, 2, 0, 0, 0, time.UTC)
+	opts.Processing.Location = time.FixedZone("EST", -5*60*60)
+	
+	_, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	// Both ends fall on the previous day in the reporting time zone
+	assert.Equal(t, "2024-01-07 to 2024-01-14", docsClient.metadata["time_range"])
+	generatedAt, ok := docsClient.metadata["generated_at"].(time.Time)
+	require.True(t, ok)
+	assert.Equal(t, opts.Processing.Location, generatedAt.Location())
+}
+
+func TestPipeline_Run_ReportingTimeZoneDefaultsToUTC(t *testing.T) {
+	logger := utils.NewMockLogger()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+	}, logger)
+	
+	// Without a reporting time zone the dates are those of UTC, not of the time range's zone
+	est := time.FixedZone("EST", -5*60*60)
+	opts := createTestOptions()
+	opts.TimeRange.Start = time.Date(2024, 1, 7, 21, 0, 0, 0, est)
+	opts.TimeRange.End = time.Date(2024, 1, 14, 21, 0, 0, 0, est)
+	opts.Processing.Location = nil
+	
+	_, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	assert.Equal(t, "2024-01-08 to 2024-01-15", docsClient.metadata["time_range"])
+	generatedAt, ok := docsClient.metadata["generated_at"].(time.Time)
+	require.True(t, ok)
+	assert.Equal(t, time.UTC, generatedAt.Location())
+}
+
+func TestPipeline_Run_SummaryLanguage(t *testing.T) {
+	logger := utils.NewMockLogger()
+	geminiClient := &fakeGeminiClient{}
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.CustomPrompt = "Focus on delivery"
+	opts.Summary.Language = "fr-CA"
+	
+	_, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "Focus on delivery\nWrite the entire summary, including section headings, in French.", geminiClient.prompt)
+	assert.Equal(t, "fr-CA", docsClient.metadata[gdocs.MetadataKeyLanguage])
+	
+	// The deterministic fallback is localized as well
+	activities := createTestActivities()
+	activities[0].Status = "Blocked"
+	p = NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: activities},
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+	}, logger)
+	opts.NoCompletionFallback = true
+	opts.Processing.GroupByStatus = true
+	
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, FallbackSummaryModel, result.AISummary.Model)
+	assert.Contains(t, result.AISummary.Summary, "Au cours de la période hebdomadaire")
+	assert.Contains(t, result.AISummary.Summary, "1 élément est bloqué ou en attente")
+	
+	// Without a language the custom prompt is passed through unchanged
+	opts = createTestOptions()
+	opts.CustomPrompt = "Focus on delivery"
+	_, err = NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+	}, logger).Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, "Focus on delivery", geminiClient.prompt)
+	assert.NotContains(t, docsClient.metadata, gdocs.MetadataKeyLanguage)
+}
+
+func TestPipeline_RunActivities(t *testing.T) {
+	logger := utils.NewMockLogger()
+	jiraClient := &fakeJiraClient{err: errors.New("jira should not be called")}
+	geminiClient := &fakeGeminiClient{}
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient:   jiraClient,
+		GeminiClient: geminiClient,
+		DocsClient:   docsClient,
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	activities := createTestActivities()
+	result, err := p.RunActivities(context.Background(), activities, createTestOptions())
+	require.NoError(t, err)
+	
+	assert.Equal(t, 0, jiraClient.calls)
+	assert.Equal(t, activities, result.Activities)
+	assert.Empty(t, result.ValidationErrors)
+	assert.Equal(t, 2, result.Processing.Summary.TotalActivities)
+	assert.Equal(t, 1, result.Processing.UserMetrics["user1"].TotalActivities)
+	assert.Equal(t, "Weekly Summary", result.Summary.Title)
+	assert.Equal(t, 1, geminiClient.calls)
+	assert.Equal(t, "doc-1", result.Document.DocumentID)
+	assert.Equal(t, []string{"Weekly Summary"}, docsClient.created)
+}
+
+func TestPipeline_RunActivities_ValidationThresholdExceeded(t *testing.T) {
+	logger := utils.NewMockLogger()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   docsClient,
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.MaxValidationErrors = 1
+	
+	_, err := p.RunActivities(context.Background(), createInvalidActivities(2), opts)
+	require.Error(t, err)
+	assert.True(t, err.(*utils.AppError).IsType(utils.ErrorCodeValidationError))
+	assert.Empty(t, docsClient.created)
+}
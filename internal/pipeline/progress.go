@@ -0,0 +1,59 @@
+package pipeline
+
+import (
+	"context"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// Phase is a step of a pipeline run
+type Phase string
+
+// Phases of a run, in the order they are reported
+const (
+	PhaseFetch     Phase = "fetch"     // Fetching activities from Jira
+	PhaseProcess   Phase = "process"   // Validating activities and computing metrics
+	PhaseSummarize Phase = "summarize" // Writing the narrative summary
+	PhasePublish   Phase = "publish"   // Creating and sharing the document, or previewing it in a dry run
+	PhaseDone      Phase = "done"      // The run finished
+)
+
+// phasePercent is how far through a run each phase starts, weighted by how long the phases
+// usually take: fetching and summarizing call remote services, processing does not
+var phasePercent = map[Phase]int{
+	PhaseFetch:     0,
+	PhaseProcess:   40,
+	PhaseSummarize: 50,
+	PhasePublish:   85,
+	PhaseDone:      100,
+}
+
+// Progress reports that a run entered a phase
+type Progress struct {
+	Phase   Phase  `json:"phase"`
+	Percent int    `json:"percent"` // Share of the run completed, 0 to 100
+	Message string `json:"message"`
+}
+
+// ProgressFunc receives the progress of a run. It is called on the goroutine running the
+// pipeline, so callbacks that update a UI must hand the update to the UI thread themselves.
+type ProgressFunc func(Progress)
+
+// startPhase reports that the run entered phase. A run whose context is done stops there
+// and returns the context error instead, so no phase of a canceled run is started or reported.
+func (p *Pipeline) startPhase(ctx context.Context, opts Options, phase Phase, message string) error {
+	if err := ctx.Err(); err != nil {
+		return utils.WrapError(err, utils.ErrorCodeTimeoutError, "Pipeline run canceled").
+			WithExtra("phase", string(phase))
+	}
+	p.report(ctx, opts, phase, message)
+	return nil
+}
+
+// report passes the progress of a run to its callback, unless the run was canceled
+func (p *Pipeline) report(ctx context.Context, opts Options, phase Phase, message string) {
+	if opts.Progress == nil || ctx.Err() != nil {
+		return
+	}
+	opts.Progress(Progress{Phase: phase, Percent: phasePercent[phase], Message: message})
+}
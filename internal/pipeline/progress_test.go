@@ -0,0 +1,128 @@
+package pipeline
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/eesa/internal/summarizer"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/company/eesa/pkg/validation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cancelingSummarizer cancels the run while it is summarizing
+type cancelingSummarizer struct {
+	cancel context.CancelFunc
+}
+
+func (s *cancelingSummarizer) GenerateSummary(ctx context.Context, activities []models.Activity, opts summarizer.Options) (*summarizer.Summary, error) {
+	s.cancel()
+	return &summarizer.Summary{Summary: "Finished as the run was canceled."}, nil
+}
+
+// recordProgress returns a callback that appends each reported progress to events
+func recordProgress(events *[]Progress) ProgressFunc {
+	return func(progress Progress) {
+		*events = append(*events, progress)
+	}
+}
+
+func TestPipeline_Run_Progress(t *testing.T) {
+	logger := utils.NewMockLogger()
+	p := NewPipeline(Dependencies{
+		JiraClient:   &fakeJiraClient{activities: createTestActivities()},
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   &fakeDocsClient{},
+		Validator:    validation.NewServiceValidationRules(logger),
+	}, logger)
+	
+	var events []Progress
+	opts := createTestOptions()
+	opts.Progress = recordProgress(&events)
+	
+	_, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	assert.Equal(t, []Progress{
+		{Phase: PhaseFetch, Percent: 0, Message: "Fetching activities from Jira"},
+		{Phase: PhaseProcess, Percent: 40, Message: "Processing 2 activities"},
+		{Phase: PhaseSummarize, Percent: 50, Message: "Generating summary"},
+		{Phase: PhasePublish, Percent: 85, Message: "Publishing document"},
+		{Phase: PhaseDone, Percent: 100, Message: "Done"},
+	}, events)
+	
+	// Runs that publish nothing skip the publish phase, and a dry run previews instead
+	events = nil
+	opts.SkipPublish = true
+	_, err = p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	assert.Equal(t, []Phase{PhaseFetch, PhaseProcess, PhaseSummarize, PhaseDone}, progressPhases(events))
+	
+	events = nil
+	opts.SkipPublish = false
+	opts.DryRun = true
+	_, err = p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	assert.Equal(t, "Previewing document", events[3].Message)
+}
+
+func TestPipeline_RunActivities_Progress(t *testing.T) {
+	logger := utils.NewMockLogger()
+	p := NewPipeline(Dependencies{
+		GeminiClient: &fakeGeminiClient{},
+		DocsClient:   &fakeDocsClient{},
+	}, logger)
+	
+	var events []Progress
+	opts := createTestOptions()
+	opts.Progress = recordProgress(&events)
+	
+	_, err := p.RunActivities(context.Background(), createTestActivities(), opts)
+	require.NoError(t, err)
+	
+	// Supplied activities are not fetched
+	assert.Equal(t, []Phase{PhaseProcess, PhaseSummarize, PhasePublish, PhaseDone}, progressPhases(events))
+}
+
+func TestPipeline_Run_ProgressCanceled(t *testing.T) {
+	logger := utils.NewMockLogger()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	docsClient := &fakeDocsClient{}
+	p := NewPipeline(Dependencies{
+		JiraClient: &fakeJiraClient{activities: createTestActivities()},
+		Summarizer: &cancelingSummarizer{cancel: cancel},
+		DocsClient: docsClient,
+	}, logger)
+	
+	var events []Progress
+	opts := createTestOptions()
+	opts.Progress = recordProgress(&events)
+	
+	result, err := p.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.True(t, result.Canceled)
+	
+	// Reporting stops with the cancellation, before the publish phase and without a done event
+	assert.Equal(t, []Phase{PhaseFetch, PhaseProcess, PhaseSummarize}, progressPhases(events))
+	assert.Zero(t, docsClient.writes)
+	
+	// A run canceled before it starts reports nothing
+	events = nil
+	result, err = p.Run(ctx, opts)
+	require.NoError(t, err)
+	assert.True(t, result.Canceled)
+	assert.Empty(t, events)
+}
+
+// progressPhases returns the phases of events in order
+func progressPhases(events []Progress) []Phase {
+	phases := make([]Phase, len(events))
+	for i, event := range events {
+		phases[i] = event.Phase
+	}
+	return phases
+}
@@ -0,0 +1,195 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/summarizer"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// DefaultMaxConcurrentSummaries is how many project summaries are generated at once when
+// Options.MaxConcurrentSummaries is not set
+const DefaultMaxConcurrentSummaries = 3
+
+// NoProjectKey is the section key of activities that belong to no project
+const NoProjectKey = "NONE"
+
+// ProjectSummary is the narrative summary of one project's activities in a per-project run
+type ProjectSummary struct {
+	Key           string `json:"key"`
+	Name          string `json:"name"`
+	ActivityCount int    `json:"activity_count"`
+	Summary       string `json:"summary"`
+	Model         string `json:"model"`
+}
+
+// projectActivities is the share of a run's activities that belong to one project
+type projectActivities struct {
+	key        string
+	name       string
+	activities []models.Activity
+}
+
+// partitionByProject groups activities by project key, in key order. Activities without a
+// project are grouped under NoProjectKey.
+func partitionByProject(activities []models.Activity) []projectActivities {
+	groups := make(map[string]*projectActivities)
+	for _, activity := range activities {
+		key := activity.Project.Key
+		name := activity.Project.Name
+		if key == "" {
+			key, name = NoProjectKey, "No project"
+		}
+		group, exists := groups[key]
+		if !exists {
+			group = &projectActivities{key: key, name: name}
+			groups[key] = group
+		}
+		if group.name == "" {
+			group.name = name
+		}
+		group.activities = append(group.activities, activity)
+	}
+	
+	projects := make([]projectActivities, 0, len(groups))
+	for _, group := range groups {
+		if group.name == "" {
+			group.name = group.key
+		}
+		projects = append(projects, *group)
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		return projects[i].key < projects[j].key
+	})
+	return projects
+}
+
+// projectSummaries generates a summary of each project's activities, at most
+// opts.MaxConcurrentSummaries at a time. Summaries keep the project key order.
+func (p *Pipeline) projectSummaries(ctx context.Context, opts Options, activities []models.Activity) ([]ProjectSummary, error) {
+	projects := partitionByProject(activities)
+	summaries := make([]ProjectSummary, len(projects))
+	errs := make([]error, len(projects))
+	
+	workers := opts.MaxConcurrentSummaries
+	if workers < 1 {
+		workers = DefaultMaxConcurrentSummaries
+	}
+	if workers > len(projects) {
+		workers = len(projects)
+	}
+	
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				summaries[i], errs[i] = p.projectSummary(ctx, opts, projects[i])
+			}
+		}()
+	}
+	
+	// Stop handing out projects once the context is done, the workers finish the ones they hold
+feed:
+	for i := range projects {
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+	
+	if err := ctx.Err(); err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeTimeoutError, "Stopped generating project summaries")
+	}
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	
+	return summaries, nil
+}
+
+// projectSummary summarizes one project. Like the run summary, a dry run or a project with
+// nothing completed under the no-completion fallback uses the deterministic summary instead
+// of calling the summarizer.
+func (p *Pipeline) projectSummary(ctx context.Context, opts Options, project projectActivities) (ProjectSummary, error) {
+	summary := ProjectSummary{
+		Key:           project.key,
+		Name:          project.name,
+		ActivityCount: len(project.activities),
+	}
+	
+	processingResult, err := p.processor.ProcessActivities(ctx, project.activities, opts.Processing)
+	if err != nil {
+		return summary, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to process project activities").
+			WithExtra("project", project.key)
+	}
+	request := opts.Summary
+	request.Title = project.name
+	deterministic, err := p.summaries.GenerateSummary(ctx, processingResult, request)
+	if err != nil {
+		return summary, utils.WrapError(err, utils.ErrorCodeInternalError, "Failed to generate project summary").
+			WithExtra("project", project.key)
+	}
+	
+	switch {
+	case opts.DryRun:
+		summary.Summary, summary.Model = deterministic.ExecutiveSummary, DryRunSummaryModel
+		return summary, nil
+	case opts.NoCompletionFallback && deterministic.FallbackUsed:
+		summary.Summary, summary.Model = deterministic.ExecutiveSummary, FallbackSummaryModel
+		return summary, nil
+	}
+	
+	aiSummary, err := p.summarizer.GenerateSummary(ctx, project.activities, summarizer.Options{
+		CustomPrompt: projectPrompt(opts, project),
+	})
+	if err != nil {
+		return summary, utils.WrapError(err, utils.ErrorCodeSummarizerError, "Failed to generate project summary").
+			WithExtra("project", project.key)
+	}
+	summary.Summary, summary.Model = aiSummary.Summary, aiSummary.Model
+	
+	return summary, nil
+}
+
+// projectPrompt returns the custom prompt for a project summary, which covers only that project
+func projectPrompt(opts Options, project projectActivities) string {
+	instruction := fmt.Sprintf("These activities all belong to the %s (%s) project. Summarize this project's work only, in a short section without a title.",
+		project.name, project.key)
+	if prompt := summaryPrompt(opts); prompt != "" {
+		return prompt + "\n" + instruction
+	}
+	return instruction
+}
+
+// withProjectSections returns a copy of the roll-up summary followed by a headed section per
+// project, which is what a per-project run publishes
+func withProjectSections(rollUp *gemini.SummaryResponse, projects []ProjectSummary) *gemini.SummaryResponse {
+	combined := *rollUp
+	
+	var text strings.Builder
+	text.WriteString(strings.TrimSpace(rollUp.Summary))
+	for _, project := range projects {
+		heading := project.Key
+		if project.Name != project.Key {
+			heading = fmt.Sprintf("%s (%s)", project.Name, project.Key)
+		}
+		text.WriteString(fmt.Sprintf("\n\n## %s\n\n%s", heading, strings.TrimSpace(project.Summary)))
+	}
+	combined.Summary = text.String()
+	
+	return &combined
+}
@@ -0,0 +1,180 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/summarizer"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// projectSummarizer summarizes each call by the projects of its activities and records how
+// many calls were in flight at once
+type projectSummarizer struct {
+	mu          sync.Mutex
+	calls       int
+	inFlight    int
+	maxInFlight int
+	prompts     []string
+}
+
+func (f *projectSummarizer) GenerateSummary(ctx context.Context, activities []models.Activity, opts summarizer.Options) (*summarizer.Summary, error) {
+	f.mu.Lock()
+	f.calls++
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.prompts = append(f.prompts, opts.CustomPrompt)
+	f.mu.Unlock()
+	
+	// Give overlapping calls the chance to show up
+	time.Sleep(5 * time.Millisecond)
+	
+	f.mu.Lock()
+	f.inFlight--
+	f.mu.Unlock()
+	
+	keys := make(map[string]bool)
+	for _, activity := range activities {
+		keys[activity.Project.Key] = true
+	}
+	text := fmt.Sprintf("Summary of %d activities.", len(activities))
+	if len(keys) == 1 {
+		text = fmt.Sprintf("Summary of %s.", activities[0].Project.Key)
+	}
+	return &summarizer.Summary{
+		Summary:     text,
+		Model:       "project-model",
+		GeneratedAt: time.Now(),
+		Activities:  activities,
+	}, nil
+}
+
+// createProjectActivities returns the test activities spread across the given projects
+func createProjectActivities(projects ...models.Project) []models.Activity {
+	var activities []models.Activity
+	for i, project := range projects {
+		for j, activity := range createTestActivities() {
+			activity.ID = fmt.Sprintf("%d-%d", i, j)
+			activity.Key = fmt.Sprintf("%s-%d", project.Key, j+1)
+			activity.Project = project
+			activities = append(activities, activity)
+		}
+	}
+	return activities
+}
+
+func TestPipeline_Run_PerProject(t *testing.T) {
+	logger := utils.NewMockLogger()
+	summaries := &projectSummarizer{}
+	docsClient := &fakeDocsClient{}
+	activities := createProjectActivities(
+		models.Project{Key: "WEB", Name: "Website"},
+		models.Project{Key: "OPS", Name: "Operations"},
+	)
+	p := NewPipeline(Dependencies{
+		JiraClient: &fakeJiraClient{activities: activities},
+		Summarizer: summaries,
+		DocsClient: docsClient,
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.PerProject = true
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	// One roll-up over every activity and one summary per project
+	assert.Equal(t, 3, summaries.calls)
+	require.Len(t, result.ProjectSummaries, 2)
+	assert.Equal(t, ProjectSummary{Key: "OPS", Name: "Operations", ActivityCount: 2, Summary: "Summary of OPS.", Model: "project-model"}, result.ProjectSummaries[0])
+	assert.Equal(t, "WEB", result.ProjectSummaries[1].Key)
+	assert.Equal(t, "Summary of OPS.", result.Summary.Sections["OPS"])
+	assert.Equal(t, "Summary of WEB.", result.Summary.Sections["WEB"])
+	
+	// The published summary is the roll-up followed by a section per project
+	assert.Equal(t, "Summary of 4 activities.\n\n## Operations (OPS)\n\nSummary of OPS.\n\n## Website (WEB)\n\nSummary of WEB.",
+		result.AISummary.Summary)
+	assert.Len(t, result.AISummary.Activities, 4)
+	assert.Equal(t, []string{"Weekly Summary"}, docsClient.created)
+	
+	// Project prompts name their project
+	var projectPrompts int
+	for _, prompt := range summaries.prompts {
+		if strings.Contains(prompt, "the Operations (OPS) project") || strings.Contains(prompt, "the Website (WEB) project") {
+			projectPrompts++
+		}
+	}
+	assert.Equal(t, 2, projectPrompts)
+}
+
+func TestPipeline_Run_PerProjectConcurrency(t *testing.T) {
+	logger := utils.NewMockLogger()
+	summaries := &projectSummarizer{}
+	var projects []models.Project
+	for i := 0; i < 6; i++ {
+		projects = append(projects, models.Project{Key: fmt.Sprintf("P%d", i)})
+	}
+	p := NewPipeline(Dependencies{
+		JiraClient: &fakeJiraClient{activities: createProjectActivities(projects...)},
+		Summarizer: summaries,
+		DocsClient: &fakeDocsClient{},
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.PerProject = true
+	opts.MaxConcurrentSummaries = 2
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	assert.Len(t, result.ProjectSummaries, 6)
+	assert.Equal(t, 7, summaries.calls)
+	assert.LessOrEqual(t, summaries.maxInFlight, 2)
+	// Projects without a name are headed by their key alone
+	assert.Contains(t, result.AISummary.Summary, "\n\n## P0\n\nSummary of P0.")
+}
+
+func TestPipeline_Run_PerProjectDryRun(t *testing.T) {
+	logger := utils.NewMockLogger()
+	summaries := &projectSummarizer{}
+	p := NewPipeline(Dependencies{
+		JiraClient: &fakeJiraClient{activities: createProjectActivities(models.Project{Key: "WEB", Name: "Website"})},
+		Summarizer: summaries,
+		DocsClient: &fakeDocsClient{},
+	}, logger)
+	
+	opts := createTestOptions()
+	opts.PerProject = true
+	opts.DryRun = true
+	result, err := p.Run(context.Background(), opts)
+	require.NoError(t, err)
+	
+	// Neither the roll-up nor the project summaries call the summarizer
+	assert.Equal(t, 0, summaries.calls)
+	require.Len(t, result.ProjectSummaries, 1)
+	assert.Equal(t, DryRunSummaryModel, result.ProjectSummaries[0].Model)
+	assert.NotEmpty(t, result.ProjectSummaries[0].Summary)
+}
+
+func TestPartitionByProject(t *testing.T) {
+	activities := createProjectActivities(models.Project{Key: "WEB", Name: "Website"})
+	unassigned := createTestActivities()[0]
+	unassigned.Key = "LOOSE-1"
+	activities = append(activities, unassigned)
+	
+	projects := partitionByProject(activities)
+	require.Len(t, projects, 2)
+	assert.Equal(t, NoProjectKey, projects[0].key)
+	assert.Equal(t, "No project", projects[0].name)
+	assert.Len(t, projects[0].activities, 1)
+	assert.Equal(t, "WEB", projects[1].key)
+	assert.Equal(t, "Website", projects[1].name)
+	assert.Len(t, projects[1].activities, 2)
+}
@@ -0,0 +1,133 @@
+package processor
+
+import "math"
+
+// Directions of a metric between two periods
+const (
+	ChangeImproved = "improved"
+	ChangeDeclined = "declined"
+	ChangeFlat     = "flat"
+)
+
+// FlatChangeThreshold is the largest relative change, in percent, still classified as flat
+const FlatChangeThreshold = 2.0
+
+// MetricChange compares one metric across two periods
+type MetricChange struct {
+	Current       float64 `json:"current"`
+	Previous      float64 `json:"previous"`
+	Delta         float64 `json:"delta"`          // Current minus previous
+	PercentChange float64 `json:"percent_change"` // Delta relative to previous, 0 when previous is 0
+	Direction     string  `json:"direction"`      // ChangeImproved, ChangeDeclined or ChangeFlat
+}
+
+// ComparisonResult compares a period's processing result with the preceding period. For every
+// metric a higher value counts as an improvement, including total time, where more logged
+// effort is read as more capacity spent on the work.
+type ComparisonResult struct {
+	CompletionRate    MetricChange  `json:"completion_rate"` // Percentage points
+	ProductivityScore MetricChange  `json:"productivity_score"`
+	TotalTimeSpent    MetricChange  `json:"total_time_spent"`   // Seconds
+	Velocity          *MetricChange `json:"velocity,omitempty"` // Nil unless both periods calculated velocity
+	// UserCompletions compares completed activities per user ID. Users active in only one of
+	// the periods count zero completions in the other.
+	UserCompletions map[string]MetricChange `json:"user_completions"`
+}
+
+// CompareResults computes the change of the headline metrics from previous to current. It
+// returns nil when either result is missing.
+func CompareResults(current, previous *ProcessingResult) *ComparisonResult {
+	if current == nil || previous == nil {
+		return nil
+	}
+
+	comparison := &ComparisonResult{
+		CompletionRate:    compareMetric(current.Summary.CompletionRate, previous.Summary.CompletionRate),
+		ProductivityScore: compareMetric(current.Summary.ProductivityScore, previous.Summary.ProductivityScore),
+		TotalTimeSpent:    compareMetric(float64(current.Summary.TotalTimeSpent), float64(previous.Summary.TotalTimeSpent)),
+		UserCompletions:   make(map[string]MetricChange),
+	}
+
+	if current.VelocityMetrics != nil && previous.VelocityMetrics != nil {
+		velocity := compareMetric(current.VelocityMetrics.CurrentVelocity, previous.VelocityMetrics.CurrentVelocity)
+		comparison.Velocity = &velocity
+	}
+
+	for userID, user := range current.UserMetrics {
+		comparison.UserCompletions[userID] = compareMetric(float64(user.CompletedActivities),
+			float64(previous.UserMetrics[userID].CompletedActivities))
+	}
+	for userID, user := range previous.UserMetrics {
+		if _, ok := current.UserMetrics[userID]; !ok {
+			comparison.UserCompletions[userID] = compareMetric(0, float64(user.CompletedActivities))
+		}
+	}
+
+	return comparison
+}
+
+// compareMetric classifies the change from previous to current. Without a previous value any
+// change is significant, so only an unchanged value is flat.
+func compareMetric(current, previous float64) MetricChange {
+	change := MetricChange{
+		Current:  current,
+		Previous: previous,
+		Delta:    current - previous,
+	}
+	if previous != 0 {
+		change.PercentChange = change.Delta / math.Abs(previous) * 100
+	}
+
+	switch {
+	case change.Delta == 0:
+		change.Direction = ChangeFlat
+	case previous != 0 && math.Abs(change.PercentChange) <= FlatChangeThreshold:
+		change.Direction = ChangeFlat
+	case change.Delta > 0:
+		change.Direction = ChangeImproved
+	default:
+		change.Direction = ChangeDeclined
+	}
+
+	return change
+}
+
+// generateComparisonHighlights describes the headline metrics that improved on the previous period
+func (sg *SummaryGenerator) generateComparisonHighlights(comparison *ComparisonResult, language string) []string {
+	return sg.comparisonStatements(comparison, ChangeImproved, msgComparisonRose, language)
+}
+
+// generateComparisonConcerns describes the headline metrics that declined from the previous period
+func (sg *SummaryGenerator) generateComparisonConcerns(comparison *ComparisonResult, language string) []string {
+	return sg.comparisonStatements(comparison, ChangeDeclined, msgComparisonFell, language)
+}
+
+// comparisonStatements describes the completion rate, productivity and velocity changes in
+// the given direction, in language. verbKey is the catalog key of the verb for that direction.
+func (sg *SummaryGenerator) comparisonStatements(comparison *ComparisonResult, direction, verbKey, language string) []string {
+	if comparison == nil {
+		return nil
+	}
+	verb := message(language, verbKey)
+
+	var statements []string
+	if change := comparison.CompletionRate; change.Direction == direction {
+		statements = append(statements, messagef(language, msgComparisonCompletionRate,
+			verb, sg.formatPercentage(change.Current), sg.formatPercentage(change.Previous), change.Delta))
+	}
+	if change := comparison.ProductivityScore; change.Direction == direction {
+		statements = append(statements, messagef(language, msgComparisonProductivity,
+			verb, change.Current, change.Previous))
+	}
+	if change := comparison.Velocity; change != nil && change.Direction == direction {
+		if change.Previous == 0 {
+			statements = append(statements, messagef(language, msgComparisonVelocityNew,
+				verb, change.Current))
+		} else {
+			statements = append(statements, messagef(language, msgComparisonVelocity,
+				verb, math.Abs(change.PercentChange), change.Current))
+		}
+	}
+
+	return statements
+}
@@ -0,0 +1,145 @@
+package processor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createComparisonResults() (current, previous *ProcessingResult) {
+	current = &ProcessingResult{
+		Summary: ProcessingSummary{
+			CompletionRate:    82,
+			ProductivityScore: 60,
+			TotalTimeSpent:    36000,
+		},
+		VelocityMetrics: &VelocityMetrics{CurrentVelocity: 3},
+		UserMetrics: map[string]UserMetrics{
+			"user1": {CompletedActivities: 8},
+			"user2": {CompletedActivities: 4},
+			"user3": {CompletedActivities: 2},
+		},
+	}
+	previous = &ProcessingResult{
+		Summary: ProcessingSummary{
+			CompletionRate:    70,
+			ProductivityScore: 75,
+			TotalTimeSpent:    35700,
+		},
+		VelocityMetrics: &VelocityMetrics{CurrentVelocity: 2},
+		UserMetrics: map[string]UserMetrics{
+			"user1": {CompletedActivities: 5},
+			"user2": {CompletedActivities: 4},
+			"user4": {CompletedActivities: 3},
+		},
+	}
+	return current, previous
+}
+
+func TestCompareResults(t *testing.T) {
+	current, previous := createComparisonResults()
+
+	comparison := CompareResults(current, previous)
+	require.NotNil(t, comparison)
+
+	assert.Equal(t, 82.0, comparison.CompletionRate.Current)
+	assert.Equal(t, 70.0, comparison.CompletionRate.Previous)
+	assert.InDelta(t, 12.0, comparison.CompletionRate.Delta, 0.001)
+	assert.InDelta(t, 17.14, comparison.CompletionRate.PercentChange, 0.01)
+	assert.Equal(t, ChangeImproved, comparison.CompletionRate.Direction)
+
+	assert.InDelta(t, -15.0, comparison.ProductivityScore.Delta, 0.001)
+	assert.InDelta(t, -20.0, comparison.ProductivityScore.PercentChange, 0.001)
+	assert.Equal(t, ChangeDeclined, comparison.ProductivityScore.Direction)
+
+	// 300 seconds more on 35700 is under the flat threshold
+	assert.InDelta(t, 300.0, comparison.TotalTimeSpent.Delta, 0.001)
+	assert.InDelta(t, 0.84, comparison.TotalTimeSpent.PercentChange, 0.01)
+	assert.Equal(t, ChangeFlat, comparison.TotalTimeSpent.Direction)
+
+	require.NotNil(t, comparison.Velocity)
+	assert.InDelta(t, 1.0, comparison.Velocity.Delta, 0.001)
+	assert.InDelta(t, 50.0, comparison.Velocity.PercentChange, 0.001)
+	assert.Equal(t, ChangeImproved, comparison.Velocity.Direction)
+
+	require.Len(t, comparison.UserCompletions, 4)
+	assert.Equal(t, MetricChange{Current: 8, Previous: 5, Delta: 3, PercentChange: 60, Direction: ChangeImproved},
+		comparison.UserCompletions["user1"])
+	assert.Equal(t, MetricChange{Current: 4, Previous: 4, Direction: ChangeFlat}, comparison.UserCompletions["user2"])
+	// Users active in only one period count zero completions in the other
+	assert.Equal(t, MetricChange{Current: 2, Delta: 2, Direction: ChangeImproved}, comparison.UserCompletions["user3"])
+	assert.Equal(t, MetricChange{Previous: 3, Delta: -3, PercentChange: -100, Direction: ChangeDeclined},
+		comparison.UserCompletions["user4"])
+}
+
+func TestCompareResults_MissingData(t *testing.T) {
+	current, previous := createComparisonResults()
+
+	assert.Nil(t, CompareResults(nil, previous))
+	assert.Nil(t, CompareResults(current, nil))
+
+	previous.VelocityMetrics = nil
+	comparison := CompareResults(current, previous)
+	require.NotNil(t, comparison)
+	assert.Nil(t, comparison.Velocity)
+}
+
+func TestCompareMetric(t *testing.T) {
+	tests := []struct {
+		name      string
+		current   float64
+		previous  float64
+		direction string
+	}{
+		{"unchanged", 50, 50, ChangeFlat},
+		{"within threshold up", 51, 50, ChangeFlat},
+		{"within threshold down", 49, 50, ChangeFlat},
+		{"past threshold up", 52, 50, ChangeImproved},
+		{"past threshold down", 48, 50, ChangeDeclined},
+		{"from zero", 0.5, 0, ChangeImproved},
+		{"both zero", 0, 0, ChangeFlat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.direction, compareMetric(tt.current, tt.previous).Direction)
+		})
+	}
+}
+
+func TestSummaryGenerator_Comparison(t *testing.T) {
+	generator := NewSummaryGenerator(utils.NewMockLogger())
+	data := createTestProcessingResult()
+	previous := createTestProcessingResult()
+	previous.Summary.CompletionRate = data.Summary.CompletionRate - 10
+	previous.Summary.ProductivityScore = data.Summary.ProductivityScore * 2
+
+	t.Run("with previous period", func(t *testing.T) {
+		response, err := generator.GenerateSummary(context.Background(), data, SummaryRequest{
+			Period:   "weekly",
+			Previous: previous,
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, response.Comparison)
+		assert.Equal(t, ChangeImproved, response.Comparison.CompletionRate.Direction)
+		assert.Equal(t, ChangeDeclined, response.Comparison.ProductivityScore.Direction)
+		assert.Contains(t, response.Highlights, "Completion rate rose to "+
+			generator.formatPercentage(data.Summary.CompletionRate)+" from "+
+			generator.formatPercentage(previous.Summary.CompletionRate)+" (+10.0 points) compared with the previous period")
+		assert.Contains(t, response.Concerns[len(response.Concerns)-1], "Productivity score fell")
+	})
+
+	t.Run("without previous period", func(t *testing.T) {
+		response, err := generator.GenerateSummary(context.Background(), data, SummaryRequest{Period: "weekly"})
+		require.NoError(t, err)
+
+		assert.Nil(t, response.Comparison)
+		for _, highlight := range response.Highlights {
+			assert.NotContains(t, highlight, "previous period")
+		}
+	})
+}
@@ -3,7 +3,9 @@ package processor
 import (
 	"context"
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/company/eesa/pkg/models"
@@ -12,29 +14,129 @@ import (
 
 // DataProcessor handles aggregation and analysis of user activities
 type DataProcessor struct {
-	logger utils.Logger
+	logger             utils.Logger
+	statusWeights      map[string]float64
+	completedStatuses  map[string]bool // Normalized status names, nil uses DefaultCompletedStatuses
+	inProgressStatuses map[string]bool // Normalized status names, nil treats all open statuses as in progress
+	location           *time.Location  // Reporting time zone of the current run, nil outside a run keeps timestamps as recorded
+	staleAfter         time.Duration   // Stale threshold of the current run, zero outside a run
+	staleAsOf          time.Time       // Time staleness is measured from in the current run
 }
 
 // NewDataProcessor creates a new data processor instance
 func NewDataProcessor(logger utils.Logger) *DataProcessor {
 	return &DataProcessor{
-		logger: logger,
+		logger:        logger,
+		statusWeights: DefaultStatusProgressWeights(),
+	}
+}
+
+// DefaultCompletedStatuses lists the statuses treated as completed when none are configured
+var DefaultCompletedStatuses = []string{"Done", "Closed", "Resolved", "Complete", "Finished"}
+
+// DefaultInProgressWeight is the progress credit for statuses without an explicit weight
+const DefaultInProgressWeight = 0.5
+
+// DefaultStatusProgressWeights returns the default progress weights for intermediate statuses
+func DefaultStatusProgressWeights() map[string]float64 {
+	return map[string]float64{
+		"Open":        0.0,
+		"To Do":       0.0,
+		"Backlog":     0.0,
+		"In Progress": 0.5,
+		"In Review":   0.8,
+		"Code Review": 0.8,
+		"Testing":     0.9,
+	}
+}
+
+// SetStatusProgressWeights overrides the progress weights used for non-completed statuses
+func (dp *DataProcessor) SetStatusProgressWeights(weights map[string]float64) {
+	dp.statusWeights = make(map[string]float64, len(weights))
+	for status, weight := range weights {
+		dp.statusWeights[status] = weight
 	}
 }
 
 // ProcessingOptions configures how activities are processed
 type ProcessingOptions struct {
-	IncludeComments     bool
+	IncludeComments     bool // Count comments added in the last 7 days towards StatusMetrics.RecentChanges
+	// IncludeWorklogs uses the summed worklog seconds of an activity as its time spent. Activities
+	// without worklog entries keep the issue-level TimeSpent.
 	IncludeWorklogs     bool
 	GroupByPriority     bool
 	GroupByStatus       bool
+	GroupByType         bool
 	GroupByUser         bool
+	GroupByProject      bool
+	GroupByComponent    bool // Activities with several components count in each component
+	GroupByLabel        bool // Activities with several labels count in each label
+	GroupByEpic         bool
+	EpicField           string // Custom field holding the epic link, defaults to DefaultEpicField
 	CalculateVelocity   bool
+	// BusinessDayVelocity measures daily velocity over business days, skipping weekends and
+	// Holidays, instead of elapsed calendar days. Sprint velocity is unaffected.
+	BusinessDayVelocity bool
+	Holidays            []time.Time // Dates excluded from business days, compared by calendar date
+	Sprints             []SprintMetrics // Sprint windows with planned points, used for sprint velocity and burndown
 	AnalyzeTrends       bool
+	// TrendHalfLife weights activities in trends and seasonality by how long ago they were last
+	// updated, halving their contribution every half-life before the latest update. Zero disables it.
+	TrendHalfLife       time.Duration
+	// TrendThreshold is the slope per range, relative to the mean range value, beyond which a
+	// trend counts as increasing or decreasing. Zero uses DefaultTrendThreshold.
+	TrendThreshold      float64
+	// TrendGranularity splits trends into calendar days, weeks, months or quarters when no
+	// CustomTimeRanges are given. Empty keeps rolling seven-day ranges from the first activity.
+	TrendGranularity    TrendGranularity
 	CustomTimeRanges    []TimeRange
 	MinimumTimeSpent    int64 // Minimum seconds to include activity
+	CompletedStatuses   []string // Statuses counted as completed, case-insensitive, defaults to DefaultCompletedStatuses
+	InProgressStatuses  []string // Statuses credited with partial progress, empty credits every open status
+	TrackScopeChanges   bool
+	ScopePeriod         *TimeRange // Period or sprint to measure scope against, defaults to the activity date range
+	// StaleAfter is how long an open activity can go without an update before it is listed in
+	// ProcessingSummary.StaleIssues. Zero uses DefaultStaleAfter.
+	StaleAfter          time.Duration
+	StaleAsOf           time.Time // Time staleness is measured from, zero uses the time of processing
+	// Location is the reporting time zone. Activity timestamps are converted to it before
+	// processing, so day boundaries, weekday seasonality, trend range membership and date
+	// labels follow the reporting calendar. Nil uses UTC.
+	Location            *time.Location
 }
 
+// DefaultProcessingOptions returns the options a run uses unless the user changes them: the
+// per-user, per-priority and per-status breakdowns, velocity and trends
+func DefaultProcessingOptions() ProcessingOptions {
+	return ProcessingOptions{
+		GroupByUser:       true,
+		GroupByPriority:   true,
+		GroupByStatus:     true,
+		CalculateVelocity: true,
+		AnalyzeTrends:     true,
+	}
+}
+
+// DefaultEpicField is the logical name of the custom field holding an issue's epic link
+const DefaultEpicField = "epic_link"
+
+// UnassignedEpic is the epic bucket for activities without an epic link
+const UnassignedEpic = "Unassigned"
+
+// DefaultTrendThreshold classifies a trend as changing once its fitted line moves by 5% of the
+// mean value per range
+const DefaultTrendThreshold = 0.05
+
+// TrendGranularity is the size of the calendar ranges trend analysis generates
+type TrendGranularity string
+
+const (
+	GranularityDaily     TrendGranularity = "daily"
+	GranularityWeekly    TrendGranularity = "weekly"    // ISO weeks starting on Monday
+	GranularityMonthly   TrendGranularity = "monthly"
+	GranularityQuarterly TrendGranularity = "quarterly"
+)
+
 // TimeRange represents a time period for analysis
 type TimeRange struct {
 	Start time.Time
@@ -48,8 +150,14 @@ type ProcessingResult struct {
 	UserMetrics       map[string]UserMetrics      `json:"user_metrics"`
 	PriorityBreakdown map[string]PriorityMetrics  `json:"priority_breakdown"`
 	StatusBreakdown   map[string]StatusMetrics    `json:"status_breakdown"`
+	TypeBreakdown     map[string]TypeMetrics      `json:"type_breakdown"`
+	ProjectBreakdown  map[string]GroupMetrics     `json:"project_breakdown,omitempty"`
+	ComponentBreakdown map[string]GroupMetrics    `json:"component_breakdown,omitempty"`
+	LabelBreakdown    map[string]GroupMetrics     `json:"label_breakdown,omitempty"`
+	EpicBreakdown     map[string]EpicMetrics      `json:"epic_breakdown,omitempty"`
 	TrendAnalysis     *TrendAnalysis              `json:"trend_analysis,omitempty"`
 	VelocityMetrics   *VelocityMetrics            `json:"velocity_metrics,omitempty"`
+	ScopeChanges      *ScopeMetrics               `json:"scope_changes,omitempty"`
 	ProcessedAt       time.Time                   `json:"processed_at"`
 	ProcessingTime    time.Duration               `json:"processing_time"`
 }
@@ -60,11 +168,38 @@ type ProcessingSummary struct {
 	TotalUsers         int           `json:"total_users"`
 	TotalTimeSpent     int64         `json:"total_time_spent"` // In seconds
 	AverageTimePerUser int64         `json:"average_time_per_user"`
+	MedianTimePerTask  int64         `json:"median_time_per_task"` // p50 of logged time per activity
+	P90TimePerTask     int64         `json:"p90_time_per_task"`
+	P95TimePerTask     int64         `json:"p95_time_per_task"`
+	StdDevTimePerTask  int64         `json:"stddev_time_per_task"`
 	DateRange          TimeRange     `json:"date_range"`
 	MostActiveUser     string        `json:"most_active_user"`
 	TopPriority        string        `json:"top_priority"`
 	CompletionRate     float64       `json:"completion_rate"`
 	ProductivityScore  float64       `json:"productivity_score"`
+	CycleTime          DurationStats `json:"cycle_time"`
+	LeadTime           DurationStats `json:"lead_time"`
+	ReworkRate         float64       `json:"rework_rate"` // Percentage of completed activities later reopened
+	ReopenCount        int           `json:"reopen_count"`
+	ReworkAvailable    bool          `json:"rework_available"` // False when no activity carries status history
+	PlannedPoints      float64       `json:"planned_points"`      // Story points of estimated activities
+	CompletedPoints    float64       `json:"completed_points"`    // Story points of completed estimated activities
+	PointCompletionRate float64      `json:"point_completion_rate"` // Percentage of planned points completed
+	AveragePoints      float64       `json:"average_points"`      // Mean story points of estimated activities
+	UnestimatedActivities int        `json:"unestimated_activities"` // Activities without story points, left out of point metrics
+	StaleIssues        []string      `json:"stale_issues"` // Keys of open activities not updated within StaleAfter, least recently updated first
+	StaleAfter         int64         `json:"stale_after"`  // Stale threshold in seconds
+	// WorkloadBalance is the Gini coefficient of time spent across users: 0 when everyone
+	// logged the same time, approaching 1 as a single user carries all of it
+	WorkloadBalance    float64       `json:"workload_balance"`
+}
+
+// DurationStats summarizes elapsed times of completed activities, in seconds
+type DurationStats struct {
+	Count  int   `json:"count"`
+	Mean   int64 `json:"mean"`
+	Median int64 `json:"median"`
+	P90    int64 `json:"p90"`
 }
 
 // UserMetrics contains metrics for a specific user
@@ -75,11 +210,25 @@ type UserMetrics struct {
 	CompletedActivities int              `json:"completed_activities"`
 	TotalTimeSpent     int64             `json:"total_time_spent"`
 	AverageTimePerTask int64             `json:"average_time_per_task"`
+	MedianTimePerTask  int64             `json:"median_time_per_task"` // p50 of logged time per activity
+	P90TimePerTask     int64             `json:"p90_time_per_task"`
+	P95TimePerTask     int64             `json:"p95_time_per_task"`
+	StdDevTimePerTask  int64             `json:"stddev_time_per_task"`
 	PriorityDistribution map[string]int  `json:"priority_distribution"`
 	StatusDistribution   map[string]int  `json:"status_distribution"`
 	CompletionRate     float64           `json:"completion_rate"`
 	ProductivityRank   int               `json:"productivity_rank"`
 	TopIssues          []string          `json:"top_issues"`
+	CycleTime          DurationStats     `json:"cycle_time"`
+	LeadTime           DurationStats     `json:"lead_time"`
+	ReworkRate         float64           `json:"rework_rate"` // Percentage of completed activities later reopened
+	ReopenCount        int               `json:"reopen_count"`
+	ReworkAvailable    bool              `json:"rework_available"` // False when no activity carries status history
+	PlannedPoints      float64           `json:"planned_points"`
+	CompletedPoints    float64           `json:"completed_points"`
+	PointCompletionRate float64          `json:"point_completion_rate"`
+	UnestimatedActivities int            `json:"unestimated_activities"`
+	StaleActivities    int               `json:"stale_activities"` // Open activities not updated within the stale threshold
 }
 
 // PriorityMetrics contains metrics for a specific priority level
@@ -89,7 +238,12 @@ type PriorityMetrics struct {
 	TotalTimeSpent  int64   `json:"total_time_spent"`
 	CompletedCount  int     `json:"completed_count"`
 	CompletionRate  float64 `json:"completion_rate"`
-	AverageTimeToComplete int64 `json:"average_time_to_complete"`
+	AverageTimeToComplete int64 `json:"average_time_to_complete"` // Average logged time of completed items
+	CycleTime       DurationStats `json:"cycle_time"`
+	LeadTime        DurationStats `json:"lead_time"`
+	PlannedPoints   float64 `json:"planned_points"`   // Story points of estimated items
+	CompletedPoints float64 `json:"completed_points"` // Story points of completed estimated items
+	PointCompletionRate float64 `json:"point_completion_rate"`
 }
 
 // StatusMetrics contains metrics for a specific status
@@ -101,12 +255,50 @@ type StatusMetrics struct {
 	RecentChanges  int       `json:"recent_changes"`
 }
 
+// TypeMetrics contains metrics for a specific issue type
+type TypeMetrics struct {
+	Type           string  `json:"type"`
+	Count          int     `json:"count"`
+	TotalTimeSpent int64   `json:"total_time_spent"`
+	CompletedCount int     `json:"completed_count"`
+	CompletionRate float64 `json:"completion_rate"`
+	Share          float64 `json:"share"` // Percentage of all activities
+}
+
+// GroupMetrics contains metrics for a project, component or label. Activities can belong to
+// several components or labels, so shares across those buckets may add up to more than 100.
+type GroupMetrics struct {
+	Name           string  `json:"name"`
+	Count          int     `json:"count"`
+	TotalTimeSpent int64   `json:"total_time_spent"`
+	CompletedCount int     `json:"completed_count"`
+	CompletionRate float64 `json:"completion_rate"`
+	Share          float64 `json:"share"` // Percentage of all activities
+}
+
+// EpicMetrics contains the roll-up of the activities linked to an epic
+type EpicMetrics struct {
+	Epic           string   `json:"epic"`
+	Count          int      `json:"count"`
+	TotalTimeSpent int64    `json:"total_time_spent"`
+	CompletedCount int      `json:"completed_count"`
+	CompletionRate float64  `json:"completion_rate"`
+	Share          float64  `json:"share"` // Percentage of all activities
+	Users          []string `json:"users"` // Display names of the assignees who contributed, sorted
+}
+
 // TrendAnalysis contains trend analysis over time
 type TrendAnalysis struct {
 	TimeRanges        []TimeRangeMetrics `json:"time_ranges"`
 	OverallTrend      string             `json:"overall_trend"` // "increasing", "decreasing", "stable"
 	VelocityTrend     string             `json:"velocity_trend"`
 	ProductivityTrend string             `json:"productivity_trend"`
+	// Least-squares fit of the range values in time order. The slope is the change per range;
+	// R² near 1 means the ranges follow the line closely and the trend is reliable.
+	VelocitySlope        float64 `json:"velocity_slope"`
+	VelocityRSquared     float64 `json:"velocity_r_squared"`
+	ProductivitySlope    float64 `json:"productivity_slope"`
+	ProductivityRSquared float64 `json:"productivity_r_squared"`
 	Seasonality       map[string]float64 `json:"seasonality"` // Day of week patterns
 }
 
@@ -130,20 +322,34 @@ type VelocityMetrics struct {
 	UserVelocities      map[string]float64 `json:"user_velocities"`
 }
 
-// SprintMetrics contains sprint-specific metrics
+// SprintMetrics contains sprint-specific metrics. As a processing option only the name, dates
+// and planned story points are read; the remaining fields are calculated.
 type SprintMetrics struct {
 	SprintName        string    `json:"sprint_name"`
 	StartDate         time.Time `json:"start_date"`
 	EndDate           time.Time `json:"end_date"`
-	PlannedStoryPoints int      `json:"planned_story_points"`
-	CompletedStoryPoints int    `json:"completed_story_points"`
-	Velocity          float64   `json:"velocity"`
-	BurndownRate      float64   `json:"burndown_rate"`
+	PlannedStoryPoints float64  `json:"planned_story_points"` // Points of items open in the sprint when zero
+	CompletedStoryPoints float64 `json:"completed_story_points"`
+	Velocity          float64   `json:"velocity"`      // Completed story points
+	BurndownRate      float64   `json:"burndown_rate"` // Remaining share of planned points per elapsed sprint day
+}
+
+// ScopeMetrics compares the work planned at the start of a period with work added or removed during it
+type ScopeMetrics struct {
+	Period       TimeRange `json:"period"`
+	Planned      int       `json:"planned"`
+	ScopeAdded   int       `json:"scope_added"`
+	ScopeRemoved int       `json:"scope_removed"`
+	Reopened     int       `json:"reopened"` // Included in ScopeAdded
+	AddedKeys    []string  `json:"added_keys"`
+	RemovedKeys  []string  `json:"removed_keys"`
+	ChangeRate   float64   `json:"change_rate"` // Added plus removed as a percentage of planned
 }
 
 // ProcessActivities processes a collection of activities and returns aggregated metrics
 func (dp *DataProcessor) ProcessActivities(ctx context.Context, activities []models.Activity, options ProcessingOptions) (*ProcessingResult, error) {
 	startTime := time.Now()
+	dp = dp.forRun(options, startTime)
 	
 	dp.logger.Info("Starting activity processing",
 		utils.NewField("activity_count", len(activities)),
@@ -158,16 +364,17 @@ func (dp *DataProcessor) ProcessActivities(ctx context.Context, activities []mod
 		}, nil
 	}
 	
+	activities = dp.inReportingZone(activities)
+	
+	// Prefer per-worklog totals over the issue-level time spent
+	if options.IncludeWorklogs {
+		activities = dp.applyWorklogTime(activities)
+	}
+	
 	// Filter activities based on minimum time spent
 	filteredActivities := dp.filterActivities(activities, options)
 	
-	// Build result structure
-	result := &ProcessingResult{
-		UserMetrics:       make(map[string]UserMetrics),
-		PriorityBreakdown: make(map[string]PriorityMetrics),
-		StatusBreakdown:   make(map[string]StatusMetrics),
-		ProcessedAt:       time.Now(),
-	}
+	result := newProcessingResult()
 	
 	// Process basic metrics
 	dp.processSummaryMetrics(filteredActivities, &result.Summary)
@@ -184,30 +391,232 @@ func (dp *DataProcessor) ProcessActivities(ctx context.Context, activities []mod
 	
 	// Process status breakdown
 	if options.GroupByStatus {
-		dp.processStatusMetrics(filteredActivities, result.StatusBreakdown)
+		dp.processStatusMetrics(filteredActivities, result.StatusBreakdown, options.IncludeComments)
+	}
+	
+	// Process issue type breakdown
+	if options.GroupByType {
+		dp.processTypeMetrics(filteredActivities, result.TypeBreakdown)
+	}
+	
+	dp.processRangeMetrics(filteredActivities, options, result)
+	
+	result.ProcessingTime = time.Since(startTime)
+	dp.logProcessingCompleted(result)
+	
+	return result, nil
+}
+
+// ProcessActivitiesStream processes activities received from a channel, such as issues paged in
+// from Jira, and returns the result once the channel is closed. The user, priority, status and
+// type breakdowns are accumulated as activities arrive. The summary, group, trend, velocity and
+// scope metrics need the full date range, so a trimmed copy of each activity without its
+// comments, worklogs or free text is kept for them until the stream ends.
+func (dp *DataProcessor) ProcessActivitiesStream(ctx context.Context, activities <-chan models.Activity, options ProcessingOptions) (*ProcessingResult, error) {
+	startTime := time.Now()
+	dp = dp.forRun(options, startTime)
+	recentCutoff := startTime.Add(-recentChangeWindow)
+	epicField := options.EpicField
+	if epicField == "" {
+		epicField = DefaultEpicField
+	}
+	
+	users := make(map[string]*activityTotals)
+	priorities := make(map[string]*activityTotals)
+	statuses := make(map[string]*activityTotals)
+	types := make(map[string]*activityTotals)
+	var records []models.Activity
+	received := 0
+	
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case activity, ok := <-activities:
+			if !ok {
+				dp.logger.Debug("Activity stream closed",
+					utils.NewField("activity_count", received),
+					utils.NewField("kept_count", len(records)),
+				)
+				if received == 0 {
+					return &ProcessingResult{
+						ProcessedAt:    time.Now(),
+						ProcessingTime: time.Since(startTime),
+					}, nil
+				}
+				
+				result := newProcessingResult()
+				dp.processSummaryMetrics(records, &result.Summary)
+				if options.GroupByUser {
+					dp.userBreakdown(users, result.UserMetrics)
+				}
+				if options.GroupByPriority {
+					priorityBreakdown(priorities, result.PriorityBreakdown)
+				}
+				if options.GroupByStatus {
+					statusBreakdown(statuses, result.StatusBreakdown)
+				}
+				if options.GroupByType {
+					typeBreakdown(types, len(records), result.TypeBreakdown)
+				}
+				dp.processRangeMetrics(records, options, result)
+				
+				result.ProcessingTime = time.Since(startTime)
+				dp.logProcessingCompleted(result)
+				return result, nil
+			}
+			
+			received++
+			activity = dp.toReportingZone(activity)
+			if options.IncludeWorklogs {
+				activity = withWorklogTime(activity)
+			}
+			if !meetsMinimumTime(activity, options) {
+				continue
+			}
+			
+			if options.GroupByUser {
+				dp.addActivityTotals(users, activity.Assignee.AccountID, activity, recentCutoff, false)
+			}
+			if options.GroupByPriority {
+				dp.addActivityTotals(priorities, priorityKey(activity), activity, recentCutoff, false)
+			}
+			if options.GroupByStatus {
+				dp.addActivityTotals(statuses, statusKey(activity), activity, recentCutoff, options.IncludeComments)
+			}
+			if options.GroupByType {
+				dp.addActivityTotals(types, typeKey(activity), activity, recentCutoff, false)
+			}
+			records = append(records, trendRecord(activity, epicField))
+		}
+	}
+}
+
+// trendRecord returns the part of an activity read by the summary, group, trend, velocity and
+// scope metrics. Comments and worklogs are reduced to the latest time either was updated, custom
+// fields to the epic link and the changelog to status and sprint changes.
+func trendRecord(activity models.Activity, epicField string) models.Activity {
+	record := models.Activity{
+		Key:           activity.Key,
+		Type:          activity.Type,
+		Status:        activity.Status,
+		Priority:      activity.Priority,
+		Assignee:      models.User{AccountID: activity.Assignee.AccountID, DisplayName: activity.Assignee.DisplayName},
+		Created:       activity.Created,
+		Updated:       activity.Updated,
+		Resolved:      activity.Resolved,
+		Project:       models.Project{Key: activity.Project.Key, Name: activity.Project.Name},
+		Components:    activity.Components,
+		Labels:        activity.Labels,
+		TimeSpent:     activity.TimeSpent,
+		StoryPoints:   activity.StoryPoints,
+		StatusHistory: activity.StatusHistory,
+	}
+	
+	// Trend recency weighting reads the latest comment or worklog update
+	if lastUpdated := activity.GetLastUpdated(); lastUpdated.After(activity.Updated) {
+		record.Comments = []models.Comment{{Updated: lastUpdated}}
+	}
+	
+	if epic, ok := activity.CustomFields[epicField]; ok {
+		record.CustomFields = map[string]interface{}{epicField: epic}
+	}
+	
+	for _, change := range activity.Changelog {
+		switch strings.ToLower(change.Field) {
+		case "status", "sprint":
+			record.Changelog = append(record.Changelog, change)
+		}
+	}
+	
+	return record
+}
+
+// newProcessingResult creates a result with empty breakdowns
+func newProcessingResult() *ProcessingResult {
+	return &ProcessingResult{
+		UserMetrics:       make(map[string]UserMetrics),
+		PriorityBreakdown: make(map[string]PriorityMetrics),
+		StatusBreakdown:   make(map[string]StatusMetrics),
+		TypeBreakdown:     make(map[string]TypeMetrics),
+		ProcessedAt:       time.Now(),
+	}
+}
+
+// processRangeMetrics calculates the metrics that need every activity of the run: the project,
+// component, label and epic breakdowns, trends, velocity and scope changes. The summary must
+// already be set, as scope changes default to its date range.
+func (dp *DataProcessor) processRangeMetrics(activities []models.Activity, options ProcessingOptions, result *ProcessingResult) {
+	// Process project, component and label breakdowns
+	if options.GroupByProject {
+		result.ProjectBreakdown = dp.processGroupMetrics(activities, projectKeys)
+	}
+	if options.GroupByComponent {
+		result.ComponentBreakdown = dp.processGroupMetrics(activities, componentKeys)
+	}
+	if options.GroupByLabel {
+		result.LabelBreakdown = dp.processGroupMetrics(activities, labelKeys)
+	}
+	
+	// Process epic roll-up
+	if options.GroupByEpic {
+		result.EpicBreakdown = dp.processEpicMetrics(activities, options.EpicField)
 	}
 	
 	// Process trend analysis
 	if options.AnalyzeTrends {
-		trendAnalysis := dp.analyzeTrends(filteredActivities, options.CustomTimeRanges)
+		trendAnalysis := dp.analyzeTrends(activities, options.CustomTimeRanges, options.TrendGranularity, options.TrendHalfLife, options.TrendThreshold)
 		result.TrendAnalysis = trendAnalysis
 	}
 	
 	// Process velocity metrics
 	if options.CalculateVelocity {
-		velocityMetrics := dp.calculateVelocityMetrics(filteredActivities)
+		velocityMetrics := dp.calculateVelocityMetrics(activities, options)
 		result.VelocityMetrics = velocityMetrics
 	}
 	
-	result.ProcessingTime = time.Since(startTime)
-	
+	// Process scope changes
+	if options.TrackScopeChanges {
+		period := result.Summary.DateRange
+		if options.ScopePeriod != nil {
+			period = *options.ScopePeriod
+		}
+		result.ScopeChanges = dp.calculateScopeMetrics(activities, period)
+	}
+}
+
+// logProcessingCompleted logs the totals of a finished run
+func (dp *DataProcessor) logProcessingCompleted(result *ProcessingResult) {
 	dp.logger.Info("Activity processing completed",
 		utils.NewField("total_activities", result.Summary.TotalActivities),
 		utils.NewField("total_users", result.Summary.TotalUsers),
 		utils.NewField("processing_time", result.ProcessingTime),
 	)
+}
+
+// forRun returns a copy of the processor set up for a run started at startTime, using the status
+// sets, reporting time zone and staleness threshold from options
+func (dp *DataProcessor) forRun(options ProcessingOptions, startTime time.Time) *DataProcessor {
+	// Use the configured status sets for every metric calculated in this run
+	dp = dp.withStatusSets(options)
 	
-	return result, nil
+	// Bucket days and weekdays in the reporting time zone
+	dp.location = options.Location
+	if dp.location == nil {
+		dp.location = time.UTC
+	}
+	
+	// Measure staleness from one instant for the whole run
+	dp.staleAfter = options.StaleAfter
+	if dp.staleAfter <= 0 {
+		dp.staleAfter = DefaultStaleAfter
+	}
+	dp.staleAsOf = options.StaleAsOf
+	if dp.staleAsOf.IsZero() {
+		dp.staleAsOf = startTime
+	}
+	
+	return dp
 }
 
 // filterActivities filters activities based on processing options
@@ -218,7 +627,7 @@ func (dp *DataProcessor) filterActivities(activities []models.Activity, options
 	
 	filtered := make([]models.Activity, 0, len(activities))
 	for _, activity := range activities {
-		if activity.TimeSpent >= options.MinimumTimeSpent {
+		if meetsMinimumTime(activity, options) {
 			filtered = append(filtered, activity)
 		}
 	}
@@ -232,6 +641,55 @@ func (dp *DataProcessor) filterActivities(activities []models.Activity, options
 	return filtered
 }
 
+// meetsMinimumTime reports whether activity has at least the minimum time spent from options
+func meetsMinimumTime(activity models.Activity, options ProcessingOptions) bool {
+	return options.MinimumTimeSpent <= 0 || activity.TimeSpent >= options.MinimumTimeSpent
+}
+
+// applyWorklogTime returns a copy of activities whose time spent is the sum of their worklog
+// entries. Activities without worklogs keep their issue-level time spent.
+func (dp *DataProcessor) applyWorklogTime(activities []models.Activity) []models.Activity {
+	applied := make([]models.Activity, len(activities))
+	for i, activity := range activities {
+		applied[i] = withWorklogTime(activity)
+	}
+	return applied
+}
+
+// withWorklogTime returns activity with its time spent set to the sum of its worklog entries
+func withWorklogTime(activity models.Activity) models.Activity {
+	if len(activity.Worklog) > 0 {
+		logged := int64(0)
+		for _, entry := range activity.Worklog {
+			logged += entry.TimeSpent
+		}
+		activity.TimeSpent = logged
+	}
+	return activity
+}
+
+// inReportingZone returns copies of activities with their creation, update and resolution
+// times converted to the reporting time zone
+func (dp *DataProcessor) inReportingZone(activities []models.Activity) []models.Activity {
+	converted := make([]models.Activity, len(activities))
+	for i, activity := range activities {
+		converted[i] = dp.toReportingZone(activity)
+	}
+	return converted
+}
+
+// toReportingZone returns activity with its creation, update and resolution times converted to
+// the reporting time zone
+func (dp *DataProcessor) toReportingZone(activity models.Activity) models.Activity {
+	activity.Created = activity.Created.In(dp.location)
+	activity.Updated = activity.Updated.In(dp.location)
+	if activity.Resolved != nil {
+		resolved := activity.Resolved.In(dp.location)
+		activity.Resolved = &resolved
+	}
+	return activity
+}
+
 // processSummaryMetrics calculates high-level summary metrics
 func (dp *DataProcessor) processSummaryMetrics(activities []models.Activity, summary *ProcessingSummary) {
 	if len(activities) == 0 {
@@ -275,21 +733,21 @@ func (dp *DataProcessor) processSummaryMetrics(activities []models.Activity, sum
 		}
 	}
 	
-	// Find most active user
+	// Find most active user, breaking ties by ID so results are repeatable
 	mostActiveUser := ""
 	maxTime := int64(0)
 	for userID, timeSpent := range userTimeSpent {
-		if timeSpent > maxTime {
+		if timeSpent > maxTime || (timeSpent == maxTime && timeSpent > 0 && userID < mostActiveUser) {
 			maxTime = timeSpent
 			mostActiveUser = userID
 		}
 	}
 	
-	// Find top priority
+	// Find top priority, breaking ties by name
 	topPriority := ""
 	maxCount := 0
 	for priority, count := range priorityCount {
-		if count > maxCount {
+		if count > maxCount || (count == maxCount && priority < topPriority) {
 			maxCount = count
 			topPriority = priority
 		}
@@ -297,6 +755,10 @@ func (dp *DataProcessor) processSummaryMetrics(activities []models.Activity, sum
 	
 	// Calculate metrics
 	totalUsers := len(userSet)
+	userTimes := make([]float64, 0, len(userTimeSpent))
+	for _, timeSpent := range userTimeSpent {
+		userTimes = append(userTimes, float64(timeSpent))
+	}
 	completionRate := float64(completedCount) / float64(len(activities)) * 100
 	averageTimePerUser := int64(0)
 	if totalUsers > 0 {
@@ -306,11 +768,19 @@ func (dp *DataProcessor) processSummaryMetrics(activities []models.Activity, sum
 	// Calculate productivity score (0-100 based on completion rate and time efficiency)
 	productivityScore := dp.calculateProductivityScore(activities, completionRate)
 	
+	timePerTask := dp.timePerTask(activities)
+	rework := dp.reworkStats(activities)
+	points := dp.storyPointStats(activities)
+	
 	*summary = ProcessingSummary{
 		TotalActivities:    len(activities),
 		TotalUsers:         totalUsers,
 		TotalTimeSpent:     totalTimeSpent,
 		AverageTimePerUser: averageTimePerUser,
+		MedianTimePerTask:  int64(dp.median(timePerTask)),
+		P90TimePerTask:     int64(dp.percentile(timePerTask, 90)),
+		P95TimePerTask:     int64(dp.percentile(timePerTask, 95)),
+		StdDevTimePerTask:  int64(dp.stddev(timePerTask)),
 		DateRange: TimeRange{
 			Start: minDate,
 			End:   maxDate,
@@ -320,23 +790,36 @@ func (dp *DataProcessor) processSummaryMetrics(activities []models.Activity, sum
 		TopPriority:       topPriority,
 		CompletionRate:    completionRate,
 		ProductivityScore: productivityScore,
+		CycleTime:         dp.cycleTimeStats(activities),
+		LeadTime:          dp.leadTimeStats(activities),
+		ReworkRate:        rework.rate,
+		ReopenCount:       rework.reopens,
+		ReworkAvailable:   rework.available,
+		PlannedPoints:     points.planned,
+		CompletedPoints:   points.completed,
+		PointCompletionRate: points.completionRate(),
+		AveragePoints:     points.average(),
+		UnestimatedActivities: points.unestimated,
+		StaleIssues:       dp.staleIssues(activities),
+		StaleAfter:        int64(dp.staleAfter / time.Second),
+		WorkloadBalance:   giniCoefficient(userTimes),
 	}
 }
 
 // processUserMetrics calculates per-user metrics
 func (dp *DataProcessor) processUserMetrics(activities []models.Activity, userMetrics map[string]UserMetrics) {
-	userActivities := make(map[string][]models.Activity)
-	
-	// Group activities by user
+	users := make(map[string]*activityTotals)
 	for _, activity := range activities {
-		userID := activity.Assignee.AccountID
-		userActivities[userID] = append(userActivities[userID], activity)
+		dp.addActivityTotals(users, activity.Assignee.AccountID, activity, time.Time{}, false)
 	}
-	
-	// Calculate metrics for each user
+	dp.userBreakdown(users, userMetrics)
+}
+
+// userBreakdown fills userMetrics from per-user totals and ranks the users by productivity
+func (dp *DataProcessor) userBreakdown(users map[string]*activityTotals, userMetrics map[string]UserMetrics) {
 	userProductivity := make(map[string]float64)
-	for userID, activities := range userActivities {
-		metrics := dp.calculateUserMetrics(userID, activities)
+	for userID, totals := range users {
+		metrics := totals.userMetrics(userID)
 		userMetrics[userID] = metrics
 		userProductivity[userID] = metrics.CompletionRate
 	}
@@ -347,181 +830,364 @@ func (dp *DataProcessor) processUserMetrics(activities []models.Activity, userMe
 
 // calculateUserMetrics calculates metrics for a single user
 func (dp *DataProcessor) calculateUserMetrics(userID string, activities []models.Activity) UserMetrics {
-	if len(activities) == 0 {
-		return UserMetrics{UserID: userID}
+	return dp.totalsOf(activities, time.Time{}, false).userMetrics(userID)
+}
+
+// processPriorityMetrics calculates priority-based metrics
+func (dp *DataProcessor) processPriorityMetrics(activities []models.Activity, priorityMetrics map[string]PriorityMetrics) {
+	priorities := make(map[string]*activityTotals)
+	for _, activity := range activities {
+		dp.addActivityTotals(priorities, priorityKey(activity), activity, time.Time{}, false)
 	}
-	
-	priorityDist := make(map[string]int)
-	statusDist := make(map[string]int)
-	totalTimeSpent := int64(0)
-	completedCount := 0
-	topIssues := make([]string, 0)
-	
-	displayName := activities[0].Assignee.DisplayName
-	
+	priorityBreakdown(priorities, priorityMetrics)
+}
+
+// priorityBreakdown fills priorityMetrics from per-priority totals
+func priorityBreakdown(priorities map[string]*activityTotals, priorityMetrics map[string]PriorityMetrics) {
+	for priority, totals := range priorities {
+		priorityMetrics[priority] = totals.priorityMetrics(priority)
+	}
+}
+
+// priorityKey groups an activity by its priority, using "None" when it has none
+func priorityKey(activity models.Activity) string {
+	if activity.Priority == "" {
+		return "None"
+	}
+	return activity.Priority
+}
+
+// calculatePriorityMetrics calculates metrics for a specific priority level
+func (dp *DataProcessor) calculatePriorityMetrics(priority string, activities []models.Activity) PriorityMetrics {
+	return dp.totalsOf(activities, time.Time{}, false).priorityMetrics(priority)
+}
+
+// processStatusMetrics calculates status-based metrics
+func (dp *DataProcessor) processStatusMetrics(activities []models.Activity, statusMetrics map[string]StatusMetrics, includeComments bool) {
+	recentCutoff := time.Now().Add(-recentChangeWindow)
+	statuses := make(map[string]*activityTotals)
 	for _, activity := range activities {
-		priorityDist[activity.Priority]++
-		statusDist[activity.Status]++
-		totalTimeSpent += activity.TimeSpent
-		
-		if dp.isCompleted(activity.Status) {
-			completedCount++
-		}
-		
-		// Track top issues (those with high time investment)
-		if activity.TimeSpent >= 7200 { // 2 hours or more
-			topIssues = append(topIssues, activity.Key)
-		}
+		dp.addActivityTotals(statuses, statusKey(activity), activity, recentCutoff, includeComments)
 	}
-	
-	averageTimePerTask := int64(0)
-	if len(activities) > 0 {
-		averageTimePerTask = totalTimeSpent / int64(len(activities))
+	statusBreakdown(statuses, statusMetrics)
+}
+
+// statusBreakdown fills statusMetrics from per-status totals
+func statusBreakdown(statuses map[string]*activityTotals, statusMetrics map[string]StatusMetrics) {
+	for status, totals := range statuses {
+		statusMetrics[status] = totals.statusMetrics(status)
 	}
-	
-	completionRate := float64(completedCount) / float64(len(activities)) * 100
-	
-	return UserMetrics{
-		UserID:               userID,
-		DisplayName:          displayName,
-		TotalActivities:      len(activities),
-		CompletedActivities:  completedCount,
-		TotalTimeSpent:       totalTimeSpent,
-		AverageTimePerTask:   averageTimePerTask,
-		PriorityDistribution: priorityDist,
-		StatusDistribution:   statusDist,
-		CompletionRate:       completionRate,
-		TopIssues:            topIssues,
+}
+
+// statusKey groups an activity by its status, using "Unknown" when it has none
+func statusKey(activity models.Activity) string {
+	if activity.Status == "" {
+		return "Unknown"
 	}
+	return activity.Status
 }
 
-// processPriorityMetrics calculates priority-based metrics
-func (dp *DataProcessor) processPriorityMetrics(activities []models.Activity, priorityMetrics map[string]PriorityMetrics) {
-	priorityActivities := make(map[string][]models.Activity)
-	
-	// Group activities by priority
+// calculateStatusMetrics calculates metrics for a specific status. Recent comments count as
+// changes when includeComments is set.
+func (dp *DataProcessor) calculateStatusMetrics(status string, activities []models.Activity, includeComments bool) StatusMetrics {
+	return dp.totalsOf(activities, time.Now().Add(-recentChangeWindow), includeComments).statusMetrics(status)
+}
+
+// processTypeMetrics calculates issue type based metrics
+func (dp *DataProcessor) processTypeMetrics(activities []models.Activity, typeMetrics map[string]TypeMetrics) {
+	types := make(map[string]*activityTotals)
 	for _, activity := range activities {
-		priority := activity.Priority
-		if priority == "" {
-			priority = "None"
-		}
-		priorityActivities[priority] = append(priorityActivities[priority], activity)
+		dp.addActivityTotals(types, typeKey(activity), activity, time.Time{}, false)
 	}
-	
-	// Calculate metrics for each priority
-	for priority, activities := range priorityActivities {
-		metrics := dp.calculatePriorityMetrics(priority, activities)
-		priorityMetrics[priority] = metrics
+	typeBreakdown(types, len(activities), typeMetrics)
+}
+
+// typeBreakdown fills typeMetrics from per-type totals, with shares taken of total activities
+func typeBreakdown(types map[string]*activityTotals, total int, typeMetrics map[string]TypeMetrics) {
+	for issueType, totals := range types {
+		metrics := totals.typeMetrics(issueType)
+		metrics.Share = float64(metrics.Count) / float64(total) * 100
+		typeMetrics[issueType] = metrics
 	}
 }
 
-// calculatePriorityMetrics calculates metrics for a specific priority level
-func (dp *DataProcessor) calculatePriorityMetrics(priority string, activities []models.Activity) PriorityMetrics {
-	if len(activities) == 0 {
-		return PriorityMetrics{Priority: priority}
+// typeKey groups an activity by its issue type, using "Unknown" when it has none
+func typeKey(activity models.Activity) string {
+	if activity.Type == "" {
+		return "Unknown"
 	}
-	
-	totalTimeSpent := int64(0)
-	completedCount := 0
-	completionTimes := make([]int64, 0)
-	
+	return activity.Type
+}
+
+// calculateTypeMetrics calculates metrics for a specific issue type
+func (dp *DataProcessor) calculateTypeMetrics(issueType string, activities []models.Activity) TypeMetrics {
+	return dp.totalsOf(activities, time.Time{}, false).typeMetrics(issueType)
+}
+
+// totalsOf adds every one of activities to new totals
+func (dp *DataProcessor) totalsOf(activities []models.Activity, recentCutoff time.Time, countComments bool) *activityTotals {
+	totals := dp.newActivityTotals(recentCutoff, countComments)
 	for _, activity := range activities {
-		totalTimeSpent += activity.TimeSpent
-		
-		if dp.isCompleted(activity.Status) {
-			completedCount++
-			// Calculate time to complete (simplified: use time spent as proxy)
-			completionTimes = append(completionTimes, activity.TimeSpent)
+		totals.add(activity)
+	}
+	return totals
+}
+
+// processGroupMetrics calculates metrics for every bucket returned by keys, counting an
+// activity in each of its buckets
+func (dp *DataProcessor) processGroupMetrics(activities []models.Activity, keys func(models.Activity) []string) map[string]GroupMetrics {
+	groupActivities := make(map[string][]models.Activity)
+	for _, activity := range activities {
+		for _, key := range keys(activity) {
+			groupActivities[key] = append(groupActivities[key], activity)
 		}
 	}
 	
-	completionRate := float64(completedCount) / float64(len(activities)) * 100
-	
-	averageTimeToComplete := int64(0)
-	if len(completionTimes) > 0 {
-		total := int64(0)
-		for _, time := range completionTimes {
-			total += time
+	groupMetrics := make(map[string]GroupMetrics, len(groupActivities))
+	for name, grouped := range groupActivities {
+		metrics := GroupMetrics{Name: name, Count: len(grouped)}
+		for _, activity := range grouped {
+			metrics.TotalTimeSpent += activity.TimeSpent
+			if dp.isCompleted(activity.Status) {
+				metrics.CompletedCount++
+			}
 		}
-		averageTimeToComplete = total / int64(len(completionTimes))
+		metrics.CompletionRate = float64(metrics.CompletedCount) / float64(metrics.Count) * 100
+		metrics.Share = float64(metrics.Count) / float64(len(activities)) * 100
+		groupMetrics[name] = metrics
 	}
 	
-	return PriorityMetrics{
-		Priority:              priority,
-		Count:                 len(activities),
-		TotalTimeSpent:        totalTimeSpent,
-		CompletedCount:        completedCount,
-		CompletionRate:        completionRate,
-		AverageTimeToComplete: averageTimeToComplete,
+	return groupMetrics
+}
+
+// projectKeys groups an activity by its project key
+func projectKeys(activity models.Activity) []string {
+	if activity.Project.Key != "" {
+		return []string{activity.Project.Key}
 	}
+	if activity.Project.Name != "" {
+		return []string{activity.Project.Name}
+	}
+	return []string{"Unknown"}
 }
 
-// processStatusMetrics calculates status-based metrics
-func (dp *DataProcessor) processStatusMetrics(activities []models.Activity, statusMetrics map[string]StatusMetrics) {
-	statusActivities := make(map[string][]models.Activity)
+// componentKeys groups an activity by each of its distinct components
+func componentKeys(activity models.Activity) []string {
+	return distinctKeys(activity.Components)
+}
+
+// labelKeys groups an activity by each of its distinct labels
+func labelKeys(activity models.Activity) []string {
+	return distinctKeys(activity.Labels)
+}
+
+// distinctKeys removes blank and repeated values, using "None" when nothing is left
+func distinctKeys(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	keys := make([]string, 0, len(values))
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		keys = append(keys, value)
+	}
+	if len(keys) == 0 {
+		return []string{"None"}
+	}
+	return keys
+}
+
+// processEpicMetrics rolls activities up by the epic named in their epic link custom field.
+// Activities without an epic link go into the UnassignedEpic bucket.
+func (dp *DataProcessor) processEpicMetrics(activities []models.Activity, epicField string) map[string]EpicMetrics {
+	if epicField == "" {
+		epicField = DefaultEpicField
+	}
 	
-	// Group activities by status
+	epicActivities := make(map[string][]models.Activity)
 	for _, activity := range activities {
-		status := activity.Status
-		if status == "" {
-			status = "Unknown"
+		epic := epicKey(activity, epicField)
+		epicActivities[epic] = append(epicActivities[epic], activity)
+	}
+	
+	epicMetrics := make(map[string]EpicMetrics, len(epicActivities))
+	for epic, grouped := range epicActivities {
+		metrics := EpicMetrics{Epic: epic, Count: len(grouped), Users: make([]string, 0)}
+		users := make(map[string]bool)
+		for _, activity := range grouped {
+			metrics.TotalTimeSpent += activity.TimeSpent
+			if dp.isCompleted(activity.Status) {
+				metrics.CompletedCount++
+			}
+			if name := activity.Assignee.DisplayName; name != "" && !users[name] {
+				users[name] = true
+				metrics.Users = append(metrics.Users, name)
+			}
 		}
-		statusActivities[status] = append(statusActivities[status], activity)
+		sort.Strings(metrics.Users)
+		metrics.CompletionRate = float64(metrics.CompletedCount) / float64(metrics.Count) * 100
+		metrics.Share = float64(metrics.Count) / float64(len(activities)) * 100
+		epicMetrics[epic] = metrics
 	}
 	
-	// Calculate metrics for each status
-	for status, activities := range statusActivities {
-		metrics := dp.calculateStatusMetrics(status, activities)
-		statusMetrics[status] = metrics
+	return epicMetrics
+}
+
+// epicKey returns the epic an activity is linked to through epicField, or UnassignedEpic
+func epicKey(activity models.Activity, epicField string) string {
+	value, ok := activity.CustomFields[epicField]
+	if !ok || value == nil {
+		return UnassignedEpic
 	}
+	epic := strings.TrimSpace(fmt.Sprint(value))
+	if epic == "" {
+		return UnassignedEpic
+	}
+	return epic
 }
 
-// calculateStatusMetrics calculates metrics for a specific status
-func (dp *DataProcessor) calculateStatusMetrics(status string, activities []models.Activity) StatusMetrics {
-	if len(activities) == 0 {
-		return StatusMetrics{Status: status}
+// calculateScopeMetrics counts items that entered or left the active set after the period started.
+// An item counts as added when it was created, moved into a sprint or reopened during the period,
+// and as removed when it was taken out of a sprint during the period. Everything else was planned.
+func (dp *DataProcessor) calculateScopeMetrics(activities []models.Activity, period TimeRange) *ScopeMetrics {
+	metrics := &ScopeMetrics{
+		Period:      period,
+		AddedKeys:   []string{},
+		RemovedKeys: []string{},
 	}
 	
-	userSet := make(map[string]bool)
-	totalTimeSpent := int64(0)
-	recentChanges := 0
-	recentCutoff := time.Now().Add(-7 * 24 * time.Hour) // Last 7 days
-	
 	for _, activity := range activities {
-		userSet[activity.Assignee.AccountID] = true
-		totalTimeSpent += activity.TimeSpent
+		// Items created after the period ended were never part of its scope
+		if !period.End.IsZero() && activity.Created.After(period.End) {
+			continue
+		}
+		
+		added := activity.Created.After(period.Start)
+		removed := false
+		reopened := false
+		
+		for _, change := range activity.Changelog {
+			if !change.Created.After(period.Start) || !dp.withinPeriod(change.Created, period) {
+				continue
+			}
+			
+			switch strings.ToLower(change.Field) {
+			case "sprint":
+				if change.From == "" && change.To != "" {
+					added = true
+				}
+				if change.From != "" && change.To == "" {
+					removed = true
+				}
+			case "status":
+				if dp.isCompleted(change.From) && !dp.isCompleted(change.To) {
+					reopened = true
+				}
+			}
+		}
 		
-		if activity.Updated.After(recentCutoff) {
-			recentChanges++
+		if reopened {
+			metrics.Reopened++
+		}
+		
+		if added || reopened {
+			metrics.ScopeAdded++
+			metrics.AddedKeys = append(metrics.AddedKeys, activity.Key)
+		} else {
+			metrics.Planned++
+		}
+		
+		if removed {
+			metrics.ScopeRemoved++
+			metrics.RemovedKeys = append(metrics.RemovedKeys, activity.Key)
 		}
 	}
 	
-	users := make([]string, 0, len(userSet))
-	for userID := range userSet {
-		users = append(users, userID)
+	if metrics.Planned > 0 {
+		metrics.ChangeRate = float64(metrics.ScopeAdded+metrics.ScopeRemoved) / float64(metrics.Planned) * 100
 	}
 	
-	return StatusMetrics{
-		Status:         status,
-		Count:          len(activities),
-		TotalTimeSpent: totalTimeSpent,
-		Users:          users,
-		RecentChanges:  recentChanges,
-	}
+	return metrics
 }
 
 // Helper methods
 
+// withinPeriod reports whether t falls inside the period, treating a zero end as open ended
+func (dp *DataProcessor) withinPeriod(t time.Time, period TimeRange) bool {
+	if t.Before(period.Start) {
+		return false
+	}
+	return period.End.IsZero() || !t.After(period.End)
+}
+
+// withStatusSets returns a copy of the processor using the status sets from options
+func (dp *DataProcessor) withStatusSets(options ProcessingOptions) *DataProcessor {
+	configured := *dp
+	if len(options.CompletedStatuses) > 0 {
+		configured.completedStatuses = normalizeStatusSet(options.CompletedStatuses)
+	}
+	if len(options.InProgressStatuses) > 0 {
+		configured.inProgressStatuses = normalizeStatusSet(options.InProgressStatuses)
+	}
+	return &configured
+}
+
+// normalizeStatusSet builds a case-insensitive lookup set from status names
+func normalizeStatusSet(statuses []string) map[string]bool {
+	set := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		if normalized := normalizeStatus(status); normalized != "" {
+			set[normalized] = true
+		}
+	}
+	return set
+}
+
+// normalizeStatus prepares a status name for case-insensitive comparison
+func normalizeStatus(status string) string {
+	return strings.ToLower(strings.TrimSpace(status))
+}
+
+// defaultCompletedStatusSet is the normalized form of DefaultCompletedStatuses
+var defaultCompletedStatusSet = normalizeStatusSet(DefaultCompletedStatuses)
+
 // isCompleted checks if a status indicates completion
 func (dp *DataProcessor) isCompleted(status string) bool {
-	completedStatuses := map[string]bool{
-		"Done":     true,
-		"Closed":   true,
-		"Resolved": true,
-		"Complete": true,
-		"Finished": true,
+	completedStatuses := dp.completedStatuses
+	if completedStatuses == nil {
+		completedStatuses = defaultCompletedStatusSet
 	}
-	return completedStatuses[status]
+	return completedStatuses[normalizeStatus(status)]
+}
+
+// isInProgress checks if an open status should be credited with partial progress
+func (dp *DataProcessor) isInProgress(status string) bool {
+	if dp.isCompleted(status) {
+		return false
+	}
+	if dp.inProgressStatuses == nil {
+		return true
+	}
+	return dp.inProgressStatuses[normalizeStatus(status)]
+}
+
+// progressWeight returns how far along a status is, from 0 (not started) to 1 (completed)
+func (dp *DataProcessor) progressWeight(status string) float64 {
+	if dp.isCompleted(status) {
+		return 1.0
+	}
+	
+	if !dp.isInProgress(status) {
+		return 0.0
+	}
+	
+	if weight, exists := dp.statusWeights[status]; exists {
+		return weight
+	}
+	
+	return DefaultInProgressWeight
 }
 
 // calculateProductivityScore calculates a productivity score based on various factors
@@ -548,12 +1214,8 @@ func (dp *DataProcessor) calculateProductivityScore(activities []models.Activity
 			}
 		}
 		
-		// Simple efficiency metric: completed items get higher score
-		if dp.isCompleted(activity.Status) {
-			totalEfficiency += 1.0
-		} else {
-			totalEfficiency += 0.5 // Partial credit for in-progress work
-		}
+		// Efficiency metric: completed items get full credit, others their progress weight
+		totalEfficiency += dp.progressWeight(activity.Status)
 	}
 	
 	// Add high-priority bonus
@@ -587,8 +1249,12 @@ func (dp *DataProcessor) rankUsersByProductivity(userMetrics map[string]UserMetr
 		scores = append(scores, userScore{userID: userID, score: score})
 	}
 	
+	// Users with equal scores are ranked by ID so ranks are repeatable
 	sort.Slice(scores, func(i, j int) bool {
-		return scores[i].score > scores[j].score
+		if scores[i].score != scores[j].score {
+			return scores[i].score > scores[j].score
+		}
+		return scores[i].userID < scores[j].userID
 	})
 	
 	// Assign ranks
@@ -601,7 +1267,7 @@ func (dp *DataProcessor) rankUsersByProductivity(userMetrics map[string]UserMetr
 }
 
 // analyzeTrends performs trend analysis over time
-func (dp *DataProcessor) analyzeTrends(activities []models.Activity, timeRanges []TimeRange) *TrendAnalysis {
+func (dp *DataProcessor) analyzeTrends(activities []models.Activity, timeRanges []TimeRange, granularity TrendGranularity, halfLife time.Duration, threshold float64) *TrendAnalysis {
 	// Implementation for trend analysis
 	// This is a simplified version - can be expanded based on needs
 	
@@ -613,32 +1279,42 @@ func (dp *DataProcessor) analyzeTrends(activities []models.Activity, timeRanges
 		Seasonality:       make(map[string]float64),
 	}
 	
-	// If no custom time ranges provided, create weekly ranges
+	// If no custom time ranges provided, create calendar ranges of the requested granularity,
+	// or rolling weekly ranges without one
 	if len(timeRanges) == 0 {
-		timeRanges = dp.generateWeeklyRanges(activities)
+		if granularity != "" {
+			timeRanges = dp.generateCalendarRanges(activities, granularity)
+		} else {
+			timeRanges = dp.generateWeeklyRanges(activities)
+		}
 	}
 	
+	// Let recent activity dominate when a half-life is configured
+	weight := dp.recencyWeight(activities, halfLife)
+	
 	// Analyze each time range
 	for _, timeRange := range timeRanges {
 		rangeActivities := dp.filterActivitiesByTimeRange(activities, timeRange)
-		rangeMetrics := dp.calculateTimeRangeMetrics(timeRange, rangeActivities)
+		rangeMetrics := dp.calculateTimeRangeMetrics(timeRange, rangeActivities, weight)
 		analysis.TimeRanges = append(analysis.TimeRanges, rangeMetrics)
 	}
 	
 	// Calculate overall trends
-	dp.calculateTrends(analysis)
+	dp.calculateTrends(analysis, threshold)
 	
 	// Calculate seasonality patterns
-	dp.calculateSeasonality(activities, analysis)
+	dp.calculateSeasonality(activities, analysis, weight)
 	
 	return analysis
 }
 
 // calculateVelocityMetrics calculates velocity-related metrics
-func (dp *DataProcessor) calculateVelocityMetrics(activities []models.Activity) *VelocityMetrics {
-	// Simplified velocity calculation
-	// In a real implementation, this would integrate with sprint data
+func (dp *DataProcessor) calculateVelocityMetrics(activities []models.Activity, options ProcessingOptions) *VelocityMetrics {
+	if len(options.Sprints) > 0 {
+		return dp.calculateSprintVelocity(activities, options.Sprints)
+	}
 	
+	// Without sprint data velocity is measured in completed items per day
 	userVelocities := make(map[string]float64)
 	userActivities := make(map[string][]models.Activity)
 	
@@ -673,6 +1349,9 @@ func (dp *DataProcessor) calculateVelocityMetrics(activities []models.Activity)
 		}
 		
 		days := maxDate.Sub(minDate).Hours() / 24
+		if options.BusinessDayVelocity {
+			days = businessDaysBetween(minDate, maxDate, options.Holidays)
+		}
 		if days > 0 {
 			velocity := float64(completedCount) / days
 			userVelocities[userID] = velocity
@@ -689,11 +1368,139 @@ func (dp *DataProcessor) calculateVelocityMetrics(activities []models.Activity)
 		CurrentVelocity: averageVelocity,
 		AverageVelocity: averageVelocity,
 		VelocityTrend:   "stable",
-		BurndownRate:    0.8, // Placeholder
 		UserVelocities:  userVelocities,
 	}
 }
 
+// businessDaysBetween returns the number of business days between start and end. Weekends and
+// holidays are skipped and partial days at either end are prorated.
+func businessDaysBetween(start, end time.Time, holidays []time.Time) float64 {
+	if !end.After(start) {
+		return 0
+	}
+	
+	skipped := make(map[string]bool, len(holidays))
+	for _, holiday := range holidays {
+		skipped[holiday.In(start.Location()).Format("2006-01-02")] = true
+	}
+	
+	days := 0.0
+	dayStart := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	for dayStart.Before(end) {
+		dayEnd := dayStart.AddDate(0, 0, 1)
+		weekday := dayStart.Weekday()
+		if weekday != time.Saturday && weekday != time.Sunday && !skipped[dayStart.Format("2006-01-02")] {
+			from, to := dayStart, dayEnd
+			if start.After(from) {
+				from = start
+			}
+			if end.Before(to) {
+				to = end
+			}
+			days += to.Sub(from).Hours() / dayEnd.Sub(dayStart).Hours()
+		}
+		dayStart = dayEnd
+	}
+	
+	return days
+}
+
+// calculateSprintVelocity measures velocity in completed story points per sprint. An item
+// counts towards a sprint when it was resolved within the sprint window. The latest sprint
+// provides the current velocity and burndown rate.
+func (dp *DataProcessor) calculateSprintVelocity(activities []models.Activity, sprints []SprintMetrics) *VelocityMetrics {
+	ordered := make([]SprintMetrics, len(sprints))
+	copy(ordered, sprints)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].StartDate.Before(ordered[j].StartDate)
+	})
+	
+	userPoints := make(map[string]float64)
+	velocities := make([]float64, 0, len(ordered))
+	for i := range ordered {
+		sprint := &ordered[i]
+		window := TimeRange{Start: sprint.StartDate, End: sprint.EndDate}
+		
+		planned := 0.0
+		for _, activity := range activities {
+			resolved, completed := dp.resolvedAt(activity)
+			if completed && dp.withinPeriod(resolved, window) {
+				sprint.CompletedStoryPoints += activity.StoryPoints
+				userPoints[activity.Assignee.AccountID] += activity.StoryPoints
+			}
+			
+			// Items created before the sprint ended and still open at its start were in play
+			if !activity.Created.After(sprint.EndDate) && (!completed || !resolved.Before(sprint.StartDate)) {
+				planned += activity.StoryPoints
+			}
+		}
+		if sprint.PlannedStoryPoints <= 0 {
+			sprint.PlannedStoryPoints = planned
+		}
+		
+		sprint.Velocity = sprint.CompletedStoryPoints
+		sprint.BurndownRate = dp.sprintBurndownRate(*sprint)
+		velocities = append(velocities, sprint.Velocity)
+	}
+	
+	userVelocities := make(map[string]float64, len(userPoints))
+	for userID, points := range userPoints {
+		userVelocities[userID] = points / float64(len(ordered))
+	}
+	
+	current := ordered[len(ordered)-1]
+	return &VelocityMetrics{
+		CurrentVelocity: current.Velocity,
+		AverageVelocity: dp.average(velocities),
+		VelocityTrend:   dp.sprintVelocityTrend(velocities),
+		BurndownRate:    current.BurndownRate,
+		SprintMetrics:   ordered,
+		UserVelocities:  userVelocities,
+	}
+}
+
+// sprintBurndownRate returns the share of planned points still remaining divided by the
+// number of sprint days elapsed, counting days up to now for a sprint in progress
+func (dp *DataProcessor) sprintBurndownRate(sprint SprintMetrics) float64 {
+	if sprint.PlannedStoryPoints <= 0 {
+		return 0
+	}
+	
+	end := sprint.EndDate
+	if now := time.Now(); now.Before(end) {
+		end = now
+	}
+	elapsedDays := end.Sub(sprint.StartDate).Hours() / 24
+	if elapsedDays <= 0 {
+		return 0
+	}
+	
+	remaining := sprint.PlannedStoryPoints - sprint.CompletedStoryPoints
+	if remaining < 0 {
+		remaining = 0
+	}
+	
+	return remaining / sprint.PlannedStoryPoints / elapsedDays
+}
+
+// sprintVelocityTrend compares the latest sprint velocity with the average of earlier sprints
+func (dp *DataProcessor) sprintVelocityTrend(velocities []float64) string {
+	if len(velocities) < 2 {
+		return "stable"
+	}
+	
+	previous := dp.average(velocities[:len(velocities)-1])
+	latest := velocities[len(velocities)-1]
+	switch {
+	case latest > previous*1.1:
+		return "increasing"
+	case latest < previous*0.9:
+		return "decreasing"
+	default:
+		return "stable"
+	}
+}
+
 // Helper methods for trend analysis
 
 func (dp *DataProcessor) generateWeeklyRanges(activities []models.Activity) []TimeRange {
@@ -714,13 +1521,21 @@ func (dp *DataProcessor) generateWeeklyRanges(activities []models.Activity) []Ti
 		}
 	}
 	
-	// Generate weekly ranges
+	// Generate weekly ranges. With a reporting time zone the weeks run from midnight to
+	// midnight on its calendar, so daylight saving changes do not shift the boundaries.
 	ranges := make([]TimeRange, 0)
 	current := minDate
+	if dp.location != nil {
+		local := minDate.In(dp.location)
+		current = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, dp.location)
+	}
 	weekNum := 1
 	
 	for current.Before(maxDate) {
 		weekEnd := current.Add(7 * 24 * time.Hour)
+		if dp.location != nil {
+			weekEnd = current.AddDate(0, 0, 7)
+		}
 		if weekEnd.After(maxDate) {
 			weekEnd = maxDate
 		}
@@ -738,12 +1553,95 @@ func (dp *DataProcessor) generateWeeklyRanges(activities []models.Activity) []Ti
 	return ranges
 }
 
+// generateCalendarRanges covers the activities with consecutive calendar days, weeks, months or
+// quarters. Boundaries fall on midnight in the reporting time zone, or the zone of the earliest
+// activity without one, and are stepped by calendar date so days across daylight saving changes
+// keep their dates. An unknown granularity falls back to rolling weekly ranges.
+func (dp *DataProcessor) generateCalendarRanges(activities []models.Activity, granularity TrendGranularity) []TimeRange {
+	if len(activities) == 0 {
+		return []TimeRange{}
+	}
+	
+	minDate := activities[0].Created
+	maxDate := activities[0].Updated
+	for _, activity := range activities {
+		if activity.Created.Before(minDate) {
+			minDate = activity.Created
+		}
+		if activity.Updated.After(maxDate) {
+			maxDate = activity.Updated
+		}
+	}
+	
+	location := dp.location
+	if location == nil {
+		location = minDate.Location()
+	}
+	local := minDate.In(location)
+	
+	// Align the first range to the start of the bucket holding the earliest activity
+	var months, days int
+	var start time.Time
+	switch granularity {
+	case GranularityDaily:
+		days = 1
+		start = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, location)
+	case GranularityWeekly:
+		days = 7
+		sinceMonday := (int(local.Weekday()) + 6) % 7
+		start = time.Date(local.Year(), local.Month(), local.Day()-sinceMonday, 0, 0, 0, 0, location)
+	case GranularityMonthly:
+		months = 1
+		start = time.Date(local.Year(), local.Month(), 1, 0, 0, 0, 0, location)
+	case GranularityQuarterly:
+		months = 3
+		quarterMonth := time.Month((int(local.Month())-1)/3*3 + 1)
+		start = time.Date(local.Year(), quarterMonth, 1, 0, 0, 0, 0, location)
+	default:
+		dp.logger.Warn("Unknown trend granularity, using weekly ranges",
+			utils.NewField("granularity", string(granularity)),
+		)
+		return dp.generateWeeklyRanges(activities)
+	}
+	
+	ranges := make([]TimeRange, 0)
+	for current := start; !current.After(maxDate); {
+		// Stepping by date keeps midnight boundaries across daylight saving changes, where a
+		// day lasts 23 or 25 hours
+		next := time.Date(current.Year(), current.Month()+time.Month(months), current.Day()+days, 0, 0, 0, 0, location)
+		ranges = append(ranges, TimeRange{
+			Start: current,
+			End:   next,
+			Label: calendarRangeLabel(current, granularity),
+		})
+		current = next
+	}
+	
+	return ranges
+}
+
+// calendarRangeLabel names a calendar range by its start, matching the summary period labels
+func calendarRangeLabel(start time.Time, granularity TrendGranularity) string {
+	switch granularity {
+	case GranularityDaily:
+		return start.Format("Jan 2, 2006")
+	case GranularityWeekly:
+		return fmt.Sprintf("Week of %s", start.Format("Jan 2, 2006"))
+	case GranularityMonthly:
+		return start.Format("January 2006")
+	default:
+		return fmt.Sprintf("Q%d %d", (int(start.Month())-1)/3+1, start.Year())
+	}
+}
+
+// filterActivitiesByTimeRange keeps the activities that were open at any point of the range, so
+// an issue created before the range or updated after it still counts towards it. Both ends of
+// the range are inclusive.
 func (dp *DataProcessor) filterActivitiesByTimeRange(activities []models.Activity, timeRange TimeRange) []models.Activity {
 	filtered := make([]models.Activity, 0)
 	
 	for _, activity := range activities {
-		if (activity.Created.After(timeRange.Start) || activity.Created.Equal(timeRange.Start)) &&
-			(activity.Updated.Before(timeRange.End) || activity.Updated.Equal(timeRange.End)) {
+		if !activity.Created.After(timeRange.End) && !activity.Updated.Before(timeRange.Start) {
 			filtered = append(filtered, activity)
 		}
 	}
@@ -751,13 +1649,20 @@ func (dp *DataProcessor) filterActivitiesByTimeRange(activities []models.Activit
 	return filtered
 }
 
-func (dp *DataProcessor) calculateTimeRangeMetrics(timeRange TimeRange, activities []models.Activity) TimeRangeMetrics {
+// calculateTimeRangeMetrics calculates the metrics of one trend range. Velocity and productivity
+// count each activity by its weight, while the counts and time spent stay unweighted.
+func (dp *DataProcessor) calculateTimeRangeMetrics(timeRange TimeRange, activities []models.Activity, weight func(models.Activity) float64) TimeRangeMetrics {
 	completionCount := 0
 	totalTimeSpent := int64(0)
+	weightedCount := 0.0
+	weightedCompletions := 0.0
 	
 	for _, activity := range activities {
+		w := weight(activity)
+		weightedCount += w
 		if dp.isCompleted(activity.Status) {
 			completionCount++
+			weightedCompletions += w
 		}
 		totalTimeSpent += activity.TimeSpent
 	}
@@ -766,13 +1671,13 @@ func (dp *DataProcessor) calculateTimeRangeMetrics(timeRange TimeRange, activiti
 	days := timeRange.End.Sub(timeRange.Start).Hours() / 24
 	velocity := 0.0
 	if days > 0 {
-		velocity = float64(completionCount) / days
+		velocity = weightedCompletions / days
 	}
 	
 	// Calculate productivity score for this range
 	productivityScore := 0.0
-	if len(activities) > 0 {
-		completionRate := float64(completionCount) / float64(len(activities)) * 100
+	if weightedCount > 0 {
+		completionRate := weightedCompletions / weightedCount * 100
 		productivityScore = dp.calculateProductivityScore(activities, completionRate)
 	}
 	
@@ -786,12 +1691,16 @@ func (dp *DataProcessor) calculateTimeRangeMetrics(timeRange TimeRange, activiti
 	}
 }
 
-func (dp *DataProcessor) calculateTrends(analysis *TrendAnalysis) {
+// calculateTrends fits a line through the velocity and productivity of the ranges in time order
+// and classifies each series by its slope relative to the series mean
+func (dp *DataProcessor) calculateTrends(analysis *TrendAnalysis, threshold float64) {
 	if len(analysis.TimeRanges) < 2 {
 		return
 	}
+	if threshold <= 0 {
+		threshold = DefaultTrendThreshold
+	}
 	
-	// Analyze velocity trend
 	velocities := make([]float64, len(analysis.TimeRanges))
 	productivities := make([]float64, len(analysis.TimeRanges))
 	
@@ -800,33 +1709,67 @@ func (dp *DataProcessor) calculateTrends(analysis *TrendAnalysis) {
 		productivities[i] = timeRange.ProductivityScore
 	}
 	
-	// Simple trend analysis: compare first half with second half
-	midpoint := len(velocities) / 2
+	analysis.VelocitySlope, analysis.VelocityRSquared = linearTrend(velocities)
+	analysis.VelocityTrend = classifyTrend(analysis.VelocitySlope, dp.average(velocities), threshold)
 	
-	firstHalfVel := dp.average(velocities[:midpoint])
-	secondHalfVel := dp.average(velocities[midpoint:])
+	analysis.ProductivitySlope, analysis.ProductivityRSquared = linearTrend(productivities)
+	analysis.ProductivityTrend = classifyTrend(analysis.ProductivitySlope, dp.average(productivities), threshold)
+	analysis.OverallTrend = analysis.ProductivityTrend
+}
+
+// linearTrend returns the least-squares slope of values against their index and the
+// coefficient of determination of that fit. R² is 0 when the values do not vary.
+func linearTrend(values []float64) (slope, rSquared float64) {
+	n := float64(len(values))
+	if n < 2 {
+		return 0, 0
+	}
 	
-	if secondHalfVel > firstHalfVel*1.1 {
-		analysis.VelocityTrend = "increasing"
-	} else if secondHalfVel < firstHalfVel*0.9 {
-		analysis.VelocityTrend = "decreasing"
+	meanX := (n - 1) / 2
+	meanY := 0.0
+	for _, value := range values {
+		meanY += value
 	}
+	meanY /= n
 	
-	firstHalfProd := dp.average(productivities[:midpoint])
-	secondHalfProd := dp.average(productivities[midpoint:])
+	var sxx, sxy, syy float64
+	for i, value := range values {
+		dx := float64(i) - meanX
+		dy := value - meanY
+		sxx += dx * dx
+		sxy += dx * dy
+		syy += dy * dy
+	}
 	
-	if secondHalfProd > firstHalfProd*1.1 {
-		analysis.ProductivityTrend = "increasing"
-		analysis.OverallTrend = "increasing"
-	} else if secondHalfProd < firstHalfProd*0.9 {
-		analysis.ProductivityTrend = "decreasing"
-		analysis.OverallTrend = "decreasing"
+	slope = sxy / sxx
+	if syy > 0 {
+		rSquared = sxy * sxy / (sxx * syy)
+	}
+	return slope, rSquared
+}
+
+// classifyTrend reads a slope as increasing or decreasing when it exceeds threshold times the
+// mean, so the same threshold suits series of any scale
+func classifyTrend(slope, mean, threshold float64) string {
+	// Velocity and productivity are never negative, so a zero mean is a series of zeros
+	if mean == 0 {
+		return "stable"
+	}
+	
+	relative := slope / math.Abs(mean)
+	switch {
+	case relative > threshold:
+		return "increasing"
+	case relative < -threshold:
+		return "decreasing"
+	default:
+		return "stable"
 	}
 }
 
-func (dp *DataProcessor) calculateSeasonality(activities []models.Activity, analysis *TrendAnalysis) {
-	dayOfWeekCounts := make(map[string]int)
-	dayOfWeekCompleted := make(map[string]int)
+func (dp *DataProcessor) calculateSeasonality(activities []models.Activity, analysis *TrendAnalysis, weight func(models.Activity) float64) {
+	dayOfWeekCounts := make(map[string]float64)
+	dayOfWeekCompleted := make(map[string]float64)
 	
 	days := []string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
 	for _, day := range days {
@@ -836,22 +1779,272 @@ func (dp *DataProcessor) calculateSeasonality(activities []models.Activity, anal
 	
 	for _, activity := range activities {
 		dayName := activity.Created.Weekday().String()
-		dayOfWeekCounts[dayName]++
+		w := weight(activity)
+		dayOfWeekCounts[dayName] += w
 		
 		if dp.isCompleted(activity.Status) {
-			dayOfWeekCompleted[dayName]++
+			dayOfWeekCompleted[dayName] += w
 		}
 	}
 	
 	// Calculate productivity ratio for each day
 	for _, day := range days {
 		if dayOfWeekCounts[day] > 0 {
-			ratio := float64(dayOfWeekCompleted[day]) / float64(dayOfWeekCounts[day])
+			ratio := dayOfWeekCompleted[day] / dayOfWeekCounts[day]
 			analysis.Seasonality[day] = ratio
 		}
 	}
 }
 
+// recencyWeight returns the trend weight of an activity. With a positive half-life the weight
+// halves for every half-life its last update lies before the latest update of all activities,
+// otherwise every activity weighs 1.
+func (dp *DataProcessor) recencyWeight(activities []models.Activity, halfLife time.Duration) func(models.Activity) float64 {
+	if halfLife <= 0 {
+		return func(models.Activity) float64 { return 1 }
+	}
+	
+	latest := time.Time{}
+	for _, activity := range activities {
+		if updated := activity.GetLastUpdated(); updated.After(latest) {
+			latest = updated
+		}
+	}
+	
+	return func(activity models.Activity) float64 {
+		age := latest.Sub(activity.GetLastUpdated())
+		if age <= 0 {
+			return 1
+		}
+		return math.Pow(0.5, float64(age)/float64(halfLife))
+	}
+}
+
+// resolvedAt returns when a completed activity was resolved, falling back to its last update
+func (dp *DataProcessor) resolvedAt(activity models.Activity) (time.Time, bool) {
+	if !dp.isCompleted(activity.Status) {
+		return time.Time{}, false
+	}
+	if activity.Resolved != nil && !activity.Resolved.IsZero() {
+		return *activity.Resolved, true
+	}
+	return activity.Updated, !activity.Updated.IsZero()
+}
+
+// workStartedAt returns when work on an activity started: its first status change, or creation
+func (dp *DataProcessor) workStartedAt(activity models.Activity) time.Time {
+	started := time.Time{}
+	for _, change := range activity.Changelog {
+		if !strings.EqualFold(change.Field, "status") {
+			continue
+		}
+		if started.IsZero() || change.Created.Before(started) {
+			started = change.Created
+		}
+	}
+	if started.IsZero() {
+		return activity.Created
+	}
+	return started
+}
+
+// pointSummary holds the story point figures shared by the summary, per-user and per-priority
+// metrics
+type pointSummary struct {
+	planned     float64
+	completed   float64
+	estimated   int
+	unestimated int
+}
+
+// storyPointStats sums the story points of activities. Activities without an estimate count as
+// unestimated and are left out of the sums, so they lower neither the point completion rate nor
+// the average estimate.
+func (dp *DataProcessor) storyPointStats(activities []models.Activity) pointSummary {
+	var stats pointSummary
+	for _, activity := range activities {
+		dp.tallyPoints(&stats, activity)
+	}
+	return stats
+}
+
+// tallyPoints adds the story points of a single activity to stats
+func (dp *DataProcessor) tallyPoints(stats *pointSummary, activity models.Activity) {
+	if activity.StoryPoints <= 0 {
+		stats.unestimated++
+		return
+	}
+	stats.estimated++
+	stats.planned += activity.StoryPoints
+	if dp.isCompleted(activity.Status) {
+		stats.completed += activity.StoryPoints
+	}
+}
+
+// completionRate returns the percentage of planned points completed, 0 when nothing is estimated
+func (s pointSummary) completionRate() float64 {
+	if s.planned == 0 {
+		return 0
+	}
+	return s.completed / s.planned * 100
+}
+
+// average returns the mean estimate of estimated activities
+func (s pointSummary) average() float64 {
+	if s.estimated == 0 {
+		return 0
+	}
+	return s.planned / float64(s.estimated)
+}
+
+// reworkSummary holds the rework figures shared by the summary and per-user metrics
+type reworkSummary struct {
+	rate      float64
+	reopens   int
+	available bool
+}
+
+// reworkTally counts the completed and reopened activities behind a reworkSummary
+type reworkTally struct {
+	completed int
+	reworked  int
+	reopens   int
+	available bool
+}
+
+// reworkStats counts transitions out of a completed status in the activities' status histories.
+// The rate is the share of activities that reached a completed status and were reopened at least
+// once. Activities without status history are skipped, and when none has any the figures are
+// marked unavailable.
+func (dp *DataProcessor) reworkStats(activities []models.Activity) reworkSummary {
+	var tally reworkTally
+	for _, activity := range activities {
+		dp.tallyRework(&tally, activity)
+	}
+	return tally.summary()
+}
+
+// tallyRework adds the status history of a single activity to tally
+func (dp *DataProcessor) tallyRework(tally *reworkTally, activity models.Activity) {
+	if len(activity.StatusHistory) == 0 {
+		return
+	}
+	tally.available = true
+	
+	reachedCompletion := dp.isCompleted(activity.Status)
+	reopens := 0
+	for _, change := range activity.StatusHistory {
+		if dp.isCompleted(change.To) {
+			reachedCompletion = true
+		}
+		if dp.isCompleted(change.From) && !dp.isCompleted(change.To) {
+			reachedCompletion = true
+			reopens++
+		}
+	}
+	
+	if reachedCompletion {
+		tally.completed++
+	}
+	if reopens > 0 {
+		tally.reworked++
+	}
+	tally.reopens += reopens
+}
+
+// summary returns the rework rate and reopen count of the tallied activities
+func (t reworkTally) summary() reworkSummary {
+	stats := reworkSummary{reopens: t.reopens, available: t.available}
+	if t.completed > 0 {
+		stats.rate = float64(t.reworked) / float64(t.completed) * 100
+	}
+	return stats
+}
+
+// leadTimeStats summarizes the time from creation to resolution of completed activities
+func (dp *DataProcessor) leadTimeStats(activities []models.Activity) DurationStats {
+	durations := make([]float64, 0, len(activities))
+	for _, activity := range activities {
+		if lead, ok := dp.leadTime(activity); ok {
+			durations = append(durations, lead)
+		}
+	}
+	return dp.durationStats(durations)
+}
+
+// leadTime returns the seconds from creation to resolution of a completed activity
+func (dp *DataProcessor) leadTime(activity models.Activity) (float64, bool) {
+	resolved, ok := dp.resolvedAt(activity)
+	if !ok || activity.Created.IsZero() || resolved.Before(activity.Created) {
+		return 0, false
+	}
+	return resolved.Sub(activity.Created).Seconds(), true
+}
+
+// cycleTimeStats summarizes the time from work starting to resolution of completed activities
+func (dp *DataProcessor) cycleTimeStats(activities []models.Activity) DurationStats {
+	durations := make([]float64, 0, len(activities))
+	for _, activity := range activities {
+		if cycle, ok := dp.cycleTime(activity); ok {
+			durations = append(durations, cycle)
+		}
+	}
+	return dp.durationStats(durations)
+}
+
+// cycleTime returns the seconds from work starting to resolution of a completed activity
+func (dp *DataProcessor) cycleTime(activity models.Activity) (float64, bool) {
+	resolved, ok := dp.resolvedAt(activity)
+	if !ok {
+		return 0, false
+	}
+	started := dp.workStartedAt(activity)
+	if started.IsZero() || resolved.Before(started) {
+		return 0, false
+	}
+	return resolved.Sub(started).Seconds(), true
+}
+
+// durationStats summarizes durations given in seconds
+func (dp *DataProcessor) durationStats(durations []float64) DurationStats {
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+	return DurationStats{
+		Count:  len(durations),
+		Mean:   int64(dp.average(durations)),
+		Median: int64(dp.median(durations)),
+		P90:    int64(dp.percentile(durations, 90)),
+	}
+}
+
+// percentile returns the p-th percentile (0-100) of values, interpolating linearly between ranks
+func (dp *DataProcessor) percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	
+	if p <= 0 {
+		return sorted[0]
+	}
+	if p >= 100 {
+		return sorted[len(sorted)-1]
+	}
+	
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(rank)
+	fraction := rank - float64(lower)
+	if lower+1 >= len(sorted) {
+		return sorted[lower]
+	}
+	
+	return sorted[lower] + fraction*(sorted[lower+1]-sorted[lower])
+}
+
 func (dp *DataProcessor) average(values []float64) float64 {
 	if len(values) == 0 {
 		return 0
@@ -863,4 +2056,57 @@ func (dp *DataProcessor) average(values []float64) float64 {
 	}
 	
 	return sum / float64(len(values))
+}
+
+// timePerTask returns the logged time of each activity in seconds
+func (dp *DataProcessor) timePerTask(activities []models.Activity) []float64 {
+	values := make([]float64, len(activities))
+	for i, activity := range activities {
+		values[i] = float64(activity.TimeSpent)
+	}
+	return values
+}
+
+// median returns the middle value of values, interpolating between the two middle values for even lengths
+func (dp *DataProcessor) median(values []float64) float64 {
+	return dp.percentile(values, 50)
+}
+
+// giniCoefficient measures how unevenly values are spread, from 0 when they are all equal to
+// (n-1)/n when one value holds the whole total. Empty and all-zero values are perfectly even.
+func giniCoefficient(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	
+	total, weighted := 0.0, 0.0
+	for i, value := range sorted {
+		total += value
+		weighted += float64(i+1) * value
+	}
+	if total <= 0 {
+		return 0
+	}
+	
+	n := float64(len(sorted))
+	return 2*weighted/(n*total) - (n+1)/n
+}
+
+// stddev returns the population standard deviation of values
+func (dp *DataProcessor) stddev(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	
+	mean := dp.average(values)
+	sumSquares := 0.0
+	for _, value := range values {
+		sumSquares += (value - mean) * (value - mean)
+	}
+	
+	return math.Sqrt(sumSquares / float64(len(values)))
 }
\ No newline at end of file
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -132,6 +133,432 @@ func TestDataProcessor_ProcessActivities_WithVelocityMetrics(t *testing.T) {
 	assert.NotEmpty(t, result.VelocityMetrics.UserVelocities)
 }
 
+func TestDataProcessor_ProcessActivities_WithSprintVelocity(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	day := func(d int) time.Time {
+		return time.Date(2023, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	resolved := func(d int) *time.Time {
+		at := day(d)
+		return &at
+	}
+	user1 := models.User{AccountID: "user1"}
+	user2 := models.User{AccountID: "user2"}
+	
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Done", Assignee: user1, StoryPoints: 5, Created: day(1), Resolved: resolved(5)},
+		{Key: "PROJ-2", Status: "Done", Assignee: user2, StoryPoints: 3, Created: day(1), Resolved: resolved(9)},
+		{Key: "PROJ-3", Status: "Done", Assignee: user1, StoryPoints: 12, Created: day(1), Resolved: resolved(15)},
+		{Key: "PROJ-4", Status: "In Progress", Assignee: user2, StoryPoints: 4, Created: day(3), Updated: day(18)},
+	}
+	
+	options := ProcessingOptions{
+		CalculateVelocity: true,
+		Sprints: []SprintMetrics{
+			// Sprint 2 has no planned points, so they are taken from the items in play
+			{SprintName: "Sprint 2", StartDate: day(11), EndDate: day(21)},
+			{SprintName: "Sprint 1", StartDate: day(1), EndDate: day(11), PlannedStoryPoints: 10},
+		},
+	}
+	
+	result, err := processor.ProcessActivities(ctx, activities, options)
+	require.NoError(t, err)
+	
+	velocity := result.VelocityMetrics
+	require.NotNil(t, velocity)
+	require.Len(t, velocity.SprintMetrics, 2)
+	
+	sprint1 := velocity.SprintMetrics[0]
+	assert.Equal(t, "Sprint 1", sprint1.SprintName)
+	assert.Equal(t, 10.0, sprint1.PlannedStoryPoints)
+	assert.Equal(t, 8.0, sprint1.CompletedStoryPoints)
+	assert.Equal(t, 8.0, sprint1.Velocity)
+	assert.InDelta(t, 0.02, sprint1.BurndownRate, 0.0001) // 2 of 10 points left over 10 days
+	
+	sprint2 := velocity.SprintMetrics[1]
+	assert.Equal(t, "Sprint 2", sprint2.SprintName)
+	assert.Equal(t, 16.0, sprint2.PlannedStoryPoints)
+	assert.Equal(t, 12.0, sprint2.CompletedStoryPoints)
+	assert.Equal(t, 12.0, sprint2.Velocity)
+	assert.InDelta(t, 0.025, sprint2.BurndownRate, 0.0001) // 4 of 16 points left over 10 days
+	
+	assert.Equal(t, 12.0, velocity.CurrentVelocity)
+	assert.Equal(t, 10.0, velocity.AverageVelocity)
+	assert.Equal(t, "increasing", velocity.VelocityTrend)
+	assert.Equal(t, sprint2.BurndownRate, velocity.BurndownRate)
+	assert.Equal(t, 8.5, velocity.UserVelocities["user1"])
+	assert.Equal(t, 1.5, velocity.UserVelocities["user2"])
+	
+	// The caller's sprint inputs are left untouched
+	assert.Zero(t, options.Sprints[0].CompletedStoryPoints)
+}
+
+func TestDataProcessor_ProcessActivities_BusinessDayVelocity(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	// Monday 2 January to Monday 9 January 2023 spans 7 calendar days and 5 business days
+	monday := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)
+	user := models.User{AccountID: "user1"}
+	activities := make([]models.Activity, 5)
+	for i := range activities {
+		activities[i] = models.Activity{
+			Key:      fmt.Sprintf("PROJ-%d", i+1),
+			Status:   "Done",
+			Assignee: user,
+			Created:  monday,
+			Updated:  monday.AddDate(0, 0, 7),
+		}
+	}
+	
+	calendar, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{CalculateVelocity: true})
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0/7.0, calendar.VelocityMetrics.UserVelocities["user1"], 0.0001)
+	
+	business, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{
+		CalculateVelocity:   true,
+		BusinessDayVelocity: true,
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, business.VelocityMetrics.UserVelocities["user1"], 0.0001)
+	assert.InDelta(t, 1.0, business.VelocityMetrics.AverageVelocity, 0.0001)
+	
+	// A holiday on the Wednesday leaves 4 business days
+	holiday, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{
+		CalculateVelocity:   true,
+		BusinessDayVelocity: true,
+		Holidays:            []time.Time{time.Date(2023, 1, 4, 12, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+	assert.InDelta(t, 1.25, holiday.VelocityMetrics.UserVelocities["user1"], 0.0001)
+}
+
+func TestDataProcessor_ProcessActivities_ReportingTimeZone(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	// 03:30 UTC on Tuesday 3 January is still Monday evening five hours west of UTC
+	eastern := time.FixedZone("EST", -5*60*60)
+	created := time.Date(2023, 1, 3, 3, 30, 0, 0, time.UTC)
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Done", Created: created, Updated: created.Add(2 * time.Hour)},
+		{Key: "PROJ-2", Status: "Done", Created: created.AddDate(0, 0, 7), Updated: created.AddDate(0, 0, 7)},
+	}
+	
+	utc, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{AnalyzeTrends: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, utc.TrendAnalysis.Seasonality["Tuesday"])
+	assert.NotContains(t, utc.TrendAnalysis.Seasonality, "Monday")
+	assert.Equal(t, "2023-01-03 to 2023-01-10", utc.Summary.DateRange.Label)
+	
+	local, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{AnalyzeTrends: true, Location: eastern})
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, local.TrendAnalysis.Seasonality["Monday"])
+	assert.NotContains(t, local.TrendAnalysis.Seasonality, "Tuesday")
+	assert.Equal(t, "2023-01-02 to 2023-01-09", local.Summary.DateRange.Label)
+	
+	// Weekly ranges start at midnight on the reporting calendar
+	require.NotEmpty(t, local.TrendAnalysis.TimeRanges)
+	firstWeek := local.TrendAnalysis.TimeRanges[0].Range
+	assert.True(t, time.Date(2023, 1, 2, 0, 0, 0, 0, eastern).Equal(firstWeek.Start))
+	assert.True(t, time.Date(2023, 1, 9, 0, 0, 0, 0, eastern).Equal(firstWeek.End))
+	assert.Equal(t, 1, local.TrendAnalysis.TimeRanges[0].ActivityCount)
+	
+	// The caller's activities are left untouched
+	assert.Equal(t, time.UTC, activities[0].Created.Location())
+}
+
+func TestDataProcessor_ProcessActivities_WeekdayAcrossZones(t *testing.T) {
+	sydney, err := time.LoadLocation("Australia/Sydney")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	// 22:00 UTC on Friday 7 June 2024 is Saturday morning in Sydney and Friday evening in
+	// Chicago. The timestamp carries Sydney's zone, as Jira would report it for a Sydney user.
+	created := time.Date(2024, 6, 7, 22, 0, 0, 0, time.UTC).In(sydney)
+	chicago := time.FixedZone("CDT", -5*60*60)
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Done", Created: created, Updated: created.Add(time.Hour)},
+	}
+	
+	tests := []struct {
+		name     string
+		location *time.Location
+		weekday  string
+		day      time.Time
+	}{
+		{"default UTC", nil, "Friday", time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)},
+		{"Sydney", sydney, "Saturday", time.Date(2024, 6, 8, 0, 0, 0, 0, sydney)},
+		{"Chicago", chicago, "Friday", time.Date(2024, 6, 7, 0, 0, 0, 0, chicago)},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{
+				AnalyzeTrends:    true,
+				TrendGranularity: GranularityDaily,
+				Location:         tt.location,
+			})
+			require.NoError(t, err)
+			
+			assert.Equal(t, 1.0, result.TrendAnalysis.Seasonality[tt.weekday])
+			assert.Len(t, result.TrendAnalysis.Seasonality, 1)
+			
+			// The activity falls in the daily range of its local date
+			require.Len(t, result.TrendAnalysis.TimeRanges, 1)
+			day := result.TrendAnalysis.TimeRanges[0]
+			assert.True(t, tt.day.Equal(day.Range.Start), "range starts at %s", day.Range.Start)
+			assert.Equal(t, 1, day.ActivityCount)
+		})
+	}
+}
+
+func TestBusinessDaysBetween(t *testing.T) {
+	friday := time.Date(2023, 1, 6, 0, 0, 0, 0, time.UTC)
+	
+	assert.Equal(t, 0.0, businessDaysBetween(friday, friday, nil))
+	assert.Equal(t, 0.0, businessDaysBetween(friday.AddDate(0, 0, 1), friday.AddDate(0, 0, 3), nil)) // Whole weekend
+	assert.InDelta(t, 1.0, businessDaysBetween(friday, friday.AddDate(0, 0, 3), nil), 0.0001)
+	
+	// Partial days at either end are prorated
+	assert.InDelta(t, 1.0, businessDaysBetween(friday.Add(12*time.Hour), friday.AddDate(0, 0, 3).Add(12*time.Hour), nil), 0.0001)
+	assert.InDelta(t, 0.25, businessDaysBetween(friday.Add(6*time.Hour), friday.Add(12*time.Hour), nil), 0.0001)
+	
+	assert.Equal(t, 0.0, businessDaysBetween(friday, friday.AddDate(0, 0, 1), []time.Time{friday}))
+}
+
+func TestDataProcessor_ProcessActivities_TrendHalfLife(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	base := time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC) // A Monday
+	week := 7 * 24 * time.Hour
+	ranges := make([]TimeRange, 4)
+	for i := range ranges {
+		start := base.Add(time.Duration(i) * week)
+		ranges[i] = TimeRange{Start: start, End: start.Add(week), Label: fmt.Sprintf("Week %d", i+1)}
+	}
+	
+	// A burst of old completions followed by steady recent work
+	completedPerWeek := []int{6, 6, 4, 4}
+	var activities []models.Activity
+	for i, count := range completedPerWeek {
+		for j := 0; j < count; j++ {
+			activities = append(activities, models.Activity{
+				Key:     fmt.Sprintf("PROJ-%d%d", i, j),
+				Status:  "Done",
+				Created: ranges[i].Start.Add(24 * time.Hour),
+				Updated: ranges[i].Start.Add(48 * time.Hour),
+			})
+		}
+	}
+	
+	unweighted, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{
+		AnalyzeTrends:    true,
+		CustomTimeRanges: ranges,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "decreasing", unweighted.TrendAnalysis.VelocityTrend)
+	
+	weighted, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{
+		AnalyzeTrends:    true,
+		CustomTimeRanges: ranges,
+		TrendHalfLife:    3 * 24 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "increasing", weighted.TrendAnalysis.VelocityTrend)
+	
+	// Counts are unchanged, only the old ranges' velocity shrinks
+	for i := range ranges {
+		assert.Equal(t, unweighted.TrendAnalysis.TimeRanges[i].ActivityCount, weighted.TrendAnalysis.TimeRanges[i].ActivityCount)
+	}
+	assert.Less(t, weighted.TrendAnalysis.TimeRanges[0].AverageVelocity, unweighted.TrendAnalysis.TimeRanges[0].AverageVelocity)
+	assert.Equal(t, unweighted.TrendAnalysis.TimeRanges[3].AverageVelocity, weighted.TrendAnalysis.TimeRanges[3].AverageVelocity)
+}
+
+func TestDataProcessor_CalculateSeasonality_RecencyWeight(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	monday := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	recentMonday := monday.Add(8 * 7 * 24 * time.Hour)
+	activities := []models.Activity{
+		{Key: "OLD-1", Status: "Done", Created: monday, Updated: monday},
+		{Key: "NEW-1", Status: "In Progress", Created: recentMonday, Updated: recentMonday},
+	}
+	
+	analysis := &TrendAnalysis{Seasonality: make(map[string]float64)}
+	processor.calculateSeasonality(activities, analysis, processor.recencyWeight(activities, 0))
+	assert.Equal(t, 0.5, analysis.Seasonality["Monday"])
+	
+	// The old completion barely counts once recency weighting is on
+	analysis = &TrendAnalysis{Seasonality: make(map[string]float64)}
+	processor.calculateSeasonality(activities, analysis, processor.recencyWeight(activities, 7*24*time.Hour))
+	assert.InDelta(t, 1.0/257, analysis.Seasonality["Monday"], 0.0001) // 2^-8 / (2^-8 + 1)
+}
+
+func TestDataProcessor_ProcessActivitiesStream(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	activities := createTestActivities()
+	activities[0].Comments = []models.Comment{{ID: "c1", Body: "Looks good", Created: time.Now().Add(-time.Hour)}}
+	activities[0].Worklog = []models.Worklog{{ID: "w1", TimeSpent: 5400, Description: "Debugging", Updated: time.Now()}}
+	
+	options := ProcessingOptions{
+		IncludeComments:   true,
+		IncludeWorklogs:   true,
+		GroupByUser:       true,
+		GroupByPriority:   true,
+		GroupByStatus:     true,
+		GroupByType:       true,
+		AnalyzeTrends:     true,
+		CalculateVelocity: true,
+		TrendHalfLife:     24 * time.Hour,
+	}
+	
+	expected, err := processor.ProcessActivities(ctx, activities, options)
+	require.NoError(t, err)
+	
+	stream := make(chan models.Activity)
+	go func() {
+		defer close(stream)
+		for _, activity := range activities {
+			stream <- activity
+		}
+	}()
+	
+	actual, err := processor.ProcessActivitiesStream(ctx, stream, options)
+	require.NoError(t, err)
+	
+	// Timing fields aside, both paths produce the same result
+	for _, result := range []*ProcessingResult{expected, actual} {
+		result.ProcessedAt = time.Time{}
+		result.ProcessingTime = 0
+	}
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, len(activities), actual.Summary.TotalActivities)
+	require.NotNil(t, actual.TrendAnalysis)
+	assert.NotEmpty(t, actual.TrendAnalysis.TimeRanges)
+}
+
+func TestDataProcessor_ProcessActivitiesStream_Empty(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	stream := make(chan models.Activity)
+	close(stream)
+	
+	result, err := processor.ProcessActivitiesStream(context.Background(), stream, ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.Summary.TotalActivities)
+}
+
+func TestDataProcessor_ProcessActivitiesStream_Cancelled(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := make(chan models.Activity, 1)
+	stream <- createTestActivities()[0]
+	cancel()
+	
+	// The stream is never closed, so processing ends on cancellation
+	_, err := processor.ProcessActivitiesStream(ctx, stream, ProcessingOptions{})
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestDataProcessor_ProcessActivitiesStream_AllOptions(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	activities := createTestActivities()
+	activities[1].CustomFields = map[string]interface{}{DefaultEpicField: "EPIC-1", "team": "Core"}
+	activities[1].Changelog = []models.ChangelogEntry{
+		{Field: "status", From: "To Do", To: "In Progress", Created: activities[1].Created.Add(time.Hour)},
+		{Field: "assignee", From: "", To: "user2", Created: activities[1].Created.Add(time.Hour)},
+	}
+	
+	options := DefaultProcessingOptions()
+	options.GroupByType = true
+	options.GroupByProject = true
+	options.GroupByComponent = true
+	options.GroupByLabel = true
+	options.GroupByEpic = true
+	options.TrackScopeChanges = true
+	options.MinimumTimeSpent = 1800
+	
+	expected, err := processor.ProcessActivities(ctx, activities, options)
+	require.NoError(t, err)
+	
+	stream := make(chan models.Activity, len(activities))
+	for _, activity := range activities {
+		stream <- activity
+	}
+	close(stream)
+	
+	actual, err := processor.ProcessActivitiesStream(ctx, stream, options)
+	require.NoError(t, err)
+	
+	for _, result := range []*ProcessingResult{expected, actual} {
+		result.ProcessedAt = time.Time{}
+		result.ProcessingTime = 0
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestTrendRecord(t *testing.T) {
+	updated := time.Date(2024, 3, 4, 10, 0, 0, 0, time.UTC)
+	activity := models.Activity{
+		Key:         "PROJ-1",
+		Summary:     "Fix login",
+		Description: "Long description",
+		Status:      "Done",
+		Assignee:    models.User{AccountID: "user1", DisplayName: "User One", EmailAddress: "one@example.com"},
+		Updated:     updated,
+		TimeSpent:   3600,
+		CustomFields: map[string]interface{}{DefaultEpicField: "EPIC-1", "team": "Core"},
+		Comments: []models.Comment{
+			{ID: "c1", Body: "First", Updated: updated.Add(time.Hour)},
+			{ID: "c2", Body: "Second", Updated: updated.Add(2 * time.Hour)},
+		},
+		Worklog: []models.Worklog{{ID: "w1", TimeSpent: 3600, Description: "Debugging", Updated: updated.Add(3 * time.Hour)}},
+		Changelog: []models.ChangelogEntry{
+			{Field: "status", From: "To Do", To: "Done"},
+			{Field: "Sprint", From: "", To: "Sprint 1"},
+			{Field: "description", From: "Old", To: "New"},
+		},
+	}
+	
+	record := trendRecord(activity, DefaultEpicField)
+	
+	assert.Equal(t, "PROJ-1", record.Key)
+	assert.Empty(t, record.Summary)
+	assert.Empty(t, record.Description)
+	assert.Empty(t, record.Assignee.EmailAddress)
+	assert.Empty(t, record.Worklog)
+	assert.Equal(t, map[string]interface{}{DefaultEpicField: "EPIC-1"}, record.CustomFields)
+	assert.Len(t, record.Changelog, 2)
+	
+	// Only the latest comment or worklog update is kept, for trend recency weighting
+	require.Len(t, record.Comments, 1)
+	assert.Empty(t, record.Comments[0].Body)
+	assert.Equal(t, activity.GetLastUpdated(), record.GetLastUpdated())
+}
+
 func TestDataProcessor_ProcessActivities_WithMinimumTimeFilter(t *testing.T) {
 	logger := utils.NewMockLogger()
 	processor := NewDataProcessor(logger)
@@ -216,6 +643,43 @@ func TestDataProcessor_CalculateProductivityScore(t *testing.T) {
 	}
 }
 
+func TestDataProcessor_CalculateProductivityScore_StatusWeights(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	inReview := []models.Activity{
+		{Priority: "Low", Status: "In Review"},
+		{Priority: "Low", Status: "In Review"},
+	}
+	toDo := []models.Activity{
+		{Priority: "Low", Status: "To Do"},
+		{Priority: "Low", Status: "To Do"},
+	}
+	
+	// In Review earns 0.8 of the 25 efficiency points, To Do earns nothing
+	assert.InDelta(t, 20.0, processor.calculateProductivityScore(inReview, 0), 0.001)
+	assert.InDelta(t, 0.0, processor.calculateProductivityScore(toDo, 0), 0.001)
+	
+	// Unknown statuses keep the default partial credit
+	unknown := []models.Activity{{Priority: "Low", Status: "Blocked"}}
+	assert.InDelta(t, DefaultInProgressWeight*25, processor.calculateProductivityScore(unknown, 0), 0.001)
+	
+	// Custom weights replace the defaults
+	processor.SetStatusProgressWeights(map[string]float64{"In Review": 0.4})
+	assert.InDelta(t, 10.0, processor.calculateProductivityScore(inReview, 0), 0.001)
+	assert.InDelta(t, DefaultInProgressWeight*25, processor.calculateProductivityScore(toDo, 0), 0.001)
+}
+
+func TestDataProcessor_ProgressWeight(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	assert.Equal(t, 1.0, processor.progressWeight("Done"))
+	assert.Equal(t, 0.8, processor.progressWeight("In Review"))
+	assert.Equal(t, 0.0, processor.progressWeight("To Do"))
+	assert.Equal(t, DefaultInProgressWeight, processor.progressWeight("Unknown"))
+}
+
 func TestDataProcessor_IsCompleted(t *testing.T) {
 	logger := utils.NewMockLogger()
 	processor := NewDataProcessor(logger)
@@ -243,6 +707,76 @@ func TestDataProcessor_IsCompleted(t *testing.T) {
 	}
 }
 
+func TestDataProcessor_IsCompleted_CaseInsensitive(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	assert.True(t, processor.isCompleted("done"))
+	assert.True(t, processor.isCompleted(" CLOSED "))
+	assert.False(t, processor.isCompleted("Erledigt"))
+}
+
+func TestDataProcessor_ProcessActivities_CustomCompletedStatuses(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	now := time.Now()
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Erledigt", Assignee: models.User{AccountID: "user1"}, Created: now, Updated: now},
+		{Key: "PROJ-2", Status: "Shipped", Assignee: models.User{AccountID: "user1"}, Created: now, Updated: now},
+		{Key: "PROJ-3", Status: "In Arbeit", Assignee: models.User{AccountID: "user2"}, Created: now, Updated: now},
+		{Key: "PROJ-4", Status: "Done", Assignee: models.User{AccountID: "user2"}, Created: now, Updated: now},
+	}
+	
+	defaultResult, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{GroupByUser: true})
+	require.NoError(t, err)
+	assert.Equal(t, 25.0, defaultResult.Summary.CompletionRate)
+	
+	customResult, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		GroupByUser:       true,
+		CompletedStatuses: []string{"erledigt", "SHIPPED"},
+	})
+	require.NoError(t, err)
+	
+	// Only the configured statuses count, so "Done" is no longer completed
+	assert.Equal(t, 50.0, customResult.Summary.CompletionRate)
+	assert.Equal(t, 2, customResult.UserMetrics["user1"].CompletedActivities)
+	assert.Equal(t, 0, customResult.UserMetrics["user2"].CompletedActivities)
+	assert.Greater(t, customResult.Summary.ProductivityScore, defaultResult.Summary.ProductivityScore)
+	
+	// The configured sets only apply to the run they were passed to
+	assert.False(t, processor.isCompleted("Shipped"))
+}
+
+func TestDataProcessor_ProcessActivities_CustomInProgressStatuses(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	now := time.Now()
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Done", Assignee: models.User{AccountID: "user1"}, Created: now, Updated: now},
+		{Key: "PROJ-2", Status: "In Arbeit", Assignee: models.User{AccountID: "user1"}, Created: now, Updated: now},
+		{Key: "PROJ-3", Status: "Warteschlange", Assignee: models.User{AccountID: "user1"}, Created: now, Updated: now},
+	}
+	
+	defaultResult, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{})
+	require.NoError(t, err)
+	
+	// Only "In Arbeit" earns partial progress, the queued item earns none
+	customResult, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		InProgressStatuses: []string{"in arbeit"},
+	})
+	require.NoError(t, err)
+	
+	assert.Equal(t, defaultResult.Summary.CompletionRate, customResult.Summary.CompletionRate)
+	assert.Less(t, customResult.Summary.ProductivityScore, defaultResult.Summary.ProductivityScore)
+	
+	configured := processor.withStatusSets(ProcessingOptions{InProgressStatuses: []string{"in arbeit"}})
+	assert.Equal(t, DefaultInProgressWeight, configured.progressWeight("In Arbeit"))
+	assert.Equal(t, 0.0, configured.progressWeight("Warteschlange"))
+	assert.Equal(t, 1.0, configured.progressWeight("done"))
+}
+
 func TestDataProcessor_FilterActivities(t *testing.T) {
 	logger := utils.NewMockLogger()
 	processor := NewDataProcessor(logger)
@@ -392,7 +926,7 @@ func TestDataProcessor_CalculateStatusMetrics(t *testing.T) {
 		},
 	}
 	
-	metrics := processor.calculateStatusMetrics("Done", activities)
+	metrics := processor.calculateStatusMetrics("Done", activities, false)
 	
 	assert.Equal(t, "Done", metrics.Status)
 	assert.Equal(t, 2, metrics.Count)
@@ -403,66 +937,1065 @@ func TestDataProcessor_CalculateStatusMetrics(t *testing.T) {
 	assert.Equal(t, 1, metrics.RecentChanges) // Only the recent one
 }
 
-func TestDataProcessor_GenerateWeeklyRanges(t *testing.T) {
+func TestDataProcessor_ProcessActivities_IncludeComments(t *testing.T) {
 	logger := utils.NewMockLogger()
 	processor := NewDataProcessor(logger)
-	
-	startDate := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
-	endDate := startDate.Add(15 * 24 * time.Hour) // 15 days
+	ctx := context.Background()
+	now := time.Now()
 	
 	activities := []models.Activity{
 		{
-			Created: startDate,
-			Updated: endDate,
+			Key:     "PROJ-1",
+			Status:  "In Progress",
+			Updated: now.Add(-10 * 24 * time.Hour), // Old
+			Comments: []models.Comment{
+				{ID: "1", Created: now.Add(-2 * 24 * time.Hour)},
+				{ID: "2", Created: now.Add(-1 * time.Hour)},
+				{ID: "3", Created: now.Add(-30 * 24 * time.Hour)}, // Too old to count
+			},
+		},
+		{
+			Key:     "PROJ-2",
+			Status:  "In Progress",
+			Updated: now.Add(-1 * time.Hour),
 		},
 	}
 	
-	ranges := processor.generateWeeklyRanges(activities)
-	
-	assert.Len(t, ranges, 3) // Should create 3 weekly ranges for 15 days
-	assert.Equal(t, "Week 1", ranges[0].Label)
-	assert.Equal(t, "Week 2", ranges[1].Label)
-	assert.Equal(t, "Week 3", ranges[2].Label)
+	result, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{GroupByStatus: true})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.StatusBreakdown["In Progress"].RecentChanges)
 	
-	// Verify ranges are continuous
-	assert.Equal(t, startDate, ranges[0].Start)
-	assert.Equal(t, ranges[0].End, ranges[1].Start)
-	assert.Equal(t, ranges[1].End, ranges[2].Start)
+	result, err = processor.ProcessActivities(ctx, activities, ProcessingOptions{GroupByStatus: true, IncludeComments: true})
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.StatusBreakdown["In Progress"].RecentChanges)
 }
 
-func TestDataProcessor_FilterActivitiesByTimeRange(t *testing.T) {
+func TestDataProcessor_ProcessActivities_IncludeWorklogs(t *testing.T) {
 	logger := utils.NewMockLogger()
 	processor := NewDataProcessor(logger)
-	
-	baseTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
 	
 	activities := []models.Activity{
 		{
-			Created: baseTime.Add(1 * 24 * time.Hour),
-			Updated: baseTime.Add(2 * 24 * time.Hour),
-		},
-		{
-			Created: baseTime.Add(3 * 24 * time.Hour),
-			Updated: baseTime.Add(4 * 24 * time.Hour),
+			Key:       "PROJ-1",
+			Status:    "Done",
+			Assignee:  models.User{AccountID: "user1"},
+			TimeSpent: 3600,
+			Worklog: []models.Worklog{
+				{ID: "1", TimeSpent: 1800},
+				{ID: "2", TimeSpent: 5400},
+			},
 		},
 		{
-			Created: baseTime.Add(8 * 24 * time.Hour),
-			Updated: baseTime.Add(9 * 24 * time.Hour),
+			// No worklogs, the issue-level time is kept
+			Key:       "PROJ-2",
+			Status:    "Done",
+			Assignee:  models.User{AccountID: "user1"},
+			TimeSpent: 1200,
 		},
 	}
 	
-	timeRange := TimeRange{
-		Start: baseTime,
-		End:   baseTime.Add(5 * 24 * time.Hour),
-		Label: "Test Range",
-	}
+	result, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{GroupByUser: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(4800), result.Summary.TotalTimeSpent)
+	assert.Equal(t, int64(4800), result.UserMetrics["user1"].TotalTimeSpent)
 	
-	filtered := processor.filterActivitiesByTimeRange(activities, timeRange)
+	result, err = processor.ProcessActivities(ctx, activities, ProcessingOptions{GroupByUser: true, IncludeWorklogs: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8400), result.Summary.TotalTimeSpent)
+	assert.Equal(t, int64(8400), result.UserMetrics["user1"].TotalTimeSpent)
+	
+	// Worklog totals also drive the minimum time filter
+	result, err = processor.ProcessActivities(ctx, activities, ProcessingOptions{IncludeWorklogs: true, MinimumTimeSpent: 7200})
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Summary.TotalActivities)
+	
+	// The caller's activities are not modified
+	assert.Equal(t, int64(3600), activities[0].TimeSpent)
+}
+
+func TestDataProcessor_ProcessActivities_GroupByProjectComponentLabel(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	activities := []models.Activity{
+		{
+			Key:        "WEB-1",
+			Status:     "Done",
+			Project:    models.Project{Key: "WEB"},
+			Components: []string{"Frontend", "API"},
+			Labels:     []string{"security", "customer"},
+			TimeSpent:  3600,
+		},
+		{
+			Key:        "WEB-2",
+			Status:     "In Progress",
+			Project:    models.Project{Key: "WEB"},
+			Components: []string{"Frontend"},
+			Labels:     []string{"security", "security"}, // Duplicates count once
+			TimeSpent:  1800,
+		},
+		{
+			Key:       "OPS-1",
+			Status:    "Done",
+			Project:   models.Project{Key: "OPS"},
+			TimeSpent: 600,
+		},
+	}
+	
+	result, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, result.ProjectBreakdown)
+	assert.Nil(t, result.ComponentBreakdown)
+	assert.Nil(t, result.LabelBreakdown)
+	
+	result, err = processor.ProcessActivities(ctx, activities, ProcessingOptions{
+		GroupByProject:   true,
+		GroupByComponent: true,
+		GroupByLabel:     true,
+	})
+	require.NoError(t, err)
+	
+	require.Len(t, result.ProjectBreakdown, 2)
+	web := result.ProjectBreakdown["WEB"]
+	assert.Equal(t, 2, web.Count)
+	assert.Equal(t, int64(5400), web.TotalTimeSpent)
+	assert.Equal(t, 1, web.CompletedCount)
+	assert.Equal(t, 50.0, web.CompletionRate)
+	assert.Equal(t, 1, result.ProjectBreakdown["OPS"].Count)
+	
+	require.Len(t, result.ComponentBreakdown, 3)
+	assert.Equal(t, 2, result.ComponentBreakdown["Frontend"].Count)
+	assert.Equal(t, 1, result.ComponentBreakdown["API"].Count)
+	assert.Equal(t, 1, result.ComponentBreakdown["None"].Count)
+	
+	// The activity with two labels appears in both buckets
+	require.Len(t, result.LabelBreakdown, 3)
+	security := result.LabelBreakdown["security"]
+	assert.Equal(t, 2, security.Count)
+	assert.Equal(t, int64(5400), security.TotalTimeSpent)
+	customer := result.LabelBreakdown["customer"]
+	assert.Equal(t, 1, customer.Count)
+	assert.Equal(t, int64(3600), customer.TotalTimeSpent)
+	assert.InDelta(t, 33.33, customer.Share, 0.01)
+	assert.Equal(t, 1, result.LabelBreakdown["None"].Count)
+	
+	// Totals count each activity once
+	assert.Equal(t, 3, result.Summary.TotalActivities)
+	assert.Equal(t, int64(6000), result.Summary.TotalTimeSpent)
+}
+
+func TestDataProcessor_ProcessActivities_GroupByEpic(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	activities := createTestActivities()
+	activities[0].CustomFields = map[string]interface{}{DefaultEpicField: "TEST-100"}
+	activities[1].CustomFields = map[string]interface{}{DefaultEpicField: "TEST-100"}
+	activities[2].CustomFields = map[string]interface{}{DefaultEpicField: "TEST-100"}
+	activities[3].CustomFields = map[string]interface{}{DefaultEpicField: nil, "team": "Platform"}
+	
+	result, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, result.EpicBreakdown)
+	
+	result, err = processor.ProcessActivities(ctx, activities, ProcessingOptions{GroupByEpic: true})
+	require.NoError(t, err)
+	require.Len(t, result.EpicBreakdown, 2)
+	
+	epic := result.EpicBreakdown["TEST-100"]
+	assert.Equal(t, "TEST-100", epic.Epic)
+	assert.Equal(t, 3, epic.Count)
+	assert.Equal(t, int64(16200), epic.TotalTimeSpent)
+	assert.Equal(t, 2, epic.CompletedCount)
+	assert.InDelta(t, 66.67, epic.CompletionRate, 0.01)
+	assert.Equal(t, 75.0, epic.Share)
+	assert.Equal(t, []string{"User One", "User Two"}, epic.Users)
+	
+	// A null epic link counts as unassigned
+	unassigned := result.EpicBreakdown[UnassignedEpic]
+	assert.Equal(t, 1, unassigned.Count)
+	assert.Zero(t, unassigned.CompletionRate)
+	assert.Equal(t, []string{"User Two"}, unassigned.Users)
+	
+	// A custom epic field reads a different custom field
+	activities[0].CustomFields["parent"] = "TEST-200"
+	result, err = processor.ProcessActivities(ctx, activities, ProcessingOptions{GroupByEpic: true, EpicField: "parent"})
+	require.NoError(t, err)
+	require.Len(t, result.EpicBreakdown, 2)
+	assert.Equal(t, 1, result.EpicBreakdown["TEST-200"].Count)
+	assert.Equal(t, 3, result.EpicBreakdown[UnassignedEpic].Count)
+}
+
+func TestDataProcessor_ProcessTypeMetrics(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	activities := []models.Activity{
+		{Type: "Bug", Status: "Done", TimeSpent: 3600},
+		{Type: "Bug", Status: "Closed", TimeSpent: 1800},
+		{Type: "Bug", Status: "In Progress", TimeSpent: 600},
+		{Type: "Story", Status: "Done", TimeSpent: 7200},
+		{Type: "Story", Status: "To Do", TimeSpent: 0},
+		{Type: "Task", Status: "Resolved", TimeSpent: 900},
+		{Type: "", Status: "Open", TimeSpent: 300},
+		{Type: "Epic", Status: "In Progress", TimeSpent: 0},
+	}
+	
+	breakdown := make(map[string]TypeMetrics)
+	processor.processTypeMetrics(activities, breakdown)
+	
+	require.Len(t, breakdown, 5)
+	
+	bugs := breakdown["Bug"]
+	assert.Equal(t, "Bug", bugs.Type)
+	assert.Equal(t, 3, bugs.Count)
+	assert.Equal(t, int64(6000), bugs.TotalTimeSpent)
+	assert.Equal(t, 2, bugs.CompletedCount)
+	assert.InDelta(t, 66.67, bugs.CompletionRate, 0.01)
+	assert.Equal(t, 37.5, bugs.Share)
+	
+	stories := breakdown["Story"]
+	assert.Equal(t, 2, stories.Count)
+	assert.Equal(t, 50.0, stories.CompletionRate)
+	assert.Equal(t, 25.0, stories.Share)
+	
+	assert.Equal(t, 100.0, breakdown["Task"].CompletionRate)
+	assert.Equal(t, 0.0, breakdown["Epic"].CompletionRate)
+	assert.Equal(t, 1, breakdown["Unknown"].Count)
+}
+
+func TestDataProcessor_ProcessActivities_WithTypeBreakdown(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	activities := createTestActivities()
+	
+	result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{GroupByType: true})
+	require.NoError(t, err)
+	
+	total := 0
+	for _, metrics := range result.TypeBreakdown {
+		total += metrics.Count
+	}
+	assert.Equal(t, len(activities), total)
+	
+	result, err = processor.ProcessActivities(context.Background(), activities, ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, result.TypeBreakdown)
+}
+
+func TestDataProcessor_CalculateScopeMetrics(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	start := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	period := TimeRange{Start: start, End: start.Add(14 * 24 * time.Hour), Label: "Sprint 5"}
+	before := start.Add(-48 * time.Hour)
+	
+	activities := []models.Activity{
+		// Planned before the sprint started
+		{Key: "PROJ-1", Status: "Done", Created: before, Changelog: []models.ChangelogEntry{
+			{Field: "Sprint", From: "", To: "Sprint 5", Created: before.Add(time.Hour)},
+		}},
+		// Created mid-sprint
+		{Key: "PROJ-2", Status: "In Progress", Created: start.Add(3 * 24 * time.Hour)},
+		// Existing item pulled into the sprint mid-period
+		{Key: "PROJ-3", Status: "To Do", Created: before, Changelog: []models.ChangelogEntry{
+			{Field: "Sprint", From: "", To: "Sprint 5", Created: start.Add(5 * 24 * time.Hour)},
+		}},
+		// Planned item dropped from the sprint
+		{Key: "PROJ-4", Status: "To Do", Created: before, Changelog: []models.ChangelogEntry{
+			{Field: "Sprint", From: "", To: "Sprint 5", Created: before},
+			{Field: "Sprint", From: "Sprint 5", To: "", Created: start.Add(6 * 24 * time.Hour)},
+		}},
+		// Completed item reopened mid-sprint
+		{Key: "PROJ-5", Status: "In Progress", Created: before, Changelog: []models.ChangelogEntry{
+			{Field: "status", From: "Done", To: "In Progress", Created: start.Add(2 * 24 * time.Hour)},
+		}},
+		// Created after the sprint ended
+		{Key: "PROJ-6", Status: "To Do", Created: period.End.Add(time.Hour)},
+	}
+	
+	metrics := processor.calculateScopeMetrics(activities, period)
+	
+	assert.Equal(t, period, metrics.Period)
+	assert.Equal(t, 2, metrics.Planned)
+	assert.Equal(t, 3, metrics.ScopeAdded)
+	assert.Equal(t, 1, metrics.ScopeRemoved)
+	assert.Equal(t, 1, metrics.Reopened)
+	assert.Equal(t, []string{"PROJ-2", "PROJ-3", "PROJ-5"}, metrics.AddedKeys)
+	assert.Equal(t, []string{"PROJ-4"}, metrics.RemovedKeys)
+	assert.Equal(t, 200.0, metrics.ChangeRate)
+}
+
+func TestDataProcessor_ProcessActivities_WithScopeChanges(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	start := time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)
+	period := TimeRange{Start: start, End: start.Add(7 * 24 * time.Hour)}
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Done", Created: start.Add(-24 * time.Hour)},
+		{Key: "PROJ-2", Status: "To Do", Created: start.Add(2 * 24 * time.Hour)},
+	}
+	
+	result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		TrackScopeChanges: true,
+		ScopePeriod:       &period,
+	})
+	require.NoError(t, err)
+	require.NotNil(t, result.ScopeChanges)
+	assert.Equal(t, 1, result.ScopeChanges.Planned)
+	assert.Equal(t, 1, result.ScopeChanges.ScopeAdded)
+	assert.Equal(t, []string{"PROJ-2"}, result.ScopeChanges.AddedKeys)
+	
+	result, err = processor.ProcessActivities(context.Background(), activities, ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Nil(t, result.ScopeChanges)
+}
+
+func TestDataProcessor_Percentile(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	values := []float64{40, 10, 30, 20}
+	
+	assert.Equal(t, 10.0, processor.percentile(values, 0))
+	assert.Equal(t, 25.0, processor.percentile(values, 50))
+	assert.InDelta(t, 37.0, processor.percentile(values, 90), 0.0001)
+	assert.Equal(t, 40.0, processor.percentile(values, 100))
+	assert.Equal(t, 0.0, processor.percentile(nil, 50))
+	assert.Equal(t, 7.0, processor.percentile([]float64{7}, 90))
+	
+	// The input is left unsorted
+	assert.Equal(t, []float64{40, 10, 30, 20}, values)
+}
+
+func TestDataProcessor_MedianAndStdDev(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	tests := []struct {
+		name   string
+		values []float64
+		median float64
+		stddev float64
+	}{
+		{name: "empty", values: nil, median: 0, stddev: 0},
+		{name: "single element", values: []float64{42}, median: 42, stddev: 0},
+		{name: "odd length", values: []float64{9, 1, 5}, median: 5, stddev: 3.2660},
+		{name: "even length interpolates", values: []float64{2, 4, 4, 4, 5, 5, 7, 9}, median: 4.5, stddev: 2},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.median, processor.median(tt.values))
+			assert.InDelta(t, tt.stddev, processor.stddev(tt.values), 0.0001)
+		})
+	}
+}
+
+func TestDataProcessor_TimePerTaskPercentiles(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	ctx := context.Background()
+	
+	// Nine one hour tasks and one long support escalation skew the average
+	activities := make([]models.Activity, 0, 10)
+	for i := 0; i < 9; i++ {
+		activities = append(activities, models.Activity{
+			Key:       fmt.Sprintf("SUP-%d", i+1),
+			Status:    "Done",
+			Assignee:  models.User{AccountID: "user1", DisplayName: "User One"},
+			TimeSpent: 3600,
+		})
+	}
+	activities = append(activities, models.Activity{
+		Key:       "SUP-10",
+		Status:    "In Progress",
+		Assignee:  models.User{AccountID: "user1", DisplayName: "User One"},
+		TimeSpent: 36000,
+	})
+	
+	result, err := processor.ProcessActivities(ctx, activities, ProcessingOptions{GroupByUser: true})
+	require.NoError(t, err)
+	
+	summary := result.Summary
+	assert.Equal(t, int64(3600), summary.MedianTimePerTask)
+	assert.InDelta(t, 6840, summary.P90TimePerTask, 1)  // 3600 + 0.1 * 32400
+	assert.InDelta(t, 21420, summary.P95TimePerTask, 1) // 3600 + 0.55 * 32400
+	assert.Equal(t, int64(9720), summary.StdDevTimePerTask)
+	
+	user := result.UserMetrics["user1"]
+	assert.Equal(t, int64(6840), user.AverageTimePerTask)
+	assert.Equal(t, summary.MedianTimePerTask, user.MedianTimePerTask)
+	assert.Equal(t, summary.P90TimePerTask, user.P90TimePerTask)
+	assert.Equal(t, summary.P95TimePerTask, user.P95TimePerTask)
+	assert.Equal(t, summary.StdDevTimePerTask, user.StdDevTimePerTask)
+}
+
+func TestDataProcessor_CycleAndLeadTime(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	created := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	resolved := func(d time.Duration) *time.Time {
+		at := created.Add(d)
+		return &at
+	}
+	
+	activities := []models.Activity{
+		// Lead 1d, picked up 12h after creation
+		{Key: "PROJ-1", Status: "Done", Priority: "High", Created: created, Resolved: resolved(day),
+			Changelog: []models.ChangelogEntry{{Field: "status", From: "To Do", To: "In Progress", Created: created.Add(12 * time.Hour)}}},
+		// Lead 2d, no changelog so cycle time equals lead time
+		{Key: "PROJ-2", Status: "Done", Priority: "High", Created: created, Resolved: resolved(2 * day)},
+		// Lead 3d, no resolution date so the last update is used
+		{Key: "PROJ-3", Status: "Closed", Priority: "Low", Created: created, Updated: created.Add(3 * day)},
+		// Outlier, lead 30d
+		{Key: "PROJ-4", Status: "Resolved", Priority: "Low", Created: created, Resolved: resolved(30 * day)},
+		// Open items are excluded
+		{Key: "PROJ-5", Status: "In Progress", Priority: "High", Created: created, Updated: created.Add(40 * day)},
+	}
+	
+	daySeconds := int64(day / time.Second)
+	
+	lead := processor.leadTimeStats(activities)
+	assert.Equal(t, 4, lead.Count)
+	assert.Equal(t, 9*daySeconds, lead.Mean)          // (1 + 2 + 3 + 30) / 4 days
+	assert.Equal(t, 5*daySeconds/2, lead.Median)      // Halfway between 2 and 3 days
+	assert.InDelta(t, 219*daySeconds/10, lead.P90, 1) // 3 days + 0.7 * 27 days
+	
+	// Cycle times are 0.5, 2, 3 and 30 days
+	cycle := processor.cycleTimeStats(activities)
+	assert.Equal(t, 4, cycle.Count)
+	assert.Equal(t, 71*daySeconds/8, cycle.Mean)
+	assert.Equal(t, 5*daySeconds/2, cycle.Median)
+	assert.InDelta(t, 219*daySeconds/10, cycle.P90, 1)
+	
+	result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		GroupByPriority: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, lead, result.Summary.LeadTime)
+	assert.Equal(t, cycle, result.Summary.CycleTime)
+	assert.Equal(t, 2, result.PriorityBreakdown["High"].LeadTime.Count)
+	assert.Equal(t, int64(3*day/2/time.Second), result.PriorityBreakdown["High"].LeadTime.Mean)
+	assert.Equal(t, int64(33*day/2/time.Second), result.PriorityBreakdown["Low"].LeadTime.Median)
+}
+
+func TestDataProcessor_ReworkRate(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	start := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	history := func(statuses ...string) []models.StatusChange {
+		changes := make([]models.StatusChange, 0, len(statuses)-1)
+		for i := 1; i < len(statuses); i++ {
+			changes = append(changes, models.StatusChange{
+				From:    statuses[i-1],
+				To:      statuses[i],
+				Changed: start.Add(time.Duration(i) * time.Hour),
+			})
+		}
+		return changes
+	}
+	
+	tests := []struct {
+		name            string
+		history         []models.StatusChange
+		status          string
+		expectedRate    float64
+		expectedReopens int
+	}{
+		{
+			name:    "completed without reopening",
+			history: history("To Do", "In Progress", "Done"),
+			status:  "Done",
+		},
+		{
+			name:            "reopened once",
+			history:         history("To Do", "In Progress", "Done", "In Progress", "Done"),
+			status:          "Done",
+			expectedRate:    100,
+			expectedReopens: 1,
+		},
+		{
+			name:            "reopened several times",
+			history:         history("In Progress", "Done", "Reopened", "Closed", "In Progress", "Resolved", "To Do"),
+			status:          "To Do",
+			expectedRate:    100,
+			expectedReopens: 3,
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activities := []models.Activity{{
+				Key:           "PROJ-1",
+				Status:        tt.status,
+				Assignee:      models.User{AccountID: "user1"},
+				Created:       start,
+				Updated:       start.Add(24 * time.Hour),
+				StatusHistory: tt.history,
+			}}
+			
+			result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{GroupByUser: true})
+			require.NoError(t, err)
+			
+			assert.True(t, result.Summary.ReworkAvailable)
+			assert.Equal(t, tt.expectedRate, result.Summary.ReworkRate)
+			assert.Equal(t, tt.expectedReopens, result.Summary.ReopenCount)
+			
+			user := result.UserMetrics["user1"]
+			assert.True(t, user.ReworkAvailable)
+			assert.Equal(t, tt.expectedRate, user.ReworkRate)
+			assert.Equal(t, tt.expectedReopens, user.ReopenCount)
+		})
+	}
+}
+
+func TestDataProcessor_ReworkRate_MixedActivities(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	changed := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	activities := []models.Activity{
+		// Completed cleanly
+		{Key: "PROJ-1", Status: "Done", StatusHistory: []models.StatusChange{
+			{From: "In Progress", To: "Done", Changed: changed},
+		}},
+		// Reopened twice, counts once towards the rate
+		{Key: "PROJ-2", Status: "Done", StatusHistory: []models.StatusChange{
+			{From: "In Progress", To: "Done", Changed: changed},
+			{From: "Done", To: "In Progress", Changed: changed.Add(time.Hour)},
+			{From: "In Progress", To: "Done", Changed: changed.Add(2 * time.Hour)},
+			{From: "Done", To: "Reopened", Changed: changed.Add(3 * time.Hour)},
+			{From: "Reopened", To: "Done", Changed: changed.Add(4 * time.Hour)},
+		}},
+		// Never completed, not part of the rate
+		{Key: "PROJ-3", Status: "In Progress", StatusHistory: []models.StatusChange{
+			{From: "To Do", To: "In Progress", Changed: changed},
+		}},
+		// Completed, no history available
+		{Key: "PROJ-4", Status: "Done"},
+	}
+	
+	rework := processor.reworkStats(activities)
+	assert.True(t, rework.available)
+	assert.Equal(t, 50.0, rework.rate)
+	assert.Equal(t, 2, rework.reopens)
+}
+
+func TestDataProcessor_ReworkRate_Unavailable(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	result, err := processor.ProcessActivities(context.Background(), createTestActivities(), ProcessingOptions{GroupByUser: true})
+	require.NoError(t, err)
+	
+	// Without status history rework cannot be measured
+	assert.False(t, result.Summary.ReworkAvailable)
+	assert.Zero(t, result.Summary.ReworkRate)
+	assert.Zero(t, result.Summary.ReopenCount)
+	for _, user := range result.UserMetrics {
+		assert.False(t, user.ReworkAvailable)
+		assert.Zero(t, user.ReworkRate)
+	}
+}
+
+func TestDataProcessor_StoryPoints(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	activities := createTestActivities()
+	activities[0].StoryPoints = 5 // High, Done, user1
+	activities[1].StoryPoints = 3 // Medium, In Progress, user1
+	activities[3].StoryPoints = 8 // Low, Open, user2
+	// TEST-3 (High, Done, user2) is unestimated
+	
+	result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		GroupByUser:     true,
+		GroupByPriority: true,
+	})
+	require.NoError(t, err)
+	
+	summary := result.Summary
+	assert.Equal(t, 16.0, summary.PlannedPoints)
+	assert.Equal(t, 5.0, summary.CompletedPoints)
+	assert.Equal(t, 31.25, summary.PointCompletionRate)
+	assert.InDelta(t, 16.0/3, summary.AveragePoints, 0.001)
+	assert.Equal(t, 1, summary.UnestimatedActivities)
+	// The count-based rate still includes the unestimated issue
+	assert.Equal(t, 50.0, summary.CompletionRate)
+	
+	user1 := result.UserMetrics["user1"]
+	assert.Equal(t, 8.0, user1.PlannedPoints)
+	assert.Equal(t, 5.0, user1.CompletedPoints)
+	assert.Equal(t, 62.5, user1.PointCompletionRate)
+	assert.Zero(t, user1.UnestimatedActivities)
+	
+	user2 := result.UserMetrics["user2"]
+	assert.Equal(t, 8.0, user2.PlannedPoints)
+	assert.Zero(t, user2.CompletedPoints)
+	assert.Zero(t, user2.PointCompletionRate)
+	assert.Equal(t, 1, user2.UnestimatedActivities)
+	
+	// The unestimated issue does not dilute the High priority point rate
+	high := result.PriorityBreakdown["High"]
+	assert.Equal(t, 5.0, high.PlannedPoints)
+	assert.Equal(t, 5.0, high.CompletedPoints)
+	assert.Equal(t, 100.0, high.PointCompletionRate)
+	assert.Equal(t, 8.0, result.PriorityBreakdown["Low"].PlannedPoints)
+	assert.Zero(t, result.PriorityBreakdown["Low"].PointCompletionRate)
+}
+
+func TestDataProcessor_StoryPoints_Unestimated(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	result, err := processor.ProcessActivities(context.Background(), createTestActivities(), ProcessingOptions{GroupByUser: true})
+	require.NoError(t, err)
+	
+	// Without estimates the point metrics stay at zero instead of reporting 0% completion of nothing
+	assert.Zero(t, result.Summary.PlannedPoints)
+	assert.Zero(t, result.Summary.PointCompletionRate)
+	assert.Zero(t, result.Summary.AveragePoints)
+	assert.Equal(t, 4, result.Summary.UnestimatedActivities)
+	for _, user := range result.UserMetrics {
+		assert.Zero(t, user.PointCompletionRate)
+		assert.Equal(t, 2, user.UnestimatedActivities)
+	}
+}
+
+func TestDataProcessor_StaleIssues(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	asOf := time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)
+	threshold := 10 * 24 * time.Hour
+	alice := models.User{AccountID: "alice"}
+	bob := models.User{AccountID: "bob"}
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "In Progress", Assignee: alice, Updated: asOf.Add(-threshold - time.Second)}, // Just past the threshold
+		{Key: "PROJ-2", Status: "In Progress", Assignee: alice, Updated: asOf.Add(-threshold)},               // Exactly at the threshold
+		{Key: "PROJ-3", Status: "To Do", Assignee: bob, Updated: asOf.Add(-30 * 24 * time.Hour)},
+		{Key: "PROJ-4", Status: "Done", Assignee: bob, Updated: asOf.Add(-30 * 24 * time.Hour)},        // Completed work is never stale
+		{Key: "PROJ-5", Status: "In Review", Assignee: bob, Updated: asOf.Add(-24 * time.Hour)},
+	}
+	for i := range activities {
+		activities[i].Created = activities[i].Updated
+	}
+	
+	result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		GroupByUser: true,
+		StaleAfter:  threshold,
+		StaleAsOf:   asOf,
+	})
+	require.NoError(t, err)
+	
+	// Least recently updated first
+	assert.Equal(t, []string{"PROJ-3", "PROJ-1"}, result.Summary.StaleIssues)
+	assert.Equal(t, int64(threshold/time.Second), result.Summary.StaleAfter)
+	assert.Equal(t, 1, result.UserMetrics["alice"].StaleActivities)
+	assert.Equal(t, 1, result.UserMetrics["bob"].StaleActivities)
+	
+	// A longer threshold leaves only the oldest issue
+	result, err = processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		StaleAfter: 20 * 24 * time.Hour,
+		StaleAsOf:  asOf,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROJ-3"}, result.Summary.StaleIssues)
+	
+	// Without a threshold the default applies, measured from the time of processing
+	result, err = processor.ProcessActivities(context.Background(), activities, ProcessingOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PROJ-3", "PROJ-1", "PROJ-2", "PROJ-5"}, result.Summary.StaleIssues)
+	assert.Equal(t, int64(DefaultStaleAfter/time.Second), result.Summary.StaleAfter)
+}
+
+func TestDataProcessor_WorkloadBalance(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	// activitiesWithTime gives one user per entry an activity with that many hours logged
+	activitiesWithTime := func(hours ...int64) []models.Activity {
+		activities := make([]models.Activity, len(hours))
+		for i, h := range hours {
+			activities[i] = models.Activity{
+				Key:       fmt.Sprintf("PROJ-%d", i+1),
+				Status:    "Done",
+				TimeSpent: h * 3600,
+				Assignee:  models.User{AccountID: fmt.Sprintf("user%d", i+1)},
+			}
+		}
+		return activities
+	}
+	
+	tests := []struct {
+		name  string
+		hours []int64
+		want  float64
+	}{
+		{"perfectly even", []int64{10, 10, 10, 10}, 0},
+		{"mildly skewed", []int64{8, 10, 12, 14}, 0.1136},
+		{"one person dominates", []int64{1, 1, 1, 37}, 0.675},
+	}
+	
+	previous := -1.0
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := processor.ProcessActivities(context.Background(), activitiesWithTime(tt.hours...), ProcessingOptions{})
+			require.NoError(t, err)
+			
+			assert.InDelta(t, tt.want, result.Summary.WorkloadBalance, 0.001)
+			assert.Greater(t, result.Summary.WorkloadBalance, previous)
+			previous = result.Summary.WorkloadBalance
+		})
+	}
+}
+
+func TestGiniCoefficient(t *testing.T) {
+	assert.Zero(t, giniCoefficient(nil))
+	assert.Zero(t, giniCoefficient([]float64{0, 0}))
+	assert.Zero(t, giniCoefficient([]float64{5}))
+	// One of n values holding everything is the maximum, (n-1)/n
+	assert.InDelta(t, 0.75, giniCoefficient([]float64{0, 40, 0, 0}), 1e-9)
+	assert.InDelta(t, 0.25, giniCoefficient([]float64{1, 3}), 1e-9)
+}
+
+func TestDataProcessor_ProcessActivities_TiesAreRepeatable(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	created := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Done", Priority: "Low", TimeSpent: 3600, Assignee: models.User{AccountID: "zoe"}, Created: created, Updated: created},
+		{Key: "PROJ-2", Status: "Done", Priority: "High", TimeSpent: 3600, Assignee: models.User{AccountID: "amir"}, Created: created, Updated: created},
+		{Key: "PROJ-3", Status: "Done", Priority: "Medium", TimeSpent: 3600, Assignee: models.User{AccountID: "mia"}, Created: created, Updated: created},
+	}
+	
+	for i := 0; i < 10; i++ {
+		result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+			GroupByUser:   true,
+			GroupByStatus: true,
+		})
+		require.NoError(t, err)
+		
+		assert.Equal(t, "amir", result.Summary.MostActiveUser)
+		assert.Equal(t, "High", result.Summary.TopPriority)
+		assert.Equal(t, 1, result.UserMetrics["amir"].ProductivityRank)
+		assert.Equal(t, 2, result.UserMetrics["mia"].ProductivityRank)
+		assert.Equal(t, 3, result.UserMetrics["zoe"].ProductivityRank)
+		assert.Equal(t, []string{"amir", "mia", "zoe"}, result.StatusBreakdown["Done"].Users)
+	}
+}
+
+func TestDataProcessor_GenerateWeeklyRanges(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	startDate := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	endDate := startDate.Add(15 * 24 * time.Hour) // 15 days
+	
+	activities := []models.Activity{
+		{
+			Created: startDate,
+			Updated: endDate,
+		},
+	}
+	
+	ranges := processor.generateWeeklyRanges(activities)
+	
+	assert.Len(t, ranges, 3) // Should create 3 weekly ranges for 15 days
+	assert.Equal(t, "Week 1", ranges[0].Label)
+	assert.Equal(t, "Week 2", ranges[1].Label)
+	assert.Equal(t, "Week 3", ranges[2].Label)
+	
+	// Verify ranges are continuous
+	assert.Equal(t, startDate, ranges[0].Start)
+	assert.Equal(t, ranges[0].End, ranges[1].Start)
+	assert.Equal(t, ranges[1].End, ranges[2].Start)
+}
+
+func TestDataProcessor_CalculateTrends(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	analysisOf := func(values []float64) *TrendAnalysis {
+		analysis := &TrendAnalysis{OverallTrend: "stable", VelocityTrend: "stable", ProductivityTrend: "stable"}
+		for _, value := range values {
+			analysis.TimeRanges = append(analysis.TimeRanges, TimeRangeMetrics{AverageVelocity: value, ProductivityScore: value})
+		}
+		return analysis
+	}
+	
+	tests := []struct {
+		name   string
+		values []float64
+		trend  string
+		slope  float64
+		minR2  float64
+		maxR2  float64
+	}{
+		{"increasing", []float64{1, 2, 3, 4, 5, 6}, "increasing", 1, 1, 1},
+		{"decreasing", []float64{12, 10, 8, 6, 4, 2}, "decreasing", -2, 1, 1},
+		{"flat", []float64{4, 4, 4, 4, 4, 4}, "stable", 0, 0, 0},
+		// Swings of half the mean that do not go anywhere; comparing halves would read as a
+		// 28% rise
+		{"noisy", []float64{10, 5, 15, 6, 14, 9, 11, 12}, "stable", 0.405, 0, 0.1},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := analysisOf(tt.values)
+			processor.calculateTrends(analysis, 0)
+			
+			assert.Equal(t, tt.trend, analysis.VelocityTrend)
+			assert.Equal(t, tt.trend, analysis.ProductivityTrend)
+			assert.Equal(t, tt.trend, analysis.OverallTrend)
+			assert.InDelta(t, tt.slope, analysis.VelocitySlope, 0.001)
+			assert.InDelta(t, tt.slope, analysis.ProductivitySlope, 0.001)
+			assert.GreaterOrEqual(t, analysis.VelocityRSquared, tt.minR2-0.0001)
+			assert.LessOrEqual(t, analysis.VelocityRSquared, tt.maxR2+0.0001)
+		})
+	}
+	
+	t.Run("threshold", func(t *testing.T) {
+		// A 2% rise per range is stable by default but increasing with a 1% threshold
+		values := []float64{100, 102, 104, 106}
+		
+		analysis := analysisOf(values)
+		processor.calculateTrends(analysis, 0)
+		assert.Equal(t, "stable", analysis.VelocityTrend)
+		
+		analysis = analysisOf(values)
+		processor.calculateTrends(analysis, 0.01)
+		assert.Equal(t, "increasing", analysis.VelocityTrend)
+	})
+	
+	t.Run("single range", func(t *testing.T) {
+		analysis := analysisOf([]float64{3})
+		processor.calculateTrends(analysis, 0)
+		assert.Equal(t, "stable", analysis.VelocityTrend)
+		assert.Zero(t, analysis.VelocitySlope)
+	})
+}
+
+func TestDataProcessor_GenerateCalendarRanges(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	// Wednesday 14 February to Thursday 2 May 2024
+	activities := []models.Activity{
+		{Created: time.Date(2024, 2, 14, 15, 30, 0, 0, time.UTC), Updated: time.Date(2024, 2, 20, 9, 0, 0, 0, time.UTC)},
+		{Created: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC), Updated: time.Date(2024, 5, 2, 17, 0, 0, 0, time.UTC)},
+	}
+	day := func(month time.Month, day int) time.Time {
+		return time.Date(2024, month, day, 0, 0, 0, 0, time.UTC)
+	}
+	
+	t.Run("daily", func(t *testing.T) {
+		ranges := processor.generateCalendarRanges(activities, GranularityDaily)
+		
+		require.Len(t, ranges, 79)
+		assert.Equal(t, TimeRange{Start: day(2, 14), End: day(2, 15), Label: "Feb 14, 2024"}, ranges[0])
+		assert.Equal(t, TimeRange{Start: day(2, 29), End: day(3, 1), Label: "Feb 29, 2024"}, ranges[15])
+		assert.Equal(t, TimeRange{Start: day(5, 2), End: day(5, 3), Label: "May 2, 2024"}, ranges[78])
+	})
+	
+	t.Run("weekly", func(t *testing.T) {
+		ranges := processor.generateCalendarRanges(activities, GranularityWeekly)
+		
+		require.Len(t, ranges, 12)
+		assert.Equal(t, TimeRange{Start: day(2, 12), End: day(2, 19), Label: "Week of Feb 12, 2024"}, ranges[0])
+		assert.Equal(t, TimeRange{Start: day(2, 26), End: day(3, 4), Label: "Week of Feb 26, 2024"}, ranges[2])
+		assert.Equal(t, TimeRange{Start: day(4, 29), End: day(5, 6), Label: "Week of Apr 29, 2024"}, ranges[11])
+		for _, r := range ranges {
+			assert.Equal(t, time.Monday, r.Start.Weekday())
+		}
+	})
+	
+	t.Run("monthly", func(t *testing.T) {
+		ranges := processor.generateCalendarRanges(activities, GranularityMonthly)
+		
+		assert.Equal(t, []TimeRange{
+			{Start: day(2, 1), End: day(3, 1), Label: "February 2024"},
+			{Start: day(3, 1), End: day(4, 1), Label: "March 2024"},
+			{Start: day(4, 1), End: day(5, 1), Label: "April 2024"},
+			{Start: day(5, 1), End: day(6, 1), Label: "May 2024"},
+		}, ranges)
+	})
+	
+	t.Run("quarterly", func(t *testing.T) {
+		ranges := processor.generateCalendarRanges(activities, GranularityQuarterly)
+		
+		assert.Equal(t, []TimeRange{
+			{Start: day(1, 1), End: day(4, 1), Label: "Q1 2024"},
+			{Start: day(4, 1), End: day(7, 1), Label: "Q2 2024"},
+		}, ranges)
+	})
+	
+	t.Run("unknown granularity", func(t *testing.T) {
+		ranges := processor.generateCalendarRanges(activities, TrendGranularity("hourly"))
+		
+		assert.Equal(t, processor.generateWeeklyRanges(activities), ranges)
+	})
+	
+	t.Run("no activities", func(t *testing.T) {
+		assert.Empty(t, processor.generateCalendarRanges(nil, GranularityDaily))
+	})
+}
+
+func TestDataProcessor_GenerateCalendarRanges_DaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	processor.location = newYork
+	
+	// Clocks go forward on Sunday 10 March 2024; the activity ends late in the evening, after
+	// midnight UTC
+	activities := []models.Activity{
+		{
+			Created: time.Date(2024, 3, 9, 23, 0, 0, 0, newYork),
+			Updated: time.Date(2024, 3, 11, 22, 30, 0, 0, newYork),
+		},
+	}
+	
+	daily := processor.generateCalendarRanges(activities, GranularityDaily)
+	require.Len(t, daily, 3)
+	for i, r := range daily {
+		expected := time.Date(2024, 3, 9+i, 0, 0, 0, 0, newYork)
+		assert.True(t, expected.Equal(r.Start), "range %d starts at %s", i, r.Start)
+		assert.Equal(t, 0, r.End.Hour(), "range %d ends at midnight", i)
+		assert.Equal(t, expected.Format("Jan 2, 2006"), r.Label)
+	}
+	// The day of the change is an hour short
+	assert.Equal(t, 23*time.Hour, daily[1].End.Sub(daily[1].Start))
+	
+	weekly := processor.generateCalendarRanges(activities, GranularityWeekly)
+	require.Len(t, weekly, 2)
+	assert.True(t, time.Date(2024, 3, 4, 0, 0, 0, 0, newYork).Equal(weekly[0].Start))
+	assert.True(t, time.Date(2024, 3, 11, 0, 0, 0, 0, newYork).Equal(weekly[0].End))
+	assert.Equal(t, "Week of Mar 11, 2024", weekly[1].Label)
+}
+
+func TestDataProcessor_TrendGranularity(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "Done", Created: time.Date(2024, 1, 10, 9, 0, 0, 0, time.UTC), Updated: time.Date(2024, 1, 12, 9, 0, 0, 0, time.UTC)},
+		{Key: "PROJ-2", Status: "Done", Created: time.Date(2024, 2, 5, 9, 0, 0, 0, time.UTC), Updated: time.Date(2024, 2, 7, 9, 0, 0, 0, time.UTC)},
+	}
+	
+	result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		AnalyzeTrends:    true,
+		TrendGranularity: GranularityMonthly,
+	})
+	require.NoError(t, err)
+	
+	require.NotNil(t, result.TrendAnalysis)
+	require.Len(t, result.TrendAnalysis.TimeRanges, 2)
+	assert.Equal(t, "January 2024", result.TrendAnalysis.TimeRanges[0].Range.Label)
+	assert.Equal(t, 1, result.TrendAnalysis.TimeRanges[0].ActivityCount)
+	assert.Equal(t, "February 2024", result.TrendAnalysis.TimeRanges[1].Range.Label)
+	assert.Equal(t, 1, result.TrendAnalysis.TimeRanges[1].ActivityCount)
+}
+
+func TestDataProcessor_FilterActivitiesByTimeRange(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	baseTime := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	
+	activities := []models.Activity{
+		{
+			Created: baseTime.Add(1 * 24 * time.Hour),
+			Updated: baseTime.Add(2 * 24 * time.Hour),
+		},
+		{
+			Created: baseTime.Add(3 * 24 * time.Hour),
+			Updated: baseTime.Add(4 * 24 * time.Hour),
+		},
+		{
+			Created: baseTime.Add(8 * 24 * time.Hour),
+			Updated: baseTime.Add(9 * 24 * time.Hour),
+		},
+	}
+	
+	timeRange := TimeRange{
+		Start: baseTime,
+		End:   baseTime.Add(5 * 24 * time.Hour),
+		Label: "Test Range",
+	}
+	
+	filtered := processor.filterActivitiesByTimeRange(activities, timeRange)
 	
 	// Should include first two activities, exclude the third
 	assert.Len(t, filtered, 2)
 }
 
+func TestDataProcessor_FilterActivitiesByTimeRange_Overlap(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	start := time.Date(2023, 1, 9, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 7)
+	timeRange := TimeRange{Start: start, End: end, Label: "Week 2"}
+	
+	activities := []models.Activity{
+		{Key: "BEFORE-AND-AFTER", Created: start.AddDate(0, 0, -20), Updated: end.AddDate(0, 0, 5)},
+		{Key: "CREATED-BEFORE", Created: start.AddDate(0, 0, -3), Updated: start.AddDate(0, 0, 2)},
+		{Key: "UPDATED-AFTER", Created: start.AddDate(0, 0, 4), Updated: end.AddDate(0, 0, 1)},
+		{Key: "INSIDE", Created: start.Add(time.Hour), Updated: start.Add(2 * time.Hour)},
+		{Key: "ENDS-AT-START", Created: start.AddDate(0, 0, -1), Updated: start},
+		{Key: "STARTS-AT-END", Created: end, Updated: end.AddDate(0, 0, 1)},
+		{Key: "ENDED-BEFORE", Created: start.AddDate(0, 0, -10), Updated: start.Add(-time.Second)},
+		{Key: "STARTED-AFTER", Created: end.Add(time.Second), Updated: end.AddDate(0, 0, 2)},
+	}
+	
+	filtered := processor.filterActivitiesByTimeRange(activities, timeRange)
+	
+	keys := make([]string, 0, len(filtered))
+	for _, activity := range filtered {
+		keys = append(keys, activity.Key)
+	}
+	assert.Equal(t, []string{"BEFORE-AND-AFTER", "CREATED-BEFORE", "UPDATED-AFTER", "INSIDE", "ENDS-AT-START", "STARTS-AT-END"}, keys)
+}
+
+func TestDataProcessor_ProcessActivities_LongLivedIssueInTrends(t *testing.T) {
+	logger := utils.NewMockLogger()
+	processor := NewDataProcessor(logger)
+	
+	// One issue open across all of February, the other inside its second week
+	activities := []models.Activity{
+		{Key: "PROJ-1", Status: "In Progress", Created: time.Date(2024, 1, 29, 9, 0, 0, 0, time.UTC), Updated: time.Date(2024, 2, 28, 9, 0, 0, 0, time.UTC)},
+		{Key: "PROJ-2", Status: "Done", Created: time.Date(2024, 2, 6, 9, 0, 0, 0, time.UTC), Updated: time.Date(2024, 2, 8, 9, 0, 0, 0, time.UTC)},
+	}
+	
+	result, err := processor.ProcessActivities(context.Background(), activities, ProcessingOptions{
+		AnalyzeTrends:    true,
+		TrendGranularity: GranularityWeekly,
+	})
+	require.NoError(t, err)
+	
+	counts := make([]int, 0, len(result.TrendAnalysis.TimeRanges))
+	for _, timeRange := range result.TrendAnalysis.TimeRanges {
+		counts = append(counts, timeRange.ActivityCount)
+	}
+	assert.Equal(t, []int{1, 2, 1, 1, 1}, counts)
+}
+
 func TestDataProcessor_CalculateTimeRangeMetrics(t *testing.T) {
 	logger := utils.NewMockLogger()
 	processor := NewDataProcessor(logger)
@@ -485,7 +2018,7 @@ func TestDataProcessor_CalculateTimeRangeMetrics(t *testing.T) {
 		},
 	}
 	
-	metrics := processor.calculateTimeRangeMetrics(timeRange, activities)
+	metrics := processor.calculateTimeRangeMetrics(timeRange, activities, processor.recencyWeight(activities, 0))
 	
 	assert.Equal(t, timeRange, metrics.Range)
 	assert.Equal(t, 2, metrics.ActivityCount)
@@ -0,0 +1,110 @@
+package processor
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// Record types in the first column of a CSV export
+const (
+	ExportRecordUser     = "user"
+	ExportRecordPriority = "priority"
+)
+
+// ExportCSVHeader is the header row of a CSV export
+var ExportCSVHeader = []string{
+	"record_type",
+	"key",
+	"name",
+	"total_activities",
+	"completed_activities",
+	"completion_rate",
+	"total_time_spent",
+	"average_time",
+}
+
+// ExportCSV writes one row per user and one per priority of result, after a header row. Users
+// come first, ordered by user ID, then priorities ordered by name, so repeated exports of the
+// same data are identical. Times are in seconds; a user's average time is per activity and a
+// priority's is per completed item.
+func ExportCSV(result *ProcessingResult, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("processing result is required")
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(ExportCSVHeader); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	userIDs := make([]string, 0, len(result.UserMetrics))
+	for userID := range result.UserMetrics {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	for _, userID := range userIDs {
+		user := result.UserMetrics[userID]
+		row := exportRow(ExportRecordUser, userID, user.DisplayName, user.TotalActivities, user.CompletedActivities,
+			user.CompletionRate, user.TotalTimeSpent, user.AverageTimePerTask)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	priorities := make([]string, 0, len(result.PriorityBreakdown))
+	for priority := range result.PriorityBreakdown {
+		priorities = append(priorities, priority)
+	}
+	sort.Strings(priorities)
+
+	for _, priority := range priorities {
+		metrics := result.PriorityBreakdown[priority]
+		row := exportRow(ExportRecordPriority, priority, metrics.Priority, metrics.Count, metrics.CompletedCount,
+			metrics.CompletionRate, metrics.TotalTimeSpent, metrics.AverageTimeToComplete)
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return nil
+}
+
+// exportRow formats one CSV row in ExportCSVHeader order
+func exportRow(recordType, key, name string, total, completed int, completionRate float64, timeSpent, averageTime int64) []string {
+	return []string{
+		recordType,
+		key,
+		name,
+		strconv.Itoa(total),
+		strconv.Itoa(completed),
+		strconv.FormatFloat(completionRate, 'f', 2, 64),
+		strconv.FormatInt(timeSpent, 10),
+		strconv.FormatInt(averageTime, 10),
+	}
+}
+
+// ExportJSON writes the full result as indented JSON. Map keys are sorted by the encoder, so
+// repeated exports of the same data are identical.
+func ExportJSON(result *ProcessingResult, w io.Writer) error {
+	if result == nil {
+		return fmt.Errorf("processing result is required")
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return fmt.Errorf("failed to write JSON: %w", err)
+	}
+
+	return nil
+}
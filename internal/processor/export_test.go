@@ -0,0 +1,120 @@
+package processor
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createExportTestResult() *ProcessingResult {
+	return &ProcessingResult{
+		UserMetrics: map[string]UserMetrics{
+			"user2": {
+				UserID:              "user2",
+				DisplayName:         "Bob, Jr.",
+				TotalActivities:     2,
+				CompletedActivities: 1,
+				CompletionRate:      50,
+				TotalTimeSpent:      3600,
+				AverageTimePerTask:  1800,
+			},
+			"user1": {
+				UserID:              "user1",
+				DisplayName:         "Alice",
+				TotalActivities:     3,
+				CompletedActivities: 2,
+				CompletionRate:      66.6666,
+				TotalTimeSpent:      9000,
+				AverageTimePerTask:  3000,
+			},
+		},
+		PriorityBreakdown: map[string]PriorityMetrics{
+			"Medium": {
+				Priority:              "Medium",
+				Count:                 1,
+				CompletedCount:        0,
+				TotalTimeSpent:        1800,
+				AverageTimeToComplete: 0,
+			},
+			"High": {
+				Priority:              "High",
+				Count:                 4,
+				CompletedCount:        3,
+				CompletionRate:        75,
+				TotalTimeSpent:        10800,
+				AverageTimeToComplete: 2400,
+			},
+		},
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportCSV(createExportTestResult(), &buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		ExportCSVHeader,
+		{"user", "user1", "Alice", "3", "2", "66.67", "9000", "3000"},
+		{"user", "user2", "Bob, Jr.", "2", "1", "50.00", "3600", "1800"},
+		{"priority", "High", "High", "4", "3", "75.00", "10800", "2400"},
+		{"priority", "Medium", "Medium", "1", "0", "0.00", "1800", "0"},
+	}, rows)
+}
+
+func TestExportCSV_StableOutput(t *testing.T) {
+	result := createExportTestResult()
+
+	var first, second bytes.Buffer
+	require.NoError(t, ExportCSV(result, &first))
+	require.NoError(t, ExportCSV(result, &second))
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestExportCSV_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, ExportCSV(&ProcessingResult{}, &buf))
+
+	rows, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{ExportCSVHeader}, rows)
+}
+
+func TestExportJSON(t *testing.T) {
+	result := createExportTestResult()
+
+	var buf bytes.Buffer
+	require.NoError(t, ExportJSON(result, &buf))
+
+	var decoded ProcessingResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, result.UserMetrics, decoded.UserMetrics)
+	assert.Equal(t, result.PriorityBreakdown, decoded.PriorityBreakdown)
+}
+
+// failingWriter rejects every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestExport_Errors(t *testing.T) {
+	assert.Error(t, ExportCSV(nil, &bytes.Buffer{}))
+	assert.Error(t, ExportJSON(nil, &bytes.Buffer{}))
+
+	err := ExportCSV(createExportTestResult(), failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+
+	err = ExportJSON(createExportTestResult(), failingWriter{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "disk full")
+}
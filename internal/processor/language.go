@@ -0,0 +1,298 @@
+package processor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultLanguage is the language used when a summary request does not set one
+const DefaultLanguage = "en"
+
+// languageNames maps supported base language subtags to their English names
+var languageNames = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"fr": "French",
+	"de": "German",
+}
+
+// BaseLanguage returns the lower-case primary subtag of a language tag, so "es-MX" and
+// "ES_es" both yield "es". An empty tag yields DefaultLanguage.
+func BaseLanguage(tag string) string {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return DefaultLanguage
+	}
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return strings.ToLower(tag)
+}
+
+// LanguageName returns the English name of a language tag for use in prompts. Tags without a
+// known name are returned unchanged.
+func LanguageName(tag string) string {
+	if name, ok := languageNames[BaseLanguage(tag)]; ok {
+		return name
+	}
+	return strings.TrimSpace(tag)
+}
+
+// Keys of the localized summary text in messageCatalogs
+const (
+	msgPeriodWeekly             = periodKeyPrefix + "weekly" // Period names, looked up by periodName
+	msgPeriodMonthly            = periodKeyPrefix + "monthly"
+	msgPeriodQuarterly          = periodKeyPrefix + "quarterly"
+	msgFallbackOpening          = "fallback.opening"     // Period name, period label and activity count
+	msgFallbackInvested         = "fallback.invested"    // Time spent and team size
+	msgFallbackInFlight         = "fallback.in_flight"   // Comma separated status counts
+	msgFallbackBlockedOne       = "fallback.blocked_one" // Blocked count
+	msgFallbackBlockedMany      = "fallback.blocked_many"
+	msgFallbackNoBlocked        = "fallback.no_blocked"
+	msgFallbackHighPriorityOne  = "fallback.high_priority_one" // Open high-priority count
+	msgFallbackHighPriorityMany = "fallback.high_priority_many"
+	msgHighlightCompletionRate  = "highlight.completion_rate" // Completion rate
+	msgHighlightProductivity    = "highlight.productivity"    // Productivity score
+	msgHighlightHighPriority    = "highlight.high_priority"   // High-priority completion rate
+	msgHighlightTopPerformers   = "highlight.top_performers"  // Names of the top performers
+	msgHighlightOverallTrend    = "highlight.overall_trend"
+	msgHighlightVelocityTrend   = "highlight.velocity_trend"
+	msgNamesJoin                = "names.join"               // Separator between the last two names
+	msgConcernCompletionRate    = "concern.completion_rate"  // Completion rate
+	msgConcernProductivity      = "concern.productivity"     // Productivity score
+	msgConcernHighPriority      = "concern.high_priority"    // High-priority completion rate
+	msgConcernUnderPerformers   = "concern.under_performers" // Number of team members
+	msgConcernOverallTrend      = "concern.overall_trend"
+	msgConcernVelocityTrend     = "concern.velocity_trend"
+	msgConcernWorkloadImbalance = "concern.workload_imbalance" // Gini coefficient of time spent
+	msgConcernStaleIssues       = "concern.stale_issues"       // Stale issue count and threshold in days
+	msgRecommendStandups        = "recommend.standups"
+	msgRecommendWIPLimits       = "recommend.wip_limits"
+	msgRecommendProcessReview   = "recommend.process_review"
+	msgRecommendTraining        = "recommend.training"
+	msgRecommendPrioritize      = "recommend.prioritize"
+	msgRecommendPrioritization  = "recommend.prioritization"
+	msgRecommendRedistribute    = "recommend.redistribute"
+	msgRecommendCrossTrain      = "recommend.cross_train"
+	msgRecommendRootCauses      = "recommend.root_causes"
+	msgRecommendRetrospectives  = "recommend.retrospectives"
+	msgRecommendMonitor         = "recommend.monitor"
+	msgRecommendRecognize       = "recommend.recognize"
+	msgComparisonRose           = "comparison.rose"
+	msgComparisonFell           = "comparison.fell"
+	msgComparisonCompletionRate = "comparison.completion_rate" // Verb, current and previous rate, point change
+	msgComparisonProductivity   = "comparison.productivity"    // Verb, current and previous score
+	msgComparisonVelocityNew    = "comparison.velocity_new"    // Verb and current velocity
+	msgComparisonVelocity       = "comparison.velocity"        // Verb, percent change and current velocity
+)
+
+// periodKeyPrefix prefixes the request period in the keys of localized period names
+const periodKeyPrefix = "period."
+
+// messageCatalogs holds the summary text by base language
+var messageCatalogs = map[string]map[string]string{
+	"en": {
+		msgFallbackOpening:          "During the %s period (%s), no items reached a completed state yet, but the team kept %d activities moving",
+		msgFallbackInvested:         " with %s invested across %d team members",
+		msgFallbackInFlight:         "Work in flight currently stands at %s. ",
+		msgFallbackBlockedOne:       "%d item is blocked or waiting, and clearing it is the fastest way to turn this effort into completed work. ",
+		msgFallbackBlockedMany:      "%d items are blocked or waiting, and clearing them is the fastest way to turn this effort into completed work. ",
+		msgFallbackNoBlocked:        "No items are flagged as blocked, so completions should follow as in-progress work moves through review. ",
+		msgFallbackHighPriorityOne:  "%d high-priority item is still open and should stay at the top of the queue.",
+		msgFallbackHighPriorityMany: "%d high-priority items are still open and should stay at the top of the queue.",
+		msgHighlightCompletionRate:  "Excellent completion rate of %s demonstrates strong execution capability",
+		msgHighlightProductivity:    "Strong productivity score of %s indicates effective team performance",
+		msgHighlightHighPriority:    "High-priority items completed at %s rate, showing good prioritization",
+		msgHighlightTopPerformers:   "Outstanding contributions from %s with consistently high performance",
+		msgHighlightOverallTrend:    "Positive trend in overall team performance and productivity",
+		msgHighlightVelocityTrend:   "Improving velocity indicates enhanced team efficiency",
+		msgNamesJoin:                " and ",
+		msgConcernCompletionRate:    "Completion rate of %s is below optimal levels and requires attention",
+		msgConcernProductivity:      "Productivity score of %s indicates potential process inefficiencies",
+		msgConcernHighPriority:      "High-priority items only %s completed, potentially impacting critical objectives",
+		msgConcernUnderPerformers:   "%d team members showing below-average performance metrics",
+		msgConcernOverallTrend:      "Declining trend in overall team performance requires investigation",
+		msgConcernVelocityTrend:     "Decreasing velocity trend may indicate capacity or process issues",
+		msgConcernWorkloadImbalance: "Uneven workload distribution (Gini coefficient %.2f) may lead to burnout and reduced efficiency",
+		msgConcernStaleIssues:       "%d open items have not been updated in over %d days and may be stuck",
+		msgRecommendStandups:        "Implement daily standups and sprint reviews to improve task completion tracking",
+		msgRecommendWIPLimits:       "Consider reducing work-in-progress limits to focus on completing current tasks",
+		msgRecommendProcessReview:   "Conduct process review to identify and eliminate bottlenecks in the workflow",
+		msgRecommendTraining:        "Provide additional training or resources to team members with lower productivity scores",
+		msgRecommendPrioritize:      "Prioritize high-priority items and consider resource reallocation",
+		msgRecommendPrioritization:  "Review and refine prioritization process to ensure critical work gets adequate attention",
+		msgRecommendRedistribute:    "Redistribute workload to balance team capacity and prevent burnout",
+		msgRecommendCrossTrain:      "Cross-train team members to provide better coverage and flexibility",
+		msgRecommendRootCauses:      "Investigate root causes of declining performance trends",
+		msgRecommendRetrospectives:  "Implement regular retrospectives to identify improvement opportunities",
+		msgRecommendMonitor:         "Continue monitoring key metrics and adjust strategies based on performance data",
+		msgRecommendRecognize:       "Recognize and celebrate high performers to maintain team motivation",
+		msgComparisonRose:           "rose",
+		msgComparisonFell:           "fell",
+		msgComparisonCompletionRate: "Completion rate %s to %s from %s (%+.1f points) compared with the previous period",
+		msgComparisonProductivity:   "Productivity score %s to %.1f from %.1f compared with the previous period",
+		msgComparisonVelocityNew:    "Velocity %s to %.2f items/day from none in the previous period",
+		msgComparisonVelocity:       "Velocity %s %.1f%% to %.2f items/day compared with the previous period",
+	},
+	"es": {
+		msgPeriodWeekly:             "semanal",
+		msgPeriodMonthly:            "mensual",
+		msgPeriodQuarterly:          "trimestral",
+		msgFallbackOpening:          "Durante el periodo %s (%s), ningún elemento se completó todavía, pero el equipo mantuvo %d actividades en marcha",
+		msgFallbackInvested:         " con %s invertidos entre %d miembros del equipo",
+		msgFallbackInFlight:         "El trabajo en curso se sitúa actualmente en %s. ",
+		msgFallbackBlockedOne:       "%d elemento está bloqueado o en espera, y desbloquearlo es la forma más rápida de convertir este esfuerzo en trabajo completado. ",
+		msgFallbackBlockedMany:      "%d elementos están bloqueados o en espera, y desbloquearlos es la forma más rápida de convertir este esfuerzo en trabajo completado. ",
+		msgFallbackNoBlocked:        "Ningún elemento está marcado como bloqueado, por lo que las finalizaciones deberían llegar a medida que el trabajo en curso avance por la revisión. ",
+		msgFallbackHighPriorityOne:  "%d elemento de alta prioridad sigue abierto y debe mantenerse al principio de la cola.",
+		msgFallbackHighPriorityMany: "%d elementos de alta prioridad siguen abiertos y deben mantenerse al principio de la cola.",
+		msgHighlightCompletionRate:  "Una excelente tasa de finalización del %s demuestra una gran capacidad de ejecución",
+		msgHighlightProductivity:    "Una sólida puntuación de productividad del %s indica un rendimiento eficaz del equipo",
+		msgHighlightHighPriority:    "Los elementos de alta prioridad se completaron en un %s, lo que refleja una buena priorización",
+		msgHighlightTopPerformers:   "Contribuciones sobresalientes de %s con un rendimiento alto y constante",
+		msgHighlightOverallTrend:    "Tendencia positiva en el rendimiento y la productividad generales del equipo",
+		msgHighlightVelocityTrend:   "La mejora de la velocidad indica una mayor eficiencia del equipo",
+		msgNamesJoin:                " y ",
+		msgConcernCompletionRate:    "La tasa de finalización del %s está por debajo del nivel óptimo y requiere atención",
+		msgConcernProductivity:      "La puntuación de productividad del %s indica posibles ineficiencias en el proceso",
+		msgConcernHighPriority:      "Solo se completó el %s de los elementos de alta prioridad, lo que puede afectar a objetivos críticos",
+		msgConcernUnderPerformers:   "%d miembros del equipo muestran métricas de rendimiento inferiores a la media",
+		msgConcernOverallTrend:      "La tendencia a la baja en el rendimiento general del equipo requiere investigación",
+		msgConcernVelocityTrend:     "La tendencia decreciente de la velocidad puede indicar problemas de capacidad o de proceso",
+		msgConcernWorkloadImbalance: "Una distribución desigual de la carga de trabajo (coeficiente de Gini %.2f) puede provocar agotamiento y menor eficiencia",
+		msgConcernStaleIssues:       "%d elementos abiertos llevan más de %d días sin actualizarse y podrían estar estancados",
+		msgRecommendStandups:        "Implantar reuniones diarias y revisiones de sprint para mejorar el seguimiento de las tareas completadas",
+		msgRecommendWIPLimits:       "Considerar reducir los límites de trabajo en curso para centrarse en completar las tareas actuales",
+		msgRecommendProcessReview:   "Revisar el proceso para identificar y eliminar cuellos de botella en el flujo de trabajo",
+		msgRecommendTraining:        "Ofrecer formación o recursos adicionales a los miembros del equipo con menor productividad",
+		msgRecommendPrioritize:      "Priorizar los elementos de alta prioridad y considerar la reasignación de recursos",
+		msgRecommendPrioritization:  "Revisar y ajustar el proceso de priorización para que el trabajo crítico reciba la atención adecuada",
+		msgRecommendRedistribute:    "Redistribuir la carga de trabajo para equilibrar la capacidad del equipo y evitar el agotamiento",
+		msgRecommendCrossTrain:      "Formar a los miembros del equipo en varias áreas para ganar cobertura y flexibilidad",
+		msgRecommendRootCauses:      "Investigar las causas de las tendencias de rendimiento a la baja",
+		msgRecommendRetrospectives:  "Celebrar retrospectivas periódicas para identificar oportunidades de mejora",
+		msgRecommendMonitor:         "Seguir supervisando las métricas clave y ajustar las estrategias según los datos de rendimiento",
+		msgRecommendRecognize:       "Reconocer y celebrar a quienes obtienen mejores resultados para mantener la motivación del equipo",
+		msgComparisonRose:           "subió",
+		msgComparisonFell:           "bajó",
+		msgComparisonCompletionRate: "La tasa de finalización %s al %s desde el %s (%+.1f puntos) respecto al periodo anterior",
+		msgComparisonProductivity:   "La puntuación de productividad %s a %.1f desde %.1f respecto al periodo anterior",
+		msgComparisonVelocityNew:    "La velocidad %s a %.2f elementos/día, sin actividad en el periodo anterior",
+		msgComparisonVelocity:       "La velocidad %s un %.1f%% hasta %.2f elementos/día respecto al periodo anterior",
+	},
+	"fr": {
+		msgPeriodWeekly:             "hebdomadaire",
+		msgPeriodMonthly:            "mensuelle",
+		msgPeriodQuarterly:          "trimestrielle",
+		msgFallbackOpening:          "Au cours de la période %s (%s), aucun élément n'a encore été terminé, mais l'équipe a fait avancer %d activités",
+		msgFallbackInvested:         " avec %s investies par %d membres de l'équipe",
+		msgFallbackInFlight:         "Le travail en cours s'établit actuellement à %s. ",
+		msgFallbackBlockedOne:       "%d élément est bloqué ou en attente, et le débloquer est le moyen le plus rapide de transformer cet effort en travail terminé. ",
+		msgFallbackBlockedMany:      "%d éléments sont bloqués ou en attente, et les débloquer est le moyen le plus rapide de transformer cet effort en travail terminé. ",
+		msgFallbackNoBlocked:        "Aucun élément n'est signalé comme bloqué, les achèvements devraient donc suivre à mesure que le travail en cours passe en revue. ",
+		msgFallbackHighPriorityOne:  "%d élément de haute priorité est toujours ouvert et doit rester en tête de la file.",
+		msgFallbackHighPriorityMany: "%d éléments de haute priorité sont toujours ouverts et doivent rester en tête de la file.",
+		msgHighlightCompletionRate:  "Un excellent taux d'achèvement de %s démontre une forte capacité d'exécution",
+		msgHighlightProductivity:    "Un solide score de productivité de %s indique une équipe performante",
+		msgHighlightHighPriority:    "Les éléments de haute priorité ont été terminés à %s, signe d'une bonne priorisation",
+		msgHighlightTopPerformers:   "Contributions remarquables de %s avec des performances élevées et régulières",
+		msgHighlightOverallTrend:    "Tendance positive de la performance et de la productivité globales de l'équipe",
+		msgHighlightVelocityTrend:   "L'amélioration de la vélocité indique une efficacité accrue de l'équipe",
+		msgNamesJoin:                " et ",
+		msgConcernCompletionRate:    "Le taux d'achèvement de %s est inférieur au niveau optimal et demande de l'attention",
+		msgConcernProductivity:      "Le score de productivité de %s révèle de possibles inefficacités dans le processus",
+		msgConcernHighPriority:      "Seulement %s des éléments de haute priorité ont été terminés, ce qui peut affecter des objectifs critiques",
+		msgConcernUnderPerformers:   "%d membres de l'équipe présentent des indicateurs de performance inférieurs à la moyenne",
+		msgConcernOverallTrend:      "La baisse de la performance globale de l'équipe nécessite une analyse",
+		msgConcernVelocityTrend:     "La baisse de la vélocité peut indiquer des problèmes de capacité ou de processus",
+		msgConcernWorkloadImbalance: "Une répartition inégale de la charge de travail (coefficient de Gini %.2f) peut entraîner de l'épuisement et une efficacité réduite",
+		msgConcernStaleIssues:       "%d éléments ouverts n'ont pas été mis à jour depuis plus de %d jours et sont peut-être bloqués",
+		msgRecommendStandups:        "Mettre en place des points quotidiens et des revues de sprint pour mieux suivre l'achèvement des tâches",
+		msgRecommendWIPLimits:       "Envisager de réduire les limites de travail en cours pour se concentrer sur les tâches actuelles",
+		msgRecommendProcessReview:   "Revoir le processus pour identifier et éliminer les goulets d'étranglement",
+		msgRecommendTraining:        "Proposer des formations ou des ressources supplémentaires aux membres de l'équipe les moins productifs",
+		msgRecommendPrioritize:      "Traiter en priorité les éléments de haute priorité et envisager de réaffecter des ressources",
+		msgRecommendPrioritization:  "Revoir le processus de priorisation afin que le travail critique reçoive l'attention nécessaire",
+		msgRecommendRedistribute:    "Redistribuer la charge de travail pour équilibrer la capacité de l'équipe et éviter l'épuisement",
+		msgRecommendCrossTrain:      "Former les membres de l'équipe à plusieurs domaines pour gagner en couverture et en flexibilité",
+		msgRecommendRootCauses:      "Rechercher les causes de la baisse des performances",
+		msgRecommendRetrospectives:  "Organiser des rétrospectives régulières pour identifier des pistes d'amélioration",
+		msgRecommendMonitor:         "Continuer à suivre les indicateurs clés et ajuster les stratégies selon les données de performance",
+		msgRecommendRecognize:       "Reconnaître et valoriser les meilleurs contributeurs pour maintenir la motivation de l'équipe",
+		msgComparisonRose:           "a augmenté",
+		msgComparisonFell:           "a baissé",
+		msgComparisonCompletionRate: "Le taux d'achèvement %s à %s contre %s (%+.1f points) par rapport à la période précédente",
+		msgComparisonProductivity:   "Le score de productivité %s à %.1f contre %.1f par rapport à la période précédente",
+		msgComparisonVelocityNew:    "La vélocité %s à %.2f éléments/jour, contre aucun lors de la période précédente",
+		msgComparisonVelocity:       "La vélocité %s de %.1f%% à %.2f éléments/jour par rapport à la période précédente",
+	},
+	"de": {
+		msgPeriodWeekly:             "wöchentlichen",
+		msgPeriodMonthly:            "monatlichen",
+		msgPeriodQuarterly:          "vierteljährlichen",
+		msgFallbackOpening:          "Im %s Berichtszeitraum (%s) wurde noch kein Element abgeschlossen, aber das Team hielt %d Aktivitäten in Bewegung",
+		msgFallbackInvested:         " bei einem Aufwand von %s, verteilt auf %d Teammitglieder",
+		msgFallbackInFlight:         "Aktuell in Arbeit: %s. ",
+		msgFallbackBlockedOne:       "%d Element ist blockiert oder wartet; es freizugeben ist der schnellste Weg, diesen Aufwand in abgeschlossene Arbeit umzuwandeln. ",
+		msgFallbackBlockedMany:      "%d Elemente sind blockiert oder warten; sie freizugeben ist der schnellste Weg, diesen Aufwand in abgeschlossene Arbeit umzuwandeln. ",
+		msgFallbackNoBlocked:        "Keine Elemente sind als blockiert markiert, daher sollten Abschlüsse folgen, sobald die laufende Arbeit das Review durchläuft. ",
+		msgFallbackHighPriorityOne:  "%d Element mit hoher Priorität ist noch offen und sollte ganz oben in der Warteschlange bleiben.",
+		msgFallbackHighPriorityMany: "%d Elemente mit hoher Priorität sind noch offen und sollten ganz oben in der Warteschlange bleiben.",
+		msgHighlightCompletionRate:  "Eine hervorragende Abschlussquote von %s zeigt eine starke Umsetzungsfähigkeit",
+		msgHighlightProductivity:    "Ein starker Produktivitätswert von %s spricht für eine leistungsfähige Zusammenarbeit im Team",
+		msgHighlightHighPriority:    "Elemente mit hoher Priorität wurden zu %s abgeschlossen, was eine gute Priorisierung zeigt",
+		msgHighlightTopPerformers:   "Herausragende Beiträge von %s mit durchgehend hoher Leistung",
+		msgHighlightOverallTrend:    "Positiver Trend bei Gesamtleistung und Produktivität des Teams",
+		msgHighlightVelocityTrend:   "Die steigende Velocity zeigt eine höhere Effizienz des Teams",
+		msgNamesJoin:                " und ",
+		msgConcernCompletionRate:    "Die Abschlussquote von %s liegt unter dem Zielniveau und erfordert Aufmerksamkeit",
+		msgConcernProductivity:      "Der Produktivitätswert von %s deutet auf mögliche Ineffizienzen im Prozess hin",
+		msgConcernHighPriority:      "Nur %s der Elemente mit hoher Priorität wurden abgeschlossen, was kritische Ziele gefährden kann",
+		msgConcernUnderPerformers:   "%d Teammitglieder zeigen unterdurchschnittliche Leistungskennzahlen",
+		msgConcernOverallTrend:      "Der rückläufige Trend der Gesamtleistung des Teams sollte untersucht werden",
+		msgConcernVelocityTrend:     "Die sinkende Velocity kann auf Kapazitäts- oder Prozessprobleme hindeuten",
+		msgConcernWorkloadImbalance: "Eine ungleiche Verteilung der Arbeitslast (Gini-Koeffizient %.2f) kann zu Überlastung und geringerer Effizienz führen",
+		msgConcernStaleIssues:       "%d offene Elemente wurden seit über %d Tagen nicht aktualisiert und stecken möglicherweise fest",
+		msgRecommendStandups:        "Tägliche Standups und Sprint-Reviews einführen, um den Abschluss von Aufgaben besser zu verfolgen",
+		msgRecommendWIPLimits:       "WIP-Limits senken, um sich auf den Abschluss laufender Aufgaben zu konzentrieren",
+		msgRecommendProcessReview:   "Den Prozess überprüfen, um Engpässe im Arbeitsablauf zu finden und zu beseitigen",
+		msgRecommendTraining:        "Teammitgliedern mit niedrigeren Produktivitätswerten zusätzliche Schulungen oder Ressourcen anbieten",
+		msgRecommendPrioritize:      "Elemente mit hoher Priorität vorziehen und eine Umverteilung von Ressourcen erwägen",
+		msgRecommendPrioritization:  "Den Priorisierungsprozess überprüfen, damit kritische Arbeit ausreichend Aufmerksamkeit erhält",
+		msgRecommendRedistribute:    "Die Arbeitslast umverteilen, um die Teamkapazität auszugleichen und Überlastung zu vermeiden",
+		msgRecommendCrossTrain:      "Teammitglieder bereichsübergreifend schulen, um Vertretung und Flexibilität zu verbessern",
+		msgRecommendRootCauses:      "Die Ursachen der rückläufigen Leistung untersuchen",
+		msgRecommendRetrospectives:  "Regelmäßige Retrospektiven durchführen, um Verbesserungsmöglichkeiten zu erkennen",
+		msgRecommendMonitor:         "Die wichtigsten Kennzahlen weiter beobachten und Strategien anhand der Leistungsdaten anpassen",
+		msgRecommendRecognize:       "Besonders starke Beiträge anerkennen und feiern, um die Motivation im Team zu erhalten",
+		msgComparisonRose:           "stieg",
+		msgComparisonFell:           "sank",
+		msgComparisonCompletionRate: "Die Abschlussquote %s im Vergleich zum Vorzeitraum auf %s von %s (%+.1f Punkte)",
+		msgComparisonProductivity:   "Der Produktivitätswert %s im Vergleich zum Vorzeitraum auf %.1f von %.1f",
+		msgComparisonVelocityNew:    "Die Velocity %s auf %.2f Elemente/Tag, nachdem im Vorzeitraum keine erreicht wurde",
+		msgComparisonVelocity:       "Die Velocity %s im Vergleich zum Vorzeitraum um %.1f%% auf %.2f Elemente/Tag",
+	},
+}
+
+// message returns the text for key in language, falling back to English for languages or keys
+// without a translation
+func message(language, key string) string {
+	if text, ok := messageCatalogs[BaseLanguage(language)][key]; ok {
+		return text
+	}
+	return messageCatalogs[DefaultLanguage][key]
+}
+
+// messagef formats the text for key in language with args
+func messagef(language, key string, args ...interface{}) string {
+	return fmt.Sprintf(message(language, key), args...)
+}
+
+// periodName returns the localized name of a reporting period, or the period itself when
+// language has no translation for it
+func periodName(language, period string) string {
+	if name, ok := messageCatalogs[BaseLanguage(language)][periodKeyPrefix+strings.ToLower(period)]; ok {
+		return name
+	}
+	return period
+}
@@ -0,0 +1,45 @@
+package processor
+
+import "fmt"
+
+// MetricRow is one named value of the key metrics table
+type MetricRow struct {
+	Name  string
+	Value string
+}
+
+// MetricRows returns the key metrics as the rows every published format shows, in display order
+func (r *SummaryResponse) MetricRows() []MetricRow {
+	metrics := r.KeyMetrics
+	return []MetricRow{
+		{Name: "Total activities", Value: fmt.Sprintf("%d", metrics.TotalActivities)},
+		{Name: "Completed activities", Value: fmt.Sprintf("%d", metrics.CompletedActivities)},
+		{Name: "Completion rate", Value: fmt.Sprintf("%.1f%%", metrics.CompletionRate)},
+		{Name: "Total time spent", Value: metrics.TotalTimeSpent},
+		{Name: "Average time per task", Value: metrics.AverageTimePerTask},
+		{Name: "Productivity score", Value: fmt.Sprintf("%.1f", metrics.ProductivityScore)},
+		{Name: "Active users", Value: fmt.Sprintf("%d", metrics.ActiveUsers)},
+		{Name: "Top priority", Value: metrics.TopPriority},
+		{Name: "Most active user", Value: metrics.MostActiveUser},
+	}
+}
+
+// PublishedRecommendations returns the recommendations as they are published, naming the
+// suggested owner of each when owners were assigned
+func (r *SummaryResponse) PublishedRecommendations() []string {
+	if len(r.OwnedRecommendations) == 0 {
+		return r.Recommendations
+	}
+
+	recommendations := make([]string, 0, len(r.OwnedRecommendations))
+	for _, recommendation := range r.OwnedRecommendations {
+		recommendations = append(recommendations, fmt.Sprintf("%s (Owner: %s)", recommendation.Text, recommendation.Owner))
+	}
+	return recommendations
+}
+
+// ListSection pairs a title with its items, for templates that pass a titled list to a shared
+// "list" template
+func ListSection(title string, items []string) map[string]interface{} {
+	return map[string]interface{}{"Title": title, "Items": items}
+}
@@ -0,0 +1,55 @@
+package processor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/company/eesa/pkg/models"
+)
+
+// DefaultStaleAfter is how long an open activity can go without an update before it counts as
+// stale when ProcessingOptions.StaleAfter is not set
+const DefaultStaleAfter = 14 * 24 * time.Hour
+
+// isStale reports whether an activity is still open and was last updated more than the stale
+// threshold before the reference time. An activity updated exactly at the threshold is not
+// stale. Outside a run no threshold is set and nothing is stale.
+func (dp *DataProcessor) isStale(activity models.Activity) bool {
+	if dp.staleAfter <= 0 || dp.isCompleted(activity.Status) {
+		return false
+	}
+	return dp.staleAsOf.Sub(activity.Updated) > dp.staleAfter
+}
+
+// staleIssues returns the keys of the stale activities, least recently updated first
+func (dp *DataProcessor) staleIssues(activities []models.Activity) []string {
+	var stale []models.Activity
+	for _, activity := range activities {
+		if dp.isStale(activity) {
+			stale = append(stale, activity)
+		}
+	}
+	sort.SliceStable(stale, func(i, j int) bool {
+		if !stale[i].Updated.Equal(stale[j].Updated) {
+			return stale[i].Updated.Before(stale[j].Updated)
+		}
+		return stale[i].Key < stale[j].Key
+	})
+	
+	keys := make([]string, len(stale))
+	for i, activity := range stale {
+		keys[i] = activity.Key
+	}
+	return keys
+}
+
+// countStale returns how many of activities are stale
+func (dp *DataProcessor) countStale(activities []models.Activity) int {
+	count := 0
+	for _, activity := range activities {
+		if dp.isStale(activity) {
+			count++
+		}
+	}
+	return count
+}
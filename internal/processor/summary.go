@@ -13,20 +13,75 @@ import (
 
 // SummaryGenerator handles the generation of executive summaries from processed data
 type SummaryGenerator struct {
-	logger utils.Logger
+	logger               utils.Logger
+	labelFormatter       PeriodLabelFormatter
+	noCompletionFallback bool
+	staleConcernCount    int
 }
 
+// DefaultStaleConcernCount is how many stale issues it takes to raise a concern
+const DefaultStaleConcernCount = 3
+
 // NewSummaryGenerator creates a new summary generator instance
 func NewSummaryGenerator(logger utils.Logger) *SummaryGenerator {
 	return &SummaryGenerator{
-		logger: logger,
+		logger:               logger,
+		labelFormatter:       DefaultPeriodLabel,
+		noCompletionFallback: true,
+		staleConcernCount:    DefaultStaleConcernCount,
+	}
+}
+
+// SetNoCompletionFallback controls whether periods with no completed items use the
+// in-progress focused executive summary instead of the standard completion narrative
+func (sg *SummaryGenerator) SetNoCompletionFallback(enabled bool) {
+	sg.noCompletionFallback = enabled
+}
+
+// SetStaleConcernCount sets how many stale issues it takes to raise a concern, values below one
+// restore DefaultStaleConcernCount
+func (sg *SummaryGenerator) SetStaleConcernCount(count int) {
+	if count < 1 {
+		count = DefaultStaleConcernCount
+	}
+	sg.staleConcernCount = count
+}
+
+// PeriodLabelFormatter builds the human readable label for a reporting period
+type PeriodLabelFormatter func(period string, dateRange TimeRange) string
+
+// SetPeriodLabelFormatter overrides how period labels are built, nil restores the default
+func (sg *SummaryGenerator) SetPeriodLabelFormatter(formatter PeriodLabelFormatter) {
+	if formatter == nil {
+		formatter = DefaultPeriodLabel
+	}
+	sg.labelFormatter = formatter
+}
+
+// DefaultPeriodLabel formats weekly periods as "Week of Jan 2, 2006", monthly periods as
+// "January 2006" and quarterly periods as "Q1 2006". Other periods keep the date range label.
+func DefaultPeriodLabel(period string, dateRange TimeRange) string {
+	start := dateRange.Start
+	if start.IsZero() {
+		return dateRange.Label
+	}
+
+	switch strings.ToLower(period) {
+	case "weekly":
+		return fmt.Sprintf("Week of %s", start.Format("Jan 2, 2006"))
+	case "monthly":
+		return start.Format("January 2006")
+	case "quarterly":
+		return fmt.Sprintf("Q%d %d", (int(start.Month())-1)/3+1, start.Year())
+	default:
+		return dateRange.Label
 	}
 }
 
 // SummaryRequest contains the configuration for summary generation
 type SummaryRequest struct {
 	Title         string           `json:"title"`
-	Period        string           `json:"period"`        // "weekly", "monthly", "quarterly"
+	Period        string           `json:"period"`        // "weekly", "monthly", "quarterly", inferred from activity dates when empty
 	IncludeMetrics bool            `json:"include_metrics"`
 	IncludeTrends  bool            `json:"include_trends"`
 	IncludeUsers   bool            `json:"include_users"`
@@ -34,6 +89,19 @@ type SummaryRequest struct {
 	MaxUsers       int             `json:"max_users"`      // Limit number of users to include
 	MinTimeSpent   int64           `json:"min_time_spent"` // Minimum time in seconds to include activities
 	Format         SummaryFormat   `json:"format"`
+	AssignOwners   bool            `json:"assign_owners"`   // Attach a suggested owner to each actionable recommendation
+	TeamLead       string          `json:"team_lead"`       // Owner for team-wide recommendations, defaults to the most active user
+	IncludeRawData *bool           `json:"include_raw_data,omitempty"` // Overrides the format default, which includes raw data only for detailed summaries
+	Language       string          `json:"language,omitempty"` // Language tag such as "es" or "de-DE" for generated text, empty uses English
+	Previous       *ProcessingResult `json:"-"`                // Result of the preceding period, compared in the highlights and concerns
+}
+
+// includeRawData reports whether the processing data should be attached to the response
+func (r SummaryRequest) includeRawData() bool {
+	if r.IncludeRawData != nil {
+		return *r.IncludeRawData
+	}
+	return r.Format == FormatDetailed
 }
 
 // SummaryFormat defines the output format for the summary
@@ -50,14 +118,20 @@ const (
 type SummaryResponse struct {
 	Title           string                 `json:"title"`
 	Period          string                 `json:"period"`
+	PeriodLabel     string                 `json:"period_label"`
+	PeriodInferred  bool                   `json:"period_inferred"` // Period was derived from activity dates because none was requested
 	GeneratedAt     time.Time              `json:"generated_at"`
 	ExecutiveSummary string                `json:"executive_summary"`
 	KeyMetrics      SummaryKeyMetrics      `json:"key_metrics"`
 	Highlights      []string               `json:"highlights"`
 	Concerns        []string               `json:"concerns"`
 	Recommendations []string               `json:"recommendations"`
+	OwnedRecommendations []OwnedRecommendation `json:"owned_recommendations,omitempty"`
 	UserInsights    []UserInsight          `json:"user_insights"`
 	TrendAnalysis   *SummaryTrendAnalysis  `json:"trend_analysis,omitempty"`
+	Spotlight       *Spotlight             `json:"spotlight,omitempty"`
+	Comparison      *ComparisonResult      `json:"comparison,omitempty"` // Change from the previous period when one was supplied
+	FallbackUsed    bool                   `json:"fallback_used"` // The executive summary came from the no-completion fallback
 	Sections        map[string]string      `json:"sections"`
 	RawData         *ProcessingResult      `json:"raw_data,omitempty"`
 }
@@ -73,6 +147,16 @@ type SummaryKeyMetrics struct {
 	ActiveUsers        int     `json:"active_users"`
 	TopPriority        string  `json:"top_priority"`
 	MostActiveUser     string  `json:"most_active_user"`
+	WorkloadBalance    float64 `json:"workload_balance"` // Gini coefficient of time spent across users
+}
+
+// UnassignedOwner is used when no suitable owner can be found for a recommendation
+const UnassignedOwner = "Unassigned"
+
+// OwnedRecommendation is an actionable recommendation with a suggested owner
+type OwnedRecommendation struct {
+	Text  string `json:"text"`
+	Owner string `json:"owner"`
 }
 
 // UserInsight contains insights about individual users
@@ -87,6 +171,45 @@ type UserInsight struct {
 	AreasForImprovement []string `json:"areas_for_improvement"`
 }
 
+// MinSpotlightActivities is the minimum number of activities a user needs to be spotlighted
+const MinSpotlightActivities = 3
+
+// Spotlight highlights a single standout contributor for the period
+type Spotlight struct {
+	UserID              string  `json:"user_id"`
+	DisplayName         string  `json:"display_name"`
+	Score               float64 `json:"score"`
+	CompletedActivities int     `json:"completed_activities"`
+	TotalActivities     int     `json:"total_activities"`
+	TimeSpent           string  `json:"time_spent"`
+	Paragraph           string  `json:"paragraph"`
+}
+
+// Period inference thresholds: activity date spans up to weeklyPeriodMaxSpan infer a weekly
+// period, spans up to monthlyPeriodMaxSpan a monthly one and longer spans a quarterly one
+const (
+	weeklyPeriodMaxSpan  = 10 * 24 * time.Hour
+	monthlyPeriodMaxSpan = 45 * 24 * time.Hour
+)
+
+// InferPeriod derives "weekly", "monthly" or "quarterly" from the span between the earliest and
+// latest activity dates. An empty date range yields an empty period.
+func InferPeriod(dateRange TimeRange) string {
+	if dateRange.Start.IsZero() || dateRange.End.IsZero() {
+		return ""
+	}
+
+	span := dateRange.End.Sub(dateRange.Start)
+	switch {
+	case span <= weeklyPeriodMaxSpan:
+		return "weekly"
+	case span <= monthlyPeriodMaxSpan:
+		return "monthly"
+	default:
+		return "quarterly"
+	}
+}
+
 // SummaryTrendAnalysis contains trend analysis for the summary
 type SummaryTrendAnalysis struct {
 	OverallTrend      string             `json:"overall_trend"`
@@ -108,37 +231,70 @@ func (sg *SummaryGenerator) GenerateSummary(ctx context.Context, data *Processin
 		return nil, fmt.Errorf("processing data is required")
 	}
 
+	// Infer the period from the activity dates when none was requested
+	periodInferred := false
+	if request.Period == "" {
+		request.Period = InferPeriod(data.Summary.DateRange)
+		periodInferred = request.Period != ""
+		if periodInferred {
+			sg.logger.Info("Inferred report period from activity dates",
+				utils.NewField("period", request.Period),
+				utils.NewField("date_range", data.Summary.DateRange.Label),
+			)
+		}
+	}
+
 	// Build the summary response
 	response := &SummaryResponse{
-		Title:       request.Title,
-		Period:      request.Period,
-		GeneratedAt: time.Now(),
-		Sections:    make(map[string]string),
+		Title:          request.Title,
+		Period:         request.Period,
+		PeriodLabel:    sg.labelFormatter(request.Period, data.Summary.DateRange),
+		PeriodInferred: periodInferred,
+		GeneratedAt:    time.Now(),
+		Sections:       make(map[string]string),
 	}
 
 	// Include raw data if requested
-	if request.Format == FormatDetailed {
+	if request.includeRawData() {
 		response.RawData = data
 	}
 
 	// Generate key metrics
 	response.KeyMetrics = sg.generateKeyMetrics(data)
 
-	// Generate executive summary
-	response.ExecutiveSummary = sg.generateExecutiveSummary(data, request)
+	// Generate executive summary, falling back to an in-progress view when nothing was completed
+	if sg.noCompletionFallback && sg.hasNoCompletions(data) {
+		response.ExecutiveSummary = sg.generateNoCompletionSummary(data, request)
+		response.FallbackUsed = true
+	} else {
+		response.ExecutiveSummary = sg.generateExecutiveSummary(data, request)
+	}
 
 	// Generate highlights and concerns
-	response.Highlights = sg.generateHighlights(data)
-	response.Concerns = sg.generateConcerns(data)
+	response.Highlights = sg.generateHighlights(data, request.Language)
+	response.Concerns = sg.generateConcerns(data, request.Language)
+
+	// Compare with the previous period when one was supplied
+	if request.Previous != nil {
+		response.Comparison = CompareResults(data, request.Previous)
+		response.Highlights = append(response.Highlights, sg.generateComparisonHighlights(response.Comparison, request.Language)...)
+		response.Concerns = append(response.Concerns, sg.generateComparisonConcerns(response.Comparison, request.Language)...)
+	}
 
 	// Generate recommendations
-	response.Recommendations = sg.generateRecommendations(data)
+	response.Recommendations = sg.generateRecommendations(data, request.Language)
+	if request.AssignOwners {
+		response.OwnedRecommendations = sg.generateOwnedRecommendations(data, request.TeamLead, request.Language)
+	}
 
 	// Generate user insights
 	if request.IncludeUsers {
 		response.UserInsights = sg.generateUserInsights(data, request.MaxUsers)
 	}
 
+	// Generate contributor spotlight
+	response.Spotlight = sg.generateSpotlight(data)
+
 	// Generate trend analysis
 	if request.IncludeTrends && data.TrendAnalysis != nil {
 		response.TrendAnalysis = sg.generateTrendAnalysis(data.TrendAnalysis)
@@ -176,6 +332,7 @@ func (sg *SummaryGenerator) generateKeyMetrics(data *ProcessingResult) SummaryKe
 		ActiveUsers:         data.Summary.TotalUsers,
 		TopPriority:         data.Summary.TopPriority,
 		MostActiveUser:      data.Summary.MostActiveUser,
+		WorkloadBalance:     data.Summary.WorkloadBalance,
 	}
 }
 
@@ -184,9 +341,9 @@ func (sg *SummaryGenerator) generateExecutiveSummary(data *ProcessingResult, req
 	var summary strings.Builder
 
 	// Opening statement
-	summary.WriteString(fmt.Sprintf("During the %s period from %s, the team completed %d activities with a %s completion rate. ",
+	summary.WriteString(fmt.Sprintf("During the %s period (%s), the team completed %d activities with a %s completion rate. ",
 		request.Period,
-		data.Summary.DateRange.Label,
+		sg.labelFormatter(request.Period, data.Summary.DateRange),
 		data.Summary.TotalActivities,
 		sg.formatPercentage(data.Summary.CompletionRate),
 	))
@@ -227,26 +384,89 @@ func (sg *SummaryGenerator) generateExecutiveSummary(data *ProcessingResult, req
 	return summary.String()
 }
 
-// generateHighlights creates a list of positive highlights
-func (sg *SummaryGenerator) generateHighlights(data *ProcessingResult) []string {
+// generateNoCompletionSummary creates an executive summary for periods where no items were
+// completed, focusing on the work in flight and what is blocking it
+func (sg *SummaryGenerator) generateNoCompletionSummary(data *ProcessingResult, request SummaryRequest) string {
+	var summary strings.Builder
+
+	// Opening statement
+	summary.WriteString(messagef(request.Language, msgFallbackOpening,
+		periodName(request.Language, request.Period),
+		sg.labelFormatter(request.Period, data.Summary.DateRange),
+		data.Summary.TotalActivities,
+	))
+	if data.Summary.TotalTimeSpent > 0 {
+		summary.WriteString(messagef(request.Language, msgFallbackInvested,
+			models.FormatTimeSpent(data.Summary.TotalTimeSpent),
+			data.Summary.TotalUsers,
+		))
+	}
+	summary.WriteString(". ")
+
+	// In-progress focus
+	inFlight := []StatusMetrics{}
+	blocked := 0
+	for status, metrics := range data.StatusBreakdown {
+		if sg.isBlockedStatus(status) {
+			blocked += metrics.Count
+			continue
+		}
+		inFlight = append(inFlight, metrics)
+	}
+	sort.Slice(inFlight, func(i, j int) bool {
+		if inFlight[i].Count != inFlight[j].Count {
+			return inFlight[i].Count > inFlight[j].Count
+		}
+		return inFlight[i].Status < inFlight[j].Status
+	})
+	if len(inFlight) > 3 {
+		inFlight = inFlight[:3]
+	}
+	if len(inFlight) > 0 {
+		parts := make([]string, len(inFlight))
+		for i, metrics := range inFlight {
+			parts[i] = fmt.Sprintf("%d %s", metrics.Count, metrics.Status)
+		}
+		summary.WriteString(messagef(request.Language, msgFallbackInFlight, strings.Join(parts, ", ")))
+	}
+
+	// Blockers, which are only known when statuses were grouped
+	if blocked > 0 {
+		summary.WriteString(messagef(request.Language, pluralize(blocked, msgFallbackBlockedOne, msgFallbackBlockedMany), blocked))
+	} else if len(data.StatusBreakdown) > 0 {
+		summary.WriteString(message(request.Language, msgFallbackNoBlocked))
+	}
+
+	// Open high-priority work
+	if highPriority, exists := data.PriorityBreakdown["High"]; exists && highPriority.Count > 0 {
+		summary.WriteString(messagef(request.Language, pluralize(highPriority.Count, msgFallbackHighPriorityOne, msgFallbackHighPriorityMany),
+			highPriority.Count,
+		))
+	}
+
+	return strings.TrimSpace(summary.String())
+}
+
+// generateHighlights creates a list of positive highlights in language
+func (sg *SummaryGenerator) generateHighlights(data *ProcessingResult, language string) []string {
 	highlights := []string{}
 
 	// High completion rate
 	if data.Summary.CompletionRate >= 80 {
-		highlights = append(highlights, fmt.Sprintf("Excellent completion rate of %s demonstrates strong execution capability",
+		highlights = append(highlights, messagef(language, msgHighlightCompletionRate,
 			sg.formatPercentage(data.Summary.CompletionRate)))
 	}
 
 	// High productivity score
 	if data.Summary.ProductivityScore >= 75 {
-		highlights = append(highlights, fmt.Sprintf("Strong productivity score of %s indicates effective team performance",
+		highlights = append(highlights, messagef(language, msgHighlightProductivity,
 			sg.formatPercentage(data.Summary.ProductivityScore)))
 	}
 
 	// High-priority focus
 	if highPriorityMetrics, exists := data.PriorityBreakdown["High"]; exists {
 		if highPriorityMetrics.CompletionRate >= 75 {
-			highlights = append(highlights, fmt.Sprintf("High-priority items completed at %s rate, showing good prioritization",
+			highlights = append(highlights, messagef(language, msgHighlightHighPriority,
 				sg.formatPercentage(highPriorityMetrics.CompletionRate)))
 		}
 	}
@@ -258,43 +478,43 @@ func (sg *SummaryGenerator) generateHighlights(data *ProcessingResult) []string
 		for i, user := range topPerformers {
 			names[i] = user.DisplayName
 		}
-		highlights = append(highlights, fmt.Sprintf("Outstanding contributions from %s with consistently high performance",
-			strings.Join(names, " and ")))
+		highlights = append(highlights, messagef(language, msgHighlightTopPerformers,
+			strings.Join(names, message(language, msgNamesJoin))))
 	}
 
 	// Trend improvements
 	if data.TrendAnalysis != nil {
 		if data.TrendAnalysis.OverallTrend == "increasing" {
-			highlights = append(highlights, "Positive trend in overall team performance and productivity")
+			highlights = append(highlights, message(language, msgHighlightOverallTrend))
 		}
 		if data.TrendAnalysis.VelocityTrend == "increasing" {
-			highlights = append(highlights, "Improving velocity indicates enhanced team efficiency")
+			highlights = append(highlights, message(language, msgHighlightVelocityTrend))
 		}
 	}
 
 	return highlights
 }
 
-// generateConcerns creates a list of areas needing attention
-func (sg *SummaryGenerator) generateConcerns(data *ProcessingResult) []string {
+// generateConcerns creates a list of areas needing attention in language
+func (sg *SummaryGenerator) generateConcerns(data *ProcessingResult, language string) []string {
 	concerns := []string{}
 
 	// Low completion rate
 	if data.Summary.CompletionRate < 60 {
-		concerns = append(concerns, fmt.Sprintf("Completion rate of %s is below optimal levels and requires attention",
+		concerns = append(concerns, messagef(language, msgConcernCompletionRate,
 			sg.formatPercentage(data.Summary.CompletionRate)))
 	}
 
 	// Low productivity score
 	if data.Summary.ProductivityScore < 50 {
-		concerns = append(concerns, fmt.Sprintf("Productivity score of %s indicates potential process inefficiencies",
+		concerns = append(concerns, messagef(language, msgConcernProductivity,
 			sg.formatPercentage(data.Summary.ProductivityScore)))
 	}
 
 	// High-priority backlog
 	if highPriorityMetrics, exists := data.PriorityBreakdown["High"]; exists {
 		if highPriorityMetrics.CompletionRate < 60 {
-			concerns = append(concerns, fmt.Sprintf("High-priority items only %s completed, potentially impacting critical objectives",
+			concerns = append(concerns, messagef(language, msgConcernHighPriority,
 				sg.formatPercentage(highPriorityMetrics.CompletionRate)))
 		}
 	}
@@ -302,65 +522,139 @@ func (sg *SummaryGenerator) generateConcerns(data *ProcessingResult) []string {
 	// Underperforming users
 	underPerformers := sg.getUnderPerformers(data.UserMetrics)
 	if len(underPerformers) > 0 {
-		concerns = append(concerns, fmt.Sprintf("%d team members showing below-average performance metrics",
+		concerns = append(concerns, messagef(language, msgConcernUnderPerformers,
 			len(underPerformers)))
 	}
 
 	// Declining trends
 	if data.TrendAnalysis != nil {
 		if data.TrendAnalysis.OverallTrend == "decreasing" {
-			concerns = append(concerns, "Declining trend in overall team performance requires investigation")
+			concerns = append(concerns, message(language, msgConcernOverallTrend))
 		}
 		if data.TrendAnalysis.VelocityTrend == "decreasing" {
-			concerns = append(concerns, "Decreasing velocity trend may indicate capacity or process issues")
+			concerns = append(concerns, message(language, msgConcernVelocityTrend))
 		}
 	}
 
+	// Open work nobody has touched in a while
+	if stale := len(data.Summary.StaleIssues); stale >= sg.staleConcernCount {
+		concerns = append(concerns, messagef(language, msgConcernStaleIssues,
+			stale, int(time.Duration(data.Summary.StaleAfter)*time.Second/(24*time.Hour))))
+	}
+
 	// Workload imbalance
-	if sg.hasWorkloadImbalance(data.UserMetrics) {
-		concerns = append(concerns, "Uneven workload distribution may lead to burnout and reduced efficiency")
+	if sg.hasWorkloadImbalance(data.Summary) {
+		concerns = append(concerns, messagef(language, msgConcernWorkloadImbalance, data.Summary.WorkloadBalance))
 	}
 
 	return concerns
 }
 
-// generateRecommendations creates actionable recommendations
-func (sg *SummaryGenerator) generateRecommendations(data *ProcessingResult) []string {
-	recommendations := []string{}
+// recommendationOwner identifies who should own a recommendation
+type recommendationOwner int
+
+const (
+	ownerNone         recommendationOwner = iota // General guidance with no single owner
+	ownerLead                                    // Team-wide process changes
+	ownerHighPriority                            // The contributor carrying the most high-priority work
+)
+
+// recommendation is a recommendation along with the kind of owner it needs
+type recommendation struct {
+	text  string
+	owner recommendationOwner
+}
+
+// generateRecommendations creates actionable recommendations in language
+func (sg *SummaryGenerator) generateRecommendations(data *ProcessingResult, language string) []string {
+	candidates := sg.recommendationCandidates(data, language)
+	recommendations := make([]string, len(candidates))
+	for i, candidate := range candidates {
+		recommendations[i] = candidate.text
+	}
+	return recommendations
+}
+
+// generateOwnedRecommendations attaches a suggested owner to each actionable recommendation.
+// Team-wide changes go to the configured lead, or the most active contributor when none is set.
+func (sg *SummaryGenerator) generateOwnedRecommendations(data *ProcessingResult, lead, language string) []OwnedRecommendation {
+	if lead == "" {
+		lead = sg.mostActiveContributor(data)
+	}
+
+	owned := []OwnedRecommendation{}
+	for _, candidate := range sg.recommendationCandidates(data, language) {
+		var owner string
+		switch candidate.owner {
+		case ownerLead:
+			owner = lead
+		case ownerHighPriority:
+			owner = sg.highPriorityOwner(data.UserMetrics)
+			if owner == "" {
+				owner = lead
+			}
+		default:
+			continue
+		}
+
+		if owner == "" {
+			owner = UnassignedOwner
+		}
+		owned = append(owned, OwnedRecommendation{Text: candidate.text, Owner: owner})
+	}
+
+	return owned
+}
+
+// recommendationCandidates builds the recommendations that apply to the processed data, in language
+func (sg *SummaryGenerator) recommendationCandidates(data *ProcessingResult, language string) []recommendation {
+	recommendations := []recommendation{}
 
 	// Based on completion rate
 	if data.Summary.CompletionRate < 70 {
-		recommendations = append(recommendations, "Implement daily standups and sprint reviews to improve task completion tracking")
-		recommendations = append(recommendations, "Consider reducing work-in-progress limits to focus on completing current tasks")
+		recommendations = append(recommendations,
+			recommendation{message(language, msgRecommendStandups), ownerLead},
+			recommendation{message(language, msgRecommendWIPLimits), ownerLead},
+		)
 	}
 
 	// Based on productivity score
 	if data.Summary.ProductivityScore < 60 {
-		recommendations = append(recommendations, "Conduct process review to identify and eliminate bottlenecks in the workflow")
-		recommendations = append(recommendations, "Provide additional training or resources to team members with lower productivity scores")
+		recommendations = append(recommendations,
+			recommendation{message(language, msgRecommendProcessReview), ownerLead},
+			recommendation{message(language, msgRecommendTraining), ownerLead},
+		)
 	}
 
 	// Based on priority distribution
 	if sg.hasHighPriorityBacklog(data.PriorityBreakdown) {
-		recommendations = append(recommendations, "Prioritize high-priority items and consider resource reallocation")
-		recommendations = append(recommendations, "Review and refine prioritization process to ensure critical work gets adequate attention")
+		recommendations = append(recommendations,
+			recommendation{message(language, msgRecommendPrioritize), ownerHighPriority},
+			recommendation{message(language, msgRecommendPrioritization), ownerLead},
+		)
 	}
 
 	// Based on workload distribution
-	if sg.hasWorkloadImbalance(data.UserMetrics) {
-		recommendations = append(recommendations, "Redistribute workload to balance team capacity and prevent burnout")
-		recommendations = append(recommendations, "Cross-train team members to provide better coverage and flexibility")
+	if sg.hasWorkloadImbalance(data.Summary) {
+		recommendations = append(recommendations,
+			recommendation{message(language, msgRecommendRedistribute), ownerLead},
+			recommendation{message(language, msgRecommendCrossTrain), ownerLead},
+		)
 	}
 
 	// Based on trends
 	if data.TrendAnalysis != nil && data.TrendAnalysis.OverallTrend == "decreasing" {
-		recommendations = append(recommendations, "Investigate root causes of declining performance trends")
-		recommendations = append(recommendations, "Implement regular retrospectives to identify improvement opportunities")
+		recommendations = append(recommendations,
+			recommendation{message(language, msgRecommendRootCauses), ownerLead},
+			recommendation{message(language, msgRecommendRetrospectives), ownerLead},
+		)
 	}
 
 	// General recommendations
-	recommendations = append(recommendations, "Continue monitoring key metrics and adjust strategies based on performance data")
-	recommendations = append(recommendations, "Recognize and celebrate high performers to maintain team motivation")
+	recommendations = append(recommendations,
+		recommendation{message(language, msgRecommendMonitor), ownerNone},
+		recommendation{message(language, msgRecommendRecognize), ownerLead},
+	)
 
 	return recommendations
 }
@@ -406,6 +700,54 @@ func (sg *SummaryGenerator) generateUserInsights(data *ProcessingResult, maxUser
 	return insights
 }
 
+// generateSpotlight selects the standout contributor and writes a short paragraph about them.
+// Bots, unassigned work and users below MinSpotlightActivities are never selected.
+func (sg *SummaryGenerator) generateSpotlight(data *ProcessingResult) *Spotlight {
+	var best *UserMetrics
+	bestScore := 0.0
+
+	for _, user := range data.UserMetrics {
+		user := user
+		if user.TotalActivities < MinSpotlightActivities || sg.isBotOrUnassigned(user) {
+			continue
+		}
+
+		score := sg.spotlightScore(user)
+		if best == nil || score > bestScore ||
+			(score == bestScore && user.TotalTimeSpent > best.TotalTimeSpent) ||
+			(score == bestScore && user.TotalTimeSpent == best.TotalTimeSpent && user.UserID < best.UserID) {
+			best = &user
+			bestScore = score
+		}
+	}
+
+	if best == nil || bestScore == 0 {
+		return nil
+	}
+
+	name := best.DisplayName
+	if name == "" {
+		name = best.UserID
+	}
+	timeSpent := models.FormatTimeSpent(best.TotalTimeSpent)
+
+	paragraph := fmt.Sprintf("%s stood out this period, completing %d of %d activities (%s completion rate) and logging %s of work.",
+		name, best.CompletedActivities, best.TotalActivities, sg.formatPercentage(best.CompletionRate), timeSpent)
+	if len(best.TopIssues) > 0 {
+		paragraph += fmt.Sprintf(" Key contributions included %s.", strings.Join(best.TopIssues, ", "))
+	}
+
+	return &Spotlight{
+		UserID:              best.UserID,
+		DisplayName:         best.DisplayName,
+		Score:               bestScore,
+		CompletedActivities: best.CompletedActivities,
+		TotalActivities:     best.TotalActivities,
+		TimeSpent:           timeSpent,
+		Paragraph:           paragraph,
+	}
+}
+
 // generateTrendAnalysis creates trend analysis summary
 func (sg *SummaryGenerator) generateTrendAnalysis(trends *TrendAnalysis) *SummaryTrendAnalysis {
 	keyChanges := []string{}
@@ -438,6 +780,8 @@ func (sg *SummaryGenerator) generateCustomSection(data *ProcessingResult, sectio
 		return sg.generatePriorityBreakdown(data.PriorityBreakdown)
 	case "status_summary":
 		return sg.generateStatusSummary(data.StatusBreakdown)
+	case "type_breakdown":
+		return sg.generateTypeBreakdown(data.TypeBreakdown)
 	case "velocity_analysis":
 		if data.VelocityMetrics != nil {
 			return sg.generateVelocityAnalysis(data.VelocityMetrics)
@@ -487,46 +831,131 @@ func (sg *SummaryGenerator) getTopPerformers(userMetrics map[string]UserMetrics,
 	return users
 }
 
-func (sg *SummaryGenerator) getUnderPerformers(userMetrics map[string]UserMetrics) []UserMetrics {
-	users := make([]UserMetrics, 0)
-	for _, user := range userMetrics {
-		if user.CompletionRate < 50 || user.ProductivityRank > len(userMetrics)*3/4 {
-			users = append(users, user)
+// spotlightScore weights completed work by completion rate so volume alone doesn't win
+func (sg *SummaryGenerator) spotlightScore(user UserMetrics) float64 {
+	return float64(user.CompletedActivities) * user.CompletionRate / 100
+}
+
+// isBotOrUnassigned reports whether a user is an automation account or the unassigned bucket
+func (sg *SummaryGenerator) isBotOrUnassigned(user UserMetrics) bool {
+	id := strings.ToLower(strings.TrimSpace(user.UserID))
+	name := strings.ToLower(strings.TrimSpace(user.DisplayName))
+
+	if id == "" || id == "unassigned" || name == "unassigned" {
+		return true
+	}
+
+	for _, value := range []string{id, name} {
+		if strings.Contains(value, "[bot]") || strings.Contains(value, "automation") {
+			return true
+		}
+		for _, word := range strings.FieldsFunc(value, func(r rune) bool {
+			return r == ' ' || r == '-' || r == '_' || r == '.' || r == '@'
+		}) {
+			if word == "bot" {
+				return true
+			}
 		}
 	}
-	return users
+
+	return false
+}
+
+// hasNoCompletions reports whether the period had activities but none were completed
+func (sg *SummaryGenerator) hasNoCompletions(data *ProcessingResult) bool {
+	return data.Summary.TotalActivities > 0 && data.Summary.CompletionRate == 0
 }
 
-func (sg *SummaryGenerator) hasWorkloadImbalance(userMetrics map[string]UserMetrics) bool {
-	if len(userMetrics) < 2 {
-		return false
+// isBlockedStatus reports whether a status indicates work that cannot currently progress
+func (sg *SummaryGenerator) isBlockedStatus(status string) bool {
+	status = strings.ToLower(status)
+	for _, marker := range []string{"block", "hold", "waiting", "impediment"} {
+		if strings.Contains(status, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// pluralize picks the singular or plural form for count
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
 	}
+	return plural
+}
 
-	var totalTime int64
-	var maxTime int64
-	var minTime int64
+// mostActiveContributor returns the display name of the most active user, skipping bots
+func (sg *SummaryGenerator) mostActiveContributor(data *ProcessingResult) string {
+	if user, exists := data.UserMetrics[data.Summary.MostActiveUser]; exists && !sg.isBotOrUnassigned(user) {
+		return sg.ownerName(user)
+	}
 
-	first := true
+	var best *UserMetrics
+	for _, user := range data.UserMetrics {
+		if sg.isBotOrUnassigned(user) {
+			continue
+		}
+		if best == nil || user.TotalActivities > best.TotalActivities ||
+			(user.TotalActivities == best.TotalActivities && user.UserID < best.UserID) {
+			candidate := user
+			best = &candidate
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return sg.ownerName(*best)
+}
+
+// highPriorityOwner returns the user carrying the most high-priority items, skipping bots
+func (sg *SummaryGenerator) highPriorityOwner(userMetrics map[string]UserMetrics) string {
+	var best *UserMetrics
+	bestCount := 0
 	for _, user := range userMetrics {
-		totalTime += user.TotalTimeSpent
-		if first {
-			maxTime = user.TotalTimeSpent
-			minTime = user.TotalTimeSpent
-			first = false
-		} else {
-			if user.TotalTimeSpent > maxTime {
-				maxTime = user.TotalTimeSpent
-			}
-			if user.TotalTimeSpent < minTime {
-				minTime = user.TotalTimeSpent
-			}
+		count := user.PriorityDistribution["High"]
+		if count == 0 || sg.isBotOrUnassigned(user) {
+			continue
 		}
+		if best == nil || count > bestCount || (count == bestCount && user.UserID < best.UserID) {
+			candidate := user
+			best = &candidate
+			bestCount = count
+		}
+	}
+	if best == nil {
+		return ""
+	}
+	return sg.ownerName(*best)
+}
+
+// ownerName returns the name used when assigning a user as an owner
+func (sg *SummaryGenerator) ownerName(user UserMetrics) string {
+	if user.DisplayName != "" {
+		return user.DisplayName
 	}
+	return user.UserID
+}
+
+func (sg *SummaryGenerator) getUnderPerformers(userMetrics map[string]UserMetrics) []UserMetrics {
+	users := make([]UserMetrics, 0)
+	for _, user := range userMetrics {
+		if user.CompletionRate < 50 || user.ProductivityRank > len(userMetrics)*3/4 {
+			users = append(users, user)
+		}
+	}
+	return users
+}
+
+// WorkloadImbalanceThreshold is the Gini coefficient of time spent across users above which
+// the workload counts as imbalanced. For two users it is the point where one logs less than a
+// third of the other's time.
+const WorkloadImbalanceThreshold = 0.25
 
-	avgTime := totalTime / int64(len(userMetrics))
-	
-	// Consider imbalanced if max is more than 2x the average or min is less than 50% of average
-	return maxTime > avgTime*2 || minTime < avgTime/2
+// hasWorkloadImbalance reports whether time spent is spread across users more unevenly than
+// WorkloadImbalanceThreshold
+func (sg *SummaryGenerator) hasWorkloadImbalance(summary ProcessingSummary) bool {
+	return summary.WorkloadBalance > WorkloadImbalanceThreshold
 }
 
 func (sg *SummaryGenerator) hasHighPriorityBacklog(priorityBreakdown map[string]PriorityMetrics) bool {
@@ -603,13 +1032,54 @@ func (sg *SummaryGenerator) generateStatusSummary(breakdown map[string]StatusMet
 	return content.String()
 }
 
+func (sg *SummaryGenerator) generateTypeBreakdown(breakdown map[string]TypeMetrics) string {
+	if len(breakdown) == 0 {
+		return "Issue type breakdown not available"
+	}
+
+	// Most common types first, ties broken alphabetically
+	types := make([]TypeMetrics, 0, len(breakdown))
+	for _, metrics := range breakdown {
+		types = append(types, metrics)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].Count != types[j].Count {
+			return types[i].Count > types[j].Count
+		}
+		return types[i].Type < types[j].Type
+	})
+
+	var content strings.Builder
+	content.WriteString("Issue Type Distribution:\n")
+
+	for _, metrics := range types {
+		content.WriteString(fmt.Sprintf("- %s: %d items (%s of work, %s completion rate, %s total time)\n",
+			metrics.Type,
+			metrics.Count,
+			sg.formatPercentage(metrics.Share),
+			sg.formatPercentage(metrics.CompletionRate),
+			models.FormatTimeSpent(metrics.TotalTimeSpent),
+		))
+	}
+
+	return content.String()
+}
+
 func (sg *SummaryGenerator) generateVelocityAnalysis(velocity *VelocityMetrics) string {
 	var content strings.Builder
 	content.WriteString("Velocity Analysis:\n")
 	content.WriteString(fmt.Sprintf("- Current Velocity: %.2f items/day\n", velocity.CurrentVelocity))
 	content.WriteString(fmt.Sprintf("- Average Velocity: %.2f items/day\n", velocity.AverageVelocity))
 	content.WriteString(fmt.Sprintf("- Velocity Trend: %s\n", velocity.VelocityTrend))
-	content.WriteString(fmt.Sprintf("- Burndown Rate: %.1f%%\n", velocity.BurndownRate*100))
+	if len(velocity.SprintMetrics) == 0 {
+		return content.String()
+	}
+
+	content.WriteString(fmt.Sprintf("- Burndown Rate: %.1f%% of planned points remaining per day\n", velocity.BurndownRate*100))
+	for _, sprint := range velocity.SprintMetrics {
+		content.WriteString(fmt.Sprintf("- %s: %.1f of %.1f story points completed\n",
+			sprint.SprintName, sprint.CompletedStoryPoints, sprint.PlannedStoryPoints))
+	}
 
 	return content.String()
 }
@@ -2,6 +2,7 @@ package processor
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -96,6 +97,115 @@ func TestSummaryGenerator_GenerateSummary(t *testing.T) {
 	})
 }
 
+func TestDefaultPeriodLabel(t *testing.T) {
+	dateRange := TimeRange{
+		Start: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2023, 1, 7, 0, 0, 0, 0, time.UTC),
+		Label: "2023-01-01 to 2023-01-07",
+	}
+
+	tests := []struct {
+		period   string
+		start    time.Time
+		expected string
+	}{
+		{period: "weekly", start: dateRange.Start, expected: "Week of Jan 1, 2023"},
+		{period: "Weekly", start: time.Date(2023, 12, 25, 0, 0, 0, 0, time.UTC), expected: "Week of Dec 25, 2023"},
+		{period: "monthly", start: dateRange.Start, expected: "January 2023"},
+		{period: "quarterly", start: dateRange.Start, expected: "Q1 2023"},
+		{period: "quarterly", start: time.Date(2023, 6, 30, 0, 0, 0, 0, time.UTC), expected: "Q2 2023"},
+		{period: "quarterly", start: time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), expected: "Q4 2023"},
+		{period: "custom", start: dateRange.Start, expected: "2023-01-01 to 2023-01-07"},
+		{period: "weekly", start: time.Time{}, expected: "2023-01-01 to 2023-01-07"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.period+" "+tt.expected, func(t *testing.T) {
+			r := dateRange
+			r.Start = tt.start
+			assert.Equal(t, tt.expected, DefaultPeriodLabel(tt.period, r))
+		})
+	}
+}
+
+func TestSummaryGenerator_PeriodLabel(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	testData := createTestProcessingResult()
+	testData.Summary.DateRange.Start = time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+
+	summary, err := generator.GenerateSummary(context.Background(), testData, SummaryRequest{Period: "quarterly"})
+	require.NoError(t, err)
+	assert.Equal(t, "Q2 2023", summary.PeriodLabel)
+	assert.Contains(t, summary.ExecutiveSummary, "quarterly period (Q2 2023)")
+
+	// A custom formatter replaces the default
+	generator.SetPeriodLabelFormatter(func(period string, dateRange TimeRange) string {
+		return "Sprint 42"
+	})
+	summary, err = generator.GenerateSummary(context.Background(), testData, SummaryRequest{Period: "weekly"})
+	require.NoError(t, err)
+	assert.Equal(t, "Sprint 42", summary.PeriodLabel)
+	assert.Contains(t, summary.ExecutiveSummary, "(Sprint 42)")
+
+	// Resetting to nil restores the default
+	generator.SetPeriodLabelFormatter(nil)
+	summary, err = generator.GenerateSummary(context.Background(), testData, SummaryRequest{Period: "monthly"})
+	require.NoError(t, err)
+	assert.Equal(t, "April 2023", summary.PeriodLabel)
+}
+
+func TestInferPeriod(t *testing.T) {
+	start := time.Date(2023, 1, 2, 9, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		span     time.Duration
+		expected string
+	}{
+		{name: "single day", span: 0, expected: "weekly"},
+		{name: "one week", span: 7 * day, expected: "weekly"},
+		{name: "ten days", span: 10 * day, expected: "weekly"},
+		{name: "two weeks", span: 14 * day, expected: "monthly"},
+		{name: "one month", span: 31 * day, expected: "monthly"},
+		{name: "forty five days", span: 45 * day, expected: "monthly"},
+		{name: "two months", span: 60 * day, expected: "quarterly"},
+		{name: "one quarter", span: 91 * day, expected: "quarterly"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, InferPeriod(TimeRange{Start: start, End: start.Add(tt.span)}))
+		})
+	}
+
+	assert.Equal(t, "", InferPeriod(TimeRange{}))
+}
+
+func TestSummaryGenerator_InferredPeriod(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	testData := createTestProcessingResult()
+	testData.Summary.DateRange.Start = time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)
+	testData.Summary.DateRange.End = time.Date(2023, 4, 28, 0, 0, 0, 0, time.UTC)
+
+	summary, err := generator.GenerateSummary(context.Background(), testData, SummaryRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "monthly", summary.Period)
+	assert.True(t, summary.PeriodInferred)
+	assert.Equal(t, "April 2023", summary.PeriodLabel)
+	assert.Contains(t, summary.ExecutiveSummary, "monthly period (April 2023)")
+
+	// An explicit period is kept as requested
+	summary, err = generator.GenerateSummary(context.Background(), testData, SummaryRequest{Period: "quarterly"})
+	require.NoError(t, err)
+	assert.Equal(t, "quarterly", summary.Period)
+	assert.False(t, summary.PeriodInferred)
+}
+
 func TestSummaryGenerator_GenerateKeyMetrics(t *testing.T) {
 	logger := utils.NewMockLogger()
 	generator := NewSummaryGenerator(logger)
@@ -142,7 +252,7 @@ func TestSummaryGenerator_GenerateHighlights(t *testing.T) {
 		testData.Summary.CompletionRate = 85.0
 		testData.Summary.ProductivityScore = 80.0
 
-		highlights := generator.generateHighlights(testData)
+		highlights := generator.generateHighlights(testData, "")
 
 		assert.True(t, len(highlights) > 0)
 		assert.Contains(t, highlights[0], "Excellent completion rate")
@@ -154,7 +264,7 @@ func TestSummaryGenerator_GenerateHighlights(t *testing.T) {
 		testData.TrendAnalysis.OverallTrend = "increasing"
 		testData.TrendAnalysis.VelocityTrend = "increasing"
 
-		highlights := generator.generateHighlights(testData)
+		highlights := generator.generateHighlights(testData, "")
 
 		assert.True(t, len(highlights) > 0)
 		// Should include trend-related highlights
@@ -169,6 +279,32 @@ func TestSummaryGenerator_GenerateHighlights(t *testing.T) {
 	})
 }
 
+func TestSummaryGenerator_StaleIssueConcern(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	testData := createTestProcessingResult()
+	testData.Summary.StaleAfter = int64(14 * 24 * time.Hour / time.Second)
+	testData.Summary.StaleIssues = []string{"PROJ-1", "PROJ-2"}
+
+	// A couple of stale issues is not worth raising
+	for _, concern := range generator.generateConcerns(testData, "") {
+		assert.NotContains(t, concern, "not been updated")
+	}
+
+	testData.Summary.StaleIssues = append(testData.Summary.StaleIssues, "PROJ-3")
+	assert.Contains(t, generator.generateConcerns(testData, ""),
+		"3 open items have not been updated in over 14 days and may be stuck")
+	assert.Contains(t, generator.generateConcerns(testData, "de"),
+		"3 offene Elemente wurden seit über 14 Tagen nicht aktualisiert und stecken möglicherweise fest")
+
+	// The count that raises the concern is configurable
+	generator.SetStaleConcernCount(5)
+	for _, concern := range generator.generateConcerns(testData, "") {
+		assert.NotContains(t, concern, "not been updated")
+	}
+}
+
 func TestSummaryGenerator_GenerateConcerns(t *testing.T) {
 	logger := utils.NewMockLogger()
 	generator := NewSummaryGenerator(logger)
@@ -179,7 +315,7 @@ func TestSummaryGenerator_GenerateConcerns(t *testing.T) {
 		testData.Summary.CompletionRate = 45.0
 		testData.Summary.ProductivityScore = 35.0
 
-		concerns := generator.generateConcerns(testData)
+		concerns := generator.generateConcerns(testData, "")
 
 		assert.True(t, len(concerns) > 0)
 		assert.Contains(t, concerns[0], "below optimal levels")
@@ -191,7 +327,7 @@ func TestSummaryGenerator_GenerateConcerns(t *testing.T) {
 		testData.TrendAnalysis.OverallTrend = "decreasing"
 		testData.TrendAnalysis.VelocityTrend = "decreasing"
 
-		concerns := generator.generateConcerns(testData)
+		concerns := generator.generateConcerns(testData, "")
 
 		assert.True(t, len(concerns) > 0)
 		// Should include trend-related concerns
@@ -216,7 +352,7 @@ func TestSummaryGenerator_GenerateRecommendations(t *testing.T) {
 		testData.Summary.CompletionRate = 50.0
 		testData.Summary.ProductivityScore = 40.0
 
-		recommendations := generator.generateRecommendations(testData)
+		recommendations := generator.generateRecommendations(testData, "")
 
 		assert.True(t, len(recommendations) > 0)
 		// Should include process improvement recommendations
@@ -233,7 +369,7 @@ func TestSummaryGenerator_GenerateRecommendations(t *testing.T) {
 	t.Run("Always Include General Recommendations", func(t *testing.T) {
 		testData := createTestProcessingResult()
 
-		recommendations := generator.generateRecommendations(testData)
+		recommendations := generator.generateRecommendations(testData, "")
 
 		assert.True(t, len(recommendations) > 0)
 		// Should always include monitoring and recognition
@@ -293,6 +429,352 @@ func TestSummaryGenerator_GenerateUserInsights(t *testing.T) {
 	})
 }
 
+func TestSummaryGenerator_GenerateSpotlight(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	t.Run("Selects Standout Contributor", func(t *testing.T) {
+		testData := createTestProcessingResult()
+
+		spotlight := generator.generateSpotlight(testData)
+
+		// user2 has a perfect completion rate but too little volume to qualify
+		require.NotNil(t, spotlight)
+		assert.Equal(t, "user1", spotlight.UserID)
+		assert.Equal(t, "User One", spotlight.DisplayName)
+		assert.Equal(t, 2, spotlight.CompletedActivities)
+		assert.Contains(t, spotlight.Paragraph, "User One")
+		assert.Contains(t, spotlight.Paragraph, "TEST-1, TEST-3")
+	})
+
+	t.Run("Prefers Weighted Productivity Over Volume", func(t *testing.T) {
+		testData := createTestProcessingResult()
+		testData.UserMetrics["user3"] = UserMetrics{
+			UserID:              "user3",
+			DisplayName:         "User Three",
+			TotalActivities:     10,
+			CompletedActivities: 2,
+			CompletionRate:      20.0,
+		}
+		testData.UserMetrics["user4"] = UserMetrics{
+			UserID:              "user4",
+			DisplayName:         "User Four",
+			TotalActivities:     4,
+			CompletedActivities: 4,
+			CompletionRate:      100.0,
+		}
+
+		spotlight := generator.generateSpotlight(testData)
+
+		require.NotNil(t, spotlight)
+		assert.Equal(t, "user4", spotlight.UserID)
+		assert.Equal(t, 4.0, spotlight.Score)
+	})
+
+	t.Run("Excludes Bots And Unassigned", func(t *testing.T) {
+		testData := createTestProcessingResult()
+		for _, user := range []UserMetrics{
+			{UserID: "dependabot[bot]", DisplayName: "dependabot[bot]"},
+			{UserID: "ci-bot", DisplayName: "CI Bot"},
+			{UserID: "jira-automation", DisplayName: "Automation for Jira"},
+			{UserID: "", DisplayName: "Unassigned"},
+			{UserID: "unassigned", DisplayName: ""},
+		} {
+			user.TotalActivities = 50
+			user.CompletedActivities = 50
+			user.CompletionRate = 100.0
+			testData.UserMetrics[user.UserID+user.DisplayName] = user
+		}
+
+		spotlight := generator.generateSpotlight(testData)
+
+		require.NotNil(t, spotlight)
+		assert.Equal(t, "user1", spotlight.UserID)
+	})
+
+	t.Run("Does Not Exclude Names Containing Bot", func(t *testing.T) {
+		assert.False(t, generator.isBotOrUnassigned(UserMetrics{UserID: "abbott", DisplayName: "Jane Abbott"}))
+		assert.True(t, generator.isBotOrUnassigned(UserMetrics{UserID: "release.bot", DisplayName: "Release"}))
+	})
+
+	t.Run("No Qualifying Users", func(t *testing.T) {
+		testData := &ProcessingResult{
+			UserMetrics: map[string]UserMetrics{
+				"user2": createTestProcessingResult().UserMetrics["user2"],
+			},
+		}
+
+		assert.Nil(t, generator.generateSpotlight(testData))
+	})
+}
+
+func TestSummaryGenerator_NoCompletionFallback(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	data := createTestProcessingResult()
+	data.Summary.CompletionRate = 0
+	data.StatusBreakdown = map[string]StatusMetrics{
+		"In Progress": {Status: "In Progress", Count: 2},
+		"In Review":   {Status: "In Review", Count: 1},
+		"Blocked":     {Status: "Blocked", Count: 1},
+	}
+	request := SummaryRequest{Title: "Weekly", Period: "weekly", Format: FormatExecutive}
+
+	response, err := generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+
+	assert.True(t, response.FallbackUsed)
+	summary := response.ExecutiveSummary
+	assert.Contains(t, summary, "no items reached a completed state yet, but the team kept 4 activities moving")
+	assert.Contains(t, summary, "Work in flight currently stands at 2 In Progress, 1 In Review.")
+	assert.Contains(t, summary, "1 item is blocked or waiting")
+	assert.Contains(t, summary, "3 high-priority items are still open")
+	assert.NotContains(t, summary, "completion rate")
+
+	// The fallback is deterministic
+	again, err := generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	assert.Equal(t, summary, again.ExecutiveSummary)
+
+	// Without blocked items the summary points at work moving through review
+	delete(data.StatusBreakdown, "Blocked")
+	response, err = generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	assert.Contains(t, response.ExecutiveSummary, "No items are flagged as blocked")
+
+	// Without a status breakdown nothing is known about blocked items
+	statuses := data.StatusBreakdown
+	data.StatusBreakdown = map[string]StatusMetrics{}
+	response, err = generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	assert.True(t, response.FallbackUsed)
+	assert.NotContains(t, response.ExecutiveSummary, "blocked")
+	data.StatusBreakdown = statuses
+
+	// Disabling the fallback restores the standard narrative
+	generator.SetNoCompletionFallback(false)
+	response, err = generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	assert.False(t, response.FallbackUsed)
+	assert.Contains(t, response.ExecutiveSummary, "completion rate")
+}
+
+func TestSummaryGenerator_NoCompletionFallback_Language(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	data := createTestProcessingResult()
+	data.Summary.CompletionRate = 0
+	data.StatusBreakdown = map[string]StatusMetrics{
+		"In Progress": {Status: "In Progress", Count: 2},
+		"Blocked":     {Status: "Blocked", Count: 1},
+	}
+	request := SummaryRequest{Title: "Semanal", Period: "weekly", Format: FormatExecutive, Language: "es-MX"}
+
+	response, err := generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	require.True(t, response.FallbackUsed)
+
+	summary := response.ExecutiveSummary
+	assert.True(t, strings.HasPrefix(summary, "Durante el periodo semanal ("))
+	assert.Contains(t, summary, "el equipo mantuvo 4 actividades en marcha")
+	assert.Contains(t, summary, "El trabajo en curso se sitúa actualmente en 2 In Progress.")
+	assert.Contains(t, summary, "1 elemento está bloqueado o en espera")
+	assert.Contains(t, summary, "3 elementos de alta prioridad siguen abiertos")
+	assert.NotContains(t, summary, "blocked or waiting")
+
+	// Languages without a catalog use English
+	request.Language = "ja"
+	response, err = generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	assert.Contains(t, response.ExecutiveSummary, "During the weekly period")
+}
+
+func TestLanguageName(t *testing.T) {
+	assert.Equal(t, "en", BaseLanguage(""))
+	assert.Equal(t, "de", BaseLanguage(" DE_at "))
+	assert.Equal(t, "Spanish", LanguageName("es-MX"))
+	assert.Equal(t, "French", LanguageName("FR"))
+	assert.Equal(t, "pt-BR", LanguageName("pt-BR"))
+}
+
+func TestMessageCatalogs(t *testing.T) {
+	// Every language translates every message, with the same arguments as English
+	for language, catalog := range messageCatalogs {
+		for key, text := range messageCatalogs[DefaultLanguage] {
+			translated, ok := catalog[key]
+			if assert.True(t, ok, "%s is missing %s", language, key) {
+				assert.Equal(t, strings.Count(text, "%"), strings.Count(translated, "%"), "%s %s", language, key)
+			}
+		}
+	}
+
+	assert.Equal(t, "Investigar las causas de las tendencias de rendimiento a la baja", message("es-MX", msgRecommendRootCauses))
+	assert.Equal(t, "Un excellent taux d'achèvement de 85.0% démontre une forte capacité d'exécution",
+		messagef("FR", msgHighlightCompletionRate, "85.0%"))
+	assert.Equal(t, "Investigate root causes of declining performance trends", message("ja", msgRecommendRootCauses))
+	assert.Equal(t, message("", msgRecommendRootCauses), message("ja", msgRecommendRootCauses))
+}
+
+func TestSummaryGenerator_Language(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	data := createTestProcessingResult()
+	data.Summary.CompletionRate = 85.0
+	data.TrendAnalysis = &TrendAnalysis{OverallTrend: "decreasing"}
+	request := SummaryRequest{Title: "Semanal", Period: "weekly", Format: FormatExecutive, Language: "es-MX"}
+
+	response, err := generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	assert.Contains(t, response.Highlights, "Una excelente tasa de finalización del 85.0% demuestra una gran capacidad de ejecución")
+	assert.Contains(t, response.Concerns, "La tendencia a la baja en el rendimiento general del equipo requiere investigación")
+	assert.Contains(t, response.Recommendations, "Investigar las causas de las tendencias de rendimiento a la baja")
+
+	// English is the default
+	request.Language = ""
+	response, err = generator.GenerateSummary(context.Background(), data, request)
+	require.NoError(t, err)
+	assert.Contains(t, response.Highlights, "Excellent completion rate of 85.0% demonstrates strong execution capability")
+	assert.Contains(t, response.Recommendations, "Investigate root causes of declining performance trends")
+}
+
+func TestSummaryGenerator_NoCompletionFallback_NotUsedWithCompletions(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	response, err := generator.GenerateSummary(context.Background(), createTestProcessingResult(), SummaryRequest{Period: "weekly"})
+	require.NoError(t, err)
+	assert.False(t, response.FallbackUsed)
+
+	// An empty period has nothing in flight either, so the standard summary applies
+	response, err = generator.GenerateSummary(context.Background(), &ProcessingResult{}, SummaryRequest{Period: "weekly"})
+	require.NoError(t, err)
+	assert.False(t, response.FallbackUsed)
+}
+
+func TestSummaryGenerator_GenerateOwnedRecommendations(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+
+	testData := createTestProcessingResult()
+	testData.Summary.CompletionRate = 50.0
+	testData.PriorityBreakdown["High"] = PriorityMetrics{Priority: "High", Count: 3, CompletionRate: 30.0}
+	testData.UserMetrics["user2"] = UserMetrics{
+		UserID:               "user2",
+		DisplayName:          "User Two",
+		TotalActivities:      2,
+		PriorityDistribution: map[string]int{"High": 4},
+	}
+
+	owners := func(owned []OwnedRecommendation) map[string]string {
+		result := make(map[string]string)
+		for _, rec := range owned {
+			result[rec.Text] = rec.Owner
+		}
+		return result
+	}
+
+	t.Run("Defaults to the most active user", func(t *testing.T) {
+		owned := owners(generator.generateOwnedRecommendations(testData, "", ""))
+
+		assert.Equal(t, "User One", owned["Implement daily standups and sprint reviews to improve task completion tracking"])
+		assert.Equal(t, "User One", owned["Recognize and celebrate high performers to maintain team motivation"])
+
+		// High-priority work goes to whoever carries the most of it
+		assert.Equal(t, "User Two", owned["Prioritize high-priority items and consider resource reallocation"])
+
+		// General guidance without a single owner is left out
+		_, exists := owned["Continue monitoring key metrics and adjust strategies based on performance data"]
+		assert.False(t, exists)
+	})
+
+	t.Run("Configured lead owns team-wide changes", func(t *testing.T) {
+		owned := owners(generator.generateOwnedRecommendations(testData, "Team Lead", ""))
+
+		assert.Equal(t, "Team Lead", owned["Implement daily standups and sprint reviews to improve task completion tracking"])
+		assert.Equal(t, "Team Lead", owned["Review and refine prioritization process to ensure critical work gets adequate attention"])
+		assert.Equal(t, "User Two", owned["Prioritize high-priority items and consider resource reallocation"])
+	})
+
+	t.Run("Bots are never assigned", func(t *testing.T) {
+		botData := createTestProcessingResult()
+		botData.Summary.MostActiveUser = "jira-bot"
+		botData.UserMetrics = map[string]UserMetrics{
+			"jira-bot": {UserID: "jira-bot", DisplayName: "Jira Bot", TotalActivities: 50},
+			"user3":    {UserID: "user3", DisplayName: "User Three", TotalActivities: 2},
+		}
+
+		for _, rec := range generator.generateOwnedRecommendations(botData, "", "") {
+			assert.Equal(t, "User Three", rec.Owner, rec.Text)
+		}
+	})
+
+	t.Run("Unassigned when nobody is available", func(t *testing.T) {
+		emptyData := &ProcessingResult{}
+
+		owned := generator.generateOwnedRecommendations(emptyData, "", "")
+		require.NotEmpty(t, owned)
+		for _, rec := range owned {
+			assert.Equal(t, UnassignedOwner, rec.Owner)
+		}
+	})
+
+	t.Run("Only included when requested", func(t *testing.T) {
+		response, err := generator.GenerateSummary(context.Background(), testData, SummaryRequest{Period: "weekly"})
+		require.NoError(t, err)
+		assert.Empty(t, response.OwnedRecommendations)
+
+		response, err = generator.GenerateSummary(context.Background(), testData, SummaryRequest{
+			Period:       "weekly",
+			AssignOwners: true,
+			TeamLead:     "Team Lead",
+		})
+		require.NoError(t, err)
+		assert.NotEmpty(t, response.OwnedRecommendations)
+		assert.Less(t, len(response.OwnedRecommendations), len(response.Recommendations))
+	})
+}
+
+func TestSummaryGenerator_IncludeRawData(t *testing.T) {
+	logger := utils.NewMockLogger()
+	generator := NewSummaryGenerator(logger)
+	data := createTestProcessingResult()
+
+	include := true
+	exclude := false
+
+	tests := []struct {
+		name     string
+		format   SummaryFormat
+		override *bool
+		expected bool
+	}{
+		{"detailed default", FormatDetailed, nil, true},
+		{"executive default", FormatExecutive, nil, false},
+		{"executive with override", FormatExecutive, &include, true},
+		{"detailed with override", FormatDetailed, &exclude, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := generator.GenerateSummary(context.Background(), data, SummaryRequest{
+				Period:         "weekly",
+				Format:         tt.format,
+				IncludeRawData: tt.override,
+			})
+			require.NoError(t, err)
+
+			if tt.expected {
+				assert.Same(t, data, response.RawData)
+			} else {
+				assert.Nil(t, response.RawData)
+			}
+		})
+	}
+}
+
 func TestSummaryGenerator_GenerateTrendAnalysis(t *testing.T) {
 	logger := utils.NewMockLogger()
 	generator := NewSummaryGenerator(logger)
@@ -338,6 +820,31 @@ func TestSummaryGenerator_GenerateCustomSection(t *testing.T) {
 		assert.Contains(t, content, "In Progress")
 	})
 
+	t.Run("Type Breakdown", func(t *testing.T) {
+		typeData := createTestProcessingResult()
+		typeData.TypeBreakdown = map[string]TypeMetrics{
+			"Bug":   {Type: "Bug", Count: 2, TotalTimeSpent: 7200, CompletedCount: 2, CompletionRate: 100.0, Share: 50.0},
+			"Story": {Type: "Story", Count: 1, TotalTimeSpent: 10800, CompletedCount: 0, CompletionRate: 0.0, Share: 25.0},
+			"Task":  {Type: "Task", Count: 1, TotalTimeSpent: 3600, CompletedCount: 1, CompletionRate: 100.0, Share: 25.0},
+		}
+
+		content := generator.generateCustomSection(typeData, "type_breakdown")
+
+		assert.Contains(t, content, "Issue Type Distribution")
+		assert.Contains(t, content, "- Bug: 2 items (50.0% of work, 100.0% completion rate")
+		assert.Contains(t, content, "- Story: 1 items (25.0% of work, 0.0% completion rate")
+
+		// Ordered by count, then name
+		assert.Less(t, strings.Index(content, "Bug"), strings.Index(content, "Story"))
+		assert.Less(t, strings.Index(content, "Story"), strings.Index(content, "Task"))
+	})
+
+	t.Run("Type Breakdown Empty", func(t *testing.T) {
+		content := generator.generateCustomSection(testData, "type_breakdown")
+
+		assert.Contains(t, content, "not available")
+	})
+
 	t.Run("Velocity Analysis", func(t *testing.T) {
 		content := generator.generateCustomSection(testData, "velocity_analysis")
 
@@ -416,31 +923,17 @@ func TestSummaryGenerator_HelperMethods(t *testing.T) {
 	t.Run("HasWorkloadImbalance", func(t *testing.T) {
 		testData := createTestProcessingResult()
 		
-		// Create balanced workload
-		balancedUserMetrics := make(map[string]UserMetrics)
-		for k, v := range testData.UserMetrics {
-			balancedUserMetrics[k] = v
-		}
-		user1 := balancedUserMetrics["user1"]
-		user1.TotalTimeSpent = 10000
-		balancedUserMetrics["user1"] = user1
-		user2 := balancedUserMetrics["user2"]
-		user2.TotalTimeSpent = 12000
-		balancedUserMetrics["user2"] = user2
-		assert.False(t, generator.hasWorkloadImbalance(balancedUserMetrics))
-
-		// Create imbalanced workload
-		imbalancedUserMetrics := make(map[string]UserMetrics)
-		for k, v := range testData.UserMetrics {
-			imbalancedUserMetrics[k] = v
-		}
-		user1 = imbalancedUserMetrics["user1"]
-		user1.TotalTimeSpent = 20000
-		imbalancedUserMetrics["user1"] = user1
-		user2 = imbalancedUserMetrics["user2"]
-		user2.TotalTimeSpent = 5000
-		imbalancedUserMetrics["user2"] = user2
-		assert.True(t, generator.hasWorkloadImbalance(imbalancedUserMetrics))
+		// Balanced workload
+		testData.Summary.WorkloadBalance = 0.05
+		assert.False(t, generator.hasWorkloadImbalance(testData.Summary))
+		
+		// Imbalanced workload
+		testData.Summary.WorkloadBalance = 0.3
+		assert.True(t, generator.hasWorkloadImbalance(testData.Summary))
+
+		// The concern says how uneven the workload is
+		assert.Contains(t, generator.generateConcerns(testData, ""),
+			"Uneven workload distribution (Gini coefficient 0.30) may lead to burnout and reduced efficiency")
 	})
 
 	t.Run("HasHighPriorityBacklog", func(t *testing.T) {
@@ -622,4 +1115,28 @@ func containsSubstring(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+func TestSummaryResponse_MetricRows(t *testing.T) {
+	summary := &SummaryResponse{KeyMetrics: SummaryKeyMetrics{
+		TotalActivities:     10,
+		CompletedActivities: 4,
+		CompletionRate:      40,
+		TotalTimeSpent:      "12h",
+		TopPriority:         "High",
+	}}
+
+	rows := summary.MetricRows()
+	require.Len(t, rows, 9)
+	assert.Equal(t, MetricRow{Name: "Total activities", Value: "10"}, rows[0])
+	assert.Equal(t, MetricRow{Name: "Completion rate", Value: "40.0%"}, rows[2])
+	assert.Equal(t, MetricRow{Name: "Top priority", Value: "High"}, rows[7])
+}
+
+func TestSummaryResponse_PublishedRecommendations(t *testing.T) {
+	summary := &SummaryResponse{Recommendations: []string{"Review the backlog"}}
+	assert.Equal(t, []string{"Review the backlog"}, summary.PublishedRecommendations())
+
+	// Owners are named once they were assigned
+	summary.OwnedRecommendations = []OwnedRecommendation{{Text: "Review the backlog", Owner: "Alice"}}
+	assert.Equal(t, []string{"Review the backlog (Owner: Alice)"}, summary.PublishedRecommendations())
+}
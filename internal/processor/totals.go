@@ -0,0 +1,219 @@
+package processor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/company/eesa/pkg/models"
+)
+
+// recentChangeWindow is how far back an update or comment counts as a recent status change
+const recentChangeWindow = 7 * 24 * time.Hour
+
+// topIssueTimeSpent is the time spent, in seconds, from which an activity is one of a user's top issues
+const topIssueTimeSpent = 7200
+
+// activityTotals accumulates the figures behind the user, priority, status and type metrics one
+// activity at a time, so a bucket's metrics can be calculated without keeping its activities
+type activityTotals struct {
+	dp *DataProcessor
+	
+	count          int
+	completed      int
+	timeSpent      int64
+	completedTime  int64
+	timePerTask    []float64
+	leadTimes      []float64
+	cycleTimes     []float64
+	rework         reworkTally
+	points         pointSummary
+	stale          int
+	displayName    string
+	priorities     map[string]int
+	statuses       map[string]int
+	users          map[string]bool
+	topIssues      []string
+	
+	// Updates after recentCutoff count as recent changes, as do comments when countComments is set
+	recentCutoff  time.Time
+	countComments bool
+	recentChanges int
+}
+
+// newActivityTotals creates empty totals counting recent changes from recentCutoff
+func (dp *DataProcessor) newActivityTotals(recentCutoff time.Time, countComments bool) *activityTotals {
+	return &activityTotals{
+		dp:            dp,
+		priorities:    make(map[string]int),
+		statuses:      make(map[string]int),
+		users:         make(map[string]bool),
+		topIssues:     make([]string, 0),
+		recentCutoff:  recentCutoff,
+		countComments: countComments,
+	}
+}
+
+// addActivityTotals adds activity to the totals of the bucket named key, creating them on first use
+func (dp *DataProcessor) addActivityTotals(buckets map[string]*activityTotals, key string, activity models.Activity, recentCutoff time.Time, countComments bool) {
+	totals, ok := buckets[key]
+	if !ok {
+		totals = dp.newActivityTotals(recentCutoff, countComments)
+		buckets[key] = totals
+	}
+	totals.add(activity)
+}
+
+// add folds a single activity into the totals
+func (t *activityTotals) add(activity models.Activity) {
+	dp := t.dp
+	
+	if t.count == 0 {
+		t.displayName = activity.Assignee.DisplayName
+	}
+	t.count++
+	t.timeSpent += activity.TimeSpent
+	t.timePerTask = append(t.timePerTask, float64(activity.TimeSpent))
+	t.priorities[activity.Priority]++
+	t.statuses[activity.Status]++
+	t.users[activity.Assignee.AccountID] = true
+	
+	if dp.isCompleted(activity.Status) {
+		t.completed++
+		// Time spent stands in for the time to complete
+		t.completedTime += activity.TimeSpent
+	}
+	
+	if activity.TimeSpent >= topIssueTimeSpent {
+		t.topIssues = append(t.topIssues, activity.Key)
+	}
+	
+	if lead, ok := dp.leadTime(activity); ok {
+		t.leadTimes = append(t.leadTimes, lead)
+	}
+	if cycle, ok := dp.cycleTime(activity); ok {
+		t.cycleTimes = append(t.cycleTimes, cycle)
+	}
+	dp.tallyRework(&t.rework, activity)
+	dp.tallyPoints(&t.points, activity)
+	if dp.isStale(activity) {
+		t.stale++
+	}
+	
+	if activity.Updated.After(t.recentCutoff) {
+		t.recentChanges++
+	}
+	if t.countComments {
+		for _, comment := range activity.Comments {
+			if comment.Created.After(t.recentCutoff) {
+				t.recentChanges++
+			}
+		}
+	}
+}
+
+// completionRate returns the percentage of added activities that are completed
+func (t *activityTotals) completionRate() float64 {
+	if t.count == 0 {
+		return 0
+	}
+	return float64(t.completed) / float64(t.count) * 100
+}
+
+// userMetrics returns the totals as the metrics of the user userID
+func (t *activityTotals) userMetrics(userID string) UserMetrics {
+	if t.count == 0 {
+		return UserMetrics{UserID: userID}
+	}
+	
+	dp := t.dp
+	rework := t.rework.summary()
+	
+	return UserMetrics{
+		UserID:               userID,
+		DisplayName:          t.displayName,
+		TotalActivities:      t.count,
+		CompletedActivities:  t.completed,
+		TotalTimeSpent:       t.timeSpent,
+		AverageTimePerTask:   t.timeSpent / int64(t.count),
+		MedianTimePerTask:    int64(dp.median(t.timePerTask)),
+		P90TimePerTask:       int64(dp.percentile(t.timePerTask, 90)),
+		P95TimePerTask:       int64(dp.percentile(t.timePerTask, 95)),
+		StdDevTimePerTask:    int64(dp.stddev(t.timePerTask)),
+		PriorityDistribution: t.priorities,
+		StatusDistribution:   t.statuses,
+		CompletionRate:       t.completionRate(),
+		TopIssues:            t.topIssues,
+		CycleTime:            dp.durationStats(t.cycleTimes),
+		LeadTime:             dp.durationStats(t.leadTimes),
+		ReworkRate:           rework.rate,
+		ReopenCount:          rework.reopens,
+		ReworkAvailable:      rework.available,
+		PlannedPoints:        t.points.planned,
+		CompletedPoints:      t.points.completed,
+		PointCompletionRate:  t.points.completionRate(),
+		UnestimatedActivities: t.points.unestimated,
+		StaleActivities:      t.stale,
+	}
+}
+
+// priorityMetrics returns the totals as the metrics of the priority level priority
+func (t *activityTotals) priorityMetrics(priority string) PriorityMetrics {
+	if t.count == 0 {
+		return PriorityMetrics{Priority: priority}
+	}
+	
+	averageTimeToComplete := int64(0)
+	if t.completed > 0 {
+		averageTimeToComplete = t.completedTime / int64(t.completed)
+	}
+	
+	return PriorityMetrics{
+		Priority:              priority,
+		Count:                 t.count,
+		TotalTimeSpent:        t.timeSpent,
+		CompletedCount:        t.completed,
+		CompletionRate:        t.completionRate(),
+		AverageTimeToComplete: averageTimeToComplete,
+		CycleTime:             t.dp.durationStats(t.cycleTimes),
+		LeadTime:              t.dp.durationStats(t.leadTimes),
+		PlannedPoints:         t.points.planned,
+		CompletedPoints:       t.points.completed,
+		PointCompletionRate:   t.points.completionRate(),
+	}
+}
+
+// statusMetrics returns the totals as the metrics of the status status
+func (t *activityTotals) statusMetrics(status string) StatusMetrics {
+	if t.count == 0 {
+		return StatusMetrics{Status: status}
+	}
+	
+	users := make([]string, 0, len(t.users))
+	for userID := range t.users {
+		users = append(users, userID)
+	}
+	sort.Strings(users)
+	
+	return StatusMetrics{
+		Status:         status,
+		Count:          t.count,
+		TotalTimeSpent: t.timeSpent,
+		Users:          users,
+		RecentChanges:  t.recentChanges,
+	}
+}
+
+// typeMetrics returns the totals as the metrics of the issue type issueType
+func (t *activityTotals) typeMetrics(issueType string) TypeMetrics {
+	if t.count == 0 {
+		return TypeMetrics{Type: issueType}
+	}
+	
+	return TypeMetrics{
+		Type:           issueType,
+		Count:          t.count,
+		TotalTimeSpent: t.timeSpent,
+		CompletedCount: t.completed,
+		CompletionRate: t.completionRate(),
+	}
+}
@@ -0,0 +1,193 @@
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// maxScheduleSearch bounds the search for the next matching time, so a spec that can never
+// match, such as 30 February, does not loop forever
+const maxScheduleSearch = 5 * 366 * 24 * time.Hour
+
+// cronField describes the allowed values of one field of a cron spec
+type cronField struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int // Symbolic values such as MON or JAN
+}
+
+var (
+	minuteField = cronField{name: "minute", min: 0, max: 59}
+	hourField   = cronField{name: "hour", min: 0, max: 23}
+	dayField    = cronField{name: "day of month", min: 1, max: 31}
+	monthField  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}}
+	// Day of week 7 is accepted as a second spelling of Sunday
+	weekdayField = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"SUN": 0, "MON": 1, "TUE": 2, "WED": 3, "THU": 4, "FRI": 5, "SAT": 6,
+	}}
+)
+
+// Schedule is a parsed five-field cron spec: minute, hour, day of month, month and day of
+// week. Fields accept *, numbers, month and weekday names, ranges (1-5), lists (1,15) and
+// steps (*/15). As in cron, when both day fields are restricted a time matches either of them.
+type Schedule struct {
+	spec       string
+	minutes    []bool
+	hours      []bool
+	days       []bool
+	months     []bool
+	weekdays   []bool
+	anyDay     bool // Day of month is *
+	anyWeekday bool // Day of week is *
+}
+
+// ParseSpec parses a five-field cron spec such as "0 9 * * MON"
+func ParseSpec(spec string) (*Schedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, invalidSpecError(spec, fmt.Sprintf("expected 5 fields, got %d", len(fields)))
+	}
+	
+	schedule := &Schedule{
+		spec:       spec,
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	
+	var err error
+	if schedule.minutes, err = minuteField.parse(spec, fields[0]); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = hourField.parse(spec, fields[1]); err != nil {
+		return nil, err
+	}
+	if schedule.days, err = dayField.parse(spec, fields[2]); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = monthField.parse(spec, fields[3]); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays, err = weekdayField.parse(spec, fields[4]); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays[7] {
+		schedule.weekdays[0] = true
+	}
+	
+	return schedule, nil
+}
+
+// String returns the spec the schedule was parsed from
+func (s *Schedule) String() string {
+	return s.spec
+}
+
+// Next returns the first matching minute strictly after t, in t's time zone. It returns the
+// zero time when nothing matches within five years.
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	next := t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(maxScheduleSearch)
+	
+	for next.Before(limit) {
+		switch {
+		case !s.months[next.Month()]:
+			next = time.Date(next.Year(), next.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.matchesDay(next):
+			next = time.Date(next.Year(), next.Month(), next.Day()+1, 0, 0, 0, 0, loc)
+		case !s.hours[next.Hour()]:
+			next = time.Date(next.Year(), next.Month(), next.Day(), next.Hour()+1, 0, 0, 0, loc)
+		case !s.minutes[next.Minute()]:
+			next = next.Add(time.Minute)
+		default:
+			return next
+		}
+	}
+	
+	return time.Time{}
+}
+
+// matchesDay applies the cron rule for the two day fields
+func (s *Schedule) matchesDay(t time.Time) bool {
+	day, weekday := s.days[t.Day()], s.weekdays[t.Weekday()]
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekday
+	case s.anyWeekday:
+		return day
+	default:
+		return day || weekday
+	}
+}
+
+// parse returns the set of values a field spec selects, indexed by value
+func (f cronField) parse(spec, field string) ([]bool, error) {
+	values := make([]bool, f.max+1)
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			parsed, err := strconv.Atoi(part[i+1:])
+			if err != nil || parsed <= 0 {
+				return nil, invalidSpecError(spec, fmt.Sprintf("invalid step %q in %s field", part[i+1:], f.name))
+			}
+			rangePart, step = part[:i], parsed
+		}
+		
+		low, high := f.min, f.max
+		if rangePart != "*" {
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if low, err = f.value(spec, bounds[0]); err != nil {
+				return nil, err
+			}
+			high = low
+			if len(bounds) == 2 {
+				if high, err = f.value(spec, bounds[1]); err != nil {
+					return nil, err
+				}
+			} else if step > 1 {
+				high = f.max // "5/15" runs from 5 to the end of the range
+			}
+			if low > high {
+				return nil, invalidSpecError(spec, fmt.Sprintf("range %q in %s field runs backwards", rangePart, f.name))
+			}
+		}
+		
+		for value := low; value <= high; value += step {
+			values[value] = true
+		}
+	}
+	
+	return values, nil
+}
+
+// value parses a single number or name of the field
+func (f cronField) value(spec, text string) (int, error) {
+	if value, ok := f.names[strings.ToUpper(text)]; ok {
+		return value, nil
+	}
+	
+	value, err := strconv.Atoi(text)
+	if err != nil || value < f.min || value > f.max {
+		return 0, invalidSpecError(spec, fmt.Sprintf("invalid %s %q, expected %d-%d", f.name, text, f.min, f.max))
+	}
+	return value, nil
+}
+
+// invalidSpecError reports a cron spec that cannot be parsed
+func invalidSpecError(spec, reason string) error {
+	return utils.NewAppError(utils.ErrorCodeValidationError,
+		fmt.Sprintf("Invalid schedule %q: %s", spec, reason), nil).
+		WithOperation("parse_schedule").
+		WithExtra("schedule", spec)
+}
@@ -0,0 +1,77 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday 6 March 2024, 10:17:30
+	from := time.Date(2024, 3, 6, 10, 17, 30, 0, time.UTC)
+	
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{spec: "* * * * *", expected: time.Date(2024, 3, 6, 10, 18, 0, 0, time.UTC)},
+		{spec: "*/15 * * * *", expected: time.Date(2024, 3, 6, 10, 30, 0, 0, time.UTC)},
+		{spec: "0 9 * * MON", expected: time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC)},
+		{spec: "0 9 * * 1-5", expected: time.Date(2024, 3, 7, 9, 0, 0, 0, time.UTC)},
+		{spec: "30 10 * * wed", expected: time.Date(2024, 3, 6, 10, 30, 0, 0, time.UTC)},
+		{spec: "0 0 1 * *", expected: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 12 29 FEB *", expected: time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{spec: "0 8 1,15 * *", expected: time.Date(2024, 3, 15, 8, 0, 0, 0, time.UTC)},
+		{spec: "0 0 * * 7", expected: time.Date(2024, 3, 10, 0, 0, 0, 0, time.UTC)},
+		// With both day fields restricted either one matches
+		{spec: "0 6 20 * FRI", expected: time.Date(2024, 3, 8, 6, 0, 0, 0, time.UTC)},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			schedule, err := ParseSpec(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, schedule.Next(from))
+		})
+	}
+}
+
+func TestSchedule_Next_TimeZone(t *testing.T) {
+	schedule, err := ParseSpec("0 9 * * MON")
+	require.NoError(t, err)
+	
+	// Sunday evening in New York is already Monday in UTC
+	newYork := time.FixedZone("EST", -5*60*60)
+	next := schedule.Next(time.Date(2024, 3, 3, 20, 0, 0, 0, newYork))
+	assert.Equal(t, time.Date(2024, 3, 4, 9, 0, 0, 0, newYork), next)
+}
+
+func TestSchedule_Next_NeverMatches(t *testing.T) {
+	schedule, err := ParseSpec("0 0 30 FEB *")
+	require.NoError(t, err)
+	assert.True(t, schedule.Next(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)).IsZero())
+}
+
+func TestParseSpec_Errors(t *testing.T) {
+	specs := []string{
+		"",
+		"0 9 * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"* * * * FUNDAY",
+		"*/0 * * * *",
+		"10-5 * * * *",
+	}
+	
+	for _, spec := range specs {
+		t.Run(spec, func(t *testing.T) {
+			_, err := ParseSpec(spec)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Package scheduler runs a job on a recurring cron schedule, such as generating the weekly
+// summary every Monday morning.
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// Job is the work run on each scheduled tick
+type Job func(ctx context.Context) error
+
+// Clock tells the scheduler the time and when to wake up, so tests can replace the real clock
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// realClock is the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Scheduler runs a job whenever its schedule matches. A tick that arrives while the previous
+// run is still going is skipped rather than queued.
+type Scheduler struct {
+	schedule *Schedule
+	job      Job
+	clock    Clock
+	logger   utils.Logger
+	running  atomic.Bool
+	wg       sync.WaitGroup
+}
+
+// New creates a scheduler that runs job on the cron spec, evaluated in the local time zone
+func New(spec string, job Job, logger utils.Logger) (*Scheduler, error) {
+	schedule, err := ParseSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &Scheduler{
+		schedule: schedule,
+		job:      job,
+		clock:    realClock{},
+		logger:   logger,
+	}, nil
+}
+
+// SetClock replaces the clock. The schedule is evaluated in the time zone of the clock's times.
+func (s *Scheduler) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+// Run runs the job on schedule until ctx is done, then waits for a run in progress to finish.
+// Job errors are logged and do not stop the schedule.
+func (s *Scheduler) Run(ctx context.Context) error {
+	defer s.wg.Wait()
+	
+	for {
+		now := s.clock.Now()
+		next := s.schedule.Next(now)
+		if next.IsZero() {
+			return utils.NewAppError(utils.ErrorCodeValidationError, "Schedule never matches", nil).
+				WithOperation("run_schedule").
+				WithExtra("schedule", s.schedule.String())
+		}
+		
+		s.logger.Debug("Waiting for next scheduled run",
+			utils.NewField("schedule", s.schedule.String()),
+			utils.NewField("next_run", next),
+		)
+		
+		select {
+		case <-ctx.Done():
+			return nil
+		case firedAt := <-s.clock.After(next.Sub(now)):
+			s.start(ctx, firedAt)
+		}
+	}
+}
+
+// start runs the job in the background unless the previous run is still going
+func (s *Scheduler) start(ctx context.Context, firedAt time.Time) {
+	if !s.running.CompareAndSwap(false, true) {
+		s.logger.Warn("Previous scheduled run is still in progress, skipping this run",
+			utils.NewField("schedule", s.schedule.String()),
+			utils.NewField("fired_at", firedAt),
+		)
+		return
+	}
+	
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer s.running.Store(false)
+		
+		s.logger.Info("Starting scheduled run", utils.NewField("schedule", s.schedule.String()))
+		if err := s.job(ctx); err != nil {
+			s.logger.Error("Scheduled run failed", err, utils.NewField("schedule", s.schedule.String()))
+		}
+	}()
+}
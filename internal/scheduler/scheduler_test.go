@@ -0,0 +1,223 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a manually advanced clock that records when the scheduler wants to wake up
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+	waiting chan time.Time // Receives the deadline of every new waiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiting: make(chan time.Time, 16)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	ch := make(chan time.Time, 1)
+	deadline := c.now.Add(d)
+	c.waiters = append(c.waiters, fakeWaiter{deadline: deadline, ch: ch})
+	c.waiting <- deadline
+	return ch
+}
+
+// Set moves the clock to t and wakes every waiter whose deadline has passed
+func (c *fakeClock) Set(t time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	c.now = t
+	remaining := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(t) {
+			remaining = append(remaining, waiter)
+			continue
+		}
+		waiter.ch <- t
+	}
+	c.waiters = remaining
+}
+
+// nextWait returns the deadline of the scheduler's next wait
+func (c *fakeClock) nextWait(t *testing.T) time.Time {
+	t.Helper()
+	select {
+	case deadline := <-c.waiting:
+		return deadline
+	case <-time.After(time.Second):
+		require.FailNow(t, "scheduler did not wait for the next run")
+		return time.Time{}
+	}
+}
+
+// lockedLogger makes the mock logger safe to share with the scheduler's job goroutines
+type lockedLogger struct {
+	mu     sync.Mutex
+	logger *utils.MockLogger
+}
+
+func (l *lockedLogger) Debug(msg string, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Debug(msg, fields...)
+}
+
+func (l *lockedLogger) Info(msg string, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Info(msg, fields...)
+}
+
+func (l *lockedLogger) Warn(msg string, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Warn(msg, fields...)
+}
+
+func (l *lockedLogger) Error(msg string, err error, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Error(msg, err, fields...)
+}
+
+func (l *lockedLogger) count(message string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.logger.GetEntriesByMessage(message))
+}
+
+func TestNew_InvalidSpec(t *testing.T) {
+	_, err := New("every monday", func(ctx context.Context) error { return nil }, utils.NewMockLogger())
+	require.Error(t, err)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeValidationError, appErr.Code)
+}
+
+func TestScheduler_FiresAtScheduledTimes(t *testing.T) {
+	// Saturday afternoon, so the first run is the coming Monday
+	clock := newFakeClock(time.Date(2024, 3, 2, 15, 30, 0, 0, time.UTC))
+	ran := make(chan time.Time, 4)
+	
+	s, err := New("0 9 * * MON", func(ctx context.Context) error {
+		ran <- clock.Now()
+		return nil
+	}, &lockedLogger{logger: utils.NewMockLogger()})
+	require.NoError(t, err)
+	s.SetClock(clock)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+	
+	expected := []time.Time{
+		time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 11, 9, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 18, 9, 0, 0, 0, time.UTC),
+	}
+	for _, at := range expected {
+		require.Equal(t, at, clock.nextWait(t))
+		
+		// Nothing runs before the scheduled minute
+		clock.Set(at.Add(-time.Second))
+		select {
+		case <-ran:
+			require.FailNow(t, "job ran early")
+		default:
+		}
+		
+		clock.Set(at)
+		select {
+		case firedAt := <-ran:
+			assert.Equal(t, at, firedAt)
+		case <-time.After(time.Second):
+			require.FailNow(t, "job did not run")
+		}
+	}
+	
+	clock.nextWait(t)
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestScheduler_SkipsOverlappingRuns(t *testing.T) {
+	start := time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	clock := newFakeClock(start)
+	logger := &lockedLogger{logger: utils.NewMockLogger()}
+	started := make(chan struct{}, 4)
+	release := make(chan struct{})
+	
+	var mu sync.Mutex
+	runs := 0
+	s, err := New("* * * * *", func(ctx context.Context) error {
+		mu.Lock()
+		runs++
+		mu.Unlock()
+		started <- struct{}{}
+		<-release
+		return errors.New("run failed")
+	}, logger)
+	require.NoError(t, err)
+	s.SetClock(clock)
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+	
+	// The first tick starts a run that keeps going
+	clock.Set(clock.nextWait(t))
+	<-started
+	
+	// The next two ticks arrive while it is still running and are skipped
+	clock.Set(clock.nextWait(t))
+	clock.Set(clock.nextWait(t))
+	clock.nextWait(t)
+	assert.Equal(t, 2, logger.count("Previous scheduled run is still in progress, skipping this run"))
+	
+	// Once the run finishes the following tick runs again
+	release <- struct{}{}
+	require.Eventually(t, func() bool { return !s.running.Load() }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, logger.count("Scheduled run failed"))
+	clock.Set(start.Add(4 * time.Minute))
+	<-started
+	
+	mu.Lock()
+	assert.Equal(t, 2, runs)
+	mu.Unlock()
+	
+	// Shutdown waits for the run in progress
+	cancel()
+	select {
+	case <-done:
+		require.FailNow(t, "Run returned before the job finished")
+	case <-time.After(10 * time.Millisecond):
+	}
+	close(release)
+	require.NoError(t, <-done)
+}
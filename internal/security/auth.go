@@ -1,10 +1,16 @@
 package security
 
 import (
+	"context"
 	"crypto/tls"
+	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/company/eesa/internal/config"
 	"github.com/company/eesa/pkg/utils"
 )
 
@@ -13,17 +19,66 @@ type AuthConfig struct {
 	TLSMinVersion string `yaml:"tls_min_version"`
 	VerifySSL     bool   `yaml:"verify_ssl"`
 	Timeout       time.Duration
+	MaxResponseBytes int64 `yaml:"max_response_bytes"`
+	GoogleClientID   string // OAuth client the Google refresh token was issued to
+	Profile          string // Credential profile to use, empty for the default profile
+	
+	// Connection pooling, zero values fall back to the defaults
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+	KeepAlive           time.Duration
+	DisableKeepAlives   bool
 }
 
+// Default connection pooling settings for the shared HTTP transport
+const (
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+	DefaultKeepAlive           = 30 * time.Second
+)
+
 // DefaultAuthConfig returns default authentication configuration
 func DefaultAuthConfig() *AuthConfig {
 	return &AuthConfig{
 		TLSMinVersion: "1.3",
 		VerifySSL:     true,
 		Timeout:       30 * time.Second,
+		MaxResponseBytes: utils.DefaultMaxResponseBytes,
+		MaxIdleConns:        DefaultMaxIdleConns,
+		MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+		IdleConnTimeout:     DefaultIdleConnTimeout,
+		KeepAlive:           DefaultKeepAlive,
 	}
 }
 
+// NewAuthConfig builds the authentication configuration from application configuration
+func NewAuthConfig(cfg *config.Config) *AuthConfig {
+	authConfig := DefaultAuthConfig()
+	if cfg == nil {
+		return authConfig
+	}
+	
+	if cfg.Security.TLSMinVersion != "" {
+		authConfig.TLSMinVersion = cfg.Security.TLSMinVersion
+	}
+	// Only an explicit setting turns certificate verification off
+	if cfg.Security.VerifySSL != nil {
+		authConfig.VerifySSL = *cfg.Security.VerifySSL
+	}
+	authConfig.GoogleClientID = cfg.Google.ClientID
+	authConfig.Profile = cfg.Security.Profile
+	
+	authConfig.MaxIdleConns = cfg.HTTP.MaxIdleConns
+	authConfig.MaxIdleConnsPerHost = cfg.HTTP.MaxIdleConnsPerHost
+	authConfig.IdleConnTimeout = cfg.HTTP.IdleConnTimeout
+	authConfig.KeepAlive = cfg.HTTP.KeepAlive
+	authConfig.DisableKeepAlives = cfg.HTTP.DisableKeepAlives
+	
+	return authConfig
+}
+
 // AuthenticatedHTTPClient provides a secure HTTP client with authentication
 type AuthenticatedHTTPClient struct {
 	httpClient *http.Client
@@ -48,12 +103,22 @@ func NewAuthenticatedHTTPClient(config *AuthConfig, logger utils.Logger) *Authen
 		},
 	}
 	
-	// Create transport with security settings
+	// Create transport with security and connection pooling settings
+	keepAlive := durationOrDefault(config.KeepAlive, DefaultKeepAlive)
+	if config.DisableKeepAlives {
+		keepAlive = -1 // Disables TCP keep-alive probes
+	}
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: keepAlive,
+	}
 	transport := &http.Transport{
+		DialContext:         dialer.DialContext,
 		TLSClientConfig:     tlsConfig,
-		MaxIdleConns:        100,
-		MaxIdleConnsPerHost: 10,
-		IdleConnTimeout:     90 * time.Second,
+		MaxIdleConns:        intOrDefault(config.MaxIdleConns, DefaultMaxIdleConns),
+		MaxIdleConnsPerHost: intOrDefault(config.MaxIdleConnsPerHost, DefaultMaxIdleConnsPerHost),
+		IdleConnTimeout:     durationOrDefault(config.IdleConnTimeout, DefaultIdleConnTimeout),
+		DisableKeepAlives:   config.DisableKeepAlives,
 		TLSHandshakeTimeout: 10 * time.Second,
 	}
 	
@@ -130,6 +195,22 @@ func (c *AuthenticatedHTTPClient) DoRequest(req *http.Request) (*http.Response,
 	return resp, nil
 }
 
+// ReadBody reads a response body, enforcing the configured size cap
+func (c *AuthenticatedHTTPClient) ReadBody(resp *http.Response) ([]byte, error) {
+	body, err := utils.ReadBodyLimited(resp, c.config.MaxResponseBytes)
+	if err != nil {
+		if appErr, ok := err.(*utils.AppError); ok && appErr.IsType(utils.ErrorCodeResponseTooLarge) {
+			c.logger.Warn("Response body exceeded size limit",
+				utils.NewField("status_code", resp.StatusCode),
+				utils.NewField("max_bytes", c.config.MaxResponseBytes),
+			)
+		}
+		return body, err
+	}
+	
+	return body, nil
+}
+
 // parseTLSVersion parses TLS version string to tls constant
 func parseTLSVersion(version string) uint16 {
 	switch version {
@@ -146,6 +227,22 @@ func parseTLSVersion(version string) uint16 {
 	}
 }
 
+// intOrDefault returns value, or fallback when value is not positive
+func intOrDefault(value, fallback int) int {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// durationOrDefault returns value, or fallback when value is not positive
+func durationOrDefault(value, fallback time.Duration) time.Duration {
+	if value <= 0 {
+		return fallback
+	}
+	return value
+}
+
 // JiraAuthenticator handles Jira authentication
 type JiraAuthenticator struct {
 	httpClient *AuthenticatedHTTPClient
@@ -220,6 +317,43 @@ func (j *JiraAuthenticator) ValidateCredentials(baseURL, username string) error
 	return nil
 }
 
+// ConfluenceAuthenticator handles Confluence authentication
+type ConfluenceAuthenticator struct {
+	creds  *CredentialStore
+	logger utils.Logger
+}
+
+// NewConfluenceAuthenticator creates a new Confluence authenticator
+func NewConfluenceAuthenticator(creds *CredentialStore, logger utils.Logger) *ConfluenceAuthenticator {
+	return &ConfluenceAuthenticator{
+		creds:  creds,
+		logger: logger,
+	}
+}
+
+// AddAuthHeaders adds Confluence authentication headers to a request. Confluence Cloud takes the
+// account email and API token as Basic Auth; with no username the token is sent as a Data Center
+// personal access token.
+func (a *ConfluenceAuthenticator) AddAuthHeaders(req *http.Request, username string) error {
+	creds, err := a.creds.GetConfluenceCredentials()
+	if err != nil {
+		return utils.WrapError(err, utils.ErrorCodeAuthFailed, "Failed to get Confluence credentials")
+	}
+	
+	if username != "" {
+		req.SetBasicAuth(username, creds.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+creds.Token)
+	}
+	
+	a.logger.Debug("Added Confluence authentication headers",
+		utils.NewField("username", username),
+		utils.NewField("url", req.URL.String()),
+	)
+	
+	return nil
+}
+
 // GeminiAuthenticator handles Google Gemini authentication
 type GeminiAuthenticator struct {
 	httpClient *AuthenticatedHTTPClient
@@ -290,11 +424,23 @@ func (g *GeminiAuthenticator) ValidateCredentials() error {
 	return nil
 }
 
-// GoogleAuthenticator handles Google API authentication
+// GoogleTokenURL is the OAuth endpoint that exchanges a refresh token for an access token
+const GoogleTokenURL = "https://oauth2.googleapis.com/token"
+
+// tokenExpiryMargin refreshes access tokens this long before they expire, so a token does not
+// lapse while a request is in flight
+const tokenExpiryMargin = time.Minute
+
+// GoogleAuthenticator handles Google API authentication. Expired access tokens are refreshed
+// with the stored refresh token and the new token is saved back to the credential store.
 type GoogleAuthenticator struct {
 	httpClient *AuthenticatedHTTPClient
 	creds      *CredentialStore
 	logger     utils.Logger
+	clientID   string
+	tokenURL   string
+	now        func() time.Time
+	refreshMu  sync.Mutex // Serializes refreshes so concurrent requests refresh only once
 }
 
 // NewGoogleAuthenticator creates a new Google authenticator
@@ -303,10 +449,24 @@ func NewGoogleAuthenticator(httpClient *AuthenticatedHTTPClient, creds *Credenti
 		httpClient: httpClient,
 		creds:      creds,
 		logger:     logger,
+		tokenURL:   GoogleTokenURL,
+		now:        time.Now,
 	}
 }
 
-// AddAuthHeaders adds Google authentication headers to a request
+// SetClientID sets the OAuth client ID sent with refresh requests. Without one, expired access
+// tokens cannot be refreshed.
+func (g *GoogleAuthenticator) SetClientID(clientID string) {
+	g.clientID = clientID
+}
+
+// SetTokenURL overrides the OAuth token endpoint
+func (g *GoogleAuthenticator) SetTokenURL(tokenURL string) {
+	g.tokenURL = tokenURL
+}
+
+// AddAuthHeaders adds Google authentication headers to a request, first refreshing the access
+// token when it has expired and a refresh token is stored
 func (g *GoogleAuthenticator) AddAuthHeaders(req *http.Request) error {
 	// Get Google credentials
 	creds, err := g.creds.GetGoogleCredentials()
@@ -314,6 +474,14 @@ func (g *GoogleAuthenticator) AddAuthHeaders(req *http.Request) error {
 		return utils.WrapError(err, utils.ErrorCodeAuthFailed, "Failed to get Google credentials")
 	}
 	
+	if g.expired(creds) {
+		token, err := g.refresh(creds.AccessToken)
+		if err != nil {
+			return err
+		}
+		creds.AccessToken = token
+	}
+	
 	// Add access token header
 	if creds.AccessToken != "" {
 		req.Header.Set("Authorization", "Bearer "+creds.AccessToken)
@@ -326,6 +494,120 @@ func (g *GoogleAuthenticator) AddAuthHeaders(req *http.Request) error {
 	return nil
 }
 
+// Do sends a request carrying Google authentication headers. When Google rejects the access
+// token, it is refreshed and the request retried once with the new token. Requests with a body
+// are only retried when the body can be replayed through GetBody.
+func (g *GoogleAuthenticator) Do(req *http.Request) (*http.Response, error) {
+	resp, err := g.httpClient.DoRequest(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return resp, nil
+	}
+	
+	// Refresh against the token the request carried; another request may already have
+	// replaced it
+	stale := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	token, err := g.refresh(stale)
+	if err != nil {
+		// The caller handles the original unauthorized response
+		g.logger.Warn("Failed to refresh Google access token after unauthorized response",
+			utils.NewField("url", req.URL.String()),
+			utils.NewField("error", err.Error()),
+		)
+		return resp, nil
+	}
+	
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+	resp.Body.Close()
+	
+	g.logger.Debug("Retrying Google request with refreshed access token",
+		utils.NewField("url", req.URL.String()),
+	)
+	
+	return g.httpClient.DoRequest(retry)
+}
+
+// expired reports whether the access token is missing, or past or about to pass its expiry,
+// and can be refreshed
+func (g *GoogleAuthenticator) expired(creds GoogleCredentials) bool {
+	if creds.RefreshToken == "" {
+		return false
+	}
+	if creds.AccessToken == "" {
+		return true
+	}
+	if creds.Expiry.IsZero() {
+		return false
+	}
+	return !g.now().Before(creds.Expiry.Add(-tokenExpiryMargin))
+}
+
+// refresh exchanges the refresh token for a new access token and stores it. stale is the token
+// found to be expired or rejected; when the stored token has been replaced by a valid one in
+// the meantime, that token is returned without another refresh.
+func (g *GoogleAuthenticator) refresh(stale string) (string, error) {
+	g.refreshMu.Lock()
+	defer g.refreshMu.Unlock()
+	
+	creds, err := g.creds.GetGoogleCredentials()
+	if err != nil {
+		return "", utils.WrapError(err, utils.ErrorCodeAuthFailed, "Failed to get Google credentials")
+	}
+	if creds.AccessToken != "" && creds.AccessToken != stale && !g.expired(creds) {
+		return creds.AccessToken, nil
+	}
+	
+	if creds.RefreshToken == "" {
+		return "", utils.NewAppError(utils.ErrorCodeTokenExpired, "Google access token expired and no refresh token is stored", nil).
+			WithService("google")
+	}
+	if g.clientID == "" {
+		return "", utils.NewAppError(utils.ErrorCodeConfigInvalid, "Google client ID is required to refresh the access token", nil).
+			WithService("google")
+	}
+	
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {g.clientID},
+		"client_secret": {creds.ClientSecret},
+		"refresh_token": {creds.RefreshToken},
+	}
+	token, err := requestToken(context.Background(), g.httpClient, g.tokenURL, form, "Google rejected the refresh token")
+	if err != nil {
+		return "", err
+	}
+	
+	creds.AccessToken = token.AccessToken
+	creds.Expiry = token.expiry(g.now())
+	// Google only occasionally rotates the refresh token
+	if token.RefreshToken != "" {
+		creds.RefreshToken = token.RefreshToken
+	}
+	
+	// The new token is usable even when it cannot be saved, so this request goes ahead
+	if err := g.creds.SetGoogleCredentials(creds); err != nil {
+		g.logger.Warn("Failed to store refreshed Google access token",
+			utils.NewField("error", err.Error()),
+		)
+	}
+	
+	g.logger.Info("Refreshed Google access token",
+		utils.NewField("expires_at", creds.Expiry),
+	)
+	
+	return creds.AccessToken, nil
+}
+
 // ValidateCredentials validates Google API credentials
 func (g *GoogleAuthenticator) ValidateCredentials() error {
 	// Get Google credentials
@@ -378,6 +660,7 @@ type AuthManager struct {
 	credentialStore   *CredentialStore
 	jiraAuth          *JiraAuthenticator
 	geminiAuth        *GeminiAuthenticator
+	confluenceAuth    *ConfluenceAuthenticator
 	googleAuth        *GoogleAuthenticator
 	logger            utils.Logger
 }
@@ -387,12 +670,21 @@ func NewAuthManager(config *AuthConfig, logger utils.Logger) *AuthManager {
 	httpClient := NewAuthenticatedHTTPClient(config, logger)
 	credentialStore := NewCredentialStore(logger)
 	
+	googleAuth := NewGoogleAuthenticator(httpClient, credentialStore, logger)
+	if config != nil {
+		googleAuth.SetClientID(config.GoogleClientID)
+		if config.Profile != "" {
+			credentialStore.UseProfile(config.Profile)
+		}
+	}
+	
 	return &AuthManager{
 		httpClient:      httpClient,
 		credentialStore: credentialStore,
 		jiraAuth:        NewJiraAuthenticator(httpClient, credentialStore, logger),
 		geminiAuth:      NewGeminiAuthenticator(httpClient, credentialStore, logger),
-		googleAuth:      NewGoogleAuthenticator(httpClient, credentialStore, logger),
+		confluenceAuth:  NewConfluenceAuthenticator(credentialStore, logger),
+		googleAuth:      googleAuth,
 		logger:          logger,
 	}
 }
@@ -407,6 +699,17 @@ func (m *AuthManager) GetCredentialStore() *CredentialStore {
 	return m.credentialStore
 }
 
+// UseProfile switches every authenticator to the credentials of the named profile, where an
+// empty name selects the default profile
+func (m *AuthManager) UseProfile(name string) {
+	m.credentialStore.UseProfile(name)
+}
+
+// ActiveProfile returns the credential profile in use
+func (m *AuthManager) ActiveProfile() string {
+	return m.credentialStore.ActiveProfile()
+}
+
 // GetJiraAuthenticator returns the Jira authenticator
 func (m *AuthManager) GetJiraAuthenticator() *JiraAuthenticator {
 	return m.jiraAuth
@@ -417,11 +720,22 @@ func (m *AuthManager) GetGeminiAuthenticator() *GeminiAuthenticator {
 	return m.geminiAuth
 }
 
+// GetConfluenceAuthenticator returns the Confluence authenticator
+func (m *AuthManager) GetConfluenceAuthenticator() *ConfluenceAuthenticator {
+	return m.confluenceAuth
+}
+
 // GetGoogleAuthenticator returns the Google authenticator
 func (m *AuthManager) GetGoogleAuthenticator() *GoogleAuthenticator {
 	return m.googleAuth
 }
 
+// NewGoogleOAuthFlow returns a login flow for the configured Google OAuth client that stores
+// the tokens it obtains in the manager's credential store
+func (m *AuthManager) NewGoogleOAuthFlow() *OAuthFlow {
+	return NewOAuthFlow(m.httpClient, m.credentialStore, m.googleAuth.clientID, m.logger)
+}
+
 // ValidateAllCredentials validates all stored credentials
 func (m *AuthManager) ValidateAllCredentials(jiraURL, jiraUsername string) error {
 	// Validate that credentials exist
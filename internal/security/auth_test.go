@@ -2,11 +2,16 @@ package security
 
 import (
 	"crypto/tls"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	appconfig "github.com/company/eesa/internal/config"
 	"github.com/company/eesa/pkg/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -18,6 +23,87 @@ func TestDefaultAuthConfig(t *testing.T) {
 	assert.Equal(t, "1.3", config.TLSMinVersion)
 	assert.True(t, config.VerifySSL)
 	assert.Equal(t, 30*time.Second, config.Timeout)
+	assert.Equal(t, utils.DefaultMaxResponseBytes, config.MaxResponseBytes)
+	assert.Equal(t, DefaultMaxIdleConns, config.MaxIdleConns)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, config.MaxIdleConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, config.IdleConnTimeout)
+	assert.Equal(t, DefaultKeepAlive, config.KeepAlive)
+}
+
+func TestNewAuthConfig(t *testing.T) {
+	cfg := appconfig.DefaultConfig()
+	cfg.Security.TLSMinVersion = "1.2"
+	cfg.Google.ClientID = "client-id.apps.googleusercontent.com"
+	cfg.HTTP.MaxIdleConns = 200
+	cfg.HTTP.MaxIdleConnsPerHost = 50
+	cfg.HTTP.IdleConnTimeout = 2 * time.Minute
+	cfg.HTTP.KeepAlive = 45 * time.Second
+	cfg.Security.Profile = "client-a"
+	
+	config := NewAuthConfig(cfg)
+	
+	assert.Equal(t, "1.2", config.TLSMinVersion)
+	assert.True(t, config.VerifySSL)
+	assert.Equal(t, 200, config.MaxIdleConns)
+	assert.Equal(t, 50, config.MaxIdleConnsPerHost)
+	assert.Equal(t, 2*time.Minute, config.IdleConnTimeout)
+	assert.Equal(t, 45*time.Second, config.KeepAlive)
+	assert.False(t, config.DisableKeepAlives)
+	assert.Equal(t, "client-id.apps.googleusercontent.com", config.GoogleClientID)
+	assert.Equal(t, "client-a", config.Profile)
+	
+	// A nil configuration yields the defaults
+	assert.Equal(t, DefaultAuthConfig(), NewAuthConfig(nil))
+}
+
+func TestNewAuthConfig_VerifySSL(t *testing.T) {
+	// A configuration that never set verify_ssl still verifies certificates
+	config := NewAuthConfig(&appconfig.Config{})
+	assert.True(t, config.VerifySSL)
+	
+	transport, ok := NewAuthenticatedHTTPClient(config, utils.NewMockLogger()).GetClient().Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+	
+	// Only an explicit false turns verification off
+	cfg := appconfig.DefaultConfig()
+	verify := false
+	cfg.Security.VerifySSL = &verify
+	assert.False(t, NewAuthConfig(cfg).VerifySSL)
+}
+
+func TestNewAuthenticatedHTTPClient_TransportFromConfig(t *testing.T) {
+	cfg := appconfig.DefaultConfig()
+	cfg.HTTP.MaxIdleConns = 64
+	cfg.HTTP.MaxIdleConnsPerHost = 32
+	cfg.HTTP.IdleConnTimeout = 3 * time.Minute
+	
+	client := NewAuthenticatedHTTPClient(NewAuthConfig(cfg), utils.NewMockLogger())
+	
+	transport, ok := client.GetClient().Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, 64, transport.MaxIdleConns)
+	assert.Equal(t, 32, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 3*time.Minute, transport.IdleConnTimeout)
+	assert.False(t, transport.DisableKeepAlives)
+	assert.NotNil(t, transport.DialContext)
+	
+	// Disabling keep-alives is passed through to the transport
+	cfg.HTTP.DisableKeepAlives = true
+	client = NewAuthenticatedHTTPClient(NewAuthConfig(cfg), utils.NewMockLogger())
+	transport = client.GetClient().Transport.(*http.Transport)
+	assert.True(t, transport.DisableKeepAlives)
+}
+
+func TestNewAuthenticatedHTTPClient_TransportDefaults(t *testing.T) {
+	// Zero pooling values fall back to the defaults
+	client := NewAuthenticatedHTTPClient(&AuthConfig{TLSMinVersion: "1.3", VerifySSL: true}, utils.NewMockLogger())
+	
+	transport, ok := client.GetClient().Transport.(*http.Transport)
+	require.True(t, ok)
+	assert.Equal(t, DefaultMaxIdleConns, transport.MaxIdleConns)
+	assert.Equal(t, DefaultMaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, DefaultIdleConnTimeout, transport.IdleConnTimeout)
 }
 
 func TestParseTLSVersion(t *testing.T) {
@@ -96,6 +182,38 @@ func TestAuthenticatedHTTPClient_DoRequest(t *testing.T) {
 	assert.Len(t, debugEntries, 2) // Request and response logs
 }
 
+func TestAuthenticatedHTTPClient_ReadBody(t *testing.T) {
+	logger := utils.NewMockLogger()
+	config := DefaultAuthConfig()
+	config.MaxResponseBytes = 16
+	client := NewAuthenticatedHTTPClient(config, logger)
+	
+	// Create test server returning an oversized body
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(strings.Repeat("x", 1024)))
+	}))
+	defer server.Close()
+	
+	req, err := client.CreateRequest("GET", server.URL, nil)
+	require.NoError(t, err)
+	
+	resp, err := client.DoRequest(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	
+	body, err := client.ReadBody(resp)
+	require.Error(t, err)
+	assert.Len(t, body, 16)
+	
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeResponseTooLarge, appErr.Code)
+	
+	warnEntries := logger.GetEntriesByLevel(utils.LogLevelWarn)
+	assert.Len(t, warnEntries, 1)
+}
+
 func TestJiraAuthenticator_AddAuthHeaders(t *testing.T) {
 	logger := utils.NewMockLogger()
 	httpClient := NewAuthenticatedHTTPClient(nil, logger)
@@ -169,6 +287,40 @@ func TestJiraAuthenticator_ValidateCredentials(t *testing.T) {
 	credStore.keyring.DeleteCredential(KeyJiraToken)
 }
 
+func TestConfluenceAuthenticator_AddAuthHeaders(t *testing.T) {
+	logger := utils.NewMockLogger()
+	httpClient := NewAuthenticatedHTTPClient(nil, logger)
+	credStore := NewCredentialStore(logger)
+	defer credStore.keyring.DeleteCredential(KeyConfluenceToken)
+	
+	auth := NewConfluenceAuthenticator(credStore, logger)
+	req, err := httpClient.CreateRequest("GET", "https://example.com", nil)
+	require.NoError(t, err)
+	
+	// Without a stored token no header is added
+	err = auth.AddAuthHeaders(req, "testuser")
+	require.Error(t, err)
+	assert.Empty(t, req.Header.Get("Authorization"))
+	
+	err = credStore.SetConfluenceCredentials(ConfluenceCredentials{Token: "test_token"})
+	require.NoError(t, err)
+	
+	// Cloud sites take the account and API token as Basic Auth
+	err = auth.AddAuthHeaders(req, "testuser")
+	require.NoError(t, err)
+	username, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "testuser", username)
+	assert.Equal(t, "test_token", password)
+	
+	// Without a username the token is a bearer personal access token
+	req, err = httpClient.CreateRequest("GET", "https://example.com", nil)
+	require.NoError(t, err)
+	err = auth.AddAuthHeaders(req, "")
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer test_token", req.Header.Get("Authorization"))
+}
+
 func TestGeminiAuthenticator_AddAuthHeaders(t *testing.T) {
 	logger := utils.NewMockLogger()
 	httpClient := NewAuthenticatedHTTPClient(nil, logger)
@@ -316,6 +468,254 @@ func TestGoogleAuthenticator_ValidateCredentials(t *testing.T) {
 	assert.Equal(t, utils.ErrorCodeAuthFailed, appErr.Code)
 }
 
+// lockedLogger makes the mock logger safe to share between concurrent requests
+type lockedLogger struct {
+	mu     sync.Mutex
+	logger *utils.MockLogger
+}
+
+func (l *lockedLogger) Debug(msg string, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Debug(msg, fields...)
+}
+
+func (l *lockedLogger) Info(msg string, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Info(msg, fields...)
+}
+
+func (l *lockedLogger) Warn(msg string, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Warn(msg, fields...)
+}
+
+func (l *lockedLogger) Error(msg string, err error, fields ...utils.Field) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.logger.Error(msg, err, fields...)
+}
+
+// newTokenServer serves OAuth refresh requests, issuing "fresh_access_token" for an hour and
+// counting the refreshes
+func newTokenServer(refreshes *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(refreshes, 1)
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "test_refresh_token" ||
+			r.Form.Get("client_id") != "test_client_id" || r.Form.Get("client_secret") != "test_client_secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "fresh_access_token", "expires_in": 3600, "token_type": "Bearer"}`))
+	}))
+}
+
+// newRefreshingAuthenticator stores an access token with the given expiry and returns an
+// authenticator refreshing it against tokenURL
+func newRefreshingAuthenticator(t *testing.T, logger utils.Logger, tokenURL string, expiry time.Time) (*GoogleAuthenticator, *CredentialStore) {
+	httpClient := NewAuthenticatedHTTPClient(nil, logger)
+	credStore := NewCredentialStore(logger)
+	
+	err := credStore.SetGoogleCredentials(GoogleCredentials{
+		ClientSecret: "test_client_secret",
+		AccessToken:  "stale_access_token",
+		RefreshToken: "test_refresh_token",
+		Expiry:       expiry,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { credStore.ClearAllCredentials() })
+	
+	auth := NewGoogleAuthenticator(httpClient, credStore, logger)
+	auth.SetClientID("test_client_id")
+	auth.SetTokenURL(tokenURL)
+	return auth, credStore
+}
+
+func TestGoogleAuthenticator_AddAuthHeaders_RefreshesExpiredToken(t *testing.T) {
+	var refreshes int32
+	tokenServer := newTokenServer(&refreshes)
+	defer tokenServer.Close()
+	
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	auth, credStore := newRefreshingAuthenticator(t, utils.NewMockLogger(), tokenServer.URL, now.Add(-time.Minute))
+	auth.now = func() time.Time { return now }
+	
+	req, err := http.NewRequest("GET", "https://docs.googleapis.com/v1/documents/doc", nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.AddAuthHeaders(req))
+	
+	assert.Equal(t, "Bearer fresh_access_token", req.Header.Get("Authorization"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+	
+	// The new token and its expiry are stored
+	stored, err := credStore.GetGoogleCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "fresh_access_token", stored.AccessToken)
+	assert.Equal(t, "test_refresh_token", stored.RefreshToken)
+	assert.True(t, now.Add(time.Hour).Equal(stored.Expiry))
+	
+	// The stored token is now valid, so the next request does not refresh
+	req, err = http.NewRequest("GET", "https://docs.googleapis.com/v1/documents/doc", nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.AddAuthHeaders(req))
+	assert.Equal(t, "Bearer fresh_access_token", req.Header.Get("Authorization"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+}
+
+func TestGoogleAuthenticator_AddAuthHeaders_ValidTokenNotRefreshed(t *testing.T) {
+	var refreshes int32
+	tokenServer := newTokenServer(&refreshes)
+	defer tokenServer.Close()
+	
+	auth, _ := newRefreshingAuthenticator(t, utils.NewMockLogger(), tokenServer.URL, time.Now().Add(time.Hour))
+	
+	req, err := http.NewRequest("GET", "https://docs.googleapis.com/v1/documents/doc", nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.AddAuthHeaders(req))
+	
+	assert.Equal(t, "Bearer stale_access_token", req.Header.Get("Authorization"))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&refreshes))
+}
+
+func TestGoogleAuthenticator_Do_RefreshesOnUnauthorized(t *testing.T) {
+	var refreshes int32
+	tokenServer := newTokenServer(&refreshes)
+	defer tokenServer.Close()
+	
+	var bodies []string
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if r.Header.Get("Authorization") != "Bearer fresh_access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+	
+	// No expiry is stored, so only the 401 reveals that the token has expired
+	auth, _ := newRefreshingAuthenticator(t, utils.NewMockLogger(), tokenServer.URL, time.Time{})
+	
+	req, err := http.NewRequest("POST", apiServer.URL+"/v1/documents", strings.NewReader(`{"title": "Summary"}`))
+	require.NoError(t, err)
+	require.NoError(t, auth.AddAuthHeaders(req))
+	
+	resp, err := auth.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+	// The retry resends the body
+	assert.Equal(t, []string{`{"title": "Summary"}`, `{"title": "Summary"}`}, bodies)
+}
+
+func TestGoogleAuthenticator_Do_ConcurrentRequestsRefreshOnce(t *testing.T) {
+	var refreshes int32
+	tokenServer := newTokenServer(&refreshes)
+	defer tokenServer.Close()
+	
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh_access_token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+	
+	logger := &lockedLogger{logger: utils.NewMockLogger()}
+	auth, _ := newRefreshingAuthenticator(t, logger, tokenServer.URL, time.Time{})
+	
+	const requests = 8
+	statuses := make(chan int, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, err := http.NewRequest("GET", apiServer.URL+"/v1/documents/doc", nil)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			req.Header.Set("Authorization", "Bearer stale_access_token")
+			
+			resp, err := auth.Do(req)
+			if err != nil {
+				statuses <- 0
+				return
+			}
+			resp.Body.Close()
+			statuses <- resp.StatusCode
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+	
+	for status := range statuses {
+		assert.Equal(t, http.StatusOK, status)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&refreshes))
+}
+
+func TestGoogleAuthenticator_Do_WithoutRefreshToken(t *testing.T) {
+	logger := utils.NewMockLogger()
+	httpClient := NewAuthenticatedHTTPClient(nil, logger)
+	credStore := NewCredentialStore(logger)
+	
+	err := credStore.SetGoogleCredentials(GoogleCredentials{
+		ClientSecret: "test_client_secret",
+		AccessToken:  "stale_access_token",
+	})
+	require.NoError(t, err)
+	defer credStore.ClearAllCredentials()
+	
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer apiServer.Close()
+	
+	auth := NewGoogleAuthenticator(httpClient, credStore, logger)
+	auth.SetClientID("test_client_id")
+	
+	req, err := http.NewRequest("GET", apiServer.URL+"/v1/documents/doc", nil)
+	require.NoError(t, err)
+	require.NoError(t, auth.AddAuthHeaders(req))
+	
+	// The unauthorized response is handed back for the caller to report
+	resp, err := auth.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestGoogleAuthenticator_RefreshRejected(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid_grant"}`))
+	}))
+	defer tokenServer.Close()
+	
+	auth, _ := newRefreshingAuthenticator(t, utils.NewMockLogger(), tokenServer.URL, time.Now().Add(-time.Hour))
+	
+	req, err := http.NewRequest("GET", "https://docs.googleapis.com/v1/documents/doc", nil)
+	require.NoError(t, err)
+	
+	err = auth.AddAuthHeaders(req)
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeTokenInvalid, appErr.Code)
+	assert.Equal(t, http.StatusBadRequest, appErr.Context.Extra["status_code"])
+}
+
 func TestAuthManager(t *testing.T) {
 	logger := utils.NewMockLogger()
 	config := DefaultAuthConfig()
@@ -328,6 +728,36 @@ func TestAuthManager(t *testing.T) {
 	assert.NotNil(t, manager.GetJiraAuthenticator())
 	assert.NotNil(t, manager.GetGeminiAuthenticator())
 	assert.NotNil(t, manager.GetGoogleAuthenticator())
+	assert.Equal(t, DefaultProfile, manager.ActiveProfile())
+}
+
+func TestAuthManager_Profiles(t *testing.T) {
+	logger := utils.NewMockLogger()
+	config := DefaultAuthConfig()
+	config.Profile = "client-a"
+	
+	manager := NewAuthManager(config, logger)
+	assert.Equal(t, "client-a", manager.ActiveProfile())
+	
+	store := manager.GetCredentialStore()
+	t.Cleanup(func() {
+		store.DeleteProfile("client-a")
+		store.UseProfile("")
+		store.ClearAllCredentials()
+	})
+	require.NoError(t, store.SetGeminiCredentials(GeminiCredentials{APIKey: "client_a_key"}))
+	
+	manager.UseProfile("")
+	require.NoError(t, store.SetGeminiCredentials(GeminiCredentials{APIKey: "internal_key"}))
+	
+	// The authenticators follow the active profile
+	for profile, apiKey := range map[string]string{"client-a": "client_a_key", "": "internal_key"} {
+		manager.UseProfile(profile)
+		req, err := http.NewRequest("GET", "https://example.com", nil)
+		require.NoError(t, err)
+		require.NoError(t, manager.GetGeminiAuthenticator().AddAuthHeaders(req))
+		assert.Equal(t, apiKey, req.Header.Get("x-goog-api-key"))
+	}
 }
 
 func TestAuthManager_ValidateAllCredentials(t *testing.T) {
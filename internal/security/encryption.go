@@ -0,0 +1,327 @@
+package security
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"os"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// CredentialFormatVersion is the version written at the start of encrypted credential blobs.
+// Decryption rejects versions it does not know, so the format and key derivation can change
+// without misreading older blobs.
+const CredentialFormatVersion byte = 1
+
+// Key source identifiers stored in the blob header
+const (
+	keySourceKeyring    byte = 1
+	keySourcePassphrase byte = 2
+)
+
+// DefaultPassphraseIterations is the PBKDF2-HMAC-SHA256 iteration count for passphrase keys
+const DefaultPassphraseIterations = 600000
+
+// maxPassphraseIterations bounds the iteration count read from a blob, so a damaged header
+// cannot stall decryption
+const maxPassphraseIterations = 10 * DefaultPassphraseIterations
+
+const (
+	encryptionKeySize = 32 // AES-256
+	saltSize          = 16
+)
+
+// KeySource supplies the AES-256 key that encrypts credentials
+type KeySource interface {
+	// header returns the key source fields of a new blob's header
+	header() ([]byte, error)
+	// key derives the key for a blob from its key source header fields, returning how many
+	// bytes of fields it read
+	key(fields []byte) ([]byte, int, error)
+	sourceID() byte
+}
+
+// keyringKeySource uses the random key kept in the OS keyring under KeyEncryptionKey
+type keyringKeySource struct {
+	keyring *KeyringManager
+}
+
+// NewKeyringKeySource returns a key source using the encryption key in the OS keyring,
+// generating one on first use
+func NewKeyringKeySource(keyring *KeyringManager) KeySource {
+	return &keyringKeySource{keyring: keyring}
+}
+
+func (s *keyringKeySource) sourceID() byte {
+	return keySourceKeyring
+}
+
+func (s *keyringKeySource) header() ([]byte, error) {
+	return nil, nil
+}
+
+func (s *keyringKeySource) key(fields []byte) ([]byte, int, error) {
+	key, err := s.keyring.GetOrGenerateEncryptionKey()
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(key) != encryptionKeySize {
+		return nil, 0, utils.NewAppError(utils.ErrorCodeEncryptionError, "Encryption key in keyring has the wrong size", nil).
+			WithExtra("size", len(key))
+	}
+	return key, 0, nil
+}
+
+// passphraseKeySource derives the key from a passphrase with PBKDF2. Each blob stores its own
+// salt and iteration count.
+type passphraseKeySource struct {
+	passphrase string
+	iterations int
+}
+
+// NewPassphraseKeySource returns a key source deriving the key from a user passphrase
+func NewPassphraseKeySource(passphrase string) KeySource {
+	return &passphraseKeySource{passphrase: passphrase, iterations: DefaultPassphraseIterations}
+}
+
+func (s *passphraseKeySource) sourceID() byte {
+	return keySourcePassphrase
+}
+
+// header holds the iteration count and a fresh random salt
+func (s *passphraseKeySource) header() ([]byte, error) {
+	fields := make([]byte, 4+saltSize)
+	binary.BigEndian.PutUint32(fields, uint32(s.iterations))
+	if _, err := rand.Read(fields[4:]); err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to generate salt", err)
+	}
+	return fields, nil
+}
+
+func (s *passphraseKeySource) key(fields []byte) ([]byte, int, error) {
+	if s.passphrase == "" {
+		return nil, 0, utils.NewAppError(utils.ErrorCodeValidationError, "Passphrase cannot be empty", nil)
+	}
+	if len(fields) < 4+saltSize {
+		return nil, 0, errCorruptCredentials()
+	}
+	iterations := int(binary.BigEndian.Uint32(fields))
+	if iterations <= 0 || iterations > maxPassphraseIterations {
+		return nil, 0, errCorruptCredentials()
+	}
+	return pbkdf2SHA256([]byte(s.passphrase), fields[4:4+saltSize], iterations, encryptionKeySize), 4 + saltSize, nil
+}
+
+// pbkdf2SHA256 derives a key of keyLen bytes with PBKDF2-HMAC-SHA256 (RFC 8018)
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	blocks := (keyLen + sha256.Size - 1) / sha256.Size
+	derived := make([]byte, 0, blocks*sha256.Size)
+	
+	var counter [4]byte
+	u := make([]byte, sha256.Size)
+	for block := 1; block <= blocks; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		u = prf.Sum(u[:0])
+		t := append([]byte(nil), u...)
+		
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		derived = append(derived, t...)
+	}
+	
+	return derived[:keyLen]
+}
+
+// EncryptCredentials serializes credentials and encrypts them with AES-256-GCM. The blob is the
+// format version, the key source and its fields, the nonce and the sealed credentials; the
+// header is authenticated along with the credentials.
+func EncryptCredentials(source KeySource, credentials map[string]string) ([]byte, error) {
+	plaintext, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to serialize credentials", err)
+	}
+	defer clearBytes(plaintext)
+	
+	fields, err := source.header()
+	if err != nil {
+		return nil, err
+	}
+	header := append([]byte{CredentialFormatVersion, source.sourceID()}, fields...)
+	
+	key, _, err := source.key(fields)
+	if err != nil {
+		return nil, err
+	}
+	defer clearBytes(key)
+	
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to generate nonce", err)
+	}
+	
+	blob := append(header, nonce...)
+	return gcm.Seal(blob, nonce, plaintext, header), nil
+}
+
+// DecryptCredentials opens a blob written by EncryptCredentials. It fails rather than return
+// partial or empty credentials when the blob is damaged, tampered with or encrypted with
+// another key.
+func DecryptCredentials(source KeySource, blob []byte) (map[string]string, error) {
+	if len(blob) < 2 {
+		return nil, errCorruptCredentials()
+	}
+	if blob[0] != CredentialFormatVersion {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError, "Unsupported encrypted credentials version", nil).
+			WithExtra("version", int(blob[0]))
+	}
+	if blob[1] != source.sourceID() {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError, "Credentials were encrypted with a different kind of key", nil).
+			WithExtra("key_source", int(blob[1]))
+	}
+	
+	key, read, err := source.key(blob[2:])
+	if err != nil {
+		return nil, err
+	}
+	defer clearBytes(key)
+	
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	headerLen := 2 + read
+	if len(blob) < headerLen+gcm.NonceSize()+gcm.Overhead() {
+		return nil, errCorruptCredentials()
+	}
+	header := blob[:headerLen]
+	nonce := blob[headerLen : headerLen+gcm.NonceSize()]
+	
+	plaintext, err := gcm.Open(nil, nonce, blob[headerLen+gcm.NonceSize():], header)
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError,
+			"Failed to decrypt credentials: the key or passphrase is wrong or the data was modified", err)
+	}
+	defer clearBytes(plaintext)
+	
+	var credentials map[string]string
+	if err := json.Unmarshal(plaintext, &credentials); err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeDataCorrupted, "Decrypted credentials are not valid", err)
+	}
+	
+	return credentials, nil
+}
+
+// newGCM creates an AES-GCM cipher for key
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to create cipher", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to create cipher", err)
+	}
+	return gcm, nil
+}
+
+// errCorruptCredentials reports a blob too short or malformed to decrypt
+func errCorruptCredentials() error {
+	return utils.NewAppError(utils.ErrorCodeDataCorrupted, "Encrypted credentials are corrupted", nil)
+}
+
+// clearBytes overwrites sensitive data once it is no longer needed
+func clearBytes(data []byte) {
+	for i := range data {
+		data[i] = 0
+	}
+}
+
+// ExportEncrypted encrypts the active profile's credentials into a blob for a file or backup.
+// The keyring encryption key is left out, since it may be what protects the export. Credentials
+// that are not set are skipped, any other keyring failure fails the export rather than writing
+// an incomplete backup.
+func (c *CredentialStore) ExportEncrypted(source KeySource) ([]byte, error) {
+	credentials := make(map[string]string)
+	for _, key := range profileCredentialKeys {
+		value, err := c.keyring.GetCredential(c.key(key))
+		if err != nil {
+			var appErr *utils.AppError
+			if errors.As(err, &appErr) && appErr.Code == utils.ErrorCodeCredentialsMissing {
+				continue
+			}
+			return nil, err
+		}
+		credentials[key] = value
+	}
+	
+	return EncryptCredentials(source, credentials)
+}
+
+// ImportEncrypted decrypts an exported blob and stores its credentials in the active profile.
+// Nothing is stored when the blob cannot be decrypted.
+func (c *CredentialStore) ImportEncrypted(source KeySource, blob []byte) error {
+	credentials, err := DecryptCredentials(source, blob)
+	if err != nil {
+		return err
+	}
+	
+	for _, key := range profileCredentialKeys {
+		if value, ok := credentials[key]; ok && value != "" {
+			if err := c.storeCredential(key, value); err != nil {
+				return err
+			}
+		}
+	}
+	
+	c.logger.Info("Imported encrypted credentials",
+		utils.NewField("count", len(credentials)),
+	)
+	
+	return nil
+}
+
+// SaveEncryptedFile exports the stored credentials to path, readable only by the owner
+func (c *CredentialStore) SaveEncryptedFile(path string, source KeySource) error {
+	blob, err := c.ExportEncrypted(source)
+	if err != nil {
+		return err
+	}
+	
+	if err := os.WriteFile(path, blob, 0600); err != nil {
+		return utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to write encrypted credentials", err).
+			WithExtra("path", path)
+	}
+	
+	return nil
+}
+
+// LoadEncryptedFile imports credentials from a file written by SaveEncryptedFile
+func (c *CredentialStore) LoadEncryptedFile(path string, source KeySource) error {
+	blob, err := os.ReadFile(path)
+	if err != nil {
+		return utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to read encrypted credentials", err).
+			WithExtra("path", path)
+	}
+	
+	return c.ImportEncrypted(source, blob)
+}
@@ -0,0 +1,146 @@
+package security
+
+import (
+	"encoding/hex"
+	"path/filepath"
+	"testing"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPassphraseSource keeps key derivation fast in tests
+func newTestPassphraseSource(passphrase string) KeySource {
+	return &passphraseKeySource{passphrase: passphrase, iterations: 1000}
+}
+
+func testCredentials() map[string]string {
+	return map[string]string{
+		KeyJiraToken:          "test_jira_token",
+		KeyGeminiAPIKey:       "test_gemini_key",
+		KeyGoogleRefreshToken: "test_refresh_token",
+	}
+}
+
+func TestPBKDF2SHA256(t *testing.T) {
+	// Test vectors for PBKDF2-HMAC-SHA256 from RFC 7914 section 11
+	key := pbkdf2SHA256([]byte("passwd"), []byte("salt"), 1, 64)
+	assert.Equal(t, "55ac046e56e3089fec1691c22544b605f94185216dde0465e68b9d57c20dacbc"+
+		"49ca9cccf179b645991664b39d77ef317c71b845b1e30bd509112041d3a19783", hex.EncodeToString(key))
+	
+	key = pbkdf2SHA256([]byte("Password"), []byte("NaCl"), 80000, 64)
+	assert.Equal(t, "4ddcd8f60b98be21830cee5ef22701f9641a4418d04c0414aeff08876b34ab56"+
+		"a1d425a1225833549adb841b51c9b3176a272bdebba1d078478f62b397f33c8d", hex.EncodeToString(key))
+}
+
+func TestEncryptCredentials_RoundTrip(t *testing.T) {
+	keyring := NewKeyringManager(utils.NewMockLogger())
+	defer keyring.DeleteCredential(KeyEncryptionKey)
+	
+	sources := map[string]KeySource{
+		"keyring":    NewKeyringKeySource(keyring),
+		"passphrase": newTestPassphraseSource("correct horse battery staple"),
+	}
+	
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			blob, err := EncryptCredentials(source, testCredentials())
+			require.NoError(t, err)
+			assert.Equal(t, CredentialFormatVersion, blob[0])
+			assert.NotContains(t, string(blob), "test_jira_token")
+			
+			decrypted, err := DecryptCredentials(source, blob)
+			require.NoError(t, err)
+			assert.Equal(t, testCredentials(), decrypted)
+			
+			// Every blob has a fresh nonce
+			other, err := EncryptCredentials(source, testCredentials())
+			require.NoError(t, err)
+			assert.NotEqual(t, blob, other)
+		})
+	}
+}
+
+func TestDecryptCredentials_Tampered(t *testing.T) {
+	source := newTestPassphraseSource("passphrase")
+	blob, err := EncryptCredentials(source, testCredentials())
+	require.NoError(t, err)
+	
+	// Flipping a bit anywhere after the version, including the authenticated header, is caught
+	for _, offset := range []int{5, 10, len(blob) / 2, len(blob) - 1} {
+		tampered := append([]byte(nil), blob...)
+		tampered[offset] ^= 0x01
+		
+		credentials, err := DecryptCredentials(source, tampered)
+		require.Error(t, err, "offset %d", offset)
+		assert.Nil(t, credentials)
+		assert.Equal(t, utils.ErrorCodeEncryptionError, err.(*utils.AppError).Code)
+	}
+	
+	_, err = DecryptCredentials(source, blob[:len(blob)-20])
+	assert.Error(t, err)
+	
+	// An absurd iteration count is rejected before deriving a key
+	tampered := append([]byte(nil), blob...)
+	tampered[2] = 0xff
+	_, err = DecryptCredentials(source, tampered)
+	require.Error(t, err)
+	assert.Equal(t, utils.ErrorCodeDataCorrupted, err.(*utils.AppError).Code)
+}
+
+func TestDecryptCredentials_WrongKey(t *testing.T) {
+	blob, err := EncryptCredentials(newTestPassphraseSource("right"), testCredentials())
+	require.NoError(t, err)
+	
+	credentials, err := DecryptCredentials(newTestPassphraseSource("wrong"), blob)
+	require.Error(t, err)
+	assert.Nil(t, credentials)
+	assert.Contains(t, err.Error(), "key or passphrase is wrong")
+	
+	keyring := NewKeyringManager(utils.NewMockLogger())
+	defer keyring.DeleteCredential(KeyEncryptionKey)
+	_, err = DecryptCredentials(NewKeyringKeySource(keyring), blob)
+	assert.Error(t, err)
+}
+
+func TestDecryptCredentials_UnsupportedVersion(t *testing.T) {
+	source := newTestPassphraseSource("passphrase")
+	blob, err := EncryptCredentials(source, testCredentials())
+	require.NoError(t, err)
+	
+	blob[0] = CredentialFormatVersion + 1
+	_, err = DecryptCredentials(source, blob)
+	require.Error(t, err)
+	assert.Equal(t, CredentialFormatVersion+1, byte(err.(*utils.AppError).Context.Extra["version"].(int)))
+	
+	_, err = DecryptCredentials(source, nil)
+	assert.Error(t, err)
+}
+
+func TestCredentialStore_EncryptedFile(t *testing.T) {
+	credStore := NewCredentialStore(utils.NewMockLogger())
+	defer credStore.ClearAllCredentials()
+	
+	require.NoError(t, credStore.SetJiraCredentials(JiraCredentials{Token: "test_jira_token"}))
+	require.NoError(t, credStore.SetGeminiCredentials(GeminiCredentials{APIKey: "test_gemini_key"}))
+	
+	path := filepath.Join(t.TempDir(), "credentials.enc")
+	source := newTestPassphraseSource("passphrase")
+	require.NoError(t, credStore.SaveEncryptedFile(path, source))
+	
+	require.NoError(t, credStore.ClearAllCredentials())
+	
+	// A wrong passphrase stores nothing
+	require.Error(t, credStore.LoadEncryptedFile(path, newTestPassphraseSource("wrong")))
+	_, err := credStore.GetJiraCredentials()
+	assert.Error(t, err)
+	
+	require.NoError(t, credStore.LoadEncryptedFile(path, source))
+	token, err := credStore.GetJiraCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "test_jira_token", token.Token)
+	apiKey, err := credStore.GetGeminiCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "test_gemini_key", apiKey.APIKey)
+}
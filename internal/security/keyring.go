@@ -4,7 +4,12 @@ import (
 	"crypto/rand"
 	"crypto/subtle"
 	"encoding/base64"
+	"encoding/json"
 	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/company/eesa/pkg/utils"
 	"github.com/zalando/go-keyring"
@@ -20,7 +25,13 @@ const (
 	KeyGoogleClientSecret = "google_client_secret"
 	KeyGoogleAccessToken  = "google_access_token"
 	KeyGoogleRefreshToken = "google_refresh_token"
+	KeyGoogleTokenExpiry  = "google_token_expiry"
+	KeyConfluenceToken    = "confluence_token"
 	KeyEncryptionKey    = "encryption_key"
+	KeyProfiles         = "profiles"
+	
+	// DefaultProfile is the credential profile stored under the unprefixed keys
+	DefaultProfile = "default"
 )
 
 // KeyringManager handles secure storage and retrieval of credentials
@@ -161,6 +172,8 @@ func (k *KeyringManager) ListCredentials() ([]string, error) {
 		KeyGoogleClientSecret,
 		KeyGoogleAccessToken,
 		KeyGoogleRefreshToken,
+		KeyGoogleTokenExpiry,
+		KeyConfluenceToken,
 		KeyEncryptionKey,
 	}
 	
@@ -258,18 +271,172 @@ func (k *KeyringManager) getServiceName() string {
 	return ServiceName
 }
 
-// CredentialStore provides a high-level interface for credential management
+// CredentialStore provides a high-level interface for credential management. Credentials
+// belong to the active profile, so separate Jira and Google tenants can each keep their own.
 type CredentialStore struct {
 	keyring *KeyringManager
 	logger  utils.Logger
+	
+	mu      sync.RWMutex
+	profile string
 }
 
-// NewCredentialStore creates a new credential store
+// NewCredentialStore creates a new credential store using the default profile
 func NewCredentialStore(logger utils.Logger) *CredentialStore {
 	return &CredentialStore{
 		keyring: NewKeyringManager(logger),
 		logger:  logger,
+		profile: DefaultProfile,
+	}
+}
+
+// ActiveProfile returns the profile whose credentials the store reads and writes
+func (c *CredentialStore) ActiveProfile() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.profile
+}
+
+// UseProfile switches to the named profile, where an empty name selects the default profile.
+// A profile need not exist yet; storing credentials in it creates it.
+func (c *CredentialStore) UseProfile(name string) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = DefaultProfile
+	}
+	
+	c.mu.Lock()
+	c.profile = name
+	c.mu.Unlock()
+	
+	c.logger.Info("Using credential profile", utils.NewField("profile", name))
+}
+
+// ListProfiles returns the default profile followed by every profile holding credentials,
+// sorted by name
+func (c *CredentialStore) ListProfiles() ([]string, error) {
+	named, err := c.storedProfiles()
+	if err != nil {
+		return nil, err
+	}
+	
+	sort.Strings(named)
+	return append([]string{DefaultProfile}, named...), nil
+}
+
+// DeleteProfile removes a named profile and its credentials. The default profile cannot be
+// deleted, and deleting the active profile switches back to the default.
+func (c *CredentialStore) DeleteProfile(name string) error {
+	if name == DefaultProfile || name == "" {
+		return utils.NewAppError(utils.ErrorCodeValidationError, "The default profile cannot be deleted", nil)
+	}
+	
+	if err := c.clearProfile(name); err != nil {
+		return err
+	}
+	
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	named, err := c.storedProfiles()
+	if err != nil {
+		return err
+	}
+	var remaining []string
+	for _, profile := range named {
+		if profile != name {
+			remaining = append(remaining, profile)
+		}
+	}
+	if err := c.saveProfiles(remaining); err != nil {
+		return err
+	}
+	
+	if c.profile == name {
+		c.profile = DefaultProfile
+	}
+	
+	c.logger.Info("Credential profile deleted", utils.NewField("profile", name))
+	
+	return nil
+}
+
+// key returns the keyring entry for a credential in the active profile
+func (c *CredentialStore) key(name string) string {
+	return profileKey(c.ActiveProfile(), name)
+}
+
+// profileKey returns the keyring entry for a credential in profile. The default profile keeps
+// the unprefixed entries used before profiles existed.
+func profileKey(profile, name string) string {
+	if profile == DefaultProfile {
+		return name
+	}
+	return "profile/" + profile + "/" + name
+}
+
+// storeCredential stores a credential in the active profile, recording a named profile so it
+// can be listed
+func (c *CredentialStore) storeCredential(name, value string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	
+	if err := c.keyring.StoreCredential(profileKey(c.profile, name), value); err != nil {
+		return err
+	}
+	if c.profile == DefaultProfile {
+		return nil
+	}
+	
+	named, err := c.storedProfiles()
+	if err != nil {
+		return err
+	}
+	for _, profile := range named {
+		if profile == c.profile {
+			return nil
+		}
+	}
+	return c.saveProfiles(append(named, c.profile))
+}
+
+// storedProfiles reads the names of the profiles other than the default
+func (c *CredentialStore) storedProfiles() ([]string, error) {
+	stored, err := c.keyring.GetCredential(KeyProfiles)
+	if err != nil {
+		if appErr, ok := err.(*utils.AppError); ok && appErr.Code == utils.ErrorCodeCredentialsMissing {
+			return nil, nil
+		}
+		return nil, err
+	}
+	
+	var named []string
+	if err := json.Unmarshal([]byte(stored), &named); err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeDataCorrupted, "Stored profile list is invalid", err).
+			WithService("keyring")
+	}
+	return named, nil
+}
+
+// saveProfiles stores the names of the profiles other than the default
+func (c *CredentialStore) saveProfiles(named []string) error {
+	if len(named) == 0 {
+		return ignoreMissing(c.keyring.DeleteCredential(KeyProfiles))
 	}
+	
+	data, err := json.Marshal(named)
+	if err != nil {
+		return utils.NewAppError(utils.ErrorCodeKeyringError, "Failed to encode profile list", err)
+	}
+	return c.keyring.StoreCredential(KeyProfiles, string(data))
+}
+
+// ignoreMissing treats deleting a credential that was never stored as success
+func ignoreMissing(err error) error {
+	if appErr, ok := err.(*utils.AppError); ok && appErr.Code == utils.ErrorCodeCredentialsMissing {
+		return nil
+	}
+	return err
 }
 
 // JiraCredentials represents Jira authentication credentials
@@ -282,11 +449,17 @@ type GeminiCredentials struct {
 	APIKey string
 }
 
+// ConfluenceCredentials represents Confluence API credentials
+type ConfluenceCredentials struct {
+	Token string // API token for Confluence Cloud, personal access token for Data Center
+}
+
 // GoogleCredentials represents Google API credentials
 type GoogleCredentials struct {
 	ClientSecret string
 	AccessToken  string
 	RefreshToken string
+	Expiry       time.Time // When the access token expires, zero when unknown
 }
 
 // SetJiraCredentials stores Jira credentials
@@ -295,12 +468,12 @@ func (c *CredentialStore) SetJiraCredentials(creds JiraCredentials) error {
 		return utils.NewAppError(utils.ErrorCodeValidationError, "Jira token cannot be empty", nil)
 	}
 	
-	return c.keyring.StoreCredential(KeyJiraToken, creds.Token)
+	return c.storeCredential(KeyJiraToken, creds.Token)
 }
 
 // GetJiraCredentials retrieves Jira credentials
 func (c *CredentialStore) GetJiraCredentials() (JiraCredentials, error) {
-	token, err := c.keyring.GetCredential(KeyJiraToken)
+	token, err := c.keyring.GetCredential(c.key(KeyJiraToken))
 	if err != nil {
 		return JiraCredentials{}, err
 	}
@@ -314,12 +487,12 @@ func (c *CredentialStore) SetGeminiCredentials(creds GeminiCredentials) error {
 		return utils.NewAppError(utils.ErrorCodeValidationError, "Gemini API key cannot be empty", nil)
 	}
 	
-	return c.keyring.StoreCredential(KeyGeminiAPIKey, creds.APIKey)
+	return c.storeCredential(KeyGeminiAPIKey, creds.APIKey)
 }
 
 // GetGeminiCredentials retrieves Gemini API credentials
 func (c *CredentialStore) GetGeminiCredentials() (GeminiCredentials, error) {
-	apiKey, err := c.keyring.GetCredential(KeyGeminiAPIKey)
+	apiKey, err := c.keyring.GetCredential(c.key(KeyGeminiAPIKey))
 	if err != nil {
 		return GeminiCredentials{}, err
 	}
@@ -327,6 +500,25 @@ func (c *CredentialStore) GetGeminiCredentials() (GeminiCredentials, error) {
 	return GeminiCredentials{APIKey: apiKey}, nil
 }
 
+// SetConfluenceCredentials stores Confluence credentials
+func (c *CredentialStore) SetConfluenceCredentials(creds ConfluenceCredentials) error {
+	if creds.Token == "" {
+		return utils.NewAppError(utils.ErrorCodeValidationError, "Confluence token cannot be empty", nil)
+	}
+	
+	return c.storeCredential(KeyConfluenceToken, creds.Token)
+}
+
+// GetConfluenceCredentials retrieves Confluence credentials
+func (c *CredentialStore) GetConfluenceCredentials() (ConfluenceCredentials, error) {
+	token, err := c.keyring.GetCredential(c.key(KeyConfluenceToken))
+	if err != nil {
+		return ConfluenceCredentials{}, err
+	}
+	
+	return ConfluenceCredentials{Token: token}, nil
+}
+
 // SetGoogleCredentials stores Google API credentials
 func (c *CredentialStore) SetGoogleCredentials(creds GoogleCredentials) error {
 	if creds.ClientSecret == "" {
@@ -334,40 +526,57 @@ func (c *CredentialStore) SetGoogleCredentials(creds GoogleCredentials) error {
 	}
 	
 	// Store all credentials
-	if err := c.keyring.StoreCredential(KeyGoogleClientSecret, creds.ClientSecret); err != nil {
+	if err := c.storeCredential(KeyGoogleClientSecret, creds.ClientSecret); err != nil {
 		return err
 	}
 	
 	if creds.AccessToken != "" {
-		if err := c.keyring.StoreCredential(KeyGoogleAccessToken, creds.AccessToken); err != nil {
+		if err := c.storeCredential(KeyGoogleAccessToken, creds.AccessToken); err != nil {
 			return err
 		}
 	}
 	
 	if creds.RefreshToken != "" {
-		if err := c.keyring.StoreCredential(KeyGoogleRefreshToken, creds.RefreshToken); err != nil {
+		if err := c.storeCredential(KeyGoogleRefreshToken, creds.RefreshToken); err != nil {
 			return err
 		}
 	}
 	
+	// An unknown expiry must not leave the previous token's expiry behind
+	if creds.Expiry.IsZero() {
+		if err := c.keyring.DeleteCredential(c.key(KeyGoogleTokenExpiry)); err != nil {
+			if appErr, ok := err.(*utils.AppError); !ok || appErr.Code != utils.ErrorCodeCredentialsMissing {
+				return err
+			}
+		}
+	} else if err := c.storeCredential(KeyGoogleTokenExpiry, creds.Expiry.UTC().Format(time.RFC3339)); err != nil {
+		return err
+	}
+	
 	return nil
 }
 
 // GetGoogleCredentials retrieves Google API credentials
 func (c *CredentialStore) GetGoogleCredentials() (GoogleCredentials, error) {
-	clientSecret, err := c.keyring.GetCredential(KeyGoogleClientSecret)
+	clientSecret, err := c.keyring.GetCredential(c.key(KeyGoogleClientSecret))
 	if err != nil {
 		return GoogleCredentials{}, err
 	}
 	
-	// Access token and refresh token are optional
-	accessToken, _ := c.keyring.GetCredential(KeyGoogleAccessToken)
-	refreshToken, _ := c.keyring.GetCredential(KeyGoogleRefreshToken)
+	// Access token, refresh token and expiry are optional
+	accessToken, _ := c.keyring.GetCredential(c.key(KeyGoogleAccessToken))
+	refreshToken, _ := c.keyring.GetCredential(c.key(KeyGoogleRefreshToken))
+	
+	var expiry time.Time
+	if stored, err := c.keyring.GetCredential(c.key(KeyGoogleTokenExpiry)); err == nil {
+		expiry, _ = time.Parse(time.RFC3339, stored)
+	}
 	
 	return GoogleCredentials{
 		ClientSecret: clientSecret,
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
+		Expiry:       expiry,
 	}, nil
 }
 
@@ -376,17 +585,17 @@ func (c *CredentialStore) ValidateAllCredentials() error {
 	var validationErrors utils.ValidationErrors
 	
 	// Validate Jira credentials
-	if err := c.keyring.ValidateCredential(KeyJiraToken); err != nil {
+	if err := c.keyring.ValidateCredential(c.key(KeyJiraToken)); err != nil {
 		validationErrors.Add("jira_token", "Jira token is required", nil)
 	}
 	
 	// Validate Gemini credentials
-	if err := c.keyring.ValidateCredential(KeyGeminiAPIKey); err != nil {
+	if err := c.keyring.ValidateCredential(c.key(KeyGeminiAPIKey)); err != nil {
 		validationErrors.Add("gemini_api_key", "Gemini API key is required", nil)
 	}
 	
 	// Validate Google credentials
-	if err := c.keyring.ValidateCredential(KeyGoogleClientSecret); err != nil {
+	if err := c.keyring.ValidateCredential(c.key(KeyGoogleClientSecret)); err != nil {
 		validationErrors.Add("google_client_secret", "Google client secret is required", nil)
 	}
 	
@@ -397,24 +606,43 @@ func (c *CredentialStore) ValidateAllCredentials() error {
 	return nil
 }
 
-// ClearAllCredentials removes all stored credentials
+// profileCredentialKeys are the credentials each profile keeps separately
+var profileCredentialKeys = []string{
+	KeyJiraToken,
+	KeyGeminiAPIKey,
+	KeyGoogleClientSecret,
+	KeyGoogleAccessToken,
+	KeyGoogleRefreshToken,
+	KeyGoogleTokenExpiry,
+	KeyConfluenceToken,
+}
+
+// ClearAllCredentials removes all credentials of the active profile. The encryption key is
+// shared by every profile, so it is only removed along with the default profile's credentials.
 func (c *CredentialStore) ClearAllCredentials() error {
-	keys := []string{
-		KeyJiraToken,
-		KeyGeminiAPIKey,
-		KeyGoogleClientSecret,
-		KeyGoogleAccessToken,
-		KeyGoogleRefreshToken,
-		KeyEncryptionKey,
+	profile := c.ActiveProfile()
+	if err := c.clearProfile(profile); err != nil {
+		return err
 	}
 	
+	if profile == DefaultProfile {
+		if err := ignoreMissing(c.keyring.DeleteCredential(KeyEncryptionKey)); err != nil {
+			return utils.NewAppError(utils.ErrorCodeKeyringError, "Failed to clear some credentials", err)
+		}
+	}
+	
+	c.logger.Info("All credentials cleared successfully", utils.NewField("profile", profile))
+	
+	return nil
+}
+
+// clearProfile removes the credentials stored in profile
+func (c *CredentialStore) clearProfile(profile string) error {
 	var errors []error
-	for _, key := range keys {
-		if err := c.keyring.DeleteCredential(key); err != nil {
-			// Only log errors for keys that actually exist
-			if appErr, ok := err.(*utils.AppError); ok && appErr.Code != utils.ErrorCodeCredentialsMissing {
-				errors = append(errors, err)
-			}
+	for _, key := range profileCredentialKeys {
+		// Only report errors for keys that actually exist
+		if err := ignoreMissing(c.keyring.DeleteCredential(profileKey(profile, key))); err != nil {
+			errors = append(errors, err)
 		}
 	}
 	
@@ -422,7 +650,5 @@ func (c *CredentialStore) ClearAllCredentials() error {
 		return utils.NewAppError(utils.ErrorCodeKeyringError, "Failed to clear some credentials", errors[0])
 	}
 	
-	c.logger.Info("All credentials cleared successfully")
-	
 	return nil
-}
\ No newline at end of file
+}
@@ -454,4 +454,71 @@ func TestCredentialStore_ClearAllCredentials(t *testing.T) {
 	assert.False(t, store.keyring.HasCredential(KeyGoogleAccessToken))
 	assert.False(t, store.keyring.HasCredential(KeyGoogleRefreshToken))
 	assert.False(t, store.keyring.HasCredential(KeyEncryptionKey))
-}
\ No newline at end of file
+}
+
+func TestCredentialStore_Profiles(t *testing.T) {
+	logger := utils.NewMockLogger()
+	store := NewCredentialStore(logger)
+	defer store.ClearAllCredentials()
+	defer store.DeleteProfile("client-a")
+	
+	assert.Equal(t, DefaultProfile, store.ActiveProfile())
+	require.NoError(t, store.SetJiraCredentials(JiraCredentials{Token: "internal_token"}))
+	
+	store.UseProfile("client-a")
+	assert.Equal(t, "client-a", store.ActiveProfile())
+	
+	// A new profile starts without credentials
+	_, err := store.GetJiraCredentials()
+	require.Error(t, err)
+	
+	require.NoError(t, store.SetJiraCredentials(JiraCredentials{Token: "client_a_token"}))
+	require.NoError(t, store.SetGoogleCredentials(GoogleCredentials{ClientSecret: "client_a_secret", RefreshToken: "client_a_refresh"}))
+	
+	creds, err := store.GetJiraCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "client_a_token", creds.Token)
+	
+	// Switching back returns the default profile's credentials untouched
+	store.UseProfile("")
+	assert.Equal(t, DefaultProfile, store.ActiveProfile())
+	creds, err = store.GetJiraCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "internal_token", creds.Token)
+	_, err = store.GetGoogleCredentials()
+	assert.Error(t, err)
+	
+	store.UseProfile("client-a")
+	google, err := store.GetGoogleCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "client_a_secret", google.ClientSecret)
+	assert.Equal(t, "client_a_refresh", google.RefreshToken)
+	
+	profiles, err := store.ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultProfile, "client-a"}, profiles)
+}
+
+func TestCredentialStore_DeleteProfile(t *testing.T) {
+	logger := utils.NewMockLogger()
+	store := NewCredentialStore(logger)
+	defer store.ClearAllCredentials()
+	
+	require.NoError(t, store.SetJiraCredentials(JiraCredentials{Token: "internal_token"}))
+	store.UseProfile("client-b")
+	require.NoError(t, store.SetJiraCredentials(JiraCredentials{Token: "client_b_token"}))
+	
+	require.NoError(t, store.DeleteProfile("client-b"))
+	assert.Equal(t, DefaultProfile, store.ActiveProfile())
+	assert.False(t, store.keyring.HasCredential(profileKey("client-b", KeyJiraToken)))
+	
+	profiles, err := store.ListProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{DefaultProfile}, profiles)
+	
+	// The default profile keeps its credentials and cannot be deleted
+	creds, err := store.GetJiraCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "internal_token", creds.Token)
+	assert.Error(t, store.DeleteProfile(DefaultProfile))
+}
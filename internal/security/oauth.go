@@ -0,0 +1,346 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// GoogleAuthURL is the OAuth consent page for Google accounts
+const GoogleAuthURL = "https://accounts.google.com/o/oauth2/v2/auth"
+
+// GoogleOAuthScopes are the permissions requested at login: creating and editing documents,
+// and sharing the files the app creates
+var GoogleOAuthScopes = []string{
+	"https://www.googleapis.com/auth/documents",
+	"https://www.googleapis.com/auth/drive.file",
+}
+
+// DefaultOAuthTimeout bounds how long the login flow waits for the user to give consent
+const DefaultOAuthTimeout = 5 * time.Minute
+
+// oauthCallbackPath is where the consent page redirects to on the local listener
+const oauthCallbackPath = "/oauth/callback"
+
+// OAuthFlow signs in to Google with the authorization code flow for installed apps, secured
+// with PKCE. The consent page redirects to a listener on the loopback interface, and the tokens
+// obtained are stored in the credential store.
+type OAuthFlow struct {
+	httpClient  *AuthenticatedHTTPClient
+	creds       *CredentialStore
+	logger      utils.Logger
+	clientID    string
+	authURL     string
+	tokenURL    string
+	scopes      []string
+	port        int // Zero picks a free port
+	timeout     time.Duration
+	openBrowser func(url string) error
+}
+
+// NewOAuthFlow creates a login flow for the OAuth client clientID. The client secret must
+// already be in the credential store.
+func NewOAuthFlow(httpClient *AuthenticatedHTTPClient, creds *CredentialStore, clientID string, logger utils.Logger) *OAuthFlow {
+	return &OAuthFlow{
+		httpClient:  httpClient,
+		creds:       creds,
+		logger:      logger,
+		clientID:    clientID,
+		authURL:     GoogleAuthURL,
+		tokenURL:    GoogleTokenURL,
+		scopes:      GoogleOAuthScopes,
+		timeout:     DefaultOAuthTimeout,
+		openBrowser: openInBrowser,
+	}
+}
+
+// SetPort sets the local port the redirect listener binds to. The port must match a redirect
+// URI registered for the client when the client requires one; zero picks a free port.
+func (f *OAuthFlow) SetPort(port int) {
+	f.port = port
+}
+
+// SetTimeout sets how long Login waits for the redirect, zero restores DefaultOAuthTimeout
+func (f *OAuthFlow) SetTimeout(timeout time.Duration) {
+	f.timeout = durationOrDefault(timeout, DefaultOAuthTimeout)
+}
+
+// SetEndpoints overrides the consent page and token endpoint
+func (f *OAuthFlow) SetEndpoints(authURL, tokenURL string) {
+	f.authURL = authURL
+	f.tokenURL = tokenURL
+}
+
+// SetBrowserOpener replaces how the consent page is opened, by default the system browser
+func (f *OAuthFlow) SetBrowserOpener(open func(url string) error) {
+	f.openBrowser = open
+}
+
+// NewPKCE generates a PKCE code verifier and its S256 challenge (RFC 7636)
+func NewPKCE() (verifier, challenge string, err error) {
+	verifier, err = randomURLString(32)
+	if err != nil {
+		return "", "", err
+	}
+	return verifier, PKCEChallenge(verifier), nil
+}
+
+// PKCEChallenge derives the S256 code challenge of a code verifier
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// randomURLString returns size random bytes encoded for use in URLs
+func randomURLString(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", utils.NewAppError(utils.ErrorCodeEncryptionError, "Failed to generate random value", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// AuthorizationURL builds the consent page URL. Offline access is requested so Google issues a
+// refresh token.
+func (f *OAuthFlow) AuthorizationURL(redirectURI, state, challenge string) string {
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {f.clientID},
+		"redirect_uri":          {redirectURI},
+		"scope":                 {strings.Join(f.scopes, " ")},
+		"state":                 {state},
+		"code_challenge":        {challenge},
+		"code_challenge_method": {"S256"},
+		"access_type":           {"offline"},
+		"prompt":                {"consent"},
+	}
+	return f.authURL + "?" + query.Encode()
+}
+
+// oauthCallback is the outcome of the consent page redirect
+type oauthCallback struct {
+	code string
+	err  error
+}
+
+// Login opens the consent page, waits for the redirect with the authorization code, exchanges
+// it for tokens and stores them. It gives up after the configured timeout or when ctx is done.
+func (f *OAuthFlow) Login(ctx context.Context) (GoogleCredentials, error) {
+	if f.clientID == "" {
+		return GoogleCredentials{}, utils.NewAppError(utils.ErrorCodeConfigInvalid, "Google client ID is required to sign in", nil).
+			WithService("google")
+	}
+	
+	verifier, challenge, err := NewPKCE()
+	if err != nil {
+		return GoogleCredentials{}, err
+	}
+	state, err := randomURLString(16)
+	if err != nil {
+		return GoogleCredentials{}, err
+	}
+	
+	// Only the loopback interface, so nothing else on the network can deliver a code
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", f.port))
+	if err != nil {
+		return GoogleCredentials{}, utils.NewAppError(utils.ErrorCodeNetworkError, "Failed to start OAuth redirect listener", err).
+			WithService("google").
+			WithExtra("port", f.port)
+	}
+	redirectURI := fmt.Sprintf("http://%s%s", listener.Addr().String(), oauthCallbackPath)
+	
+	callbacks := make(chan oauthCallback, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(oauthCallbackPath, func(w http.ResponseWriter, r *http.Request) {
+		result := parseOAuthCallback(r.URL.Query(), state)
+		if result.err != nil {
+			http.Error(w, "Sign-in failed. You can close this window and try again.", http.StatusBadRequest)
+		} else {
+			fmt.Fprint(w, "Sign-in complete. You can close this window and return to the application.")
+		}
+		select {
+		case callbacks <- result:
+		default:
+		}
+	})
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go server.Serve(listener)
+	defer server.Close()
+	
+	consentURL := f.AuthorizationURL(redirectURI, state, challenge)
+	if err := f.openBrowser(consentURL); err != nil {
+		// The user can still open the page by hand
+		f.logger.Warn("Failed to open browser, open the sign-in page manually",
+			utils.NewField("url", consentURL),
+			utils.NewField("error", err.Error()),
+		)
+	}
+	f.logger.Info("Waiting for Google sign-in",
+		utils.NewField("redirect_uri", redirectURI),
+		utils.NewField("timeout", f.timeout.String()),
+	)
+	
+	timer := time.NewTimer(f.timeout)
+	defer timer.Stop()
+	
+	select {
+	case result := <-callbacks:
+		if result.err != nil {
+			return GoogleCredentials{}, result.err
+		}
+		return f.Exchange(ctx, result.code, verifier, redirectURI)
+	case <-timer.C:
+		return GoogleCredentials{}, utils.NewAppError(utils.ErrorCodeTimeoutError, "Timed out waiting for Google sign-in", nil).
+			WithService("google").
+			WithExtra("timeout", f.timeout.String())
+	case <-ctx.Done():
+		return GoogleCredentials{}, utils.WrapError(ctx.Err(), utils.ErrorCodeAuthFailed, "Google sign-in was cancelled").
+			WithService("google")
+	}
+}
+
+// parseOAuthCallback reads the authorization code from the redirect query, rejecting a
+// redirect whose state does not match the one sent
+func parseOAuthCallback(query url.Values, state string) oauthCallback {
+	if query.Get("state") != state {
+		return oauthCallback{err: utils.NewAppError(utils.ErrorCodeAuthFailed, "OAuth redirect state does not match", nil).
+			WithService("google")}
+	}
+	if reason := query.Get("error"); reason != "" {
+		return oauthCallback{err: utils.NewAppError(utils.ErrorCodeAuthFailed, "Google sign-in was denied", nil).
+			WithService("google").
+			WithExtra("reason", reason)}
+	}
+	code := query.Get("code")
+	if code == "" {
+		return oauthCallback{err: utils.NewAppError(utils.ErrorCodeAuthFailed, "OAuth redirect has no authorization code", nil).
+			WithService("google")}
+	}
+	return oauthCallback{code: code}
+}
+
+// Exchange trades an authorization code and its PKCE verifier for tokens, stores them with
+// the client secret already in the credential store, and returns the stored credentials
+func (f *OAuthFlow) Exchange(ctx context.Context, code, verifier, redirectURI string) (GoogleCredentials, error) {
+	creds, err := f.creds.GetGoogleCredentials()
+	if err != nil {
+		return GoogleCredentials{}, utils.WrapError(err, utils.ErrorCodeCredentialsMissing, "Google client secret is required to sign in").
+			WithService("google")
+	}
+	
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"code_verifier": {verifier},
+		"redirect_uri":  {redirectURI},
+		"client_id":     {f.clientID},
+		"client_secret": {creds.ClientSecret},
+	}
+	token, err := requestToken(ctx, f.httpClient, f.tokenURL, form, "Google rejected the authorization code")
+	if err != nil {
+		return GoogleCredentials{}, err
+	}
+	
+	creds.AccessToken = token.AccessToken
+	creds.Expiry = token.expiry(time.Now())
+	if token.RefreshToken != "" {
+		creds.RefreshToken = token.RefreshToken
+	}
+	
+	if err := f.creds.SetGoogleCredentials(creds); err != nil {
+		return GoogleCredentials{}, utils.WrapError(err, utils.ErrorCodeKeyringError, "Failed to store Google credentials")
+	}
+	
+	f.logger.Info("Signed in to Google",
+		utils.NewField("refresh_token", creds.RefreshToken != ""),
+		utils.NewField("expires_at", creds.Expiry),
+	)
+	
+	return creds, nil
+}
+
+// tokenResponse is the body of a successful OAuth token request
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// expiry returns when the access token expires, zero when the response gave no lifetime
+func (t *tokenResponse) expiry(now time.Time) time.Time {
+	if t.ExpiresIn <= 0 {
+		return time.Time{}
+	}
+	return now.Add(time.Duration(t.ExpiresIn) * time.Second)
+}
+
+// requestToken posts form to an OAuth token endpoint. A 400 or 401 means the grant was
+// rejected and is reported with rejectedMessage.
+func requestToken(ctx context.Context, httpClient *AuthenticatedHTTPClient, tokenURL string, form url.Values, rejectedMessage string) (*tokenResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, utils.NewAppError(utils.ErrorCodeNetworkError, "Failed to create token request", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	
+	resp, err := httpClient.DoRequest(req)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeAuthFailed, "Failed to request Google access token").
+			WithService("google")
+	}
+	defer resp.Body.Close()
+	
+	body, err := httpClient.ReadBody(resp)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeAuthFailed, "Failed to read token response").
+			WithService("google")
+	}
+	
+	// A rejected grant has been revoked, expired or already used, and needs a new sign-in
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		return nil, utils.NewAppError(utils.ErrorCodeTokenInvalid, rejectedMessage, nil).
+			WithService("google").
+			WithExtra("status_code", resp.StatusCode).
+			WithExtra("response", utils.DescribeErrorBody(resp.StatusCode, body))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, utils.NewAppError(utils.ErrorCodeAuthFailed, "Unexpected response from Google token endpoint", nil).
+			WithService("google").
+			WithExtra("status_code", resp.StatusCode)
+	}
+	
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil || token.AccessToken == "" {
+		return nil, utils.NewAppError(utils.ErrorCodeAuthFailed, "Invalid token response from Google", err).
+			WithService("google")
+	}
+	
+	return &token, nil
+}
+
+// openInBrowser opens url with the platform's default browser
+func openInBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
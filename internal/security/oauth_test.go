@@ -0,0 +1,186 @@
+package security
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewPKCE(t *testing.T) {
+	verifier, challenge, err := NewPKCE()
+	require.NoError(t, err)
+	
+	// RFC 7636 allows 43 to 128 unreserved characters
+	assert.Regexp(t, regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`), verifier)
+	assert.Equal(t, PKCEChallenge(verifier), challenge)
+	
+	other, _, err := NewPKCE()
+	require.NoError(t, err)
+	assert.NotEqual(t, verifier, other)
+}
+
+func TestPKCEChallenge(t *testing.T) {
+	// Example from RFC 7636 appendix B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		PKCEChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestOAuthFlow_AuthorizationURL(t *testing.T) {
+	logger := utils.NewMockLogger()
+	flow := NewOAuthFlow(NewAuthenticatedHTTPClient(nil, logger), NewCredentialStore(logger), "test_client_id", logger)
+	
+	consent, err := url.Parse(flow.AuthorizationURL("http://127.0.0.1:8085/oauth/callback", "test_state", "test_challenge"))
+	require.NoError(t, err)
+	
+	assert.Equal(t, "accounts.google.com", consent.Host)
+	query := consent.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, "test_client_id", query.Get("client_id"))
+	assert.Equal(t, "http://127.0.0.1:8085/oauth/callback", query.Get("redirect_uri"))
+	assert.Equal(t, "test_state", query.Get("state"))
+	assert.Equal(t, "test_challenge", query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Equal(t, "offline", query.Get("access_type"))
+	assert.Equal(t, "https://www.googleapis.com/auth/documents https://www.googleapis.com/auth/drive.file", query.Get("scope"))
+}
+
+// newCodeExchangeServer accepts the authorization code "test_code" with the verifier
+// "test_verifier" and issues an access and refresh token
+func newCodeExchangeServer(redirectURI *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("grant_type") != "authorization_code" || r.Form.Get("code") != "test_code" ||
+			r.Form.Get("code_verifier") == "" || r.Form.Get("client_id") != "test_client_id" ||
+			r.Form.Get("client_secret") != "test_client_secret" || r.Form.Get("redirect_uri") != *redirectURI {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error": "invalid_grant"}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token": "new_access_token", "refresh_token": "new_refresh_token", "expires_in": 3599}`))
+	}))
+}
+
+func newTestOAuthFlow(t *testing.T, tokenURL string) (*OAuthFlow, *CredentialStore) {
+	logger := utils.NewMockLogger()
+	credStore := NewCredentialStore(logger)
+	require.NoError(t, credStore.SetGoogleCredentials(GoogleCredentials{ClientSecret: "test_client_secret"}))
+	t.Cleanup(func() { credStore.ClearAllCredentials() })
+	
+	flow := NewOAuthFlow(NewAuthenticatedHTTPClient(nil, logger), credStore, "test_client_id", logger)
+	flow.SetEndpoints("https://accounts.example.com/auth", tokenURL)
+	return flow, credStore
+}
+
+func TestOAuthFlow_Exchange(t *testing.T) {
+	redirectURI := "http://127.0.0.1:8085/oauth/callback"
+	tokenServer := newCodeExchangeServer(&redirectURI)
+	defer tokenServer.Close()
+	
+	flow, credStore := newTestOAuthFlow(t, tokenServer.URL)
+	
+	before := time.Now()
+	creds, err := flow.Exchange(context.Background(), "test_code", "test_verifier", redirectURI)
+	require.NoError(t, err)
+	
+	assert.Equal(t, "new_access_token", creds.AccessToken)
+	assert.Equal(t, "new_refresh_token", creds.RefreshToken)
+	assert.WithinDuration(t, before.Add(3599*time.Second), creds.Expiry, 5*time.Second)
+	
+	stored, err := credStore.GetGoogleCredentials()
+	require.NoError(t, err)
+	assert.Equal(t, "test_client_secret", stored.ClientSecret)
+	assert.Equal(t, "new_access_token", stored.AccessToken)
+	assert.Equal(t, "new_refresh_token", stored.RefreshToken)
+}
+
+func TestOAuthFlow_Exchange_Rejected(t *testing.T) {
+	redirectURI := "http://127.0.0.1:8085/oauth/callback"
+	tokenServer := newCodeExchangeServer(&redirectURI)
+	defer tokenServer.Close()
+	
+	flow, credStore := newTestOAuthFlow(t, tokenServer.URL)
+	
+	_, err := flow.Exchange(context.Background(), "used_code", "test_verifier", redirectURI)
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeTokenInvalid, appErr.Code)
+	
+	// Nothing is stored for a rejected code
+	stored, err := credStore.GetGoogleCredentials()
+	require.NoError(t, err)
+	assert.Empty(t, stored.AccessToken)
+}
+
+func TestOAuthFlow_Login(t *testing.T) {
+	var redirectURI string
+	tokenServer := newCodeExchangeServer(&redirectURI)
+	defer tokenServer.Close()
+	
+	flow, _ := newTestOAuthFlow(t, tokenServer.URL)
+	flow.SetTimeout(5 * time.Second)
+	
+	// Stand in for the user approving the consent page
+	flow.SetBrowserOpener(func(consentURL string) error {
+		consent, err := url.Parse(consentURL)
+		if err != nil {
+			return err
+		}
+		redirectURI = consent.Query().Get("redirect_uri")
+		callback := redirectURI + "?" + url.Values{
+			"state": {consent.Query().Get("state")},
+			"code":  {"test_code"},
+		}.Encode()
+		go func() {
+			resp, err := http.Get(callback)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	})
+	
+	creds, err := flow.Login(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "new_access_token", creds.AccessToken)
+	assert.Equal(t, "new_refresh_token", creds.RefreshToken)
+	assert.Regexp(t, `^http://127\.0\.0\.1:\d+/oauth/callback$`, redirectURI)
+}
+
+func TestOAuthFlow_Login_Timeout(t *testing.T) {
+	flow, _ := newTestOAuthFlow(t, "http://127.0.0.1:1/token")
+	flow.SetTimeout(50 * time.Millisecond)
+	flow.SetBrowserOpener(func(string) error { return nil })
+	
+	_, err := flow.Login(context.Background())
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeTimeoutError, appErr.Code)
+}
+
+func TestParseOAuthCallback(t *testing.T) {
+	result := parseOAuthCallback(url.Values{"state": {"expected"}, "code": {"abc"}}, "expected")
+	require.NoError(t, result.err)
+	assert.Equal(t, "abc", result.code)
+	
+	// A redirect carrying another state may be forged
+	result = parseOAuthCallback(url.Values{"state": {"forged"}, "code": {"abc"}}, "expected")
+	assert.Error(t, result.err)
+	
+	result = parseOAuthCallback(url.Values{"state": {"expected"}, "error": {"access_denied"}}, "expected")
+	require.Error(t, result.err)
+	assert.Equal(t, "access_denied", result.err.(*utils.AppError).Context.Extra["reason"])
+	
+	result = parseOAuthCallback(url.Values{"state": {"expected"}}, "expected")
+	assert.Error(t, result.err)
+}
@@ -0,0 +1,115 @@
+package summarizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// ProviderGemini is the name of the built-in Gemini summarizer and the default provider
+const ProviderGemini = "gemini"
+
+// Factory builds a summarizer from the configuration, taking credentials from authManager
+type Factory func(cfg *config.Config, authManager *security.AuthManager, logger utils.Logger) (Summarizer, error)
+
+// Registry maps provider names to the factories that build them
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]Factory)}
+}
+
+// defaultRegistry holds the providers available to the application
+var defaultRegistry = newDefaultRegistry()
+
+// newDefaultRegistry creates a registry with the built-in providers
+func newDefaultRegistry() *Registry {
+	registry := NewRegistry()
+	registry.Register(ProviderGemini, func(cfg *config.Config, authManager *security.AuthManager, logger utils.Logger) (Summarizer, error) {
+		client := gemini.NewClient(cfg, authManager, logger)
+		prompt, err := gemini.LoadPromptTemplate(cfg)
+		if err != nil {
+			return nil, err
+		}
+		client.SetPromptTemplate(prompt)
+		client.SetSummaryCache(gemini.NewSummaryCache(cfg))
+		return NewGeminiSummarizer(client), nil
+	})
+	return registry
+}
+
+// Register makes a provider available under name, which is matched case-insensitively.
+// Registering a name again replaces its factory.
+func (r *Registry) Register(name string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[normalizeProvider(name)] = factory
+}
+
+// New builds the provider registered under name. An empty name selects ProviderGemini.
+func (r *Registry) New(name string, cfg *config.Config, authManager *security.AuthManager, logger utils.Logger) (Summarizer, error) {
+	name = normalizeProvider(name)
+	if name == "" {
+		name = ProviderGemini
+	}
+	
+	r.mu.RLock()
+	factory, exists := r.factories[name]
+	r.mu.RUnlock()
+	
+	if !exists {
+		return nil, utils.NewAppError(utils.ErrorCodeConfigInvalid,
+			fmt.Sprintf("Unknown summary provider %q, expected one of: %s", name, strings.Join(r.Providers(), ", ")), nil).
+			WithExtra("provider", name)
+	}
+	
+	summarizer, err := factory(cfg, authManager, logger)
+	if err != nil {
+		return nil, utils.WrapError(err, utils.ErrorCodeSummarizerError,
+			fmt.Sprintf("Failed to create summary provider %q", name))
+	}
+	return summarizer, nil
+}
+
+// Providers returns the registered provider names in sorted order
+func (r *Registry) Providers() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	
+	names := make([]string, 0, len(r.factories))
+	for name := range r.factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Register makes a provider available to New under name
+func Register(name string, factory Factory) {
+	defaultRegistry.Register(name, factory)
+}
+
+// New builds the provider registered under name, ProviderGemini when name is empty
+func New(name string, cfg *config.Config, authManager *security.AuthManager, logger utils.Logger) (Summarizer, error) {
+	return defaultRegistry.New(name, cfg, authManager, logger)
+}
+
+// Providers returns the names of the available providers in sorted order
+func Providers() []string {
+	return defaultRegistry.Providers()
+}
+
+// normalizeProvider trims and lowercases a provider name
+func normalizeProvider(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,42 @@
+// Package summarizer defines the interface the pipeline uses to write the narrative summary,
+// so a language model other than Gemini can be plugged in, and a registry for selecting one
+// by name from the configuration.
+package summarizer
+
+import (
+	"context"
+
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/pkg/models"
+)
+
+// Summary is a generated narrative summary. It keeps the shape of the Gemini response, which
+// the cache, the published metadata and the JSON output already use; other providers fill in
+// Summary, Model and GeneratedAt and leave what does not apply to them empty.
+type Summary = gemini.SummaryResponse
+
+// Options configures a single summary
+type Options struct {
+	CustomPrompt string // Instructions added to the prompt, such as a focus or the summary language
+}
+
+// Summarizer writes a narrative summary of activities. Implementations must be safe for
+// concurrent use.
+type Summarizer interface {
+	GenerateSummary(ctx context.Context, activities []models.Activity, opts Options) (*Summary, error)
+}
+
+// GeminiSummarizer is the Summarizer backed by a Gemini client
+type GeminiSummarizer struct {
+	client gemini.GeminiClientInterface
+}
+
+// NewGeminiSummarizer creates a Summarizer that generates summaries with client
+func NewGeminiSummarizer(client gemini.GeminiClientInterface) *GeminiSummarizer {
+	return &GeminiSummarizer{client: client}
+}
+
+// GenerateSummary generates a summary with the Gemini client
+func (s *GeminiSummarizer) GenerateSummary(ctx context.Context, activities []models.Activity, opts Options) (*Summary, error) {
+	return s.client.GenerateSummary(ctx, activities, opts.CustomPrompt)
+}
@@ -0,0 +1,98 @@
+package summarizer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/security"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGeminiClient records the prompt it is asked to summarize with
+type fakeGeminiClient struct {
+	gemini.GeminiClientInterface
+	prompt string
+}
+
+func (f *fakeGeminiClient) GenerateSummary(ctx context.Context, activities []models.Activity, prompt string) (*gemini.SummaryResponse, error) {
+	f.prompt = prompt
+	return &gemini.SummaryResponse{Summary: "Done", Activities: activities}, nil
+}
+
+// fakeSummarizer returns a fixed summary
+type fakeSummarizer struct {
+	text string
+}
+
+func (f *fakeSummarizer) GenerateSummary(ctx context.Context, activities []models.Activity, opts Options) (*Summary, error) {
+	return &Summary{Summary: f.text, Model: "fake"}, nil
+}
+
+func TestGeminiSummarizer_GenerateSummary(t *testing.T) {
+	client := &fakeGeminiClient{}
+	activities := []models.Activity{{Key: "PROJ-1"}}
+	
+	summary, err := NewGeminiSummarizer(client).GenerateSummary(context.Background(), activities, Options{CustomPrompt: "Focus on risks"})
+	require.NoError(t, err)
+	
+	assert.Equal(t, "Focus on risks", client.prompt)
+	assert.Equal(t, "Done", summary.Summary)
+	assert.Equal(t, activities, summary.Activities)
+}
+
+func TestRegistry_New(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	
+	registry := NewRegistry()
+	registry.Register("Self-Hosted", func(cfg *config.Config, authManager *security.AuthManager, logger utils.Logger) (Summarizer, error) {
+		return &fakeSummarizer{text: "local"}, nil
+	})
+	registry.Register("broken", func(cfg *config.Config, authManager *security.AuthManager, logger utils.Logger) (Summarizer, error) {
+		return nil, errors.New("endpoint not configured")
+	})
+	assert.Equal(t, []string{"broken", "self-hosted"}, registry.Providers())
+	
+	// Names match case-insensitively
+	s, err := registry.New(" SELF-HOSTED ", cfg, authManager, logger)
+	require.NoError(t, err)
+	summary, err := s.GenerateSummary(context.Background(), nil, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "local", summary.Summary)
+	
+	// Factory errors are wrapped
+	_, err = registry.New("broken", cfg, authManager, logger)
+	require.Error(t, err)
+	appErr, ok := err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeSummarizerError, appErr.Code)
+	
+	// Unknown names list the registered providers
+	_, err = registry.New("openai", cfg, authManager, logger)
+	require.Error(t, err)
+	appErr, ok = err.(*utils.AppError)
+	require.True(t, ok)
+	assert.Equal(t, utils.ErrorCodeConfigInvalid, appErr.Code)
+	assert.Contains(t, appErr.Message, "broken, self-hosted")
+}
+
+func TestNew_DefaultsToGemini(t *testing.T) {
+	logger := utils.NewMockLogger()
+	cfg := config.DefaultConfig()
+	authManager := security.NewAuthManager(security.DefaultAuthConfig(), logger)
+	
+	assert.Contains(t, Providers(), ProviderGemini)
+	
+	for _, name := range []string{"", ProviderGemini} {
+		s, err := New(name, cfg, authManager, logger)
+		require.NoError(t, err)
+		assert.IsType(t, &GeminiSummarizer{}, s)
+	}
+}
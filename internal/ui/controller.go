@@ -0,0 +1,235 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/history"
+	"github.com/company/eesa/internal/pipeline"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// dateLayout is the layout dates are shown in, in titles and messages
+const dateLayout = "2006-01-02"
+
+// FormState holds the values of the main window's widgets. It is kept apart from the widgets
+// so the options a run is started with can be built and tested without a window.
+type FormState struct {
+	StartDate  time.Time // First day of the range
+	EndDate    time.Time // Last day of the range, included in full
+	Users      []string  // Users selected in the user list
+	OtherUsers string    // Comma separated users typed in addition to the selection
+	Title      string    // Document title, defaults to one naming the date range
+	
+	GroupByUser       bool
+	GroupByPriority   bool
+	GroupByStatus     bool
+	GroupByType       bool
+	GroupByProject    bool
+	GroupByEpic       bool
+	IncludeWorklogs   bool
+	CalculateVelocity bool
+	AnalyzeTrends     bool
+	Detailed          bool // Write a detailed summary instead of an executive one
+	DryRun            bool // Preview the document writes instead of making them
+}
+
+// NewFormState returns the initial state of the form: the configured default time range and
+// users, with the checkboxes set from processor.DefaultProcessingOptions
+func NewFormState(cfg *config.Config) FormState {
+	defaults := processor.DefaultProcessingOptions()
+	state := FormState{
+		Users:             append([]string(nil), cfg.Defaults.Users...),
+		GroupByUser:       defaults.GroupByUser,
+		GroupByPriority:   defaults.GroupByPriority,
+		GroupByStatus:     defaults.GroupByStatus,
+		GroupByType:       defaults.GroupByType,
+		GroupByProject:    defaults.GroupByProject,
+		GroupByEpic:       defaults.GroupByEpic,
+		IncludeWorklogs:   defaults.IncludeWorklogs,
+		CalculateVelocity: defaults.CalculateVelocity,
+		AnalyzeTrends:     defaults.AnalyzeTrends,
+	}
+	
+	defaultRange := cfg.Defaults.TimeRange
+	if defaultRange == "" {
+		defaultRange = "1w"
+	}
+	timeRange, err := config.ParseTimeRange(defaultRange)
+	if err != nil {
+		timeRange, _ = config.ParseTimeRange("1w")
+	}
+	state.StartDate = dateOf(timeRange.Start)
+	state.EndDate = dateOf(timeRange.End)
+	
+	return state
+}
+
+// AllUsers returns the selected users followed by the typed ones, without blanks or repeats
+func (s FormState) AllUsers() []string {
+	var users []string
+	seen := make(map[string]bool)
+	for _, user := range append(append([]string(nil), s.Users...), strings.Split(s.OtherUsers, ",")...) {
+		user = strings.TrimSpace(user)
+		if user == "" || seen[user] {
+			continue
+		}
+		seen[user] = true
+		users = append(users, user)
+	}
+	return users
+}
+
+// TimeRange returns the range from the start of the start date to the end of the end date
+func (s FormState) TimeRange() (config.TimeRange, error) {
+	if s.StartDate.IsZero() || s.EndDate.IsZero() {
+		return config.TimeRange{}, utils.NewAppError(utils.ErrorCodeValidationError,
+			"Select a start and end date", nil).
+			WithOperation("build_options")
+	}
+	
+	start, end := dateOf(s.StartDate), dateOf(s.EndDate)
+	if end.Before(start) {
+		return config.TimeRange{}, utils.NewAppError(utils.ErrorCodeValidationError,
+			"The end date must not be before the start date", nil).
+			WithOperation("build_options").
+			WithExtra("start", start.Format(dateLayout)).
+			WithExtra("end", end.Format(dateLayout))
+	}
+	
+	return config.TimeRange{Start: start, End: end.AddDate(0, 0, 1)}, nil
+}
+
+// ProcessingOptions returns the processing options selected with the checkboxes
+func (s FormState) ProcessingOptions() processor.ProcessingOptions {
+	return processor.ProcessingOptions{
+		IncludeWorklogs:   s.IncludeWorklogs,
+		GroupByUser:       s.GroupByUser,
+		GroupByPriority:   s.GroupByPriority,
+		GroupByStatus:     s.GroupByStatus,
+		GroupByType:       s.GroupByType,
+		GroupByProject:    s.GroupByProject,
+		GroupByEpic:       s.GroupByEpic,
+		CalculateVelocity: s.CalculateVelocity,
+		AnalyzeTrends:     s.AnalyzeTrends,
+	}
+}
+
+// SummaryRequest returns the summary request for a document titled title. Trends and users
+// are only covered when they were analyzed.
+func (s FormState) SummaryRequest(title string) processor.SummaryRequest {
+	format := processor.FormatExecutive
+	if s.Detailed {
+		format = processor.FormatDetailed
+	}
+	
+	return processor.SummaryRequest{
+		Title:          title,
+		IncludeMetrics: true,
+		IncludeTrends:  s.AnalyzeTrends,
+		IncludeUsers:   s.GroupByUser,
+		Format:         format,
+	}
+}
+
+// PipelineOptions validates the form and returns the options of the run it describes
+func (s FormState) PipelineOptions() (pipeline.Options, error) {
+	timeRange, err := s.TimeRange()
+	if err != nil {
+		return pipeline.Options{}, err
+	}
+	
+	users := s.AllUsers()
+	if len(users) == 0 {
+		return pipeline.Options{}, utils.NewAppError(utils.ErrorCodeValidationError,
+			"Select at least one user", nil).
+			WithOperation("build_options")
+	}
+	
+	title := strings.TrimSpace(s.Title)
+	if title == "" {
+		title = fmt.Sprintf("Executive Summary %s to %s",
+			timeRange.Start.Format(dateLayout), timeRange.End.Add(-time.Nanosecond).Format(dateLayout))
+	}
+	
+	return pipeline.Options{
+		Users:                users,
+		TimeRange:            timeRange,
+		Processing:           s.ProcessingOptions(),
+		Summary:              s.SummaryRequest(title),
+		DocumentTitle:        title,
+		NoCompletionFallback: true,
+		DryRun:               s.DryRun,
+	}, nil
+}
+
+// dateOf returns midnight UTC of t's calendar date, the form dates pick days, not instants
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ResultView is what the results pane shows for a finished run
+type ResultView struct {
+	Status        string
+	Summary       string
+	DocumentTitle string
+	DocumentURL   string // Empty when no document was created
+}
+
+// NewResultView describes a pipeline result for the results pane. The AI summary is shown when
+// there is one, otherwise the executive summary of the deterministic one; a dry run lists the
+// writes it skipped instead of linking a document, and a canceled run only says so.
+func NewResultView(result *pipeline.Result) ResultView {
+	if result.Canceled {
+		return ResultView{Status: "Canceled, nothing was published"}
+	}
+	
+	view := ResultView{Status: fmt.Sprintf("Summarized %d activities", len(result.Activities))}
+	
+	switch {
+	case result.AISummary != nil && result.AISummary.Summary != "":
+		view.Summary = result.AISummary.Summary
+	case result.Summary != nil:
+		view.Summary = result.Summary.ExecutiveSummary
+	}
+	
+	switch {
+	case result.Preview != nil:
+		lines := []string{"Dry run, nothing was written"}
+		for _, action := range result.Preview.Actions {
+			lines = append(lines, "Would: "+action)
+		}
+		view.Status = strings.Join(lines, "\n")
+	case result.Document != nil:
+		view.DocumentTitle = result.Document.Title
+		view.DocumentURL = gdocs.DocumentURL(result.Document.DocumentID)
+	}
+	
+	return view
+}
+
+// HistoryFilter returns the filter for reports generated from the start of from to the end of
+// to. Either date may be zero to leave that side open.
+func HistoryFilter(from, to time.Time) history.Filter {
+	var filter history.Filter
+	if !from.IsZero() {
+		filter.Since = dateOf(from)
+	}
+	if !to.IsZero() {
+		filter.Until = dateOf(to).AddDate(0, 0, 1)
+	}
+	return filter
+}
+
+// HistoryLabel is the line a report is listed under in the history
+func HistoryLabel(entry history.Entry) string {
+	title := entry.Title
+	if title == "" {
+		title = "Untitled report"
+	}
+	return fmt.Sprintf("%s  %s", entry.GeneratedAt.Local().Format("2006-01-02 15:04"), title)
+}
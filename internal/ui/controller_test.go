@@ -0,0 +1,193 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/gdocs"
+	"github.com/company/eesa/internal/gemini"
+	"github.com/company/eesa/internal/history"
+	"github.com/company/eesa/internal/pipeline"
+	"github.com/company/eesa/internal/processor"
+	"github.com/company/eesa/pkg/models"
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testFormState() FormState {
+	return FormState{
+		StartDate:         time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		EndDate:           time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC),
+		Users:             []string{"alice", "bob"},
+		GroupByUser:       true,
+		GroupByEpic:       true,
+		CalculateVelocity: true,
+		AnalyzeTrends:     true,
+	}
+}
+
+func TestNewFormState(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Defaults.Users = []string{"alice", "bob"}
+	cfg.Defaults.TimeRange = "2w"
+	
+	state := NewFormState(cfg)
+	
+	assert.Equal(t, []string{"alice", "bob"}, state.Users)
+	assert.Equal(t, 14*24*time.Hour, state.EndDate.Sub(state.StartDate))
+	assert.True(t, state.GroupByUser)
+	assert.True(t, state.CalculateVelocity)
+	assert.True(t, state.AnalyzeTrends)
+	assert.False(t, state.DryRun)
+	
+	// Changing the form leaves the configured users alone
+	state.Users[0] = "carol"
+	assert.Equal(t, "alice", cfg.Defaults.Users[0])
+	
+	// An unknown default range falls back to a week
+	cfg.Defaults.TimeRange = "bogus"
+	state = NewFormState(cfg)
+	assert.Equal(t, 7*24*time.Hour, state.EndDate.Sub(state.StartDate))
+}
+
+func TestFormState_AllUsers(t *testing.T) {
+	state := FormState{Users: []string{"alice", "bob"}, OtherUsers: " carol, ,alice,dave "}
+	assert.Equal(t, []string{"alice", "bob", "carol", "dave"}, state.AllUsers())
+	
+	assert.Empty(t, FormState{}.AllUsers())
+}
+
+func TestFormState_PipelineOptions(t *testing.T) {
+	state := testFormState()
+	state.OtherUsers = "carol"
+	state.IncludeWorklogs = true
+	state.DryRun = true
+	
+	opts, err := state.PipelineOptions()
+	require.NoError(t, err)
+	
+	assert.Equal(t, []string{"alice", "bob", "carol"}, opts.Users)
+	// The end date is included in full
+	assert.Equal(t, time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), opts.TimeRange.Start)
+	assert.Equal(t, time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC), opts.TimeRange.End)
+	assert.Equal(t, processor.ProcessingOptions{
+		IncludeWorklogs:   true,
+		GroupByUser:       true,
+		GroupByEpic:       true,
+		CalculateVelocity: true,
+		AnalyzeTrends:     true,
+	}, opts.Processing)
+	assert.Equal(t, processor.SummaryRequest{
+		Title:          "Executive Summary 2024-03-04 to 2024-03-08",
+		IncludeMetrics: true,
+		IncludeTrends:  true,
+		IncludeUsers:   true,
+		Format:         processor.FormatExecutive,
+	}, opts.Summary)
+	assert.Equal(t, "Executive Summary 2024-03-04 to 2024-03-08", opts.DocumentTitle)
+	assert.True(t, opts.NoCompletionFallback)
+	assert.True(t, opts.DryRun)
+	
+	// A typed title is used as is, and unchecked trends and users leave them out of the summary
+	state = testFormState()
+	state.Title = "  Team Report "
+	state.AnalyzeTrends = false
+	state.GroupByUser = false
+	state.Detailed = true
+	
+	opts, err = state.PipelineOptions()
+	require.NoError(t, err)
+	assert.Equal(t, "Team Report", opts.DocumentTitle)
+	assert.Equal(t, "Team Report", opts.Summary.Title)
+	assert.False(t, opts.Summary.IncludeTrends)
+	assert.False(t, opts.Summary.IncludeUsers)
+	assert.Equal(t, processor.FormatDetailed, opts.Summary.Format)
+	assert.False(t, opts.Processing.AnalyzeTrends)
+}
+
+func TestFormState_PipelineOptions_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*FormState)
+		message string
+	}{
+		{"missing start date", func(s *FormState) { s.StartDate = time.Time{} }, "Select a start and end date"},
+		{"end before start", func(s *FormState) { s.EndDate = s.StartDate.AddDate(0, 0, -1) }, "The end date must not be before the start date"},
+		{"no users", func(s *FormState) { s.Users = nil; s.OtherUsers = " , " }, "Select at least one user"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := testFormState()
+			tt.modify(&state)
+			
+			_, err := state.PipelineOptions()
+			require.Error(t, err)
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, utils.ErrorCodeValidationError, appErr.Code)
+			assert.Equal(t, tt.message, appErr.Message)
+		})
+	}
+	
+	// A single day is a valid range
+	state := testFormState()
+	state.EndDate = state.StartDate
+	opts, err := state.PipelineOptions()
+	require.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, opts.TimeRange.End.Sub(opts.TimeRange.Start))
+}
+
+func TestNewResultView(t *testing.T) {
+	result := &pipeline.Result{
+		Activities: []models.Activity{{Key: "ESA-1"}, {Key: "ESA-2"}},
+		Summary:    &processor.SummaryResponse{ExecutiveSummary: "Deterministic summary"},
+		AISummary:  &gemini.SummaryResponse{Summary: "AI summary"},
+		Document:   &gdocs.DocumentResponse{DocumentID: "doc-123", Title: "Weekly Report"},
+	}
+	
+	view := NewResultView(result)
+	assert.Equal(t, "Summarized 2 activities", view.Status)
+	assert.Equal(t, "AI summary", view.Summary)
+	assert.Equal(t, "Weekly Report", view.DocumentTitle)
+	assert.Equal(t, gdocs.DocumentURL("doc-123"), view.DocumentURL)
+	
+	// A dry run lists the skipped writes and falls back to the deterministic summary
+	result.AISummary = nil
+	result.Document = nil
+	result.Preview = &pipeline.PublishPreview{Actions: []string{"create document", "share document"}}
+	
+	view = NewResultView(result)
+	assert.Equal(t, "Deterministic summary", view.Summary)
+	assert.Equal(t, "Dry run, nothing was written\nWould: create document\nWould: share document", view.Status)
+	assert.Empty(t, view.DocumentURL)
+	
+	// A canceled run shows nothing it got to
+	result.Canceled = true
+	view = NewResultView(result)
+	assert.Equal(t, ResultView{Status: "Canceled, nothing was published"}, view)
+}
+
+func TestHistoryFilter(t *testing.T) {
+	from := time.Date(2024, 3, 4, 15, 30, 0, 0, time.UTC)
+	to := time.Date(2024, 3, 8, 9, 0, 0, 0, time.UTC)
+	
+	// The dates cover whole days
+	assert.Equal(t, history.Filter{
+		Since: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC),
+		Until: time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC),
+	}, HistoryFilter(from, to))
+	
+	// A missing date leaves that side open
+	assert.Equal(t, history.Filter{Since: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC)}, HistoryFilter(from, time.Time{}))
+	assert.Equal(t, history.Filter{}, HistoryFilter(time.Time{}, time.Time{}))
+}
+
+func TestHistoryLabel(t *testing.T) {
+	generatedAt := time.Date(2024, 3, 8, 17, 5, 0, 0, time.Local)
+	
+	assert.Equal(t, "2024-03-08 17:05  Weekly Report", HistoryLabel(history.Entry{GeneratedAt: generatedAt, Title: "Weekly Report"}))
+	assert.Equal(t, "2024-03-08 17:05  Untitled report", HistoryLabel(history.Entry{GeneratedAt: generatedAt}))
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/widget"
+	"github.com/company/eesa/internal/history"
+	"github.com/company/eesa/pkg/utils"
+)
+
+// historyView lists the reports generated earlier, newest first, and shows the summary and
+// document link of the one selected
+type historyView struct {
+	store   *history.Store
+	window  fyne.Window
+	logger  utils.Logger
+	entries []history.Entry
+	
+	from    *widget.DateEntry
+	to      *widget.DateEntry
+	list    *widget.List
+	details *widget.Label
+	docLink *widget.Hyperlink
+	summary *widget.Label
+}
+
+// newHistoryView creates the history tab for the reports in store
+func newHistoryView(store *history.Store, window fyne.Window, logger utils.Logger) *historyView {
+	return &historyView{store: store, window: window, logger: logger}
+}
+
+// content lays out the date filter and list on the left and the selected report on the right
+func (v *historyView) content() fyne.CanvasObject {
+	v.from = widget.NewDateEntry()
+	v.to = widget.NewDateEntry()
+	filter := widget.NewButton("Filter", v.refresh)
+	
+	v.list = widget.NewList(
+		func() int {
+			return len(v.entries)
+		},
+		func() fyne.CanvasObject {
+			return widget.NewLabel("")
+		},
+		func(id widget.ListItemID, item fyne.CanvasObject) {
+			item.(*widget.Label).SetText(HistoryLabel(v.entries[id]))
+		},
+	)
+	v.list.OnSelected = func(id widget.ListItemID) {
+		v.show(v.entries[id])
+	}
+	
+	v.details = widget.NewLabel("Select a report to see its summary")
+	v.details.Wrapping = fyne.TextWrapWord
+	v.docLink = widget.NewHyperlink("", nil)
+	v.docLink.Hide()
+	v.summary = widget.NewLabel("")
+	v.summary.Wrapping = fyne.TextWrapWord
+	
+	filters := widget.NewForm(
+		widget.NewFormItem("From", v.from),
+		widget.NewFormItem("To", v.to),
+	)
+	left := container.NewBorder(container.NewVBox(filters, filter), nil, nil, nil, v.list)
+	right := container.NewBorder(container.NewVBox(v.details, v.docLink), nil, nil, nil,
+		container.NewVScroll(v.summary))
+	
+	split := container.NewHSplit(left, right)
+	split.Offset = 0.4
+	
+	v.refresh()
+	return split
+}
+
+// refresh reloads the reports within the filter dates. It must run on the main thread.
+func (v *historyView) refresh() {
+	entries, err := v.store.List(HistoryFilter(dateValue(v.from), dateValue(v.to)))
+	if err != nil {
+		v.logger.Error("Failed to load report history", err)
+		dialog.ShowError(err, v.window)
+		return
+	}
+	
+	v.entries = entries
+	v.list.UnselectAll()
+	v.list.Refresh()
+}
+
+// show displays a report from the history
+func (v *historyView) show(entry history.Entry) {
+	metrics := entry.KeyMetrics
+	v.details.SetText(fmt.Sprintf("%s\n%s to %s for %d users\n%d activities, %d completed (%.1f%%)",
+		entry.Title,
+		entry.Start.Format(dateLayout), entry.End.AddDate(0, 0, -1).Format(dateLayout), len(entry.Users),
+		metrics.TotalActivities, metrics.CompletedActivities, metrics.CompletionRate))
+	v.summary.SetText(entry.Summary)
+	
+	v.docLink.Hide()
+	if entry.DocumentURL != "" && v.docLink.SetURLFromString(entry.DocumentURL) == nil {
+		v.docLink.SetText(entry.DocumentURL)
+		v.docLink.Show()
+	}
+}
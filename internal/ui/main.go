@@ -2,35 +2,252 @@ package ui
 
 import (
 	"context"
+	"time"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/dialog"
 	"fyne.io/fyne/v2/widget"
 	"github.com/company/eesa/internal/config"
+	"github.com/company/eesa/internal/history"
+	"github.com/company/eesa/internal/pipeline"
 	"github.com/company/eesa/pkg/utils"
 )
 
 // MainWindow represents the main application window
 type MainWindow struct {
-	app    fyne.App
-	window fyne.Window
-	config *config.Config
-	logger utils.Logger
+	app      fyne.App
+	window   fyne.Window
+	config   *config.Config
+	logger   utils.Logger
+	ctx      context.Context
+	pipeline *pipeline.Pipeline
+	cancel   context.CancelFunc // Stops the run in progress, nil when none is
+	history  *history.Store
+	reports  *historyView
+	
+	// state holds the checkbox values; the other fields are read from their widgets on Generate
+	state FormState
+	
+	startDate  *widget.DateEntry
+	endDate    *widget.DateEntry
+	users      *widget.CheckGroup
+	otherUsers *widget.Entry
+	title      *widget.Entry
+	generate   *widget.Button
+	stop       *widget.Button
+	progress   *widget.ProgressBar
+	status     *widget.Label
+	docLink    *widget.Hyperlink
+	summary    *widget.Label
 }
 
-// NewMainWindow creates a new main window
-func NewMainWindow(ctx context.Context, app fyne.App, config *config.Config, logger utils.Logger) *MainWindow {
-	window := app.NewWindow("Executive Summary Automation")
-	window.SetContent(widget.NewLabel("ESA - Coming Soon"))
-	
-	return &MainWindow{
-		app:    app,
-		window: window,
-		config: config,
-		logger: logger,
+// NewMainWindow creates a new main window. Runs use deps and are stopped when ctx is done or
+// the Cancel button is pressed; the reports they publish are recorded in the history file.
+func NewMainWindow(ctx context.Context, app fyne.App, config *config.Config, deps pipeline.Dependencies, logger utils.Logger) *MainWindow {
+	w := &MainWindow{
+		app:      app,
+		window:   app.NewWindow("Executive Summary Automation"),
+		config:   config,
+		logger:   logger,
+		ctx:      ctx,
+		pipeline: pipeline.NewPipeline(deps, logger),
+		state:    NewFormState(config),
+		history:  history.NewStore(history.DefaultPath()),
 	}
+	w.reports = newHistoryView(w.history, w.window, logger)
+	
+	w.window.SetContent(container.NewAppTabs(
+		container.NewTabItem("Generate", w.buildContent()),
+		container.NewTabItem("History", w.reports.content()),
+	))
+	w.window.Resize(fyne.NewSize(900, 700))
+	
+	return w
 }
 
 // ShowAndRun shows the window and runs the application
 func (w *MainWindow) ShowAndRun() {
 	w.window.ShowAndRun()
-}
\ No newline at end of file
+}
+
+// buildContent lays out the form on the left and the results pane on the right
+func (w *MainWindow) buildContent() fyne.CanvasObject {
+	w.startDate = widget.NewDateEntry()
+	w.startDate.SetDate(&w.state.StartDate)
+	w.endDate = widget.NewDateEntry()
+	w.endDate.SetDate(&w.state.EndDate)
+	
+	w.users = widget.NewCheckGroup(w.state.Users, nil)
+	w.users.SetSelected(w.state.Users)
+	w.otherUsers = widget.NewEntry()
+	w.otherUsers.SetPlaceHolder("Other users, comma separated")
+	
+	w.title = widget.NewEntry()
+	w.title.SetPlaceHolder("Defaults to one naming the date range")
+	
+	options := container.NewVBox(
+		w.optionCheck("Group by user", &w.state.GroupByUser),
+		w.optionCheck("Group by priority", &w.state.GroupByPriority),
+		w.optionCheck("Group by status", &w.state.GroupByStatus),
+		w.optionCheck("Group by type", &w.state.GroupByType),
+		w.optionCheck("Group by project", &w.state.GroupByProject),
+		w.optionCheck("Group by epic", &w.state.GroupByEpic),
+		w.optionCheck("Use worklogs for time spent", &w.state.IncludeWorklogs),
+		w.optionCheck("Calculate velocity", &w.state.CalculateVelocity),
+		w.optionCheck("Analyze trends", &w.state.AnalyzeTrends),
+		w.optionCheck("Detailed summary", &w.state.Detailed),
+		w.optionCheck("Dry run (preview without writing)", &w.state.DryRun),
+	)
+	
+	w.generate = widget.NewButton("Generate", w.onGenerate)
+	w.generate.Importance = widget.HighImportance
+	w.stop = widget.NewButton("Cancel", w.onCancel)
+	w.stop.Disable()
+	w.progress = widget.NewProgressBar()
+	w.progress.Hide()
+	
+	form := widget.NewForm(
+		widget.NewFormItem("Start date", w.startDate),
+		widget.NewFormItem("End date", w.endDate),
+		widget.NewFormItem("Users", container.NewVBox(w.users, w.otherUsers)),
+		widget.NewFormItem("Title", w.title),
+		widget.NewFormItem("Options", options),
+	)
+	controls := container.NewVBox(form, container.NewGridWithColumns(2, w.generate, w.stop), w.progress)
+	
+	w.status = widget.NewLabel("Choose a date range and users, then press Generate")
+	w.status.Wrapping = fyne.TextWrapWord
+	w.docLink = widget.NewHyperlink("", nil)
+	w.docLink.Hide()
+	w.summary = widget.NewLabel("")
+	w.summary.Wrapping = fyne.TextWrapWord
+	results := container.NewBorder(container.NewVBox(w.status, w.docLink), nil, nil, nil,
+		container.NewVScroll(w.summary))
+	
+	split := container.NewHSplit(container.NewVScroll(controls), results)
+	split.Offset = 0.4
+	return split
+}
+
+// optionCheck creates a checkbox that keeps value in step with it
+func (w *MainWindow) optionCheck(label string, value *bool) *widget.Check {
+	check := widget.NewCheck(label, func(checked bool) {
+		*value = checked
+	})
+	check.SetChecked(*value)
+	return check
+}
+
+// formState collects the current widget values
+func (w *MainWindow) formState() FormState {
+	state := w.state
+	state.StartDate, state.EndDate = dateValue(w.startDate), dateValue(w.endDate)
+	state.Users = w.users.Selected
+	state.OtherUsers = w.otherUsers.Text
+	state.Title = w.title.Text
+	return state
+}
+
+// onGenerate starts a run with the form's options. The pipeline runs on its own goroutine so
+// the window stays responsive; its result is shown back on the main thread.
+func (w *MainWindow) onGenerate() {
+	opts, err := w.formState().PipelineOptions()
+	if err != nil {
+		dialog.ShowError(err, w.window)
+		return
+	}
+	
+	// The pipeline reports from its own goroutine, so each update is handed to the main thread
+	opts.Progress = func(progress pipeline.Progress) {
+		fyne.Do(func() {
+			w.progress.SetValue(float64(progress.Percent) / 100)
+			w.status.SetText(progress.Message)
+		})
+	}
+	
+	ctx, cancel := context.WithCancel(w.ctx)
+	w.cancel = cancel
+	w.generate.Disable()
+	w.stop.Enable()
+	w.progress.SetValue(0)
+	w.progress.Show()
+	w.status.SetText("Starting...")
+	w.docLink.Hide()
+	w.summary.SetText("")
+	
+	go func() {
+		defer cancel()
+		result, err := w.pipeline.Run(ctx, opts)
+		if err != nil {
+			w.logger.Error("Summary generation failed", err)
+		}
+		recorded := err == nil && w.record(opts, result)
+		fyne.Do(func() {
+			w.showResult(result, err)
+			if recorded {
+				w.reports.refresh()
+			}
+		})
+	}()
+}
+
+// record adds a finished run to the history, reporting whether it did. Canceled runs and dry
+// runs published nothing and are left out.
+func (w *MainWindow) record(opts pipeline.Options, result *pipeline.Result) bool {
+	if result.Canceled || result.Preview != nil {
+		return false
+	}
+	if err := w.history.Append(history.NewEntry(opts, result)); err != nil {
+		w.logger.Warn("Failed to record report in history",
+			utils.NewField("path", w.history.Path()),
+			utils.NewField("error", err.Error()),
+		)
+		return false
+	}
+	return true
+}
+
+// onCancel stops the run in progress. The run ends with a canceled result, shown like any other.
+func (w *MainWindow) onCancel() {
+	if w.cancel == nil {
+		return
+	}
+	w.cancel()
+	w.stop.Disable()
+	w.status.SetText("Canceling...")
+}
+
+// showResult shows a finished run in the results pane. It must run on the main thread.
+func (w *MainWindow) showResult(result *pipeline.Result, err error) {
+	w.cancel = nil
+	w.progress.Hide()
+	w.stop.Disable()
+	w.generate.Enable()
+	
+	if err != nil {
+		w.status.SetText("Summary generation failed")
+		dialog.ShowError(err, w.window)
+		return
+	}
+	
+	view := NewResultView(result)
+	w.status.SetText(view.Status)
+	w.summary.SetText(view.Summary)
+	if view.DocumentURL != "" {
+		w.docLink.SetText(view.DocumentTitle)
+		if err := w.docLink.SetURLFromString(view.DocumentURL); err != nil {
+			w.logger.Warn("Invalid document URL", utils.NewField("url", view.DocumentURL))
+			return
+		}
+		w.docLink.Show()
+	}
+}
+
+// dateValue returns the date picked in entry, zero when none is
+func dateValue(entry *widget.DateEntry) time.Time {
+	if entry.Date == nil {
+		return time.Time{}
+	}
+	return *entry.Date
+}
@@ -3,6 +3,8 @@ package models
 import (
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -19,10 +21,17 @@ type Activity struct {
 	Assignee    User      `json:"assignee"`
 	Created     time.Time `json:"created"`
 	Updated     time.Time `json:"updated"`
+	Resolved    *time.Time `json:"resolved,omitempty"` // Nil until the issue is resolved
 	Project     Project   `json:"project"`
+	Components  []string  `json:"components,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
 	TimeSpent   int64     `json:"time_spent"` // In seconds
+	StoryPoints float64   `json:"story_points,omitempty"`
+	CustomFields map[string]interface{} `json:"custom_fields,omitempty"` // Configured Jira custom fields by logical name
 	Comments    []Comment `json:"comments"`
 	Worklog     []Worklog `json:"worklog"`
+	Changelog   []ChangelogEntry `json:"changelog,omitempty"`
+	StatusHistory []StatusChange `json:"status_history,omitempty"` // Status transitions in the order they happened
 }
 
 // User represents a Jira user
@@ -63,6 +72,22 @@ type Worklog struct {
 	Updated     time.Time `json:"updated"`
 }
 
+// ChangelogEntry represents a single field change from an issue's history
+type ChangelogEntry struct {
+	Field   string    `json:"field"`
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Author  User      `json:"author"`
+	Created time.Time `json:"created"`
+}
+
+// StatusChange represents a workflow status transition from an issue's history
+type StatusChange struct {
+	From    string    `json:"from"`
+	To      string    `json:"to"`
+	Changed time.Time `json:"changed"`
+}
+
 // ActivityFilter represents filters for querying activities
 type ActivityFilter struct {
 	Users       []string  `json:"users"`
@@ -141,6 +166,26 @@ func FormatTimeSpent(seconds int64) string {
 	return fmt.Sprintf("%dm", minutes)
 }
 
+// StatusChanges extracts the status transitions from changelog entries in chronological order
+func StatusChanges(entries []ChangelogEntry) []StatusChange {
+	var changes []StatusChange
+	for _, entry := range entries {
+		if strings.EqualFold(entry.Field, "status") {
+			changes = append(changes, StatusChange{
+				From:    entry.From,
+				To:      entry.To,
+				Changed: entry.Created,
+			})
+		}
+	}
+	
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Changed.Before(changes[j].Changed)
+	})
+	
+	return changes
+}
+
 // GetFormattedTimeSpent returns formatted time spent string
 func (a *Activity) GetFormattedTimeSpent() string {
 	return FormatTimeSpent(a.TimeSpent)
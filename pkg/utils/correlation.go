@@ -0,0 +1,144 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// CorrelationIDField is the log field holding the correlation ID of a run
+const CorrelationIDField = "correlation_id"
+
+// correlationIDKey is the context key for the correlation ID
+type correlationIDKey struct{}
+
+// NewCorrelationID returns a random ID for correlating the log lines of one run
+func NewCorrelationID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(id)
+}
+
+// WithCorrelationID returns a copy of ctx carrying id
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or an empty string
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// EnsureCorrelationID seeds ctx with a new correlation ID unless it already carries one, so a
+// run started by a caller that set its own ID keeps it
+func EnsureCorrelationID(ctx context.Context) (context.Context, string) {
+	if id := CorrelationID(ctx); id != "" {
+		return ctx, id
+	}
+	
+	id := NewCorrelationID()
+	return WithCorrelationID(ctx, id), id
+}
+
+// fieldLogger adds fixed fields to every entry written through the logger it wraps
+type fieldLogger struct {
+	logger Logger
+	fields []Field
+}
+
+// LoggerWithFields returns a logger adding fields to every entry. Fields given to a derived
+// logger replace inherited fields with the same key.
+func LoggerWithFields(logger Logger, fields ...Field) Logger {
+	if len(fields) == 0 {
+		return logger
+	}
+	
+	var inherited []Field
+	if parent, ok := logger.(*fieldLogger); ok {
+		logger = parent.logger
+		inherited = parent.fields
+	}
+	
+	merged := make([]Field, 0, len(inherited)+len(fields))
+	for _, field := range inherited {
+		if !hasField(fields, field.Key) {
+			merged = append(merged, field)
+		}
+	}
+	merged = append(merged, fields...)
+	
+	return &fieldLogger{logger: logger, fields: merged}
+}
+
+// LoggerWithContext returns a logger adding the correlation ID carried by ctx to every entry.
+// Without an ID in ctx the logger is returned unchanged, keeping any ID it already adds.
+func LoggerWithContext(ctx context.Context, logger Logger) Logger {
+	id := CorrelationID(ctx)
+	if id == "" {
+		return logger
+	}
+	return LoggerWithFields(logger, NewField(CorrelationIDField, id))
+}
+
+// hasField reports whether fields includes key
+func hasField(fields []Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// WithContext returns a logger adding the correlation ID carried by ctx
+func (l *fieldLogger) WithContext(ctx context.Context) Logger {
+	return LoggerWithContext(ctx, l)
+}
+
+// With returns a logger adding fields to those of l
+func (l *fieldLogger) With(fields ...Field) Logger {
+	return LoggerWithFields(l, fields...)
+}
+
+// Debug logs a debug message
+func (l *fieldLogger) Debug(msg string, fields ...Field) {
+	l.logger.Debug(msg, l.append(fields)...)
+}
+
+// Info logs an info message
+func (l *fieldLogger) Info(msg string, fields ...Field) {
+	l.logger.Info(msg, l.append(fields)...)
+}
+
+// Warn logs a warning message
+func (l *fieldLogger) Warn(msg string, fields ...Field) {
+	l.logger.Warn(msg, l.append(fields)...)
+}
+
+// Error logs an error message
+func (l *fieldLogger) Error(msg string, err error, fields ...Field) {
+	l.logger.Error(msg, err, l.append(fields)...)
+}
+
+// append returns the logger's fields followed by those of one call
+func (l *fieldLogger) append(fields []Field) []Field {
+	all := make([]Field, 0, len(l.fields)+len(fields))
+	all = append(all, l.fields...)
+	return append(all, fields...)
+}
+
+// WithContext returns a logger adding the correlation ID carried by ctx
+func (l *StructuredLogger) WithContext(ctx context.Context) Logger {
+	return LoggerWithContext(ctx, l)
+}
+
+// With returns a logger adding fields to every entry
+func (l *StructuredLogger) With(fields ...Field) Logger {
+	return LoggerWithFields(l, fields...)
+}
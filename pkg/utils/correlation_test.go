@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnsureCorrelationID(t *testing.T) {
+	ctx, id := EnsureCorrelationID(context.Background())
+	assert.Regexp(t, `^[0-9a-f]{16}$`, id)
+	assert.Equal(t, id, CorrelationID(ctx))
+	
+	// An ID already in the context is kept
+	same, sameID := EnsureCorrelationID(ctx)
+	assert.Equal(t, id, sameID)
+	assert.Equal(t, ctx, same)
+	
+	_, other := EnsureCorrelationID(context.Background())
+	assert.NotEqual(t, id, other)
+	
+	assert.Empty(t, CorrelationID(context.Background()))
+}
+
+func TestStructuredLogger_WithContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLoggerWithFormat("info", LogFormatJSON, &buf).(*StructuredLogger)
+	ctx := WithCorrelationID(context.Background(), "run-123")
+	
+	logger.WithContext(ctx).Info("Fetched activities", NewField("count", 3))
+	logger.WithContext(context.Background()).Info("No run")
+	
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 2)
+	
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "run-123", entry[CorrelationIDField])
+	assert.Equal(t, float64(3), entry["count"])
+	
+	entry = nil
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.NotContains(t, entry, CorrelationIDField)
+}
+
+func TestLoggerWithContext_DerivedLoggers(t *testing.T) {
+	mock := NewMockLogger()
+	ctx := WithCorrelationID(context.Background(), "run-123")
+	
+	// A child logger keeps the ID and its parent's fields
+	child := LoggerWithFields(LoggerWithContext(ctx, mock), NewField("service", "jira"))
+	child.Warn("Slow response")
+	child.Error("Request failed", assert.AnError, NewField("status", 500))
+	
+	require.Len(t, mock.Entries, 2)
+	for _, entry := range mock.Entries {
+		assert.Contains(t, entry.Fields, NewField(CorrelationIDField, "run-123"))
+		assert.Contains(t, entry.Fields, NewField("service", "jira"))
+	}
+	assert.Contains(t, mock.Entries[1].Fields, NewField("status", 500))
+	assert.Equal(t, assert.AnError, mock.Entries[1].Error)
+	
+	// Deriving from a context without an ID keeps the inherited one
+	mock.Reset()
+	LoggerWithContext(context.Background(), child).Info("Still correlated")
+	assert.True(t, mock.HasFieldValue(CorrelationIDField, "run-123"))
+	
+	// A new ID replaces the inherited one instead of repeating the key
+	mock.Reset()
+	LoggerWithContext(WithCorrelationID(ctx, "run-456"), child).Info("Next run")
+	require.Len(t, mock.Entries, 1)
+	var ids []interface{}
+	for _, field := range mock.Entries[0].Fields {
+		if field.Key == CorrelationIDField {
+			ids = append(ids, field.Value)
+		}
+	}
+	assert.Equal(t, []interface{}{"run-456"}, ids)
+	
+	// Without an ID or fields nothing is wrapped
+	assert.Same(t, mock, LoggerWithContext(context.Background(), mock))
+	assert.Same(t, mock, LoggerWithFields(mock))
+}
@@ -27,6 +27,8 @@ const (
 	ErrorCodeAPINotFound    ErrorCode = "API_NOT_FOUND"
 	ErrorCodeAPIServerError ErrorCode = "API_SERVER_ERROR"
 	ErrorCodeAPIBadRequest  ErrorCode = "API_BAD_REQUEST"
+	ErrorCodeResponseTooLarge ErrorCode = "RESPONSE_TOO_LARGE"
+	ErrorCodeSearchLimitExceeded ErrorCode = "SEARCH_LIMIT_EXCEEDED"
 	
 	// Data processing errors
 	ErrorCodeDataInvalid    ErrorCode = "DATA_INVALID"
@@ -37,7 +39,10 @@ const (
 	// External service errors
 	ErrorCodeJiraError     ErrorCode = "JIRA_ERROR"
 	ErrorCodeGeminiError   ErrorCode = "GEMINI_ERROR"
+	ErrorCodeGeminiSafetyBlock ErrorCode = "GEMINI_SAFETY_BLOCK"
 	ErrorCodeGoogleError   ErrorCode = "GOOGLE_ERROR"
+	ErrorCodeConfluenceError ErrorCode = "CONFLUENCE_ERROR"
+	ErrorCodeSummarizerError ErrorCode = "SUMMARIZER_ERROR"
 	
 	// Security errors
 	ErrorCodeKeyringError    ErrorCode = "KEYRING_ERROR"
@@ -53,6 +58,7 @@ const (
 	ErrorCodeInternalError  ErrorCode = "INTERNAL_ERROR"
 	ErrorCodeNetworkError   ErrorCode = "NETWORK_ERROR"
 	ErrorCodeTimeoutError   ErrorCode = "TIMEOUT_ERROR"
+	ErrorCodeConflict       ErrorCode = "CONFLICT"
 )
 
 // AppError represents a structured application error
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultMaxResponseBytes is the default cap on API response body size (10 MiB)
+const DefaultMaxResponseBytes int64 = 10 * 1024 * 1024
+
+// MaxErrorSnippetLength caps how much of a raw error body is echoed into error messages
+const MaxErrorSnippetLength = 200
+
+// ReadBodyLimited reads an HTTP response body, failing if it exceeds maxBytes.
+// When the cap is exceeded the bytes read so far are returned along with the error.
+func ReadBodyLimited(resp *http.Response, maxBytes int64) ([]byte, error) {
+	if resp == nil || resp.Body == nil {
+		return nil, NewAppError(ErrorCodeDataMissing, "Response body is missing", nil)
+	}
+	
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxResponseBytes
+	}
+	
+	// Read one byte past the cap so we can tell an exact fit from an overflow
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return body, NewAppError(ErrorCodeNetworkError, "Failed to read response body", err)
+	}
+	
+	if int64(len(body)) > maxBytes {
+		return body[:maxBytes], NewAppError(ErrorCodeResponseTooLarge,
+			fmt.Sprintf("Response body exceeds maximum size of %d bytes", maxBytes), nil).
+			WithExtra("max_bytes", maxBytes)
+	}
+	
+	return body, nil
+}
+
+// DescribeErrorBody summarizes an error response that has no parseable JSON body,
+// using the HTTP status text and a single line snippet of the raw body. Gateways and
+// proxies often reply with plain text or HTML instead of JSON, so clients use it to keep
+// those replies readable in their errors.
+func DescribeErrorBody(statusCode int, body []byte) string {
+	status := http.StatusText(statusCode)
+	if status == "" {
+		status = "Unknown Status"
+	}
+	description := fmt.Sprintf("%d %s", statusCode, status)
+	
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if snippet == "" {
+		return description
+	}
+	
+	if runes := []rune(snippet); len(runes) > MaxErrorSnippetLength {
+		snippet = string(runes[:MaxErrorSnippetLength]) + "..."
+	}
+	
+	return fmt.Sprintf("%s: %s", description, snippet)
+}
+
+// ParseRetryAfter parses a Retry-After header given either as delay seconds or an HTTP date.
+// It returns false when the header is missing or malformed.
+func ParseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	
+	return 0, false
+}
@@ -0,0 +1,120 @@
+package utils
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResponse(body string) *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestReadBodyLimited(t *testing.T) {
+	body, err := ReadBodyLimited(newTestResponse("hello world"), 1024)
+	
+	require.NoError(t, err)
+	assert.Equal(t, "hello world", string(body))
+}
+
+func TestReadBodyLimited_ExactFit(t *testing.T) {
+	body, err := ReadBodyLimited(newTestResponse("0123456789"), 10)
+	
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789", string(body))
+}
+
+func TestReadBodyLimited_Oversized(t *testing.T) {
+	oversized := strings.Repeat("a", 4096)
+	
+	body, err := ReadBodyLimited(newTestResponse(oversized), 100)
+	
+	require.Error(t, err)
+	assert.Len(t, body, 100)
+	
+	appErr, ok := err.(*AppError)
+	require.True(t, ok)
+	assert.Equal(t, ErrorCodeResponseTooLarge, appErr.Code)
+	assert.False(t, appErr.Retryable)
+	assert.Equal(t, int64(100), appErr.Context.Extra["max_bytes"])
+	assert.Contains(t, appErr.Message, "100 bytes")
+}
+
+func TestReadBodyLimited_DefaultLimit(t *testing.T) {
+	body, err := ReadBodyLimited(newTestResponse("small"), 0)
+	
+	require.NoError(t, err)
+	assert.Equal(t, "small", string(body))
+}
+
+func TestReadBodyLimited_MissingBody(t *testing.T) {
+	_, err := ReadBodyLimited(&http.Response{}, 100)
+	
+	require.Error(t, err)
+	appErr, ok := err.(*AppError)
+	require.True(t, ok)
+	assert.Equal(t, ErrorCodeDataMissing, appErr.Code)
+}
+
+func TestDescribeErrorBody(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		expected   string
+	}{
+		{name: "plain text", statusCode: http.StatusTooManyRequests, body: "Too many requests, slow down\n", expected: "429 Too Many Requests: Too many requests, slow down"},
+		{name: "html", statusCode: http.StatusServiceUnavailable, body: "<html>\n  <body>upstream unavailable</body>\n</html>", expected: "503 Service Unavailable: <html> <body>upstream unavailable</body> </html>"},
+		{name: "empty body", statusCode: http.StatusBadGateway, body: "", expected: "502 Bad Gateway"},
+		{name: "unknown status", statusCode: 599, body: "oops", expected: "599 Unknown Status: oops"},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DescribeErrorBody(tt.statusCode, []byte(tt.body)))
+		})
+	}
+}
+
+func TestDescribeErrorBody_TruncatesSnippet(t *testing.T) {
+	body := strings.Repeat("é", MaxErrorSnippetLength+50)
+	
+	description := DescribeErrorBody(http.StatusServiceUnavailable, []byte(body))
+	
+	assert.Equal(t, "503 Service Unavailable: "+strings.Repeat("é", MaxErrorSnippetLength)+"...", description)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{name: "seconds", value: "5", expected: 5 * time.Second, ok: true},
+		{name: "seconds with whitespace", value: " 120 ", expected: 2 * time.Minute, ok: true},
+		{name: "http date", value: now.Add(30 * time.Second).Format(http.TimeFormat), expected: 30 * time.Second, ok: true},
+		{name: "http date in the past", value: now.Add(-time.Minute).Format(http.TimeFormat), expected: 0, ok: true},
+		{name: "empty", value: "", ok: false},
+		{name: "negative", value: "-5", ok: false},
+		{name: "garbage", value: "soon", ok: false},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delay, ok := ParseRetryAfter(tt.value, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, delay)
+		})
+	}
+}
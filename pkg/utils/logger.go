@@ -1,9 +1,16 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -63,19 +70,58 @@ type Logger interface {
 	Error(msg string, err error, fields ...Field)
 }
 
+// LogFormat selects how a StructuredLogger writes entries
+type LogFormat string
+
+const (
+	// LogFormatJSON writes one JSON object per line for log pipelines. Keys come in a fixed
+	// order: timestamp, level, message, error, then the fields sorted by key.
+	LogFormatJSON LogFormat = "json"
+	// LogFormatText writes one human-readable line per entry
+	LogFormatText LogFormat = "text"
+)
+
+// ParseLogFormat parses a log format string, defaulting to JSON
+func ParseLogFormat(s string) LogFormat {
+	switch LogFormat(strings.ToLower(s)) {
+	case LogFormatText:
+		return LogFormatText
+	default:
+		return LogFormatJSON
+	}
+}
+
 // StructuredLogger implements the Logger interface with structured logging
 type StructuredLogger struct {
 	level  LogLevel
+	format LogFormat
 	logger *log.Logger
 }
 
 // NewLogger creates a new structured logger
 func NewLogger(levelStr string) Logger {
+	return NewLoggerWithOutput(levelStr, os.Stdout)
+}
+
+// NewJSONLogger creates a logger writing one JSON object per line to standard output
+func NewJSONLogger(levelStr string) Logger {
+	return NewLoggerWithFormat(levelStr, LogFormatJSON, os.Stdout)
+}
+
+// NewLoggerWithOutput creates a new structured logger that writes to w, for callers that use
+// standard output for their own results
+func NewLoggerWithOutput(levelStr string, w io.Writer) Logger {
+	return NewLoggerWithFormat(levelStr, LogFormatJSON, w)
+}
+
+// NewLoggerWithFormat creates a new structured logger that writes entries to w in format
+func NewLoggerWithFormat(levelStr string, format LogFormat, w io.Writer) Logger {
 	level := ParseLogLevel(levelStr)
-	logger := log.New(os.Stdout, "", 0)
+	logger := log.New(w, "", 0)
 	
 	return &StructuredLogger{
 		level:  level,
+		format: format,
 		logger: logger,
 	}
 }
@@ -110,35 +156,88 @@ func (l *StructuredLogger) Error(msg string, err error, fields ...Field) {
 
 // log formats and outputs a log message
 func (l *StructuredLogger) log(level LogLevel, msg string, err error, fields ...Field) {
-	logEntry := map[string]interface{}{
-		"timestamp": time.Now().UTC().Format(time.RFC3339),
-		"level":     level.String(),
-		"message":   msg,
+	entry := []Field{
+		NewField("timestamp", time.Now().UTC().Format(time.RFC3339)),
+		NewField("level", level.String()),
+		NewField("message", msg),
 	}
 	
 	// Add error if present
 	if err != nil {
-		logEntry["error"] = err.Error()
+		entry = append(entry, NewField("error", err.Error()))
 	}
 	
-	// Add fields
+	entry = append(entry, sortedFields(fields)...)
+	
+	if l.format == LogFormatText {
+		l.logger.Println(formatText(entry))
+		return
+	}
+	l.logger.Println(formatJSON(entry))
+}
+
+// sortedFields returns fields ordered by key, keeping the last value of a repeated key and
+// leaving out keys reserved for the standard entry fields
+func sortedFields(fields []Field) []Field {
+	values := make(map[string]interface{}, len(fields))
 	for _, field := range fields {
-		// Avoid overwriting standard fields
-		if field.Key != "timestamp" && field.Key != "level" && field.Key != "message" && field.Key != "error" {
-			logEntry[field.Key] = field.Value
+		switch field.Key {
+		case "timestamp", "level", "message", "error":
+			// Avoid overwriting standard fields
+		default:
+			values[field.Key] = field.Value
 		}
 	}
 	
-	// Marshal to JSON
-	jsonData, jsonErr := json.Marshal(logEntry)
-	if jsonErr != nil {
-		// Fallback to simple logging if JSON marshaling fails
-		l.logger.Printf("LOG_ERROR: Failed to marshal log entry: %v", jsonErr)
-		l.logger.Printf("%s: %s", level.String(), msg)
-		return
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 	
-	l.logger.Println(string(jsonData))
+	sorted := make([]Field, len(keys))
+	for i, key := range keys {
+		sorted[i] = NewField(key, values[key])
+	}
+	return sorted
+}
+
+// formatJSON writes entry as a JSON object with its keys in order. A value that cannot be
+// encoded is written as its string form rather than dropping the entry.
+func formatJSON(entry []Field) string {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, field := range entry {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(field.Key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		
+		value, err := json.Marshal(field.Value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprintf("%v", field.Value))
+		}
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.String()
+}
+
+// formatText writes entry as "timestamp LEVEL message key=value ...", quoting values that
+// contain spaces or quotes
+func formatText(entry []Field) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("%v %-5v %v", entry[0].Value, entry[1].Value, entry[2].Value))
+	for _, field := range entry[3:] {
+		value := fmt.Sprintf("%v", field.Value)
+		if value == "" || strings.ContainsAny(value, " \t\n\"=") {
+			value = strconv.Quote(value)
+		}
+		b.WriteString(" " + field.Key + "=" + value)
+	}
+	return b.String()
 }
 
 // SecurityLogger provides security-specific logging functionality
@@ -194,8 +293,10 @@ func (s *SecurityLogger) LogCredentialAccess(service string, operation string, s
 	)
 }
 
-// MockLogger is a mock implementation of Logger for testing
+// MockLogger is a mock implementation of Logger for testing. It is safe for concurrent use;
+// read Entries once the code under test has finished logging.
 type MockLogger struct {
+	mu      sync.Mutex
 	Entries []LogEntry
 }
 
@@ -216,6 +317,9 @@ func NewMockLogger() *MockLogger {
 
 // Debug logs a debug message
 func (m *MockLogger) Debug(msg string, fields ...Field) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
 	m.Entries = append(m.Entries, LogEntry{
 		Level:   LogLevelDebug,
 		Message: msg,
@@ -225,6 +329,9 @@ func (m *MockLogger) Debug(msg string, fields ...Field) {
 
 // Info logs an info message
 func (m *MockLogger) Info(msg string, fields ...Field) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
 	m.Entries = append(m.Entries, LogEntry{
 		Level:   LogLevelInfo,
 		Message: msg,
@@ -234,6 +341,9 @@ func (m *MockLogger) Info(msg string, fields ...Field) {
 
 // Warn logs a warning message
 func (m *MockLogger) Warn(msg string, fields ...Field) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
 	m.Entries = append(m.Entries, LogEntry{
 		Level:   LogLevelWarn,
 		Message: msg,
@@ -243,6 +353,9 @@ func (m *MockLogger) Warn(msg string, fields ...Field) {
 
 // Error logs an error message
 func (m *MockLogger) Error(msg string, err error, fields ...Field) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
 	m.Entries = append(m.Entries, LogEntry{
 		Level:   LogLevelError,
 		Message: msg,
@@ -253,11 +366,16 @@ func (m *MockLogger) Error(msg string, err error, fields ...Field) {
 
 // Reset clears all logged entries
 func (m *MockLogger) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	m.Entries = make([]LogEntry, 0)
 }
 
 // GetEntriesByLevel returns all log entries with the specified level
 func (m *MockLogger) GetEntriesByLevel(level LogLevel) []LogEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
 	var entries []LogEntry
 	for _, entry := range m.Entries {
 		if entry.Level == level {
@@ -269,6 +387,9 @@ func (m *MockLogger) GetEntriesByLevel(level LogLevel) []LogEntry {
 
 // GetEntriesByMessage returns all log entries containing the specified message
 func (m *MockLogger) GetEntriesByMessage(message string) []LogEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
 	var entries []LogEntry
 	for _, entry := range m.Entries {
 		if entry.Message == message {
@@ -280,6 +401,9 @@ func (m *MockLogger) GetEntriesByMessage(message string) []LogEntry {
 
 // HasFieldValue checks if any log entry has a field with the specified key and value
 func (m *MockLogger) HasFieldValue(key string, value interface{}) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	
 	for _, entry := range m.Entries {
 		for _, field := range entry.Fields {
 			if field.Key == key && field.Value == value {
@@ -1,11 +1,15 @@
 package utils
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseLogLevel(t *testing.T) {
@@ -267,4 +271,80 @@ func TestStructuredLogger_LogLevels(t *testing.T) {
 			})
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestNewLoggerWithOutput(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLoggerWithOutput("warn", &buf)
+	
+	logger.Info("not written")
+	logger.Warn("written", NewField("key", "value"))
+	
+	assert.NotContains(t, buf.String(), "not written")
+	assert.Contains(t, buf.String(), `"message":"written"`)
+	assert.Contains(t, buf.String(), `"key":"value"`)
+}
+
+func TestParseLogFormat(t *testing.T) {
+	assert.Equal(t, LogFormatJSON, ParseLogFormat("json"))
+	assert.Equal(t, LogFormatText, ParseLogFormat("text"))
+	assert.Equal(t, LogFormatText, ParseLogFormat("TEXT"))
+	assert.Equal(t, LogFormatJSON, ParseLogFormat(""))
+	assert.Equal(t, LogFormatJSON, ParseLogFormat("xml"))
+}
+
+func TestStructuredLogger_JSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLoggerWithFormat("debug", LogFormatJSON, &buf)
+	
+	logger.Info("first", NewField("zeta", 1), NewField("alpha", "a"), NewField("level", "ignored"))
+	logger.Error("second", errors.New("boom"), NewField("nested", map[string]int{"count": 2}))
+	logger.Debug("third", NewField("unencodable", make(chan int)))
+	
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	require.Len(t, lines, 3)
+	
+	// Every line is a complete JSON object
+	var entries []map[string]interface{}
+	for _, line := range lines {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry), line)
+		entries = append(entries, entry)
+	}
+	
+	assert.Equal(t, "INFO", entries[0]["level"])
+	assert.Equal(t, "first", entries[0]["message"])
+	assert.Equal(t, "a", entries[0]["alpha"])
+	assert.Equal(t, float64(1), entries[0]["zeta"])
+	_, err := time.Parse(time.RFC3339, entries[0]["timestamp"].(string))
+	assert.NoError(t, err)
+	
+	assert.Equal(t, "ERROR", entries[1]["level"])
+	assert.Equal(t, "boom", entries[1]["error"])
+	assert.Equal(t, map[string]interface{}{"count": float64(2)}, entries[1]["nested"])
+	
+	assert.Equal(t, "DEBUG", entries[2]["level"])
+	assert.Contains(t, entries[2]["unencodable"], "0x")
+	
+	// Standard keys come first, then the fields sorted by key
+	assert.Regexp(t, `^\{"timestamp":"[^"]+","level":"INFO","message":"first","alpha":"a","zeta":1\}$`, lines[0])
+	assert.Regexp(t, `^\{"timestamp":"[^"]+","level":"ERROR","message":"second","error":"boom","nested":\{"count":2\}\}$`, lines[1])
+}
+
+func TestStructuredLogger_TextFormat(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewLoggerWithFormat("info", LogFormatText, &buf)
+	
+	logger.Debug("not written")
+	logger.Warn("Slow response", NewField("service", "jira"), NewField("path", "/rest/api 2"))
+	
+	line := strings.TrimSuffix(buf.String(), "\n")
+	assert.Regexp(t, `^\S+ WARN  Slow response path="/rest/api 2" service=jira$`, line)
+}
+
+func TestNewJSONLogger(t *testing.T) {
+	logger, ok := NewJSONLogger("warn").(*StructuredLogger)
+	require.True(t, ok)
+	assert.Equal(t, LogFormatJSON, logger.format)
+	assert.Equal(t, LogLevelWarn, logger.level)
+}
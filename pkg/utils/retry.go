@@ -2,7 +2,9 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"math"
+	"sync"
 	"time"
 )
 
@@ -35,11 +37,20 @@ func DefaultRetryConfig() *RetryConfig {
 // RetryableFunc is a function that can be retried
 type RetryableFunc func() error
 
-// Retry executes a function with retry logic
-func Retry(ctx context.Context, config *RetryConfig, fn RetryableFunc, logger Logger) error {
+// Retry executes a function with exponential backoff and no rate limiting.
+// It is safe for callers such as pollers that have no rate limiter or logger.
+func Retry(ctx context.Context, config *RetryConfig, fn RetryableFunc) error {
+	return retry(ctx, config, fn, nopLogger{})
+}
+
+// retry implements the retry loop shared by Retry and RetryWithRateLimit
+func retry(ctx context.Context, config *RetryConfig, fn RetryableFunc, logger Logger) error {
 	if config == nil {
 		config = DefaultRetryConfig()
 	}
+	if logger == nil {
+		logger = nopLogger{}
+	}
 	
 	var lastErr error
 	
@@ -75,8 +86,16 @@ func Retry(ctx context.Context, config *RetryConfig, fn RetryableFunc, logger Lo
 			break
 		}
 		
-		// Calculate delay with exponential backoff
-		delay := calculateDelay(attempt, config)
+		// Calculate delay with exponential backoff, honoring any server requested delay
+		delay, delayErr := retryDelay(err, attempt, config)
+		if delayErr != nil {
+			logger.Warn("Server requested retry delay exceeds the maximum, giving up",
+				NewField("error", err.Error()),
+				NewField("attempt", attempt),
+				NewField("max_delay_ms", config.MaxDelay.Milliseconds()),
+			)
+			return delayErr
+		}
 		
 		logger.Warn("Function failed, retrying",
 			NewField("error", err.Error()),
@@ -97,18 +116,56 @@ func Retry(ctx context.Context, config *RetryConfig, fn RetryableFunc, logger Lo
 	return lastErr
 }
 
-// isRetryableError checks if an error is retryable
+// isRetryableError checks if an error is retryable. Wrapped errors are unwrapped so that an
+// AppError anywhere in the chain with a retryable code makes the error retryable.
 func isRetryableError(err error, retryableErrors []ErrorCode) bool {
-	if appErr, ok := err.(*AppError); ok {
+	var appErr *AppError
+	for errors.As(err, &appErr) {
 		for _, retryableCode := range retryableErrors {
 			if appErr.Code == retryableCode {
 				return true
 			}
 		}
+		err = appErr.Cause
 	}
 	return false
 }
 
+// RetryAfterKey is the AppError extra holding a server requested retry delay
+const RetryAfterKey = "retry_after"
+
+// RetryAfter returns the server requested retry delay carried by an error or any error it wraps
+func RetryAfter(err error) (time.Duration, bool) {
+	var appErr *AppError
+	for errors.As(err, &appErr) {
+		if delay, ok := appErr.Context.Extra[RetryAfterKey].(time.Duration); ok {
+			return delay, true
+		}
+		err = appErr.Cause
+	}
+	return 0, false
+}
+
+// retryDelay returns the backoff delay for an attempt, never shorter than a server requested
+// delay. A requested delay longer than config.MaxDelay returns a rate limit error instead, so a
+// server cannot stall the caller for as long as it likes.
+func retryDelay(err error, attempt int, config *RetryConfig) (time.Duration, error) {
+	delay := calculateDelay(attempt, config)
+	retryAfter, ok := RetryAfter(err)
+	if !ok {
+		return delay, nil
+	}
+	if retryAfter > config.MaxDelay {
+		return 0, NewAppError(ErrorCodeAPIRateLimit, "Server requested retry delay exceeds the maximum", err).
+			WithExtra(RetryAfterKey, retryAfter).
+			WithExtra("max_delay", config.MaxDelay)
+	}
+	if retryAfter > delay {
+		delay = retryAfter
+	}
+	return delay, nil
+}
+
 // calculateDelay calculates the delay for exponential backoff
 func calculateDelay(attempt int, config *RetryConfig) time.Duration {
 	delay := float64(config.InitialDelay) * math.Pow(config.BackoffFactor, float64(attempt))
@@ -120,8 +177,9 @@ func calculateDelay(attempt int, config *RetryConfig) time.Duration {
 	return time.Duration(delay)
 }
 
-// RateLimiter implements rate limiting functionality
+// RateLimiter implements rate limiting functionality. It is safe for concurrent use.
 type RateLimiter struct {
+	mu          sync.Mutex
 	maxRequests int
 	window      time.Duration
 	requests    []time.Time
@@ -140,6 +198,9 @@ func NewRateLimiter(maxRequests int, window time.Duration, logger Logger) *RateL
 
 // Allow checks if a request is allowed under the rate limit
 func (rl *RateLimiter) Allow() bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	
 	now := time.Now()
 	
 	// Remove old requests outside the window
@@ -168,18 +229,7 @@ func (rl *RateLimiter) WaitForSlot(ctx context.Context) error {
 		}
 		
 		// Calculate wait time until next slot
-		now := time.Now()
-		rl.cleanupOldRequests(now)
-		
-		if len(rl.requests) == 0 {
-			// No requests, should be able to proceed
-			continue
-		}
-		
-		// Wait until the oldest request expires
-		oldestRequest := rl.requests[0]
-		waitTime := rl.window - now.Sub(oldestRequest)
-		
+		waitTime := rl.GetTimeToNextSlot()
 		if waitTime <= 0 {
 			continue
 		}
@@ -219,12 +269,18 @@ func (rl *RateLimiter) cleanupOldRequests(now time.Time) {
 
 // GetCurrentRequestCount returns the current number of requests in the window
 func (rl *RateLimiter) GetCurrentRequestCount() int {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	
 	rl.cleanupOldRequests(time.Now())
 	return len(rl.requests)
 }
 
 // GetTimeToNextSlot returns the time until the next slot becomes available
 func (rl *RateLimiter) GetTimeToNextSlot() time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	
 	now := time.Now()
 	rl.cleanupOldRequests(now)
 	
@@ -238,11 +294,11 @@ func (rl *RateLimiter) GetTimeToNextSlot() time.Duration {
 
 // RetryWithRateLimit combines retry logic with rate limiting
 func RetryWithRateLimit(ctx context.Context, retryConfig *RetryConfig, rateLimiter *RateLimiter, fn RetryableFunc, logger Logger) error {
-	if retryConfig == nil {
-		retryConfig = DefaultRetryConfig()
+	if rateLimiter == nil {
+		return retry(ctx, retryConfig, fn, logger)
 	}
 	
-	return Retry(ctx, retryConfig, func() error {
+	return retry(ctx, retryConfig, func() error {
 		// Wait for rate limit slot
 		if err := rateLimiter.WaitForSlot(ctx); err != nil {
 			return err
@@ -253,6 +309,14 @@ func RetryWithRateLimit(ctx context.Context, retryConfig *RetryConfig, rateLimit
 	}, logger)
 }
 
+// nopLogger discards all log output
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, fields ...Field)            {}
+func (nopLogger) Info(msg string, fields ...Field)             {}
+func (nopLogger) Warn(msg string, fields ...Field)             {}
+func (nopLogger) Error(msg string, err error, fields ...Field) {}
+
 // CircuitBreaker implements circuit breaker pattern
 type CircuitBreaker struct {
 	maxFailures     int
@@ -361,11 +425,7 @@ func (cb *CircuitBreaker) Reset() {
 
 // RetryWithCircuitBreaker combines retry logic with circuit breaker
 func RetryWithCircuitBreaker(ctx context.Context, retryConfig *RetryConfig, circuitBreaker *CircuitBreaker, fn RetryableFunc, logger Logger) error {
-	if retryConfig == nil {
-		retryConfig = DefaultRetryConfig()
-	}
-	
-	return Retry(ctx, retryConfig, func() error {
+	return retry(ctx, retryConfig, func() error {
 		return circuitBreaker.Execute(fn)
 	}, logger)
 }
\ No newline at end of file
@@ -0,0 +1,261 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRetryConfig(maxRetries int) *RetryConfig {
+	return &RetryConfig{
+		MaxRetries:      maxRetries,
+		InitialDelay:    time.Millisecond,
+		MaxDelay:        4 * time.Millisecond,
+		BackoffFactor:   2.0,
+		RetryableErrors: []ErrorCode{ErrorCodeNetworkError},
+	}
+}
+
+func TestRetry_SucceedsAfterRetries(t *testing.T) {
+	attempts := 0
+	err := Retry(context.Background(), newTestRetryConfig(3), func() error {
+		attempts++
+		if attempts < 3 {
+			return NewAppError(ErrorCodeNetworkError, "connection reset", nil)
+		}
+		return nil
+	})
+	
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetry_StopsAfterMaxRetries(t *testing.T) {
+	attempts := 0
+	err := Retry(context.Background(), newTestRetryConfig(2), func() error {
+		attempts++
+		return NewAppError(ErrorCodeNetworkError, "connection reset", nil)
+	})
+	
+	require.Error(t, err)
+	assert.Equal(t, 3, attempts) // Initial attempt plus two retries
+}
+
+func TestRetry_NonRetryableError(t *testing.T) {
+	attempts := 0
+	err := Retry(context.Background(), newTestRetryConfig(3), func() error {
+		attempts++
+		return errors.New("permanent failure")
+	})
+	
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetryWithRateLimit_WrappedRetryableError(t *testing.T) {
+	tests := []struct {
+		name string
+		wrap func(err error) error
+	}{
+		{
+			name: "wrapped by WrapError",
+			wrap: func(err error) error {
+				return WrapError(err, ErrorCodeDataInvalid, "Failed to fetch issues")
+			},
+		},
+		{
+			name: "wrapped with fmt.Errorf",
+			wrap: func(err error) error {
+				return fmt.Errorf("fetch issues: %w", err)
+			},
+		},
+		{
+			name: "wrapped twice",
+			wrap: func(err error) error {
+				return fmt.Errorf("sync: %w", WrapError(err, ErrorCodeJiraError, "Failed to fetch issues"))
+			},
+		},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := RetryWithRateLimit(context.Background(), newTestRetryConfig(3), nil, func() error {
+				attempts++
+				if attempts < 3 {
+					return tt.wrap(NewAppError(ErrorCodeNetworkError, "connection reset", nil))
+				}
+				return nil
+			}, NewMockLogger())
+			
+			require.NoError(t, err)
+			assert.Equal(t, 3, attempts)
+		})
+	}
+}
+
+func TestRetryWithRateLimit_WrappedNonRetryableError(t *testing.T) {
+	attempts := 0
+	err := RetryWithRateLimit(context.Background(), newTestRetryConfig(3), nil, func() error {
+		attempts++
+		return WrapError(errors.New("bad request"), ErrorCodeDataInvalid, "Failed to fetch issues")
+	}, NewMockLogger())
+	
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetry_ContextCancelledDuringBackoff(t *testing.T) {
+	config := newTestRetryConfig(5)
+	config.InitialDelay = time.Hour
+	config.MaxDelay = time.Hour
+	
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts := 0
+	err := Retry(ctx, config, func() error {
+		attempts++
+		cancel()
+		return NewAppError(ErrorCodeNetworkError, "connection reset", nil)
+	})
+	
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestCalculateDelay(t *testing.T) {
+	config := newTestRetryConfig(5)
+	
+	assert.Equal(t, 1*time.Millisecond, calculateDelay(0, config))
+	assert.Equal(t, 2*time.Millisecond, calculateDelay(1, config))
+	assert.Equal(t, 4*time.Millisecond, calculateDelay(2, config))
+	assert.Equal(t, 4*time.Millisecond, calculateDelay(3, config)) // Capped at MaxDelay
+}
+
+func TestRetryWithRateLimit_NilLimiter(t *testing.T) {
+	logger := NewMockLogger()
+	attempts := 0
+	err := RetryWithRateLimit(context.Background(), newTestRetryConfig(1), nil, func() error {
+		attempts++
+		if attempts == 1 {
+			return NewAppError(ErrorCodeNetworkError, "connection reset", nil)
+		}
+		return nil
+	}, logger)
+	
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Len(t, logger.GetEntriesByLevel(LogLevelWarn), 1)
+}
+
+func TestRetryWithRateLimit_UsesLimiter(t *testing.T) {
+	logger := NewMockLogger()
+	limiter := NewRateLimiter(5, time.Minute, logger)
+	
+	err := RetryWithRateLimit(context.Background(), newTestRetryConfig(0), limiter, func() error {
+		return nil
+	}, logger)
+	
+	require.NoError(t, err)
+	assert.Equal(t, 1, limiter.GetCurrentRequestCount())
+}
+
+func TestRetryDelay_HonorsRetryAfter(t *testing.T) {
+	config := newTestRetryConfig(3)
+	config.MaxDelay = 10 * time.Second
+	rateLimited := NewAppError(ErrorCodeAPIRateLimit, "Too many requests", nil).
+		WithExtra(RetryAfterKey, 5*time.Second)
+	
+	// The server requested delay wins over the shorter backoff
+	delay, err := retryDelay(rateLimited, 0, config)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, delay)
+	
+	// A shorter requested delay never reduces the computed backoff
+	config.InitialDelay = 10 * time.Second
+	delay, err = retryDelay(rateLimited, 0, config)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, delay)
+	
+	// Errors without a requested delay use plain backoff
+	delay, err = retryDelay(errors.New("plain"), 0, config)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, delay)
+}
+
+func TestRetryDelay_RetryAfterPastMaxDelay(t *testing.T) {
+	config := newTestRetryConfig(3)
+	rateLimited := NewAppError(ErrorCodeAPIRateLimit, "Too many requests", nil).
+		WithExtra(RetryAfterKey, time.Hour)
+	
+	_, err := retryDelay(rateLimited, 0, config)
+	require.Error(t, err)
+	
+	var appErr *AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, ErrorCodeAPIRateLimit, appErr.Code)
+	assert.ErrorIs(t, err, rateLimited)
+	delay, ok := RetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, delay)
+}
+
+func TestRetry_WaitsForRetryAfter(t *testing.T) {
+	config := newTestRetryConfig(1)
+	config.MaxDelay = 10 * time.Second
+	config.RetryableErrors = []ErrorCode{ErrorCodeAPIRateLimit}
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	
+	attempts := 0
+	err := Retry(ctx, config, func() error {
+		attempts++
+		return NewAppError(ErrorCodeAPIRateLimit, "Too many requests", nil).
+			WithExtra(RetryAfterKey, 5*time.Second)
+	})
+	
+	// Without Retry-After the 1ms backoff would have allowed a second attempt
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestRetry_FailsFastOnLongRetryAfter(t *testing.T) {
+	config := newTestRetryConfig(3)
+	config.RetryableErrors = []ErrorCode{ErrorCodeAPIRateLimit}
+	
+	attempts := 0
+	start := time.Now()
+	err := Retry(context.Background(), config, func() error {
+		attempts++
+		return NewAppError(ErrorCodeAPIRateLimit, "Too many requests", nil).
+			WithExtra(RetryAfterKey, time.Hour)
+	})
+	
+	var appErr *AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, ErrorCodeAPIRateLimit, appErr.Code)
+	assert.Equal(t, 1, attempts)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryAfter(t *testing.T) {
+	delay, ok := RetryAfter(NewAppError(ErrorCodeAPIRateLimit, "Too many requests", nil).WithExtra(RetryAfterKey, 3*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, delay)
+	
+	_, ok = RetryAfter(NewAppError(ErrorCodeAPIRateLimit, "Too many requests", nil))
+	assert.False(t, ok)
+	
+	_, ok = RetryAfter(errors.New("plain"))
+	assert.False(t, ok)
+	
+	wrapped := fmt.Errorf("fetch issues: %w", NewAppError(ErrorCodeAPIRateLimit, "Too many requests", nil).WithExtra(RetryAfterKey, 2*time.Second))
+	delay, ok = RetryAfter(wrapped)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Second, delay)
+}
@@ -33,7 +33,7 @@ func (e *IntegrationExample) ValidateJiraSearchResponse(ctx context.Context, res
 	e.metrics.RecordValidation("jira", "search", httpResult)
 	
 	if !httpResult.Valid {
-		e.logger.Error("HTTP response validation failed",
+		e.logger.Error("HTTP response validation failed", nil,
 			utils.NewField("service", "jira"),
 			utils.NewField("endpoint", "search"),
 			utils.NewField("errors", len(httpResult.Errors)),
@@ -59,7 +59,7 @@ func (e *IntegrationExample) ValidateJiraSearchResponse(ctx context.Context, res
 		
 		// Log specific validation errors
 		for _, err := range result.Errors {
-			e.logger.Error("Validation error",
+			e.logger.Error("Validation error", nil,
 				utils.NewField("field", err.Field),
 				utils.NewField("type", err.Type),
 				utils.NewField("message", err.Message),
@@ -76,7 +76,7 @@ func (e *IntegrationExample) ValidateGeminiGenerateResponse(ctx context.Context,
 	e.metrics.RecordValidation("gemini", "generateContent", result)
 	
 	if !result.Valid {
-		e.logger.Error("Gemini generate response validation failed",
+		e.logger.Error("Gemini generate response validation failed", nil,
 			utils.NewField("errors", len(result.Errors)),
 			utils.NewField("warnings", len(result.Warnings)),
 		)
@@ -97,7 +97,7 @@ func (e *IntegrationExample) ValidateGoogleDocsCreateResponse(ctx context.Contex
 	e.metrics.RecordValidation("google_docs", "create", result)
 	
 	if !result.Valid {
-		e.logger.Error("Google Docs create response validation failed",
+		e.logger.Error("Google Docs create response validation failed", nil,
 			utils.NewField("errors", len(result.Errors)),
 		)
 		return result, fmt.Errorf("validation failed: %d errors", len(result.Errors))
@@ -274,7 +274,7 @@ func ExampleUsagePatterns() {
 	
 	result, err := example.ValidateJiraSearchResponse(context.Background(), resp, []byte(jiraResponseBody))
 	if err != nil {
-		logger.Error("Jira validation failed", utils.NewField("error", err.Error()))
+		logger.Error("Jira validation failed", err)
 	} else if result.Valid {
 		logger.Info("Jira validation successful")
 	}
@@ -299,7 +299,7 @@ func ExampleUsagePatterns() {
 	
 	geminiResult, err := example.ValidateGeminiGenerateResponse(context.Background(), geminiResponseData)
 	if err != nil {
-		logger.Error("Gemini validation failed", utils.NewField("error", err.Error()))
+		logger.Error("Gemini validation failed", err)
 	} else if geminiResult.Valid {
 		logger.Info("Gemini validation successful")
 	}
@@ -307,7 +307,7 @@ func ExampleUsagePatterns() {
 	// Example 3: Custom validation rules
 	err = example.ValidateWithCustomRules(context.Background())
 	if err != nil {
-		logger.Error("Custom validation failed", utils.NewField("error", err.Error()))
+		logger.Error("Custom validation failed", err)
 	}
 	
 	// Example 4: Health monitoring
@@ -326,16 +326,3 @@ func ExampleUsagePatterns() {
 		utils.NewField("failed_count", metrics.FailedCount),
 	)
 }
-
-// Helper functions for pointer types
-func intPtr(i int) *int {
-	return &i
-}
-
-func floatPtr(f float64) *float64 {
-	return &f
-}
-
-func stringPtr(s string) *string {
-	return &s
-}
\ No newline at end of file
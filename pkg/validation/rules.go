@@ -5,6 +5,9 @@ import (
 	"github.com/company/eesa/pkg/utils"
 )
 
+// ActivityService is the rule namespace for normalized activity records
+const ActivityService = "activity"
+
 // ServiceValidationRules contains predefined validation rules for all services
 type ServiceValidationRules struct {
 	validator *APIResponseValidator
@@ -21,6 +24,7 @@ func NewServiceValidationRules(logger utils.Logger) *ServiceValidationRules {
 	rules.registerJiraRules()
 	rules.registerGeminiRules()
 	rules.registerGoogleDocsRules()
+	rules.registerActivityRules()
 	
 	return rules
 }
@@ -62,6 +66,24 @@ func (r *ServiceValidationRules) registerJiraRules() {
 					Field:    "issues",
 					Type:     "array",
 					Required: true,
+					Items: &ValidationRule{
+						Field:    "issue",
+						Type:     "object",
+						Required: true,
+						Nested: map[string]ValidationRule{
+							"id": {
+								Field:    "id",
+								Type:     "string",
+								Required: true,
+							},
+							"key": {
+								Field:       "key",
+								Type:        "string",
+								Required:    true,
+								CustomRules: []string{"non_empty"},
+							},
+						},
+					},
 				},
 			},
 		},
@@ -373,6 +395,67 @@ func (r *ServiceValidationRules) registerGoogleDocsRules() {
 	r.validator.RegisterRules("google_docs", googleDocsRules)
 }
 
+// registerActivityRules registers validation rules for normalized activity records
+func (r *ServiceValidationRules) registerActivityRules() {
+	activityRules := map[string]ValidationRule{
+		"activity": {
+			Field:    "root",
+			Type:     "object",
+			Required: true,
+			Nested: map[string]ValidationRule{
+				"id": {
+					Field:       "id",
+					Type:        "string",
+					Required:    true,
+					CustomRules: []string{"non_empty"},
+				},
+				"key": {
+					Field:    "key",
+					Type:     "string",
+					Required: true,
+					Pattern:  stringPtr(`^[A-Z][A-Z0-9_]*-\d+$`),
+				},
+				"summary": {
+					Field:       "summary",
+					Type:        "string",
+					Required:    true,
+					CustomRules: []string{"non_empty"},
+				},
+				"type": {
+					Field:       "type",
+					Type:        "string",
+					Required:    true,
+					CustomRules: []string{"non_empty"},
+				},
+				"status": {
+					Field:       "status",
+					Type:        "string",
+					Required:    true,
+					CustomRules: []string{"non_empty"},
+				},
+				"created": {
+					Field:    "created",
+					Type:     "timestamp",
+					Required: true,
+				},
+				"updated": {
+					Field:    "updated",
+					Type:     "timestamp",
+					Required: true,
+				},
+				"time_spent": {
+					Field:    "time_spent",
+					Type:     "integer",
+					Required: false,
+					MinValue: floatPtr(0),
+				},
+			},
+		},
+	}
+	
+	r.validator.RegisterRules(ActivityService, activityRules)
+}
+
 // GetJiraValidationRule returns a specific Jira validation rule
 func (r *ServiceValidationRules) GetJiraValidationRule(endpoint string) (ValidationRule, bool) {
 	if rules, exists := r.validator.rules["jira"]; exists {
@@ -418,6 +501,11 @@ func (r *ServiceValidationRules) ValidateGoogleDocsResponse(endpoint string, res
 	return r.validator.ValidateResponse("google_docs", endpoint, response)
 }
 
+// ValidateActivity validates a normalized activity record
+func (r *ServiceValidationRules) ValidateActivity(activity interface{}) *ValidationResult {
+	return r.validator.ValidateResponse(ActivityService, "activity", activity)
+}
+
 // CreateCustomRule creates a custom validation rule
 func (r *ServiceValidationRules) CreateCustomRule(field, fieldType string, required bool) ValidationRule {
 	return ValidationRule{
@@ -499,6 +587,10 @@ func floatPtr(f float64) *float64 {
 	return &f
 }
 
+func boolPtr(b bool) *bool {
+	return &b
+}
+
 // ValidationSummary provides a summary of validation results across services
 type ValidationSummary struct {
 	TotalValidations int                            `json:"total_validations"`
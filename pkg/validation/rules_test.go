@@ -19,6 +19,41 @@ func TestNewServiceValidationRules(t *testing.T) {
 	assert.True(t, rules.validator.HasRules("jira", "search"))
 	assert.True(t, rules.validator.HasRules("gemini", "generateContent"))
 	assert.True(t, rules.validator.HasRules("google_docs", "create"))
+	assert.True(t, rules.validator.HasRules(ActivityService, "activity"))
+}
+
+func TestServiceValidationRules_ActivityValidation(t *testing.T) {
+	logger := utils.NewMockLogger()
+	rules := NewServiceValidationRules(logger)
+	
+	valid := map[string]interface{}{
+		"id":         "10001",
+		"key":        "PROJ-123",
+		"summary":    "Fix login bug",
+		"type":       "Bug",
+		"status":     "Done",
+		"created":    "2023-01-01T10:00:00Z",
+		"updated":    "2023-01-02T10:00:00Z",
+		"time_spent": float64(3600),
+	}
+	
+	result := rules.ValidateActivity(valid)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Errors)
+	
+	invalid := map[string]interface{}{
+		"id":      "10002",
+		"key":     "not-a-key",
+		"summary": "  ",
+		"type":    "Bug",
+		"status":  "Done",
+		"created": "yesterday",
+		"updated": "2023-01-02T10:00:00Z",
+	}
+	
+	result = rules.ValidateActivity(invalid)
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Errors, 3)
 }
 
 func TestServiceValidationRules_JiraValidation(t *testing.T) {
@@ -52,6 +87,20 @@ func TestServiceValidationRules_JiraValidation(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name:     "invalid search response - issue without key",
+			endpoint: "search",
+			data: map[string]interface{}{
+				"startAt":    0,
+				"maxResults": 50,
+				"total":      2,
+				"issues": []interface{}{
+					map[string]interface{}{"id": "10001", "key": "TEST-1"},
+					map[string]interface{}{"id": "10002"},
+				},
+			},
+			expected: false,
+		},
 		{
 			name:     "invalid search response - negative startAt",
 			endpoint: "search",
@@ -0,0 +1,177 @@
+package validation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/company/eesa/pkg/utils"
+)
+
+// jsonSchema is the subset of JSON Schema understood by ParseJSONSchema
+type jsonSchema struct {
+	Title                string                 `json:"title"`
+	Type                 json.RawMessage        `json:"type"` // A type name or a list of type names
+	Format               string                 `json:"format"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	AdditionalProperties *bool                  `json:"additionalProperties"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	MinLength            *int                   `json:"minLength"`
+	MaxLength            *int                   `json:"maxLength"`
+	MinItems             *int                   `json:"minItems"`
+	MaxItems             *int                   `json:"maxItems"`
+	Minimum              *float64               `json:"minimum"`
+	Maximum              *float64               `json:"maximum"`
+	Pattern              *string                `json:"pattern"`
+	OneOf                []*jsonSchema          `json:"oneOf"`
+	AnyOf                []*jsonSchema          `json:"anyOf"`
+}
+
+// ParseJSONSchema converts a JSON Schema document into a ValidationRule tree. It supports
+// type, required, properties, additionalProperties, items, enum, minLength/maxLength,
+// minItems/maxItems, minimum/maximum, pattern, oneOf, anyOf and the date-time format.
+// Keywords outside that subset are ignored. The root rule is required.
+func ParseJSONSchema(data []byte) (ValidationRule, error) {
+	var schema jsonSchema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return ValidationRule{}, utils.NewAppError(utils.ErrorCodeParseError, "Failed to parse JSON schema", err)
+	}
+	
+	field := schema.Title
+	if field == "" {
+		field = "root"
+	}
+	
+	return convertJSONSchema(field, &schema, true)
+}
+
+// convertJSONSchema converts a single schema node, and its children, into a rule for field.
+// A required field whose type also allows null is left optional.
+func convertJSONSchema(field string, schema *jsonSchema, required bool) (ValidationRule, error) {
+	ruleType, nullable, err := jsonSchemaType(schema.Type)
+	if err != nil {
+		return ValidationRule{}, utils.NewAppError(utils.ErrorCodeParseError,
+			fmt.Sprintf("Invalid type for schema field %q", field), err).
+			WithExtra("field", field)
+	}
+	if ruleType == "" && len(schema.Properties) > 0 {
+		ruleType = "object"
+	}
+	if ruleType == "string" && schema.Format == "date-time" {
+		ruleType = "timestamp"
+	}
+	
+	rule := ValidationRule{
+		Field:                field,
+		Type:                 ruleType,
+		Required:             required && !nullable,
+		Pattern:              schema.Pattern,
+		MinValue:             schema.Minimum,
+		MaxValue:             schema.Maximum,
+		AdditionalProperties: schema.AdditionalProperties,
+	}
+	
+	// Array sizes share the length bounds with strings
+	if ruleType == "array" {
+		rule.MinLength = schema.MinItems
+		rule.MaxLength = schema.MaxItems
+	} else {
+		rule.MinLength = schema.MinLength
+		rule.MaxLength = schema.MaxLength
+	}
+	
+	for _, value := range schema.Enum {
+		if value == nil {
+			continue // null is accepted through the nullable type instead
+		}
+		rule.Enum = append(rule.Enum, fmt.Sprint(value))
+	}
+	
+	if len(schema.Properties) > 0 {
+		requiredFields := make(map[string]bool, len(schema.Required))
+		for _, name := range schema.Required {
+			requiredFields[name] = true
+		}
+		
+		rule.Nested = make(map[string]ValidationRule, len(schema.Properties))
+		for name, property := range schema.Properties {
+			if property == nil {
+				continue
+			}
+			nested, err := convertJSONSchema(name, property, requiredFields[name])
+			if err != nil {
+				return ValidationRule{}, err
+			}
+			rule.Nested[name] = nested
+		}
+	}
+	
+	if schema.Items != nil {
+		items, err := convertJSONSchema(field, schema.Items, true)
+		if err != nil {
+			return ValidationRule{}, err
+		}
+		rule.Items = &items
+	}
+	
+	if rule.OneOf, err = convertJSONSubschemas(field, schema.OneOf); err != nil {
+		return ValidationRule{}, err
+	}
+	if rule.AnyOf, err = convertJSONSubschemas(field, schema.AnyOf); err != nil {
+		return ValidationRule{}, err
+	}
+	
+	return rule, nil
+}
+
+// convertJSONSubschemas converts the branches of a oneOf or anyOf keyword
+func convertJSONSubschemas(field string, schemas []*jsonSchema) ([]ValidationRule, error) {
+	var rules []ValidationRule
+	for _, schema := range schemas {
+		if schema == nil {
+			continue
+		}
+		rule, err := convertJSONSchema(field, schema, true)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	
+	return rules, nil
+}
+
+// jsonSchemaType maps a JSON Schema type keyword onto a rule type and reports whether null is
+// also allowed. An absent type yields an empty rule type.
+func jsonSchemaType(raw json.RawMessage) (string, bool, error) {
+	if len(raw) == 0 {
+		return "", false, nil
+	}
+	
+	var types []string
+	var single string
+	if err := json.Unmarshal(raw, &single); err == nil {
+		types = []string{single}
+	} else if err := json.Unmarshal(raw, &types); err != nil {
+		return "", false, fmt.Errorf("type must be a string or a list of strings")
+	}
+	
+	ruleType := ""
+	nullable := false
+	for _, schemaType := range types {
+		switch schemaType {
+		case "null":
+			nullable = true
+		case "string", "number", "integer", "boolean", "array", "object":
+			if ruleType != "" && ruleType != schemaType {
+				return "", false, fmt.Errorf("multiple non-null types are not supported")
+			}
+			ruleType = schemaType
+		default:
+			return "", false, fmt.Errorf("unsupported type %q", schemaType)
+		}
+	}
+	
+	return ruleType, nullable, nil
+}
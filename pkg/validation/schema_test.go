@@ -0,0 +1,135 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/company/eesa/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const issueSearchSchema = `{
+	"title": "search",
+	"type": "object",
+	"required": ["issues", "total"],
+	"properties": {
+		"total": {"type": "integer", "minimum": 0},
+		"issues": {
+			"type": "array",
+			"maxItems": 50,
+			"items": {
+				"type": "object",
+				"required": ["key", "fields"],
+				"additionalProperties": false,
+				"properties": {
+					"id": {"type": "string"},
+					"key": {"type": "string", "pattern": "^[A-Z]+-[0-9]+$"},
+					"fields": {
+						"type": "object",
+						"required": ["summary", "status"],
+						"properties": {
+							"summary": {"type": "string", "minLength": 1, "maxLength": 255},
+							"status": {"type": "string", "enum": ["To Do", "In Progress", "Done"]},
+							"resolutiondate": {"type": ["string", "null"], "format": "date-time"}
+						}
+					}
+				}
+			}
+		}
+	}
+}`
+
+func TestParseJSONSchema(t *testing.T) {
+	rule, err := ParseJSONSchema([]byte(issueSearchSchema))
+	require.NoError(t, err)
+	
+	assert.Equal(t, "search", rule.Field)
+	assert.Equal(t, "object", rule.Type)
+	assert.True(t, rule.Required)
+	
+	issues := rule.Nested["issues"]
+	assert.Equal(t, "array", issues.Type)
+	assert.True(t, issues.Required)
+	assert.Equal(t, testIntPtr(50), issues.MaxLength)
+	require.NotNil(t, issues.Items)
+	assert.Equal(t, boolPtr(false), issues.Items.AdditionalProperties)
+	
+	key := issues.Items.Nested["key"]
+	assert.True(t, key.Required)
+	assert.Equal(t, testStringPtr("^[A-Z]+-[0-9]+$"), key.Pattern)
+	assert.False(t, issues.Items.Nested["id"].Required)
+	
+	fields := issues.Items.Nested["fields"].Nested
+	assert.Equal(t, testIntPtr(1), fields["summary"].MinLength)
+	assert.Equal(t, []string{"To Do", "In Progress", "Done"}, fields["status"].Enum)
+	assert.Equal(t, "timestamp", fields["resolutiondate"].Type)
+	assert.False(t, fields["resolutiondate"].Required)
+	assert.Equal(t, testFloatPtr(0), rule.Nested["total"].MinValue)
+}
+
+func TestParseJSONSchema_ValidatesResponses(t *testing.T) {
+	rule, err := ParseJSONSchema([]byte(issueSearchSchema))
+	require.NoError(t, err)
+	
+	validator := NewAPIResponseValidator(utils.NewMockLogger())
+	validator.RegisterRule("jira", "search", rule)
+	
+	issue := func(key, status string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":  "10001",
+			"key": key,
+			"fields": map[string]interface{}{
+				"summary":        "Ship the login page",
+				"status":         status,
+				"resolutiondate": nil,
+			},
+		}
+	}
+	
+	result := validator.ValidateResponse("jira", "search", map[string]interface{}{
+		"total":  float64(2),
+		"issues": []interface{}{issue("PROJ-1", "Done"), issue("PROJ-2", "In Progress")},
+	})
+	assert.True(t, result.Valid, result.Errors)
+	
+	unknown := issue("PROJ-4", "Done")
+	unknown["expand"] = "changelog"
+	result = validator.ValidateResponse("jira", "search", map[string]interface{}{
+		"total":  float64(-1),
+		"issues": []interface{}{issue("proj-3", "Done"), unknown, issue("PROJ-5", "Blocked")},
+	})
+	assert.False(t, result.Valid)
+	
+	errorFields := make([]string, 0, len(result.Errors))
+	for _, validationError := range result.Errors {
+		errorFields = append(errorFields, validationError.Field)
+	}
+	assert.ElementsMatch(t, []string{"total", "issues[0].key", "issues[1].expand", "issues[2].fields.status"}, errorFields)
+	
+	// Required fields are enforced
+	result = validator.ValidateResponse("jira", "search", map[string]interface{}{"issues": []interface{}{}})
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "total", result.Errors[0].Field)
+	assert.Equal(t, "required", result.Errors[0].Type)
+}
+
+func TestParseJSONSchema_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+	}{
+		{name: "malformed JSON", schema: `{"type": "object"`},
+		{name: "unsupported type", schema: `{"type": "object", "properties": {"id": {"type": "uuid"}}}`},
+		{name: "several types", schema: `{"type": ["string", "integer"]}`},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJSONSchema([]byte(tt.schema))
+			require.Error(t, err)
+			appErr, ok := err.(*utils.AppError)
+			require.True(t, ok)
+			assert.Equal(t, utils.ErrorCodeParseError, appErr.Code)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -26,6 +27,10 @@ type ValidationRule struct {
 	Enum        []string               `json:"enum,omitempty"`
 	CustomRules []string               `json:"custom_rules,omitempty"`
 	Nested      map[string]ValidationRule `json:"nested,omitempty"`
+	Items       *ValidationRule        `json:"items,omitempty"` // Applied to every element of an array
+	AdditionalProperties *bool         `json:"additional_properties,omitempty"` // Nil or true allows keys not listed in Nested
+	OneOf       []ValidationRule       `json:"one_of,omitempty"` // Exactly one subschema must match
+	AnyOf       []ValidationRule       `json:"any_of,omitempty"` // At least one subschema must match
 }
 
 // ValidationResult represents the result of validation
@@ -48,18 +53,29 @@ type ValidationError struct {
 
 // APIResponseValidator provides validation for API responses
 type APIResponseValidator struct {
-	logger utils.Logger
-	rules  map[string]map[string]ValidationRule // service -> endpoint -> rules
+	logger           utils.Logger
+	rules            map[string]map[string]ValidationRule // service -> endpoint -> rules
+	customValidators map[string]func(value interface{}) error
 }
 
 // NewAPIResponseValidator creates a new API response validator
 func NewAPIResponseValidator(logger utils.Logger) *APIResponseValidator {
 	return &APIResponseValidator{
-		logger: logger,
-		rules:  make(map[string]map[string]ValidationRule),
+		logger:           logger,
+		rules:            make(map[string]map[string]ValidationRule),
+		customValidators: make(map[string]func(value interface{}) error),
 	}
 }
 
+// RegisterCustomValidator registers a function for the custom rule name. Registered functions
+// take precedence over the built-in rules of the same name, and an error returned by fn is
+// reported as a validation error carrying its message.
+func (v *APIResponseValidator) RegisterCustomValidator(name string, fn func(value interface{}) error) {
+	v.customValidators[name] = fn
+	
+	v.logger.Debug("Registered custom validator", utils.NewField("name", name))
+}
+
 // RegisterRule registers a validation rule for a specific service and endpoint
 func (v *APIResponseValidator) RegisterRule(service, endpoint string, rule ValidationRule) {
 	if v.rules[service] == nil {
@@ -220,6 +236,16 @@ func (v *APIResponseValidator) validateField(fieldPath string, value interface{}
 	
 	// Handle different value types
 	switch rule.Type {
+	case "":
+		// A rule made only of subschemas leaves type checks to its branches
+		if len(rule.OneOf) == 0 && len(rule.AnyOf) == 0 {
+			result.Warnings = append(result.Warnings, ValidationError{
+				Field:   fieldPath,
+				Type:    "unknown_type",
+				Message: "Unknown validation type: ",
+				Value:   actualType.String(),
+			})
+		}
 	case "string":
 		v.validateString(fieldPath, value, rule, result)
 	case "number", "integer", "float":
@@ -250,6 +276,74 @@ func (v *APIResponseValidator) validateField(fieldPath string, value interface{}
 	for _, customRule := range rule.CustomRules {
 		v.applyCustomRule(fieldPath, value, customRule, result)
 	}
+	
+	if len(rule.OneOf) > 0 {
+		v.validateOneOf(fieldPath, value, rule.OneOf, result)
+	}
+	if len(rule.AnyOf) > 0 {
+		v.validateAnyOf(fieldPath, value, rule.AnyOf, result)
+	}
+}
+
+// matchSubschemas validates value against each subschema separately. It returns how many
+// subschemas matched and the errors of the closest failing one.
+func (v *APIResponseValidator) matchSubschemas(fieldPath string, value interface{}, subschemas []ValidationRule) (int, []ValidationError) {
+	matches := 0
+	var closest []ValidationError
+	for _, subschema := range subschemas {
+		branch := &ValidationResult{}
+		v.validateField(fieldPath, value, subschema, branch)
+		if len(branch.Errors) == 0 {
+			matches++
+			continue
+		}
+		if closest == nil || len(branch.Errors) < len(closest) {
+			closest = branch.Errors
+		}
+	}
+	
+	return matches, closest
+}
+
+// validateOneOf requires value to match exactly one of the subschemas
+func (v *APIResponseValidator) validateOneOf(fieldPath string, value interface{}, subschemas []ValidationRule, result *ValidationResult) {
+	matches, closest := v.matchSubschemas(fieldPath, value, subschemas)
+	switch {
+	case matches == 0:
+		result.Errors = append(result.Errors, ValidationError{
+			Field:    fieldPath,
+			Type:     "one_of",
+			Message:  fmt.Sprintf("Value matches none of the %d allowed schemas", len(subschemas)),
+			Value:    value,
+			Expected: "exactly one schema",
+		})
+		result.Errors = append(result.Errors, closest...)
+	case matches > 1:
+		result.Errors = append(result.Errors, ValidationError{
+			Field:    fieldPath,
+			Type:     "one_of",
+			Message:  fmt.Sprintf("Value matches %d schemas, expected exactly one", matches),
+			Value:    value,
+			Expected: "exactly one schema",
+		})
+	}
+}
+
+// validateAnyOf requires value to match at least one of the subschemas
+func (v *APIResponseValidator) validateAnyOf(fieldPath string, value interface{}, subschemas []ValidationRule, result *ValidationResult) {
+	matches, closest := v.matchSubschemas(fieldPath, value, subschemas)
+	if matches > 0 {
+		return
+	}
+	
+	result.Errors = append(result.Errors, ValidationError{
+		Field:    fieldPath,
+		Type:     "any_of",
+		Message:  fmt.Sprintf("Value matches none of the %d allowed schemas", len(subschemas)),
+		Value:    value,
+		Expected: "at least one schema",
+	})
+	result.Errors = append(result.Errors, closest...)
 }
 
 // validateString validates string values
@@ -430,6 +524,14 @@ func (v *APIResponseValidator) validateArray(fieldPath string, value interface{}
 			Expected: *rule.MaxLength,
 		})
 	}
+	
+	// Validate each element against the item rule, indexing the field path
+	if rule.Items != nil {
+		for i := 0; i < length; i++ {
+			itemPath := fmt.Sprintf("%s[%d]", fieldPath, i)
+			v.validateField(itemPath, val.Index(i).Interface(), *rule.Items, result)
+		}
+	}
 }
 
 // validateObject validates object values
@@ -478,6 +580,32 @@ func (v *APIResponseValidator) validateObjectFields(fieldPath string, obj map[st
 			})
 		}
 	}
+	
+	if rule.AdditionalProperties == nil || *rule.AdditionalProperties {
+		return
+	}
+	
+	// Report unknown keys in a stable order
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		if _, known := rule.Nested[key]; !known {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	
+	for _, key := range keys {
+		keyPath := key
+		if fieldPath != "" {
+			keyPath = fieldPath + "." + key
+		}
+		result.Errors = append(result.Errors, ValidationError{
+			Field:   keyPath,
+			Type:    "additional_property",
+			Message: fmt.Sprintf("Unexpected field %q", key),
+			Value:   obj[key],
+		})
+	}
 }
 
 // validateTimestamp validates timestamp values
@@ -545,6 +673,18 @@ func (v *APIResponseValidator) validateEnum(fieldPath string, value interface{},
 
 // applyCustomRule applies custom validation rules
 func (v *APIResponseValidator) applyCustomRule(fieldPath string, value interface{}, customRule string, result *ValidationResult) {
+	if fn, ok := v.customValidators[customRule]; ok {
+		if err := fn(value); err != nil {
+			result.Errors = append(result.Errors, ValidationError{
+				Field:   fieldPath,
+				Type:    "custom_" + customRule,
+				Message: err.Error(),
+				Value:   value,
+			})
+		}
+		return
+	}
+	
 	switch customRule {
 	case "non_empty":
 		if str, ok := value.(string); ok && strings.TrimSpace(str) == "" {
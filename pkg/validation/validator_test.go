@@ -1,11 +1,14 @@
 package validation
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/company/eesa/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewAPIResponseValidator(t *testing.T) {
@@ -334,6 +337,145 @@ func TestAPIResponseValidator_ValidateArray(t *testing.T) {
 	}
 }
 
+func TestAPIResponseValidator_ValidateArrayItems(t *testing.T) {
+	logger := utils.NewMockLogger()
+	validator := NewAPIResponseValidator(logger)
+	
+	validator.RegisterRule("jira", "search", ValidationRule{
+		Field:    "root",
+		Type:     "object",
+		Required: true,
+		Nested: map[string]ValidationRule{
+			"issues": {
+				Field:    "issues",
+				Type:     "array",
+				Required: true,
+				Items: &ValidationRule{
+					Field:    "issue",
+					Type:     "object",
+					Required: true,
+					Nested: map[string]ValidationRule{
+						"key": {Field: "key", Type: "string", Required: true},
+						"fields": {
+							Field:    "fields",
+							Type:     "object",
+							Required: true,
+							Nested: map[string]ValidationRule{
+								"summary": {Field: "summary", Type: "string", Required: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	
+	issue := func(key string) map[string]interface{} {
+		return map[string]interface{}{
+			"key":    key,
+			"fields": map[string]interface{}{"summary": "Summary of " + key},
+		}
+	}
+	
+	// Every element is well formed
+	result := validator.ValidateResponse("jira", "search", map[string]interface{}{
+		"issues": []interface{}{issue("TEST-1"), issue("TEST-2")},
+	})
+	assert.True(t, result.Valid)
+	
+	// One element misses a nested required field
+	broken := issue("TEST-4")
+	delete(broken["fields"].(map[string]interface{}), "summary")
+	result = validator.ValidateResponse("jira", "search", map[string]interface{}{
+		"issues": []interface{}{issue("TEST-1"), issue("TEST-2"), issue("TEST-3"), broken},
+	})
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "issues[3].fields.summary", result.Errors[0].Field)
+	assert.Equal(t, "required", result.Errors[0].Type)
+	
+	// Element type mismatches and null elements are reported by index
+	result = validator.ValidateResponse("jira", "search", map[string]interface{}{
+		"issues": []interface{}{issue("TEST-1"), "TEST-2", nil, map[string]interface{}{"key": 3, "fields": map[string]interface{}{"summary": "x"}}},
+	})
+	assert.False(t, result.Valid)
+	fields := make([]string, 0, len(result.Errors))
+	for _, validationError := range result.Errors {
+		fields = append(fields, validationError.Field)
+	}
+	assert.ElementsMatch(t, []string{"issues[1]", "issues[2]", "issues[3].key"}, fields)
+}
+
+func TestAPIResponseValidator_ValidateOneOfAnyOf(t *testing.T) {
+	logger := utils.NewMockLogger()
+	validator := NewAPIResponseValidator(logger)
+	
+	// A docs batch update request sets exactly one of its operations
+	branch := func(field string) ValidationRule {
+		return ValidationRule{
+			Field: "request",
+			Type:  "object",
+			Nested: map[string]ValidationRule{
+				field: {Field: field, Type: "object", Required: true},
+			},
+		}
+	}
+	branches := []ValidationRule{branch("insertText"), branch("updateTextStyle")}
+	validator.RegisterRule("gdocs", "one_of", ValidationRule{Field: "request", Required: true, OneOf: branches})
+	validator.RegisterRule("gdocs", "any_of", ValidationRule{Field: "request", Required: true, AnyOf: branches})
+	
+	none := map[string]interface{}{"deleteContentRange": map[string]interface{}{}}
+	one := map[string]interface{}{"insertText": map[string]interface{}{"text": "Summary"}}
+	both := map[string]interface{}{
+		"insertText":      map[string]interface{}{"text": "Summary"},
+		"updateTextStyle": map[string]interface{}{"fields": "bold"},
+	}
+	
+	result := validator.ValidateResponse("gdocs", "one_of", one)
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Warnings)
+	
+	// With no branch set the closest branch explains what is missing
+	result = validator.ValidateResponse("gdocs", "one_of", none)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "one_of", result.Errors[0].Type)
+	assert.Equal(t, "required", result.Errors[1].Type)
+	assert.Equal(t, "insertText", result.Errors[1].Field)
+	
+	result = validator.ValidateResponse("gdocs", "one_of", both)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "one_of", result.Errors[0].Type)
+	assert.Contains(t, result.Errors[0].Message, "matches 2 schemas")
+	
+	// AnyOf accepts one or more matching branches
+	assert.True(t, validator.ValidateResponse("gdocs", "any_of", one).Valid)
+	assert.True(t, validator.ValidateResponse("gdocs", "any_of", both).Valid)
+	
+	result = validator.ValidateResponse("gdocs", "any_of", none)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 2)
+	assert.Equal(t, "any_of", result.Errors[0].Type)
+	assert.Equal(t, "required", result.Errors[1].Type)
+}
+
+func TestAPIResponseValidator_ValidateArrayWithoutItems(t *testing.T) {
+	logger := utils.NewMockLogger()
+	validator := NewAPIResponseValidator(logger)
+	
+	// Without an item rule elements are not inspected
+	validator.RegisterRule("test", "endpoint", ValidationRule{
+		Field:     "test",
+		Type:      "array",
+		Required:  true,
+		MinLength: testIntPtr(1),
+	})
+	result := validator.ValidateResponse("test", "endpoint", []interface{}{nil, 42, map[string]interface{}{}})
+	assert.True(t, result.Valid)
+	assert.Equal(t, 1, result.FieldCount)
+}
+
 func TestAPIResponseValidator_ValidateObject(t *testing.T) {
 	logger := utils.NewMockLogger()
 	validator := NewAPIResponseValidator(logger)
@@ -385,6 +527,65 @@ func TestAPIResponseValidator_ValidateObject(t *testing.T) {
 	assert.True(t, len(result.Errors) > 0)
 }
 
+func TestAPIResponseValidator_ValidateObjectStrict(t *testing.T) {
+	logger := utils.NewMockLogger()
+	validator := NewAPIResponseValidator(logger)
+	
+	rule := func(additional *bool) ValidationRule {
+		return ValidationRule{
+			Field:    "root",
+			Type:     "object",
+			Required: true,
+			Nested: map[string]ValidationRule{
+				"id": {Field: "id", Type: "string", Required: true},
+				"fields": {
+					Field:                "fields",
+					Type:                 "object",
+					Required:             true,
+					AdditionalProperties: additional,
+					Nested: map[string]ValidationRule{
+						"summary": {Field: "summary", Type: "string", Required: true},
+					},
+				},
+			},
+			AdditionalProperties: additional,
+		}
+	}
+	
+	data := map[string]interface{}{
+		"id":     "10001",
+		"expand": "changelog",
+		"fields": map[string]interface{}{"summary": "Ship it", "statsu": "Done"},
+	}
+	
+	// Unknown keys are allowed by default and when explicitly permitted
+	validator.RegisterRule("test", "default", rule(nil))
+	result := validator.ValidateResponse("test", "default", data)
+	assert.True(t, result.Valid)
+	
+	validator.RegisterRule("test", "allow", rule(boolPtr(true)))
+	result = validator.ValidateResponse("test", "allow", data)
+	assert.True(t, result.Valid)
+	
+	// Strict mode reports every unknown key with its full path
+	validator.RegisterRule("test", "strict", rule(boolPtr(false)))
+	result = validator.ValidateResponse("test", "strict", data)
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 2)
+	fields := []string{result.Errors[0].Field, result.Errors[1].Field}
+	assert.ElementsMatch(t, []string{"expand", "fields.statsu"}, fields)
+	for _, validationError := range result.Errors {
+		assert.Equal(t, "additional_property", validationError.Type)
+	}
+	
+	// Objects containing only known keys pass strict mode
+	result = validator.ValidateResponse("test", "strict", map[string]interface{}{
+		"id":     "10001",
+		"fields": map[string]interface{}{"summary": "Ship it"},
+	})
+	assert.True(t, result.Valid)
+}
+
 func TestAPIResponseValidator_ValidateTimestamp(t *testing.T) {
 	logger := utils.NewMockLogger()
 	validator := NewAPIResponseValidator(logger)
@@ -544,6 +745,51 @@ func TestAPIResponseValidator_CustomRules(t *testing.T) {
 	}
 }
 
+func TestAPIResponseValidator_RegisterCustomValidator(t *testing.T) {
+	logger := utils.NewMockLogger()
+	validator := NewAPIResponseValidator(logger)
+	
+	// Issue keys must belong to an allowed project
+	validator.RegisterCustomValidator("allowed_project", func(value interface{}) error {
+		key, _ := value.(string)
+		if !strings.HasPrefix(key, "PROJ-") && !strings.HasPrefix(key, "OPS-") {
+			return fmt.Errorf("issue %s is not in an allowed project", key)
+		}
+		return nil
+	})
+	validator.RegisterRule("jira", "issue", ValidationRule{
+		Field:    "root",
+		Type:     "object",
+		Required: true,
+		Nested: map[string]ValidationRule{
+			"key": {Field: "key", Type: "string", Required: true, CustomRules: []string{"allowed_project"}},
+		},
+	})
+	
+	result := validator.ValidateResponse("jira", "issue", map[string]interface{}{"key": "OPS-7"})
+	assert.True(t, result.Valid)
+	assert.Empty(t, result.Warnings)
+	
+	result = validator.ValidateResponse("jira", "issue", map[string]interface{}{"key": "HR-3"})
+	assert.False(t, result.Valid)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "key", result.Errors[0].Field)
+	assert.Equal(t, "custom_allowed_project", result.Errors[0].Type)
+	assert.Equal(t, "issue HR-3 is not in an allowed project", result.Errors[0].Message)
+	
+	// Registered functions take precedence over built-in rules
+	validator.RegisterCustomValidator("email", func(value interface{}) error {
+		return nil
+	})
+	validator.RegisterRule("test", "endpoint", ValidationRule{
+		Field:       "test",
+		Type:        "string",
+		Required:    true,
+		CustomRules: []string{"email"},
+	})
+	assert.True(t, validator.ValidateResponse("test", "endpoint", "invalid-email").Valid)
+}
+
 func TestAPIResponseValidator_ValidateHTTPResponse(t *testing.T) {
 	logger := utils.NewMockLogger()
 	validator := NewAPIResponseValidator(logger)